@@ -26,13 +26,15 @@ import (
 )
 
 const (
-	getSignedBlockPath       = "/eth/v2/beacon/blocks"
-	getBlockRootPath         = "/eth/v1/beacon/blocks/{{.Id}}/root"
-	getForkForStatePath      = "/eth/v1/beacon/states/{{.Id}}/fork"
-	getForkSchedulePath      = "/eth/v1/config/fork_schedule"
-	getConfigSpecPath        = "/eth/v1/config/spec"
-	getStatePath             = "/eth/v2/debug/beacon/states"
-	changeBLStoExecutionPath = "/eth/v1/beacon/pool/bls_to_execution_changes"
+	getSignedBlockPath         = "/eth/v2/beacon/blocks"
+	getBlockRootPath           = "/eth/v1/beacon/blocks/{{.Id}}/root"
+	getForkForStatePath        = "/eth/v1/beacon/states/{{.Id}}/fork"
+	getForkSchedulePath        = "/eth/v1/config/fork_schedule"
+	getConfigSpecPath          = "/eth/v1/config/spec"
+	getStatePath               = "/eth/v2/debug/beacon/states"
+	getFinalityCheckpointsPath = "/eth/v1/beacon/states/{{.Id}}/finality_checkpoints"
+	getBlobSidecarsPath        = "/eth/v1/beacon/blob_sidecars"
+	changeBLStoExecutionPath   = "/eth/v1/beacon/pool/bls_to_execution_changes"
 
 	GetNodeVersionPath      = "/eth/v1/node/version"
 	GetWeakSubjectivityPath = "/prysm/v1/beacon/weak_subjectivity"
@@ -253,6 +255,40 @@ func (c *Client) GetState(ctx context.Context, stateId StateOrBlockId) ([]byte,
 	return b, nil
 }
 
+var getFinalityCheckpointsTpl = idTemplate(getFinalityCheckpointsPath)
+
+// GetFinalityCheckpoints retrieves the finality checkpoints of the BeaconState for the given state id.
+// State identifier can be one of: "head" (canonical head in node's view), "genesis", "finalized",
+// <slot>, <hex encoded stateRoot with 0x prefix>. Variables of type StateOrBlockId are exported by this package
+// for the named identifiers.
+func (c *Client) GetFinalityCheckpoints(ctx context.Context, stateId StateOrBlockId) (*structs.FinalityCheckpoints, error) {
+	body, err := c.Get(ctx, getFinalityCheckpointsTpl(stateId))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting finality checkpoints by state id = %s", stateId)
+	}
+	fr := &structs.GetFinalityCheckpointsResponse{}
+	if err := json.Unmarshal(body, fr); err != nil {
+		return nil, errors.Wrap(err, "error decoding json data from get finality checkpoints response")
+	}
+	if fr.Data == nil || fr.Data.Finalized == nil {
+		return nil, errors.New("finality checkpoints response is missing data")
+	}
+	return fr.Data, nil
+}
+
+// GetBlobSidecars retrieves the BlobSidecars of the block for the given block id.
+// Block identifier can be one of: "head" (canonical head in node's view), "genesis", "finalized",
+// <slot>, <hex encoded blockRoot with 0x prefix>. Variables of type StateOrBlockId are exported by this package
+// for the named identifiers.
+// The return value contains the concatenated ssz-encoded sidecars.
+func (c *Client) GetBlobSidecars(ctx context.Context, blockId StateOrBlockId) ([]byte, error) {
+	b, err := c.Get(ctx, path.Join(getBlobSidecarsPath, string(blockId)), client.WithSSZEncoding())
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting blob sidecars by id = %s", blockId)
+	}
+	return b, nil
+}
+
 // WeakSubjectivityData represents the state root, block root and epoch of the BeaconState + ReadOnlySignedBeaconBlock
 // that falls at the beginning of the current weak subjectivity period. These values can be used to construct
 // a weak subjectivity checkpoint beacon node flag to be used for validation.
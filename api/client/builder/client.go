@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync/atomic"
 	"text/template"
 
 	"github.com/pkg/errors"
@@ -97,6 +98,13 @@ type Client struct {
 	hc      *http.Client
 	baseURL *url.URL
 	obvs    []observer
+	// sszEnabled and gzipEnabled are unset once the builder rejects a request body encoded with them, after
+	// which requests are encoded as plain JSON.
+	sszEnabled  atomic.Bool
+	gzipEnabled atomic.Bool
+	// sszBlindedBlocks is set once the builder answered GetHeader in ssz, which is when blinded blocks are
+	// submitted in ssz.
+	sszBlindedBlocks atomic.Bool
 }
 
 // NewClient constructs a new client with the provided options (ex WithTimeout).
@@ -111,6 +119,8 @@ func NewClient(host string, opts ...ClientOpt) (*Client, error) {
 		hc:      &http.Client{},
 		baseURL: u,
 	}
+	c.sszEnabled.Store(true)
+	c.gzipEnabled.Store(true)
 	for _, o := range opts {
 		o(c)
 	}
@@ -138,7 +148,8 @@ func (c *Client) NodeURL() string {
 type reqOption func(*http.Request)
 
 // do is a generic, opinionated request function to reduce boilerplate amongst the methods in this package api/client/builder.
-func (c *Client) do(ctx context.Context, method string, path string, body io.Reader, opts ...reqOption) (res []byte, err error) {
+// The headers of the response are returned along with its body.
+func (c *Client) do(ctx context.Context, method string, path string, body io.Reader, opts ...reqOption) (res []byte, header http.Header, err error) {
 	ctx, span := trace.StartSpan(ctx, "builder.client.do")
 	defer func() {
 		tracing.AnnotateError(span, err)
@@ -177,6 +188,7 @@ func (c *Client) do(ctx context.Context, method string, path string, body io.Rea
 		err = non200Err(r)
 		return
 	}
+	header = r.Header
 	res, err = io.ReadAll(io.LimitReader(r.Body, client.MaxBodySize))
 	if err != nil {
 		err = errors.Wrap(err, "error reading http response body from builder server")
@@ -211,10 +223,29 @@ func (c *Client) GetHeader(ctx context.Context, slot primitives.Slot, parentHash
 	if err != nil {
 		return nil, err
 	}
-	hb, err := c.do(ctx, http.MethodGet, path, nil)
+	accept := func(r *http.Request) {
+		if c.sszEnabled.Load() {
+			r.Header.Set("Accept", sszAcceptHeader)
+		} else {
+			r.Header.Set("Accept", api.JsonMediaType)
+		}
+	}
+	hb, header, err := c.do(ctx, http.MethodGet, path, nil, accept)
 	if err != nil {
 		return nil, err
 	}
+	c.sszBlindedBlocks.Store(isSSZ(header))
+	if isSSZ(header) {
+		ver, err := headerVersion(header)
+		if err != nil {
+			return nil, err
+		}
+		bid, err := signedBidFromSSZ(ver, hb)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error unmarshaling the builder GetHeader ssz response, using slot=%d, parentHash=%#x, pubkey=%#x", slot, parentHash, pubkey)
+		}
+		return bid, nil
+	}
 	v := &VersionResponse{}
 	if err := json.Unmarshal(hb, v); err != nil {
 		return nil, errors.Wrapf(err, "error unmarshaling the builder GetHeader response, using slot=%d, parentHash=%#x, pubkey=%#x", slot, parentHash, pubkey)
@@ -271,8 +302,9 @@ func (c *Client) GetHeader(ctx context.Context, slot primitives.Slot, parentHash
 	return nil, fmt.Errorf("unsupported header version %s", strings.ToLower(v.Version))
 }
 
-// RegisterValidator encodes the SignedValidatorRegistrationV1 message to json (including hex-encoding the byte
-// fields with 0x prefixes) and posts to the builder validator registration endpoint.
+// RegisterValidator encodes the SignedValidatorRegistrationV1 messages to ssz, or to json (including hex-encoding
+// the byte fields with 0x prefixes) for builders which do not support ssz, and posts them gzip compressed to the
+// builder validator registration endpoint.
 func (c *Client) RegisterValidator(ctx context.Context, svr []*ethpb.SignedValidatorRegistrationV1) error {
 	ctx, span := trace.StartSpan(ctx, "builder.client.RegisterValidator")
 	defer span.End()
@@ -283,25 +315,73 @@ func (c *Client) RegisterValidator(ctx context.Context, svr []*ethpb.SignedValid
 		tracing.AnnotateError(span, err)
 		return err
 	}
-	vs := make([]*structs.SignedValidatorRegistration, len(svr))
-	for i := 0; i < len(svr); i++ {
-		vs[i] = structs.SignedValidatorRegistrationFromConsensus(svr[i])
+	useSSZ, useGzip := c.sszEnabled.Load(), c.gzipEnabled.Load()
+	for {
+		err := c.registerValidator(ctx, svr, useSSZ, useGzip)
+		if err == nil {
+			break
+		}
+		if !isUnsupportedEncoding(err) || !(useSSZ || useGzip) {
+			tracing.AnnotateError(span, err)
+			return err
+		}
+		// Fall back to json, then to an uncompressed body.
+		if useSSZ {
+			log.WithError(err).Debug("Builder rejected ssz validator registrations, retrying with json")
+			useSSZ = false
+		} else {
+			log.WithError(err).Debug("Builder rejected gzip validator registrations, retrying uncompressed")
+			useGzip = false
+		}
 	}
-	body, err := json.Marshal(vs)
-	if err != nil {
-		err := errors.Wrap(err, "error encoding the SignedValidatorRegistration value body in RegisterValidator")
-		tracing.AnnotateError(span, err)
-		return err
+	// The encodings are only disabled once the builder accepted the registrations without them, as a 400
+	// response may be caused by the registrations rather than by their encoding.
+	if !useSSZ {
+		c.sszEnabled.Store(false)
 	}
-
-	_, err = c.do(ctx, http.MethodPost, postRegisterValidatorPath, bytes.NewBuffer(body))
-	if err != nil {
-		return err
+	if !useGzip {
+		c.gzipEnabled.Store(false)
 	}
 	log.WithField("registrationCount", len(svr)).Debug("Successfully registered validator(s) on builder")
 	return nil
 }
 
+func (c *Client) registerValidator(ctx context.Context, svr []*ethpb.SignedValidatorRegistrationV1, useSSZ, useGzip bool) error {
+	var body []byte
+	var err error
+	contentType := api.JsonMediaType
+	if useSSZ {
+		contentType = api.OctetStreamMediaType
+		body, err = registrationsToSSZ(svr)
+		if err != nil {
+			return errors.Wrap(err, "error encoding the SignedValidatorRegistration value body in RegisterValidator")
+		}
+	} else {
+		vs := make([]*structs.SignedValidatorRegistration, len(svr))
+		for i := 0; i < len(svr); i++ {
+			vs[i] = structs.SignedValidatorRegistrationFromConsensus(svr[i])
+		}
+		body, err = json.Marshal(vs)
+		if err != nil {
+			return errors.Wrap(err, "error encoding the SignedValidatorRegistration value body in RegisterValidator")
+		}
+	}
+	if useGzip {
+		body, err = gzipEncode(body)
+		if err != nil {
+			return errors.Wrap(err, "error compressing the SignedValidatorRegistration value body in RegisterValidator")
+		}
+	}
+	postOpts := func(r *http.Request) {
+		r.Header.Set("Content-Type", contentType)
+		if useGzip {
+			r.Header.Set("Content-Encoding", "gzip")
+		}
+	}
+	_, _, err = c.do(ctx, http.MethodPost, postRegisterValidatorPath, bytes.NewBuffer(body), postOpts)
+	return err
+}
+
 var errResponseVersionMismatch = errors.New("builder API response uses a different version than requested in " + api.VersionHeader + " header")
 
 // SubmitBlindedBlock calls the builder API endpoint that binds the validator to the builder and submits the block.
@@ -311,27 +391,34 @@ func (c *Client) SubmitBlindedBlock(ctx context.Context, sb interfaces.ReadOnlyS
 		return nil, nil, errNotBlinded
 	}
 
-	// massage the proto struct type data into the api response type.
-	mj, err := structs.SignedBeaconBlockMessageJsoner(sb)
-	if err != nil {
-		return nil, nil, errors.Wrap(err, "error generating blinded beacon block post request")
-	}
-
-	body, err := json.Marshal(mj)
-	if err != nil {
-		return nil, nil, errors.Wrap(err, "error marshaling blinded block post request to json")
-	}
-	postOpts := func(r *http.Request) {
-		r.Header.Add("Eth-Consensus-Version", version.String(sb.Version()))
-		r.Header.Set("Content-Type", api.JsonMediaType)
-		r.Header.Set("Accept", api.JsonMediaType)
-	}
 	// post the blinded block - the execution payload response should contain the unblinded payload, along with the
-	// blobs bundle if it is post deneb.
-	rb, err := c.do(ctx, http.MethodPost, postBlindedBeaconBlockPath, bytes.NewBuffer(body), postOpts)
+	// blobs bundle if it is post deneb. The block is only posted in ssz to builders which answered GetHeader in ssz.
+	useSSZ := c.sszEnabled.Load() && c.sszBlindedBlocks.Load()
+	rb, header, err := c.submitBlindedBlock(ctx, sb, useSSZ)
+	if useSSZ && isUnsupportedEncoding(err) {
+		log.WithError(err).Debug("Builder rejected the ssz blinded block, retrying with json")
+		rb, header, err = c.submitBlindedBlock(ctx, sb, false)
+		if err == nil {
+			c.sszBlindedBlocks.Store(false)
+		}
+	}
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "error posting the blinded block to the builder api")
 	}
+	if isSSZ(header) {
+		ver, err := headerVersion(header)
+		if err != nil {
+			return nil, nil, err
+		}
+		if ver != sb.Version() {
+			return nil, nil, errors.Wrapf(errResponseVersionMismatch, "req=%s, recv=%s", version.String(sb.Version()), version.String(ver))
+		}
+		ed, bundle, err := payloadFromSSZ(ver, rb)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to parse ssz execution payload from builder with version=%s", version.String(ver))
+		}
+		return ed, bundle, nil
+	}
 	// ExecutionPayloadResponse parses just the outer container and the Value key, enabling it to use the .Value
 	// key to determine which underlying data type to use to finish the unmarshaling.
 	ep := &ExecutionPayloadResponse{}
@@ -366,14 +453,53 @@ func (c *Client) SubmitBlindedBlock(ctx context.Context, sb interfaces.ReadOnlyS
 	return ed, nil, nil
 }
 
+func (c *Client) submitBlindedBlock(ctx context.Context, sb interfaces.ReadOnlySignedBeaconBlock, useSSZ bool) ([]byte, http.Header, error) {
+	var body []byte
+	var err error
+	if useSSZ {
+		body, err = sb.MarshalSSZ()
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error marshaling blinded block post request to ssz")
+		}
+	} else {
+		// massage the proto struct type data into the api response type.
+		var mj structs.SignedMessageJsoner
+		mj, err = structs.SignedBeaconBlockMessageJsoner(sb)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error generating blinded beacon block post request")
+		}
+		body, err = json.Marshal(mj)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error marshaling blinded block post request to json")
+		}
+	}
+	postOpts := func(r *http.Request) {
+		r.Header.Add("Eth-Consensus-Version", version.String(sb.Version()))
+		if useSSZ {
+			r.Header.Set("Content-Type", api.OctetStreamMediaType)
+			r.Header.Set("Accept", sszAcceptHeader)
+		} else {
+			r.Header.Set("Content-Type", api.JsonMediaType)
+			r.Header.Set("Accept", api.JsonMediaType)
+		}
+	}
+	return c.do(ctx, http.MethodPost, postBlindedBeaconBlockPath, bytes.NewBuffer(body), postOpts)
+}
+
 // Status asks the remote builder server for a health check. A response of 200 with an empty body is the success/healthy
 // response, and an error response may have an error message. This method will return a nil value for error in the
 // happy path, and an error with information about the server response body for a non-200 response.
 func (c *Client) Status(ctx context.Context) error {
-	_, err := c.do(ctx, http.MethodGet, getStatus, nil)
+	_, _, err := c.do(ctx, http.MethodGet, getStatus, nil)
 	return err
 }
 
+// isUnsupportedEncoding returns true if the builder may have rejected a request because of the encoding of its
+// body. Builders which only support json typically answer a 400 rather than a 415 to an ssz or compressed body.
+func isUnsupportedEncoding(err error) bool {
+	return errors.Is(err, ErrUnsupportedMediaType) || errors.Is(err, ErrBadRequest)
+}
+
 func non200Err(response *http.Response) error {
 	bodyBytes, err := io.ReadAll(io.LimitReader(response.Body, client.MaxErrBodySize))
 	var errMessage ErrorMessage
@@ -391,7 +517,8 @@ func non200Err(response *http.Response) error {
 	case http.StatusBadRequest:
 		log.WithError(ErrBadRequest).Debug(msg)
 		if jsonErr := json.Unmarshal(bodyBytes, &errMessage); jsonErr != nil {
-			return errors.Wrap(jsonErr, "unable to read response body")
+			// Builders may reject a body they can't decode without a json error message.
+			return errors.Wrap(ErrBadRequest, errors.Wrap(jsonErr, "unable to read response body").Error())
 		}
 		return errors.Wrap(ErrBadRequest, errMessage.Message)
 	case http.StatusNotFound:
@@ -400,6 +527,9 @@ func non200Err(response *http.Response) error {
 			return errors.Wrap(jsonErr, "unable to read response body")
 		}
 		return errors.Wrap(ErrNotFound, errMessage.Message)
+	case http.StatusUnsupportedMediaType:
+		log.WithError(ErrUnsupportedMediaType).Debug(msg)
+		return ErrUnsupportedMediaType
 	case http.StatusInternalServerError:
 		log.WithError(ErrNotOK).Debug(msg)
 		if jsonErr := json.Unmarshal(bodyBytes, &errMessage); jsonErr != nil {
@@ -2,25 +2,32 @@ package builder
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"strconv"
 	"testing"
 
 	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/api"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	v1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
 	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -939,3 +946,291 @@ func TestRequestLogger(t *testing.T) {
 	err = c.Status(ctx)
 	require.NoError(t, err)
 }
+
+func sszClient(hc *http.Client) *Client {
+	c := &Client{
+		hc:      hc,
+		baseURL: &url.URL{Host: "localhost:3500", Scheme: "http"},
+	}
+	c.sszEnabled.Store(true)
+	c.gzipEnabled.Store(true)
+	return c
+}
+
+func TestClient_GetHeaderSSZ(t *testing.T) {
+	ctx := context.Background()
+	hr := &ExecHeaderResponseDeneb{}
+	require.NoError(t, json.Unmarshal([]byte(testExampleHeaderResponseDeneb), hr))
+	expected, err := hr.ToProto()
+	require.NoError(t, err)
+	msg, err := expected.Message.MarshalSSZ()
+	require.NoError(t, err)
+	enc := append([]byte{signedBidFixedSize, 0, 0, 0}, expected.Signature...)
+	enc = append(enc, msg...)
+
+	hc := &http.Client{
+		Transport: roundtrip(func(r *http.Request) (*http.Response, error) {
+			require.Equal(t, sszAcceptHeader, r.Header.Get("Accept"))
+			header := http.Header{}
+			header.Set("Content-Type", api.OctetStreamMediaType)
+			header.Set(api.VersionHeader, "deneb")
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     header,
+				Body:       io.NopCloser(bytes.NewBuffer(enc)),
+				Request:    r.Clone(ctx),
+			}, nil
+		}),
+	}
+	c := sszClient(hc)
+	h, err := c.GetHeader(ctx, 1, [32]byte{}, [48]byte{})
+	require.NoError(t, err)
+	// Blinded blocks are submitted in ssz once the builder answered in ssz.
+	assert.Equal(t, true, c.sszBlindedBlocks.Load())
+	assert.Equal(t, version.Deneb, h.Version())
+	assert.DeepEqual(t, expected.Signature, h.Signature())
+	bid, err := h.Message()
+	require.NoError(t, err)
+	root, err := bid.HashTreeRoot()
+	require.NoError(t, err)
+	expectedRoot, err := expected.Message.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, expectedRoot, root)
+
+	// Truncated responses are rejected.
+	enc = enc[:signedBidFixedSize-1]
+	_, err = c.GetHeader(ctx, 1, [32]byte{}, [48]byte{})
+	require.ErrorIs(t, err, errInvalidSSZ)
+}
+
+func TestSubmitBlindedBlockSSZ(t *testing.T) {
+	ctx := context.Background()
+	ep := &ExecutionPayloadResponse{}
+	require.NoError(t, json.Unmarshal([]byte(testExampleExecutionPayloadDeneb), ep))
+	pp, err := ep.ParsePayload()
+	require.NoError(t, err)
+	payloadProto, err := pp.PayloadProto()
+	require.NoError(t, err)
+	payload, ok := payloadProto.(*v1.ExecutionPayloadDeneb)
+	require.Equal(t, true, ok)
+	bundle := &v1.BlobsBundle{
+		KzgCommitments: [][]byte{bytesutil.PadTo([]byte{1}, 48)},
+		Proofs:         [][]byte{bytesutil.PadTo([]byte{2}, 48)},
+		Blobs:          [][]byte{bytesutil.PadTo([]byte{3}, fieldparams.BlobLength)},
+	}
+	payloadEnc, err := payload.MarshalSSZ()
+	require.NoError(t, err)
+	bundleEnc, err := bundle.MarshalSSZ()
+	require.NoError(t, err)
+	enc := make([]byte, payloadAndBlobsBundleFixedSize)
+	binary.LittleEndian.PutUint32(enc[:4], payloadAndBlobsBundleFixedSize)
+	binary.LittleEndian.PutUint32(enc[4:], uint32(payloadAndBlobsBundleFixedSize+len(payloadEnc)))
+	enc = append(append(enc, payloadEnc...), bundleEnc...)
+
+	sbb, err := blocks.NewSignedBeaconBlock(util.NewBlindedBeaconBlockDeneb())
+	require.NoError(t, err)
+	blockEnc, err := sbb.MarshalSSZ()
+	require.NoError(t, err)
+
+	t.Run("ssz", func(t *testing.T) {
+		hc := &http.Client{
+			Transport: roundtrip(func(r *http.Request) (*http.Response, error) {
+				require.Equal(t, api.OctetStreamMediaType, r.Header.Get("Content-Type"))
+				require.Equal(t, sszAcceptHeader, r.Header.Get("Accept"))
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.DeepEqual(t, blockEnc, body)
+				header := http.Header{}
+				header.Set("Content-Type", api.OctetStreamMediaType)
+				header.Set(api.VersionHeader, "deneb")
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     header,
+					Body:       io.NopCloser(bytes.NewBuffer(enc)),
+					Request:    r.Clone(ctx),
+				}, nil
+			}),
+		}
+		c := sszClient(hc)
+		c.sszBlindedBlocks.Store(true)
+		ed, blobsBundle, err := c.SubmitBlindedBlock(ctx, sbb)
+		require.NoError(t, err)
+		assert.DeepEqual(t, payload.BlockHash, ed.BlockHash())
+		assert.DeepEqual(t, bundle, blobsBundle)
+	})
+	t.Run("json until the builder answers get header in ssz", func(t *testing.T) {
+		hc := &http.Client{
+			Transport: roundtrip(func(r *http.Request) (*http.Response, error) {
+				require.Equal(t, api.JsonMediaType, r.Header.Get("Content-Type"))
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewBufferString(testExampleExecutionPayloadDeneb)),
+					Request:    r.Clone(ctx),
+				}, nil
+			}),
+		}
+		_, _, err := sszClient(hc).SubmitBlindedBlock(ctx, sbb)
+		require.NoError(t, err)
+	})
+	t.Run("version mismatch", func(t *testing.T) {
+		hc := &http.Client{
+			Transport: roundtrip(func(r *http.Request) (*http.Response, error) {
+				header := http.Header{}
+				header.Set("Content-Type", api.OctetStreamMediaType)
+				header.Set(api.VersionHeader, "electra")
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     header,
+					Body:       io.NopCloser(bytes.NewBuffer(enc)),
+					Request:    r.Clone(ctx),
+				}, nil
+			}),
+		}
+		_, _, err := sszClient(hc).SubmitBlindedBlock(ctx, sbb)
+		require.ErrorIs(t, err, errResponseVersionMismatch)
+	})
+	t.Run("json fallback", func(t *testing.T) {
+		var contentTypes []string
+		hc := &http.Client{
+			Transport: roundtrip(func(r *http.Request) (*http.Response, error) {
+				contentTypes = append(contentTypes, r.Header.Get("Content-Type"))
+				if r.Header.Get("Content-Type") == api.OctetStreamMediaType {
+					return &http.Response{
+						StatusCode: http.StatusUnsupportedMediaType,
+						Body:       io.NopCloser(bytes.NewBuffer(nil)),
+						Request:    r.Clone(ctx),
+					}, nil
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewBufferString(testExampleExecutionPayloadDeneb)),
+					Request:    r.Clone(ctx),
+				}, nil
+			}),
+		}
+		c := sszClient(hc)
+		c.sszBlindedBlocks.Store(true)
+		ed, blobsBundle, err := c.SubmitBlindedBlock(ctx, sbb)
+		require.NoError(t, err)
+		assert.DeepEqual(t, payload.BlockHash, ed.BlockHash())
+		require.NotNil(t, blobsBundle)
+		// The builder is not sent ssz requests anymore.
+		_, _, err = c.SubmitBlindedBlock(ctx, sbb)
+		require.NoError(t, err)
+		assert.DeepEqual(t, []string{api.OctetStreamMediaType, api.JsonMediaType, api.JsonMediaType}, contentTypes)
+	})
+}
+
+func TestClient_RegisterValidatorSSZ(t *testing.T) {
+	ctx := context.Background()
+	reg := &eth.SignedValidatorRegistrationV1{
+		Message: &eth.ValidatorRegistrationV1{
+			FeeRecipient: make([]byte, 20),
+			GasLimit:     23,
+			Timestamp:    42,
+			Pubkey:       make([]byte, 48),
+		},
+		Signature: make([]byte, 96),
+	}
+	regs := []*eth.SignedValidatorRegistrationV1{reg, reg}
+	regEnc, err := reg.MarshalSSZ()
+	require.NoError(t, err)
+
+	type request struct {
+		contentType string
+		encoding    string
+	}
+	var requests []request
+	hc := &http.Client{
+		Transport: roundtrip(func(r *http.Request) (*http.Response, error) {
+			req := request{contentType: r.Header.Get("Content-Type"), encoding: r.Header.Get("Content-Encoding")}
+			requests = append(requests, req)
+			status := http.StatusOK
+			switch {
+			case req.contentType == api.OctetStreamMediaType && len(requests) == 1:
+				var body io.Reader = r.Body
+				if req.encoding == "gzip" {
+					zr, err := gzip.NewReader(r.Body)
+					require.NoError(t, err)
+					body = zr
+				}
+				enc, err := io.ReadAll(body)
+				require.NoError(t, err)
+				require.DeepEqual(t, append(append([]byte{}, regEnc...), regEnc...), enc)
+			case len(requests) > 1 && len(requests) < 4:
+				status = http.StatusUnsupportedMediaType
+			}
+			return &http.Response{
+				StatusCode: status,
+				Body:       io.NopCloser(bytes.NewBuffer(nil)),
+				Request:    r.Clone(ctx),
+			}, nil
+		}),
+	}
+	c := sszClient(hc)
+	require.NoError(t, c.RegisterValidator(ctx, regs))
+	// The builder rejects ssz, then gzip.
+	require.NoError(t, c.RegisterValidator(ctx, regs))
+	require.DeepEqual(t, []request{
+		{contentType: api.OctetStreamMediaType, encoding: "gzip"},
+		{contentType: api.OctetStreamMediaType, encoding: "gzip"},
+		{contentType: api.JsonMediaType, encoding: "gzip"},
+		{contentType: api.JsonMediaType},
+	}, requests)
+	assert.Equal(t, false, c.sszEnabled.Load())
+	assert.Equal(t, false, c.gzipEnabled.Load())
+}
+
+// jsonOnlyBuilder answers requests with a body which is not plain json with a 400, like builders which don't
+// support ssz or compression.
+func jsonOnlyBuilder(t *testing.T, contentTypes *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*contentTypes = append(*contentTypes, r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		if r.Header.Get("Content-Encoding") != "" || !json.Valid(body) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, err = w.Write([]byte("invalid character in request body"))
+			require.NoError(t, err)
+			return
+		}
+		w.Header().Set("Content-Type", api.JsonMediaType)
+		if r.URL.Path == postBlindedBeaconBlockPath {
+			_, err = w.Write([]byte(testExampleExecutionPayloadDeneb))
+			require.NoError(t, err)
+		}
+	}))
+}
+
+func TestClient_JSONOnlyBuilder(t *testing.T) {
+	ctx := context.Background()
+	var contentTypes []string
+	srv := jsonOnlyBuilder(t, &contentTypes)
+	defer srv.Close()
+	c, err := NewClient(srv.URL)
+	require.NoError(t, err)
+
+	reg := &eth.SignedValidatorRegistrationV1{
+		Message: &eth.ValidatorRegistrationV1{
+			FeeRecipient: make([]byte, 20),
+			Pubkey:       make([]byte, 48),
+		},
+		Signature: make([]byte, 96),
+	}
+	require.NoError(t, c.RegisterValidator(ctx, []*eth.SignedValidatorRegistrationV1{reg}))
+	assert.DeepEqual(t, []string{api.OctetStreamMediaType, api.JsonMediaType, api.JsonMediaType}, contentTypes)
+	assert.Equal(t, false, c.sszEnabled.Load())
+	assert.Equal(t, false, c.gzipEnabled.Load())
+
+	// A blinded block which failed to be posted in ssz is posted again in json.
+	sbb, err := blocks.NewSignedBeaconBlock(util.NewBlindedBeaconBlockDeneb())
+	require.NoError(t, err)
+	c.sszEnabled.Store(true)
+	c.sszBlindedBlocks.Store(true)
+	contentTypes = nil
+	ed, _, err := c.SubmitBlindedBlock(ctx, sbb)
+	require.NoError(t, err)
+	require.NotNil(t, ed)
+	assert.DeepEqual(t, []string{api.OctetStreamMediaType, api.JsonMediaType}, contentTypes)
+	assert.Equal(t, false, c.sszBlindedBlocks.Load())
+}
@@ -15,3 +15,7 @@ var ErrBadRequest = errors.Wrap(ErrNotOK, "recv 400 BadRequest response from API
 // ErrNoContent specifically means that a '204 - No Content' response was received from the API.
 // Typically, a 204 is a success but in this case for the Header API means No header is available
 var ErrNoContent = errors.New("recv 204 no content response from API, No header is available")
+
+// ErrUnsupportedMediaType specifically means that a '415 - UNSUPPORTED MEDIA TYPE' response was received from the API.
+// The relay does not accept the encoding of the request body, and the request should be sent again as JSON.
+var ErrUnsupportedMediaType = errors.Wrap(ErrNotOK, "recv 415 UnsupportedMediaType response from API")
@@ -0,0 +1,152 @@
+package builder
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	v1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+// sszAcceptHeader asks for SSZ encoded responses, while accepting JSON from relays which do not support SSZ.
+const sszAcceptHeader = api.OctetStreamMediaType + ";q=1.0," + api.JsonMediaType + ";q=0.9"
+
+const (
+	// A signed builder bid is the offset of the variable size bid, followed by the signature.
+	signedBidFixedSize = 4 + fieldparams.BLSSignatureLength
+	// An execution payload and blobs bundle is the offsets of the two variable size fields.
+	payloadAndBlobsBundleFixedSize = 8
+)
+
+var errInvalidSSZ = errors.New("invalid ssz encoding")
+
+// isSSZ returns true if the response headers state an SSZ encoded body.
+func isSSZ(header http.Header) bool {
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	return err == nil && mediaType == api.OctetStreamMediaType
+}
+
+// headerVersion returns the fork version of an SSZ encoded response from its consensus version header.
+func headerVersion(header http.Header) (int, error) {
+	v := strings.ToLower(header.Get(api.VersionHeader))
+	ver, err := version.FromString(v)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unsupported %s header %q", api.VersionHeader, v)
+	}
+	return ver, nil
+}
+
+// signedBidFromSSZ decodes an SSZ encoded signed builder bid of the given fork version.
+func signedBidFromSSZ(ver int, enc []byte) (SignedBid, error) {
+	if len(enc) < signedBidFixedSize || binary.LittleEndian.Uint32(enc[:4]) != signedBidFixedSize {
+		return nil, errors.Wrap(errInvalidSSZ, "signed builder bid offset")
+	}
+	sig := enc[4:signedBidFixedSize]
+	msg := enc[signedBidFixedSize:]
+	switch {
+	case ver >= version.Electra:
+		bid := &ethpb.BuilderBidElectra{}
+		if err := bid.UnmarshalSSZ(msg); err != nil {
+			return nil, err
+		}
+		return WrappedSignedBuilderBidElectra(&ethpb.SignedBuilderBidElectra{Message: bid, Signature: sig})
+	case ver >= version.Deneb:
+		bid := &ethpb.BuilderBidDeneb{}
+		if err := bid.UnmarshalSSZ(msg); err != nil {
+			return nil, err
+		}
+		return WrappedSignedBuilderBidDeneb(&ethpb.SignedBuilderBidDeneb{Message: bid, Signature: sig})
+	case ver >= version.Capella:
+		bid := &ethpb.BuilderBidCapella{}
+		if err := bid.UnmarshalSSZ(msg); err != nil {
+			return nil, err
+		}
+		return WrappedSignedBuilderBidCapella(&ethpb.SignedBuilderBidCapella{Message: bid, Signature: sig})
+	case ver >= version.Bellatrix:
+		bid := &ethpb.BuilderBid{}
+		if err := bid.UnmarshalSSZ(msg); err != nil {
+			return nil, err
+		}
+		return WrappedSignedBuilderBid(&ethpb.SignedBuilderBid{Message: bid, Signature: sig})
+	default:
+		return nil, fmt.Errorf("unsupported header version %s", version.String(ver))
+	}
+}
+
+// payloadFromSSZ decodes the SSZ encoded execution payload revealed for a blinded block of the given fork version,
+// along with its blobs bundle after Deneb.
+func payloadFromSSZ(ver int, enc []byte) (interfaces.ExecutionData, *v1.BlobsBundle, error) {
+	switch {
+	case ver >= version.Deneb:
+		if len(enc) < payloadAndBlobsBundleFixedSize || binary.LittleEndian.Uint32(enc[:4]) != payloadAndBlobsBundleFixedSize {
+			return nil, nil, errors.Wrap(errInvalidSSZ, "execution payload offset")
+		}
+		o := binary.LittleEndian.Uint32(enc[4:8])
+		if o < payloadAndBlobsBundleFixedSize || uint64(o) > uint64(len(enc)) {
+			return nil, nil, errors.Wrap(errInvalidSSZ, "blobs bundle offset")
+		}
+		payload := &v1.ExecutionPayloadDeneb{}
+		if err := payload.UnmarshalSSZ(enc[payloadAndBlobsBundleFixedSize:o]); err != nil {
+			return nil, nil, err
+		}
+		bundle := &v1.BlobsBundle{}
+		if err := bundle.UnmarshalSSZ(enc[o:]); err != nil {
+			return nil, nil, err
+		}
+		ed, err := blocks.WrappedExecutionPayloadDeneb(payload)
+		return ed, bundle, err
+	case ver >= version.Capella:
+		payload := &v1.ExecutionPayloadCapella{}
+		if err := payload.UnmarshalSSZ(enc); err != nil {
+			return nil, nil, err
+		}
+		ed, err := blocks.WrappedExecutionPayloadCapella(payload)
+		return ed, nil, err
+	case ver >= version.Bellatrix:
+		payload := &v1.ExecutionPayload{}
+		if err := payload.UnmarshalSSZ(enc); err != nil {
+			return nil, nil, err
+		}
+		ed, err := blocks.WrappedExecutionPayload(payload)
+		return ed, nil, err
+	default:
+		return nil, nil, fmt.Errorf("unsupported payload version %s", version.String(ver))
+	}
+}
+
+// registrationsToSSZ encodes a list of signed validator registrations. Registrations have a fixed size, so the
+// list is the concatenation of their encodings.
+func registrationsToSSZ(svr []*ethpb.SignedValidatorRegistrationV1) ([]byte, error) {
+	var enc []byte
+	for _, r := range svr {
+		var err error
+		enc, err = r.MarshalSSZTo(enc)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return enc, nil
+}
+
+func gzipEncode(b []byte) ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+	w := gzip.NewWriter(buf)
+	if _, err := w.Write(b); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/api/server"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/forkchoice"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/validator"
 	"github.com/prysmaticlabs/prysm/v5/container/slice"
@@ -583,6 +584,41 @@ func CheckpointFromConsensus(c *eth.Checkpoint) *Checkpoint {
 	}
 }
 
+func ForkChoiceDumpFromConsensus(dump *forkchoice.Dump) *GetForkChoiceDumpResponse {
+	nodes := make([]*ForkChoiceNode, len(dump.ForkChoiceNodes))
+	for i, n := range dump.ForkChoiceNodes {
+		nodes[i] = &ForkChoiceNode{
+			Slot:               fmt.Sprintf("%d", n.Slot),
+			BlockRoot:          hexutil.Encode(n.BlockRoot),
+			ParentRoot:         hexutil.Encode(n.ParentRoot),
+			JustifiedEpoch:     fmt.Sprintf("%d", n.JustifiedEpoch),
+			FinalizedEpoch:     fmt.Sprintf("%d", n.FinalizedEpoch),
+			Weight:             fmt.Sprintf("%d", n.Weight),
+			ExecutionBlockHash: hexutil.Encode(n.ExecutionBlockHash),
+			Validity:           n.Validity.String(),
+			ExtraData: &ForkChoiceNodeExtraData{
+				UnrealizedJustifiedEpoch: fmt.Sprintf("%d", n.UnrealizedJustifiedEpoch),
+				UnrealizedFinalizedEpoch: fmt.Sprintf("%d", n.UnrealizedFinalizedEpoch),
+				Balance:                  fmt.Sprintf("%d", n.Balance),
+				ExecutionOptimistic:      n.ExecutionOptimistic,
+				TimeStamp:                fmt.Sprintf("%d", n.Timestamp),
+			},
+		}
+	}
+	return &GetForkChoiceDumpResponse{
+		JustifiedCheckpoint: CheckpointFromConsensus(dump.JustifiedCheckpoint),
+		FinalizedCheckpoint: CheckpointFromConsensus(dump.FinalizedCheckpoint),
+		ForkChoiceNodes:     nodes,
+		ExtraData: &ForkChoiceDumpExtraData{
+			UnrealizedJustifiedCheckpoint: CheckpointFromConsensus(dump.UnrealizedJustifiedCheckpoint),
+			UnrealizedFinalizedCheckpoint: CheckpointFromConsensus(dump.UnrealizedFinalizedCheckpoint),
+			ProposerBoostRoot:             hexutil.Encode(dump.ProposerBoostRoot),
+			PreviousProposerBoostRoot:     hexutil.Encode(dump.PreviousProposerBoostRoot),
+			HeadRoot:                      hexutil.Encode(dump.HeadRoot),
+		},
+	}
+}
+
 func (s *SyncCommitteeSubscription) ToConsensus() (*validator.SyncCommitteeSubscription, error) {
 	index, err := strconv.ParseUint(s.ValidatorIndex, 10, 64)
 	if err != nil {
@@ -708,6 +744,15 @@ func (m *SyncCommitteeMessage) ToConsensus() (*eth.SyncCommitteeMessage, error)
 	}, nil
 }
 
+func SyncCommitteeMessageFromConsensus(m *eth.SyncCommitteeMessage) *SyncCommitteeMessage {
+	return &SyncCommitteeMessage{
+		Slot:            fmt.Sprintf("%d", m.Slot),
+		BeaconBlockRoot: hexutil.Encode(m.BlockRoot),
+		ValidatorIndex:  fmt.Sprintf("%d", m.ValidatorIndex),
+		Signature:       hexutil.Encode(m.Signature),
+	}
+}
+
 func SyncCommitteeFromConsensus(sc *eth.SyncCommittee) *SyncCommittee {
 	var sPubKeys []string
 	for _, p := range sc.Pubkeys {
@@ -250,3 +250,158 @@ type ChainHead struct {
 	PreviousJustifiedBlockRoot string `json:"previous_justified_block_root"`
 	OptimisticStatus           bool   `json:"optimistic_status"`
 }
+
+type GetSlotTimingsResponse struct {
+	Data []*SlotTiming `json:"data"`
+}
+
+// SlotTiming holds the times, in milliseconds since the start of the slot, at which a block
+// and its blob sidecars were received and processed. Events which were not observed are empty.
+type SlotTiming struct {
+	Slot          string         `json:"slot"`
+	BlockRoot     string         `json:"block_root"`
+	SlotStartTime string         `json:"slot_start_time"`
+	BlockArrival  string         `json:"block_arrival_ms,omitempty"`
+	BlockPeerId   string         `json:"block_peer_id,omitempty"`
+	BlobArrivals  []*BlobArrival `json:"blob_arrivals"`
+	NewPayload    string         `json:"new_payload_ms,omitempty"`
+	Imported      string         `json:"imported_ms,omitempty"`
+	Head          string         `json:"head_ms,omitempty"`
+}
+
+type BlobArrival struct {
+	Index   string `json:"index"`
+	Arrival string `json:"arrival_ms"`
+}
+
+type GetChainHealthResponse struct {
+	Data *ChainHealth `json:"data"`
+}
+
+// ChainHealth summarizes the health of the chain over the most recent epochs. Participation rates are the
+// fraction of the active balance which attested, and are empty when they are not known yet.
+type ChainHealth struct {
+	CurrentSlot         string         `json:"current_slot"`
+	HeadSlot            string         `json:"head_slot"`
+	HeadBlockRoot       string         `json:"head_block_root"`
+	ExecutionOptimistic bool           `json:"execution_optimistic"`
+	JustifiedEpoch      string         `json:"justified_epoch"`
+	FinalizedEpoch      string         `json:"finalized_epoch"`
+	FinalityDistance    string         `json:"finality_distance"`
+	Epochs              []*EpochHealth `json:"epochs"`
+	ReorgCount          string         `json:"reorg_count"`
+	MaxReorgDepth       string         `json:"max_reorg_depth"`
+	Reorgs              []*ChainReorg  `json:"reorgs"`
+	OrphanedBlockCount  string         `json:"orphaned_block_count"`
+}
+
+type EpochHealth struct {
+	Epoch                   string        `json:"epoch"`
+	MissedSlots             []*MissedSlot `json:"missed_slots"`
+	SourceParticipationRate string        `json:"source_participation_rate"`
+	TargetParticipationRate string        `json:"target_participation_rate"`
+	HeadParticipationRate   string        `json:"head_participation_rate"`
+}
+
+type MissedSlot struct {
+	Slot          string `json:"slot"`
+	ProposerIndex string `json:"proposer_index"`
+}
+
+type ChainReorg struct {
+	Slot         string `json:"slot"`
+	Depth        string `json:"depth"`
+	Distance     string `json:"distance"`
+	OldHeadBlock string `json:"old_head_block"`
+	NewHeadBlock string `json:"new_head_block"`
+	Time         string `json:"time"`
+}
+
+// PoolInclusion tells whether a pooled operation would be included in the next block, and if not, why.
+type PoolInclusion struct {
+	Included bool   `json:"included"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+type GetPoolAttestationsResponse struct {
+	NextBlockSlot string             `json:"next_block_slot"`
+	Data          []*PoolAttestation `json:"data"`
+}
+
+// PoolAttestation is an attestation of the attestation pool. Attestation is an Attestation before Electra
+// and an AttestationElectra afterwards. Participants is the number of aggregation bits set out of Bits.
+type PoolAttestation struct {
+	Version        string          `json:"version"`
+	Aggregated     bool            `json:"aggregated"`
+	Slot           string          `json:"slot"`
+	CommitteeIndex string          `json:"committee_index"`
+	Participants   string          `json:"participants"`
+	Bits           string          `json:"bits"`
+	Attestation    json.RawMessage `json:"attestation"`
+	Inclusion      *PoolInclusion  `json:"inclusion"`
+}
+
+type GetPoolSyncCommitteesResponse struct {
+	NextBlockSlot string             `json:"next_block_slot"`
+	Data          *PoolSyncCommittee `json:"data"`
+}
+
+type PoolSyncCommittee struct {
+	Messages      []*PoolSyncCommitteeMessage      `json:"messages"`
+	Contributions []*PoolSyncCommitteeContribution `json:"contributions"`
+}
+
+type PoolSyncCommitteeMessage struct {
+	Message   *SyncCommitteeMessage `json:"message"`
+	Inclusion *PoolInclusion        `json:"inclusion"`
+}
+
+type PoolSyncCommitteeContribution struct {
+	Contribution *SyncCommitteeContribution `json:"contribution"`
+	Participants string                     `json:"participants"`
+	Bits         string                     `json:"bits"`
+	Inclusion    *PoolInclusion             `json:"inclusion"`
+}
+
+type GetPoolVoluntaryExitsResponse struct {
+	NextBlockSlot string               `json:"next_block_slot"`
+	Data          []*PoolVoluntaryExit `json:"data"`
+}
+
+type PoolVoluntaryExit struct {
+	Exit      *SignedVoluntaryExit `json:"exit"`
+	Inclusion *PoolInclusion       `json:"inclusion"`
+}
+
+type GetPoolSlashingsResponse struct {
+	NextBlockSlot string         `json:"next_block_slot"`
+	Data          *PoolSlashings `json:"data"`
+}
+
+// PoolSlashings lists the slashings of the slashing pool. An attester slashing is an AttesterSlashing
+// before Electra and an AttesterSlashingElectra afterwards.
+type PoolSlashings struct {
+	ProposerSlashings []*PoolProposerSlashing `json:"proposer_slashings"`
+	AttesterSlashings []*PoolAttesterSlashing `json:"attester_slashings"`
+}
+
+type PoolProposerSlashing struct {
+	Slashing  *ProposerSlashing `json:"slashing"`
+	Inclusion *PoolInclusion    `json:"inclusion"`
+}
+
+type PoolAttesterSlashing struct {
+	Version   string          `json:"version"`
+	Slashing  json.RawMessage `json:"slashing"`
+	Inclusion *PoolInclusion  `json:"inclusion"`
+}
+
+type GetPoolBLSToExecutionChangesResponse struct {
+	NextBlockSlot string                      `json:"next_block_slot"`
+	Data          []*PoolBLSToExecutionChange `json:"data"`
+}
+
+type PoolBLSToExecutionChange struct {
+	Change    *SignedBLSToExecutionChange `json:"change"`
+	Inclusion *PoolInclusion              `json:"inclusion"`
+}
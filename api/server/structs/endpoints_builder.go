@@ -12,3 +12,39 @@ type ExpectedWithdrawal struct {
 	Index          string `json:"index"`
 	ValidatorIndex string `json:"validator_index"`
 }
+
+type GetBuilderBidsResponse struct {
+	Data *BuilderBids `json:"data"`
+}
+
+// BuilderBids is the audit record of a proposal of the node: the bids received from the relays, the local payload
+// and the payload selected. Times are in milliseconds since the start of the slot.
+type BuilderBids struct {
+	Slot           string              `json:"slot"`
+	ProposerIndex  string              `json:"proposer_index"`
+	Bids           []*BuilderBidRecord `json:"bids"`
+	WinningRelay   string              `json:"winning_relay,omitempty"`
+	LocalValue     string              `json:"local_value,omitempty"`
+	LocalBlockHash string              `json:"local_block_hash,omitempty"`
+	Selected       string              `json:"selected,omitempty"`
+	Reason         string              `json:"reason,omitempty"`
+	Reveal         *PayloadReveal      `json:"reveal,omitempty"`
+}
+
+type BuilderBidRecord struct {
+	Relay         string `json:"relay"`
+	BuilderPubkey string `json:"builder_pubkey,omitempty"`
+	Value         string `json:"value,omitempty"`
+	BlockHash     string `json:"block_hash,omitempty"`
+	Received      string `json:"received_ms"`
+	Latency       string `json:"latency_ms"`
+	Error         string `json:"error,omitempty"`
+}
+
+type PayloadReveal struct {
+	Relay    string `json:"relay"`
+	Revealed bool   `json:"revealed"`
+	Time     string `json:"time_ms"`
+	Latency  string `json:"latency_ms"`
+	Error    string `json:"error,omitempty"`
+}
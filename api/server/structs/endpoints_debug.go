@@ -55,3 +55,51 @@ type ForkChoiceNodeExtraData struct {
 	ExecutionOptimistic      bool   `json:"execution_optimistic"`
 	TimeStamp                string `json:"timestamp"`
 }
+
+type GetInvalidBlocksResponse struct {
+	Data []*InvalidBlock `json:"data"`
+}
+
+type GetInvalidBlockResponse struct {
+	Version      string        `json:"version"`
+	Data         *InvalidBlock `json:"data"`
+	Block        string        `json:"block"`
+	BlobSidecars []string      `json:"blob_sidecars"`
+}
+
+type InvalidBlock struct {
+	BlockRoot          string `json:"block_root"`
+	ParentRoot         string `json:"parent_root"`
+	Slot               string `json:"slot"`
+	ProposerIndex      string `json:"proposer_index"`
+	PeerId             string `json:"peer_id"`
+	Stage              string `json:"stage"`
+	Error              string `json:"error"`
+	Time               string `json:"time"`
+	RejectsDescendants bool   `json:"rejects_descendants"`
+	HasBlock           bool   `json:"has_block"`
+	BlobSidecarCount   string `json:"blob_sidecar_count"`
+}
+
+type GetBlockImportResponse struct {
+	Data *BlockImport `json:"data"`
+}
+
+// BlockImport holds the stages of the import of a recent block. Times are in milliseconds since the start of the
+// slot, and stages which were not observed are omitted.
+type BlockImport struct {
+	Slot          string              `json:"slot"`
+	BlockRoot     string              `json:"block_root"`
+	SlotStartTime string              `json:"slot_start_time"`
+	BlockArrival  string              `json:"block_arrival_ms,omitempty"`
+	BlockPeerId   string              `json:"block_peer_id,omitempty"`
+	Stages        []*BlockImportStage `json:"stages"`
+	Imported      string              `json:"imported_ms,omitempty"`
+	Head          string              `json:"head_ms,omitempty"`
+}
+
+type BlockImportStage struct {
+	Name     string `json:"name"`
+	Start    string `json:"start_ms"`
+	Duration string `json:"duration_ms"`
+}
@@ -20,6 +20,15 @@ type BlockEvent struct {
 	ExecutionOptimistic bool   `json:"execution_optimistic"`
 }
 
+type ExecutionClientStatusEvent struct {
+	Previous     string `json:"previous"`
+	State        string `json:"state"`
+	CurrentBlock string `json:"current_block"`
+	HighestBlock string `json:"highest_block"`
+	PeerCount    string `json:"peer_count,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
 type AggregatedAttEventSource struct {
 	Aggregate *Attestation `json:"aggregate"`
 }
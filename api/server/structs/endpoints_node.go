@@ -71,3 +71,107 @@ type AddrRequest struct {
 type PeersResponse struct {
 	Peers []*Peer `json:"peers"`
 }
+
+type PeerBan struct {
+	PeerId    string `json:"peer_id,omitempty"`
+	Agent     string `json:"agent,omitempty"`
+	Reason    string `json:"reason"`
+	Source    string `json:"source"`
+	CreatedAt string `json:"created_at"`
+	ExpiresAt string `json:"expires_at,omitempty"`
+}
+
+type AddPeerBanRequest struct {
+	PeerId string `json:"peer_id"`
+	Agent  string `json:"agent"`
+	Reason string `json:"reason"`
+	// Duration of the ban in seconds. An empty or zero duration bans permanently.
+	Duration string `json:"duration"`
+}
+
+type PeerBansResponse struct {
+	Bans []*PeerBan `json:"bans"`
+}
+
+type GossipStatsResponse struct {
+	Data *GossipStats `json:"data"`
+}
+
+type GossipStats struct {
+	Since    string                `json:"since"`
+	Failures []*GossipFailureStats `json:"failures"`
+	Peers    []*GossipPeerStats    `json:"peers"`
+}
+
+type GossipFailureStats struct {
+	Topic    string                  `json:"topic"`
+	Result   string                  `json:"result"`
+	Reason   string                  `json:"reason"`
+	Count    string                  `json:"count"`
+	Examples []*GossipFailureExample `json:"examples,omitempty"`
+}
+
+type GossipFailureExample struct {
+	PeerId string `json:"peer_id"`
+	Agent  string `json:"agent"`
+	Error  string `json:"error,omitempty"`
+	Time   string `json:"time"`
+}
+
+type GossipPeerStats struct {
+	PeerId   string                `json:"peer_id"`
+	Agent    string                `json:"agent"`
+	Rejected string                `json:"rejected"`
+	Ignored  string                `json:"ignored"`
+	Failures []*GossipFailureStats `json:"failures"`
+}
+
+type BackfillStatusResponse struct {
+	Data *BackfillStatus `json:"data"`
+}
+
+type BackfillStatus struct {
+	Enabled    bool   `json:"enabled"`
+	Running    bool   `json:"running"`
+	Paused     bool   `json:"paused"`
+	Complete   bool   `json:"complete"`
+	LowSlot    string `json:"low_slot"`
+	TargetSlot string `json:"target_slot"`
+	// Maximum number of slots requested from peers per second, 0 if unlimited.
+	RateLimit      string            `json:"rate_limit"`
+	SlotsPerSecond string            `json:"slots_per_second"`
+	EtaSeconds     string            `json:"eta_seconds,omitempty"`
+	Workers        []*BackfillWorker `json:"workers"`
+}
+
+type BackfillWorker struct {
+	Id        string `json:"id"`
+	State     string `json:"state"`
+	StartSlot string `json:"start_slot,omitempty"`
+	EndSlot   string `json:"end_slot,omitempty"`
+	PeerId    string `json:"peer_id,omitempty"`
+	Since     string `json:"since"`
+}
+
+type UpdateBackfillRequest struct {
+	TargetSlot string `json:"target_slot"`
+	// Maximum number of slots requested from peers per second. "0" removes the limit.
+	RateLimit string `json:"rate_limit"`
+}
+
+type ExecutionStatusResponse struct {
+	Data *ExecutionStatus `json:"data"`
+}
+
+type ExecutionStatus struct {
+	Connected bool `json:"connected"`
+	// State of the execution client: "unknown", "offline", "syncing" or "synced".
+	State        string `json:"state"`
+	CurrentBlock string `json:"current_block"`
+	HighestBlock string `json:"highest_block"`
+	// Omitted when the execution client does not expose net_peerCount on the engine endpoint.
+	PeerCount           string `json:"peer_count,omitempty"`
+	ExecutionOptimistic bool   `json:"execution_optimistic"`
+	Updated             string `json:"updated,omitempty"`
+	Error               string `json:"error,omitempty"`
+}
@@ -0,0 +1,80 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/sirupsen/logrus"
+)
+
+// saveForkChoiceStore saves the fork choice store to the database, so that votes, weights and the optimistic status
+// of the nodes which are not finalized yet survive a restart.
+func (s *Service) saveForkChoiceStore(ctx context.Context) error {
+	if s.cfg.ForkChoiceStore == nil {
+		return nil
+	}
+	s.cfg.ForkChoiceStore.RLock()
+	snapshot, err := s.cfg.ForkChoiceStore.Snapshot(ctx)
+	nodes := s.cfg.ForkChoiceStore.NodeCount()
+	s.cfg.ForkChoiceStore.RUnlock()
+	if err != nil {
+		// This happens when the node is stopped before the chain has started.
+		log.WithError(err).Debug("Could not save fork choice store")
+		return nil
+	}
+	if err := s.cfg.BeaconDB.SaveForkChoiceSnapshot(ctx, snapshot); err != nil {
+		return errors.Wrap(err, "could not save fork choice store")
+	}
+	log.WithFields(logrus.Fields{
+		"nodes": nodes,
+		"size":  len(snapshot),
+	}).Info("Saved fork choice store")
+	return nil
+}
+
+// restoreForkChoiceStore restores the fork choice store saved on shutdown, if there is one and it still matches
+// the head and finalized checkpoint of the database. It returns false when fork choice has to be initialized
+// from the finalized checkpoint instead.
+// This function requires a lock in forkchoice.
+func (s *Service) restoreForkChoiceStore(ctx context.Context, finalized *ethpb.Checkpoint) bool {
+	snapshot, err := s.cfg.BeaconDB.ForkChoiceSnapshot(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return false
+	}
+	if err != nil {
+		log.WithError(err).Warn("Could not read saved fork choice store")
+		return false
+	}
+	// The snapshot becomes stale as soon as new blocks are processed, so it is only ever restored once.
+	defer func() {
+		if err := s.cfg.BeaconDB.DeleteForkChoiceSnapshot(ctx); err != nil {
+			log.WithError(err).Error("Could not delete saved fork choice store")
+		}
+	}()
+
+	headBlock, err := s.cfg.BeaconDB.HeadBlock(ctx)
+	if err != nil || headBlock == nil || headBlock.IsNil() {
+		log.WithError(err).Warn("Could not get head block, not restoring saved fork choice store")
+		return false
+	}
+	headRoot, err := headBlock.Block().HashTreeRoot()
+	if err != nil {
+		log.WithError(err).Warn("Could not compute head block root, not restoring saved fork choice store")
+		return false
+	}
+	fc := &forkchoicetypes.Checkpoint{Epoch: finalized.Epoch, Root: bytesutil.ToBytes32(finalized.Root)}
+	if err := s.cfg.ForkChoiceStore.Restore(ctx, snapshot, headRoot, fc); err != nil {
+		log.WithError(err).Warn("Could not restore saved fork choice store, initializing it from the finalized checkpoint")
+		return false
+	}
+	log.WithFields(logrus.Fields{
+		"nodes":    s.cfg.ForkChoiceStore.NodeCount(),
+		"headRoot": fmt.Sprintf("%#x", headRoot),
+	}).Info("Restored fork choice store saved on shutdown")
+	return true
+}
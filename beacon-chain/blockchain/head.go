@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
 	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
@@ -131,6 +133,14 @@ func (s *Service) saveHead(ctx context.Context, newHeadRoot [32]byte, headBlock
 		}).Info("Chain reorg occurred")
 		reorgDistance.Observe(float64(dis))
 		reorgDepth.Observe(float64(dep))
+		s.cfg.ReorgCache.Add(cache.Reorg{
+			Slot:        newHeadSlot,
+			Depth:       dep,
+			Distance:    uint64(dis),
+			OldHeadRoot: oldHeadRoot,
+			NewHeadRoot: newHeadRoot,
+			Time:        time.Now(),
+		})
 
 		s.cfg.StateNotifier.StateFeed().Send(&feed.Event{
 			Type: statefeed.Reorg,
@@ -163,6 +173,9 @@ func (s *Service) saveHead(ctx context.Context, newHeadRoot [32]byte, headBlock
 	if err := s.setHead(newHead); err != nil {
 		return errors.Wrap(err, "could not set head")
 	}
+	headTime := time.Now()
+	s.cfg.SlotTimingCache.SetHead(newHeadSlot, newHeadRoot, headTime)
+	blockHeadSlotTime.Observe(s.sinceSlotStart(newHeadSlot, headTime))
 
 	// Save the new head root to DB.
 	if err := s.cfg.BeaconDB.SaveHeadBlockRoot(ctx, newHeadRoot); err != nil {
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
 	testDB "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
 	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/blstoexec"
@@ -42,6 +43,7 @@ func TestSaveHead_Different(t *testing.T) {
 	ctx := context.Background()
 	beaconDB := testDB.SetupDB(t)
 	service := setupBeaconChain(t, beaconDB)
+	service.cfg.ReorgCache = cache.NewReorgCache(4)
 
 	oldBlock := util.SaveBlock(t, context.Background(), service.cfg.BeaconDB, util.NewBeaconBlock())
 	oldRoot, err := oldBlock.Block().HashTreeRoot()
@@ -148,6 +150,12 @@ func TestSaveHead_Different_Reorg(t *testing.T) {
 	require.LogsContain(t, hook, "Chain reorg occurred")
 	require.LogsContain(t, hook, "distance=1")
 	require.LogsContain(t, hook, "depth=1")
+	reorgs := service.cfg.ReorgCache.Since(0)
+	require.Equal(t, 1, len(reorgs))
+	assert.Equal(t, primitives.Slot(1), reorgs[0].Slot)
+	assert.Equal(t, uint64(1), reorgs[0].Depth)
+	assert.Equal(t, oldRoot, reorgs[0].OldHeadRoot)
+	assert.Equal(t, newRoot, reorgs[0].NewHeadRoot)
 }
 
 func Test_notifyNewHeadEvent(t *testing.T) {
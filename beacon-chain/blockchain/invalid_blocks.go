@@ -0,0 +1,54 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/sirupsen/logrus"
+)
+
+// recordInvalidBlock adds a block which failed processing to the invalid block registry, along with the
+// invalid ancestors reported by the execution engine. Failures due to the context being done are not recorded.
+func (s *Service) recordInvalidBlock(ctx context.Context, blk interfaces.ReadOnlySignedBeaconBlock, root [32]byte, stage cache.InvalidBlockStage, err error) {
+	if s.cfg.InvalidBlocks == nil || ctx.Err() != nil {
+		return
+	}
+	now := time.Now()
+	enc, encErr := blk.MarshalSSZ()
+	if encErr != nil {
+		log.WithError(encErr).Error("Could not encode invalid block")
+	}
+	b := blk.Block()
+	record := &cache.InvalidBlock{
+		Root:          root,
+		ParentRoot:    b.ParentRoot(),
+		Slot:          b.Slot(),
+		ProposerIndex: b.ProposerIndex(),
+		Peer:          s.cfg.SlotTimingCache.BlockPeer(b.Slot(), root),
+		Stage:         stage,
+		Error:         err.Error(),
+		Time:          now,
+		Version:       blk.Version(),
+		Block:         enc,
+	}
+	if addErr := s.cfg.InvalidBlocks.Add(ctx, record); addErr != nil {
+		log.WithError(addErr).Error("Could not record invalid block")
+	}
+	for _, r := range InvalidAncestorRoots(err) {
+		if r == root {
+			continue
+		}
+		ancestor := &cache.InvalidBlock{Root: r, Stage: stage, Error: err.Error(), Time: now}
+		if addErr := s.cfg.InvalidBlocks.Add(ctx, ancestor); addErr != nil {
+			log.WithError(addErr).Error("Could not record invalid block")
+		}
+	}
+	log.WithError(err).WithFields(logrus.Fields{
+		"blockRoot": fmt.Sprintf("%#x", root),
+		"slot":      b.Slot(),
+		"stage":     stage,
+	}).Debug("Recorded invalid block")
+}
@@ -0,0 +1,15 @@
+package kzg
+
+import "github.com/pkg/errors"
+
+// ComputeBlobKZGProof computes the KZG proof of a blob for its commitment.
+func ComputeBlobKZGProof(blob []byte, commitment []byte) ([]byte, error) {
+	if kzgContext == nil {
+		return nil, errors.New("kzg context is not initialized")
+	}
+	proof, err := kzgContext.ComputeBlobKZGProof(bytesToBlob(blob), bytesToCommitment(commitment), 0)
+	if err != nil {
+		return nil, err
+	}
+	return proof[:], nil
+}
@@ -0,0 +1,18 @@
+package kzg
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestComputeBlobKZGProof(t *testing.T) {
+	require.NoError(t, Start())
+	blob := util.GetRandBlob(123)
+	commitment, expectedProof, err := GenerateCommitmentAndProof(blob)
+	require.NoError(t, err)
+	proof, err := ComputeBlobKZGProof(blob[:], commitment[:])
+	require.NoError(t, err)
+	require.DeepEqual(t, expectedProof[:], proof)
+}
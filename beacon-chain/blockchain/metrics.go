@@ -2,6 +2,7 @@ package blockchain
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
@@ -15,6 +16,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
 )
 
 var (
@@ -221,8 +223,34 @@ var (
 			Buckets: []float64{1, 2, 4, 8, 16, 32},
 		},
 	)
+	newPayloadSlotTime = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "block_new_payload_slot_time_milliseconds",
+			Help:    "Captures the time since the start of the slot at which the execution engine returned from newPayload for a block",
+			Buckets: []float64{100, 250, 500, 750, 1000, 1500, 2000, 4000, 8000, 12000, 16000, 20000, 24000},
+		},
+	)
+	blockImportSlotTime = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "block_import_slot_time_milliseconds",
+			Help:    "Captures the time since the start of the slot at which a block was imported",
+			Buckets: []float64{100, 250, 500, 750, 1000, 1500, 2000, 4000, 8000, 12000, 16000, 20000, 24000},
+		},
+	)
+	blockHeadSlotTime = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "block_head_slot_time_milliseconds",
+			Help:    "Captures the time since the start of the slot at which a block became head",
+			Buckets: []float64{100, 250, 500, 750, 1000, 1500, 2000, 4000, 8000, 12000, 16000, 20000, 24000},
+		},
+	)
 )
 
+// sinceSlotStart returns the number of milliseconds between the start of the slot and the given time.
+func (s *Service) sinceSlotStart(slot primitives.Slot, t time.Time) float64 {
+	return float64(t.Sub(slots.StartTime(uint64(s.genesisTime.Unix()), slot)).Milliseconds())
+}
+
 // reportSlotMetrics reports slot related metrics.
 func reportSlotMetrics(stateSlot, headSlot, clockSlot primitives.Slot, finalizedCheckpoint *ethpb.Checkpoint) {
 	clockTimeSlot.Set(float64(clockSlot))
@@ -85,6 +85,30 @@ func WithTrackedValidatorsCache(c *cache.TrackedValidatorsCache) Option {
 	}
 }
 
+// WithSlotTimingCache for recording block processing timings.
+func WithSlotTimingCache(c *cache.SlotTimingCache) Option {
+	return func(s *Service) error {
+		s.cfg.SlotTimingCache = c
+		return nil
+	}
+}
+
+// WithInvalidBlockRegistry for recording rejected blocks and rejecting their descendants.
+func WithInvalidBlockRegistry(r *cache.InvalidBlockRegistry) Option {
+	return func(s *Service) error {
+		s.cfg.InvalidBlocks = r
+		return nil
+	}
+}
+
+// WithReorgCache for recording the reorgs observed by the node.
+func WithReorgCache(c *cache.ReorgCache) Option {
+	return func(s *Service) error {
+		s.cfg.ReorgCache = c
+		return nil
+	}
+}
+
 // WithAttestationCache for attestation lifecycle after chain inclusion.
 func WithAttestationCache(c *cache.AttestationCache) Option {
 	return func(s *Service) error {
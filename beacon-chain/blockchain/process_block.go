@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
 	coreTime "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
@@ -75,6 +76,7 @@ func (s *Service) postBlockProcess(cfg *postBlockProcessConfig) error {
 	defer reportProcessingTime(startTime)
 	defer reportAttestationInclusion(cfg.roblock.Block())
 
+	forkchoiceStartTime := time.Now()
 	err := s.cfg.ForkChoiceStore.InsertNode(ctx, cfg.postState, cfg.roblock)
 	if err != nil {
 		// Do not use parent context in the event it deadlined
@@ -93,6 +95,7 @@ func (s *Service) postBlockProcess(cfg *postBlockProcessConfig) error {
 		}
 	}
 	start := time.Now()
+	s.recordImportStage(cfg.roblock, cache.ImportStageForkchoice, forkchoiceStartTime)
 	cfg.headRoot, err = s.cfg.ForkChoiceStore.Head(ctx)
 	if err != nil {
 		log.WithError(err).Warn("Could not update head")
@@ -109,6 +112,7 @@ func (s *Service) postBlockProcess(cfg *postBlockProcessConfig) error {
 	if err := s.sendFCU(cfg, fcuArgs); err != nil {
 		return errors.Wrap(err, "could not send FCU to engine")
 	}
+	s.recordImportStage(cfg.roblock, cache.ImportStageHeadUpdate, start)
 
 	return nil
 }
@@ -182,10 +186,12 @@ func (s *Service) onBlockBatch(ctx context.Context, blks []consensusblocks.ROBlo
 			header:  h,
 		}
 
+		stateTransitionStartTime := time.Now()
 		set, preState, err = transition.ExecuteStateTransitionNoVerifyAnySig(ctx, preState, b)
 		if err != nil {
 			return invalidBlock{error: err}
 		}
+		s.recordImportStage(b, cache.ImportStageStateTransition, stateTransitionStartTime)
 		// Save potential boundary states.
 		if slots.IsEpochStart(preState.Slot()) {
 			boundaries[b.Root()] = preState.Copy()
@@ -205,6 +211,7 @@ func (s *Service) onBlockBatch(ctx context.Context, blks []consensusblocks.ROBlo
 	}
 
 	var verify bool
+	sigStartTime := time.Now()
 	if features.Get().EnableVerboseSigVerification {
 		verify, err = sigSet.VerifyVerbosely()
 	} else {
@@ -216,6 +223,11 @@ func (s *Service) onBlockBatch(ctx context.Context, blks []consensusblocks.ROBlo
 	if !verify {
 		return errors.New("batch block signature verification failed")
 	}
+	// The signatures of the batch are verified together, so all its blocks share the span.
+	sigEndTime := time.Now()
+	for _, b := range blks {
+		s.cfg.SlotTimingCache.AddImportStage(b.Block().Slot(), b.Root(), cache.ImportStageSignatureVerification, sigStartTime, sigEndTime)
+	}
 
 	// blocks have been verified, save them and call the engine
 	pendingNodes := make([]*forkchoicetypes.BlockAndCheckpoints, len(blks))
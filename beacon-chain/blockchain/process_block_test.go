@@ -46,7 +46,7 @@ import (
 )
 
 func TestStore_OnBlockBatch(t *testing.T) {
-	service, tr := minimalTestService(t)
+	service, tr := minimalTestService(t, WithSlotTimingCache(cache.NewSlotTimingCache(128)))
 	ctx := tr.ctx
 
 	st, keys := util.DeterministicGenesisState(t, 64)
@@ -76,6 +76,12 @@ func TestStore_OnBlockBatch(t *testing.T) {
 	jroot := bytesutil.ToBytes32(jcp.Root)
 	require.Equal(t, blks[63].Root(), jroot)
 	require.Equal(t, primitives.Epoch(2), service.cfg.ForkChoiceStore.JustifiedCheckpoint().Epoch)
+
+	timing, ok := service.cfg.SlotTimingCache.Timing(blks[96].Block().Slot(), blks[96].Root())
+	require.Equal(t, true, ok)
+	require.Equal(t, 2, len(timing.ImportStages))
+	assert.Equal(t, cache.ImportStageStateTransition, timing.ImportStages[0].Name)
+	assert.Equal(t, cache.ImportStageSignatureVerification, timing.ImportStages[1].Name)
 }
 
 func TestStore_OnBlockBatch_NotifyNewPayload(t *testing.T) {
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/electra"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
 	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
@@ -74,20 +75,28 @@ func (s *Service) ReceiveBlock(ctx context.Context, block interfaces.ReadOnlySig
 	s.blockBeingSynced.set(blockRoot)
 	defer s.blockBeingSynced.unset(blockRoot)
 
+	if parentRoot := block.Block().ParentRoot(); s.cfg.InvalidBlocks.IsInvalid(parentRoot) {
+		err := invalidBlock{error: errors.Wrapf(errInvalidParent, "parent root %#x", parentRoot), root: blockRoot}
+		s.recordInvalidBlock(ctx, block, blockRoot, cache.InvalidBlockStageStateTransition, err)
+		return err
+	}
+
 	blockCopy, err := block.Copy()
 	if err != nil {
 		return err
 	}
+	roblock, err := blocks.NewROBlockWithRoot(blockCopy, blockRoot)
+	if err != nil {
+		return err
+	}
+	preStateStartTime := time.Now()
 	preState, err := s.getBlockPreState(ctx, blockCopy.Block())
 	if err != nil {
 		return errors.Wrap(err, "could not get block's prestate")
 	}
+	s.recordImportStage(roblock, cache.ImportStagePreState, preStateStartTime)
 
 	currentCheckpoints := s.saveCurrentCheckpoints(preState)
-	roblock, err := blocks.NewROBlockWithRoot(blockCopy, blockRoot)
-	if err != nil {
-		return err
-	}
 
 	postState, isValidPayload, err := s.validateExecutionAndConsensus(ctx, preState, roblock)
 	if err != nil {
@@ -95,6 +104,7 @@ func (s *Service) ReceiveBlock(ctx context.Context, block interfaces.ReadOnlySig
 	}
 	daWaitedTime, err := s.handleDA(ctx, blockCopy, blockRoot, avs)
 	if err != nil {
+		s.recordInvalidBlock(ctx, blockCopy, blockRoot, cache.InvalidBlockStageDataAvailability, err)
 		return err
 	}
 	// Defragment the state before continuing block processing.
@@ -103,9 +113,14 @@ func (s *Service) ReceiveBlock(ctx context.Context, block interfaces.ReadOnlySig
 	// The rest of block processing takes a lock on forkchoice.
 	s.cfg.ForkChoiceStore.Lock()
 	defer s.cfg.ForkChoiceStore.Unlock()
+	postStateStartTime := time.Now()
 	if err := s.savePostStateInfo(ctx, blockRoot, blockCopy, postState); err != nil {
 		return errors.Wrap(err, "could not save post state info")
 	}
+	s.recordImportStage(roblock, cache.ImportStagePostState, postStateStartTime)
+	importedTime := time.Now()
+	s.cfg.SlotTimingCache.SetImported(blockCopy.Block().Slot(), blockRoot, importedTime)
+	blockImportSlotTime.Observe(s.sinceSlotStart(blockCopy.Block().Slot(), importedTime))
 	args := &postBlockProcessConfig{
 		ctx:            ctx,
 		roblock:        roblock,
@@ -113,6 +128,9 @@ func (s *Service) ReceiveBlock(ctx context.Context, block interfaces.ReadOnlySig
 		isValidPayload: isValidPayload,
 	}
 	if err := s.postBlockProcess(args); err != nil {
+		if IsInvalidBlock(err) && InvalidBlockRoot(err) == blockRoot {
+			s.recordInvalidBlock(ctx, blockCopy, blockRoot, cache.InvalidBlockStageExecution, err)
+		}
 		err := errors.Wrap(err, "could not process block")
 		tracing.AnnotateError(span, err)
 		return err
@@ -199,10 +217,15 @@ func (s *Service) validateExecutionAndConsensus(
 	var postState state.BeaconState
 	eg.Go(func() error {
 		var err error
+		startTime := time.Now()
 		postState, err = s.validateStateTransition(ctx, preState, block)
 		if err != nil {
+			if IsInvalidBlock(err) {
+				s.recordInvalidBlock(ctx, block, block.Root(), cache.InvalidBlockStageStateTransition, err)
+			}
 			return errors.Wrap(err, "failed to validate consensus state transition function")
 		}
+		s.recordImportStage(block, cache.ImportStageStateTransition, startTime)
 		return nil
 	})
 	var isValidPayload bool
@@ -210,6 +233,9 @@ func (s *Service) validateExecutionAndConsensus(
 		var err error
 		isValidPayload, err = s.validateExecutionOnBlock(ctx, preStateVersion, preStateHeader, block)
 		if err != nil {
+			if IsInvalidBlock(err) {
+				s.recordInvalidBlock(ctx, block, block.Root(), cache.InvalidBlockStageExecution, err)
+			}
 			return errors.Wrap(err, "could not notify the engine of the new payload")
 		}
 		return nil
@@ -241,6 +267,7 @@ func (s *Service) handleDA(
 		}
 	}
 	daWaitedTime := time.Since(daStartTime)
+	s.cfg.SlotTimingCache.AddImportStage(block.Block().Slot(), blockRoot, cache.ImportStageDataAvailability, daStartTime, daStartTime.Add(daWaitedTime))
 	dataAvailWaitedTime.Observe(float64(daWaitedTime.Milliseconds()))
 	return daWaitedTime, nil
 }
@@ -542,6 +569,11 @@ func (s *Service) sendNewFinalizedEvent(ctx context.Context, postState state.Bea
 	})
 }
 
+// recordImportStage records the time spent by a block in a stage of its import, from start until now.
+func (s *Service) recordImportStage(b blocks.ROBlock, stage string, start time.Time) {
+	s.cfg.SlotTimingCache.AddImportStage(b.Block().Slot(), b.Root(), stage, start, time.Now())
+}
+
 // sendBlockAttestationsToSlasher sends the incoming block's attestation to the slasher
 func (s *Service) sendBlockAttestationsToSlasher(signed interfaces.ReadOnlySignedBeaconBlock, preState state.BeaconState) {
 	// Feed the indexed attestation to slasher if enabled. This action
@@ -564,7 +596,14 @@ func (s *Service) sendBlockAttestationsToSlasher(signed interfaces.ReadOnlySigne
 
 // validateExecutionOnBlock notifies the engine of the incoming block execution payload and returns true if the payload is valid
 func (s *Service) validateExecutionOnBlock(ctx context.Context, ver int, header interfaces.ExecutionData, block blocks.ROBlock) (bool, error) {
+	startTime := time.Now()
 	isValidPayload, err := s.notifyNewPayload(ctx, ver, header, block)
+	if ver >= version.Bellatrix {
+		returnedTime := time.Now()
+		s.cfg.SlotTimingCache.SetNewPayload(block.Block().Slot(), block.Root(), returnedTime)
+		s.cfg.SlotTimingCache.AddImportStage(block.Block().Slot(), block.Root(), cache.ImportStageExecution, startTime, returnedTime)
+		newPayloadSlotTime.Observe(s.sinceSlotStart(block.Block().Slot(), returnedTime))
+	}
 	if err != nil {
 		s.cfg.ForkChoiceStore.Lock()
 		err = s.handleInvalidExecutionError(ctx, err, block.Root(), block.Block().ParentRoot())
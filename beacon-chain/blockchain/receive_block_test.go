@@ -197,6 +197,42 @@ func TestService_ReceiveBlockUpdateHead(t *testing.T) {
 	assert.Equal(t, 2, s.cfg.ForkChoiceStore.NodeCount())
 }
 
+func TestService_ReceiveBlock_InvalidParent(t *testing.T) {
+	registry := cache.NewInvalidBlockRegistry(8)
+	s, tr := minimalTestService(t, WithInvalidBlockRegistry(registry), WithSlotTimingCache(cache.NewSlotTimingCache(4)))
+	ctx := tr.ctx
+	parentRoot := [32]byte{'a'}
+	require.NoError(t, registry.Add(ctx, &cache.InvalidBlock{Root: parentRoot, Stage: cache.InvalidBlockStageExecution}))
+
+	b := util.NewBeaconBlock()
+	b.Block.Slot = 2
+	b.Block.ProposerIndex = 5
+	b.Block.ParentRoot = parentRoot[:]
+	wsb, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	root, err := b.Block.HashTreeRoot()
+	require.NoError(t, err)
+	s.cfg.SlotTimingCache.SetBlockArrival(2, root, "peer", time.Now())
+
+	err = s.ReceiveBlock(ctx, wsb, root, nil)
+	require.ErrorContains(t, errInvalidParent.Error(), err)
+	require.Equal(t, true, IsInvalidBlock(err))
+	require.Equal(t, root, InvalidBlockRoot(err))
+
+	// The block is recorded, so that its own descendants are rejected too.
+	require.Equal(t, true, registry.IsInvalid(root))
+	record, ok := registry.Get(root)
+	require.Equal(t, true, ok)
+	assert.Equal(t, cache.InvalidBlockStageStateTransition, record.Stage)
+	assert.Equal(t, parentRoot, record.ParentRoot)
+	assert.Equal(t, primitives.Slot(2), record.Slot)
+	assert.Equal(t, primitives.ValidatorIndex(5), record.ProposerIndex)
+	assert.Equal(t, "peer", record.Peer)
+	enc, err := wsb.MarshalSSZ()
+	require.NoError(t, err)
+	assert.DeepEqual(t, enc, record.Block)
+}
+
 func TestService_ReceiveBlockBatch(t *testing.T) {
 	ctx := context.Background()
 
@@ -533,3 +569,22 @@ func Test_executePostFinalizationTasks(t *testing.T) {
 	})
 
 }
+
+func TestService_HandleDA_RecordsImportStage(t *testing.T) {
+	s, tr := minimalTestService(t, WithSlotTimingCache(cache.NewSlotTimingCache(4)))
+	b := util.NewBeaconBlock()
+	b.Block.Slot = 2
+	wsb, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	root := [32]byte{'a'}
+
+	_, err = s.handleDA(tr.ctx, wsb, root, nil)
+	require.NoError(t, err)
+	timings := s.cfg.SlotTimingCache.Timings(2)
+	require.Equal(t, 1, len(timings))
+	assert.Equal(t, root, timings[0].BlockRoot)
+	require.Equal(t, 1, len(timings[0].ImportStages))
+	stage := timings[0].ImportStages[0]
+	assert.Equal(t, cache.ImportStageDataAvailability, stage.Name)
+	assert.Equal(t, false, stage.End.Before(stage.Start))
+}
@@ -344,6 +344,70 @@ func TestChainService_InitializeChainInfo_SetHeadAtGenesis(t *testing.T) {
 	assert.DeepEqual(t, headBlock, pb)
 }
 
+func TestChainService_RestoreForkChoiceStore(t *testing.T) {
+	hook := logTest.NewGlobal()
+	genesis := util.NewBeaconBlock()
+	genesisRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	finalizedSlot := params.BeaconConfig().SlotsPerEpoch*2 + 1
+	headBlock := util.NewBeaconBlock()
+	headBlock.Block.Slot = finalizedSlot
+	headBlock.Block.ParentRoot = bytesutil.PadTo(genesisRoot[:], 32)
+	headState, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, headState.SetSlot(finalizedSlot))
+	require.NoError(t, headState.SetGenesisValidatorsRoot(params.BeaconConfig().ZeroHash[:]))
+	headRoot, err := headBlock.Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	c, tr := minimalTestService(t, WithFinalizedStateAtStartUp(headState))
+	ctx, beaconDB := tr.ctx, tr.db
+	util.SaveBlock(t, ctx, beaconDB, genesis)
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, genesisRoot))
+	require.NoError(t, beaconDB.SaveState(ctx, headState, genesisRoot))
+	require.NoError(t, beaconDB.SaveState(ctx, headState, headRoot))
+	util.SaveBlock(t, ctx, beaconDB, headBlock)
+	require.NoError(t, beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: finalizedSlot, Root: headRoot[:]}))
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Root: headRoot[:], Epoch: slots.ToEpoch(finalizedSlot)}))
+	require.NoError(t, beaconDB.SaveHeadBlockRoot(ctx, headRoot))
+
+	require.NoError(t, c.StartFromSavedState(headState))
+	require.NoError(t, c.Stop())
+	require.LogsContain(t, hook, "Saved fork choice store")
+
+	restart := func() *Service {
+		fcs := doublylinkedtree.New()
+		s, err := NewService(ctx,
+			WithFinalizedStateAtStartUp(headState),
+			WithDatabase(beaconDB),
+			WithStateGen(stategen.New(beaconDB, fcs)),
+			WithForkChoiceStore(fcs),
+			WithClockSynchronizer(startup.NewClockSynchronizer()),
+			WithAttestationService(tr.attSrv))
+		require.NoError(t, err)
+		require.NoError(t, s.StartFromSavedState(headState))
+		return s
+	}
+	restarted := restart()
+	require.LogsContain(t, hook, "Restored fork choice store saved on shutdown")
+	want, err := c.cfg.ForkChoiceStore.ForkChoiceDump(ctx)
+	require.NoError(t, err)
+	got, err := restarted.cfg.ForkChoiceStore.ForkChoiceDump(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, want, got)
+	// The snapshot is only restored once.
+	_, err = beaconDB.ForkChoiceSnapshot(ctx)
+	require.ErrorIs(t, err, db.ErrNotFound)
+
+	// A snapshot which doesn't match the database head is discarded.
+	require.NoError(t, restarted.Stop())
+	require.NoError(t, beaconDB.SaveHeadBlockRoot(ctx, genesisRoot))
+	hook.Reset()
+	restarted = restart()
+	require.LogsContain(t, hook, "Could not restore saved fork choice store")
+	require.Equal(t, 1, restarted.cfg.ForkChoiceStore.NodeCount())
+}
+
 func TestChainService_SaveHeadNoDB(t *testing.T) {
 	beaconDB := testDB.SetupDB(t)
 	ctx := context.Background()
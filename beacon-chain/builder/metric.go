@@ -27,4 +27,33 @@ var (
 			Buckets: []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000},
 		},
 	)
+	relayGetHeaderLatency = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "relay_get_header_latency_milliseconds",
+			Help:    "Captures RPC latency for get header in milliseconds, per relay",
+			Buckets: []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000},
+		},
+		[]string{"relay"},
+	)
+	relayBidsCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "relay_bids_total",
+			Help: "The number of header requests to a relay, by whether its bid won, lost or could not be used",
+		},
+		[]string{"relay", "result"},
+	)
+	relayCircuitBreakerCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "relay_circuit_breaker_total",
+			Help: "The number of header requests not sent to a relay, by the circuit breaker which was active",
+		},
+		[]string{"relay", "breaker"},
+	)
+	relayMissedSlotsCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "relay_missed_slots_total",
+			Help: "The number of slots for which a relay won the bid but did not reveal the payload",
+		},
+		[]string{"relay"},
+	)
 )
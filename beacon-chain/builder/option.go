@@ -13,25 +13,26 @@ type Option func(s *Service) error
 
 // FlagOptions for builder service flag configurations.
 func FlagOptions(c *cli.Context) ([]Option, error) {
-	endpoint := c.String(flags.MevRelayEndpoint.Name)
-	var client *builder.Client
-	if endpoint != "" {
-		var err error
-		client, err = builder.NewClient(endpoint)
+	endpoints := c.StringSlice(flags.MevRelayEndpoints.Name)
+	if endpoint := c.String(flags.MevRelayEndpoint.Name); endpoint != "" {
+		endpoints = append([]string{endpoint}, endpoints...)
+	}
+	var opts []Option
+	for _, endpoint := range endpoints {
+		client, err := builder.NewClient(endpoint)
 		if err != nil {
 			return nil, err
 		}
-	}
-	opts := []Option{
-		WithBuilderClient(client),
+		opts = append(opts, WithBuilderClient(client))
 	}
 	return opts, nil
 }
 
-// WithBuilderClient sets the builder client for the beacon chain builder service.
+// WithBuilderClient adds a relay client to the beacon chain builder service. Headers are requested from all the
+// relays, and the blinded block is submitted to the relay whose bid was chosen.
 func WithBuilderClient(client builder.BuilderClient) Option {
 	return func(s *Service) error {
-		s.cfg.builderClient = client
+		s.cfg.builderClients = append(s.cfg.builderClients, client)
 		return nil
 	}
 }
@@ -59,3 +60,11 @@ func WithRegistrationCache() Option {
 		return nil
 	}
 }
+
+// WithBidLog records the bids received from the relays and the payloads they revealed in the builder bid log.
+func WithBidLog(l *cache.BuilderBidLog) Option {
+	return func(s *Service) error {
+		s.cfg.bidLog = l
+		return nil
+	}
+}
@@ -0,0 +1,119 @@
+package builder
+
+import (
+	"context"
+	"net/url"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client/builder"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+// maxRelayConsecutiveFailures is the number of consecutive failed header requests after which a relay is not
+// requested anymore, until its status endpoint reports it as healthy again.
+const maxRelayConsecutiveFailures = 3
+
+var errNilBid = errors.New("relay returned nil bid")
+
+// relay is a MEV relay, with the state of its health and missed slot circuit breakers.
+type relay struct {
+	client builder.BuilderClient
+	name   string
+
+	sync.Mutex
+	statusErr           error
+	consecutiveFailures int
+	// missedSlots are the slots for which the relay won the bid but did not reveal the payload.
+	missedSlots       []primitives.Slot
+	consecutiveMissed int
+}
+
+func newRelay(client builder.BuilderClient) *relay {
+	return &relay{client: client, name: relayName(client.NodeURL())}
+}
+
+// relayName returns the host of the relay url, without the relay public key or credentials it may contain.
+func relayName(nodeURL string) string {
+	u, err := url.Parse(nodeURL)
+	if err != nil || u.Host == "" {
+		return nodeURL
+	}
+	return u.Host
+}
+
+// available returns true if none of the circuit breakers of the relay is active at the slot.
+func (r *relay) available(slot primitives.Slot) bool {
+	return r.breaker(slot) == ""
+}
+
+// breaker returns the name of the active circuit breaker of the relay at the slot, or an empty string.
+func (r *relay) breaker(slot primitives.Slot) string {
+	r.Lock()
+	defer r.Unlock()
+	if r.statusErr != nil || r.consecutiveFailures >= maxRelayConsecutiveFailures {
+		return "health"
+	}
+	r.pruneMissedSlots(slot)
+	if len(r.missedSlots) == 0 {
+		// The consecutive missed slots breaker is reset once the relay did not miss a slot for an epoch.
+		r.consecutiveMissed = 0
+	}
+	cfg := params.BeaconConfig()
+	if r.consecutiveMissed >= int(cfg.MaxBuilderConsecutiveMissedSlots) || len(r.missedSlots) >= int(cfg.MaxBuilderEpochMissedSlots) {
+		return "missed_slots"
+	}
+	return ""
+}
+
+// pruneMissedSlots drops the missed slots outside the epoch rolling window ending at the slot.
+func (r *relay) pruneMissedSlots(slot primitives.Slot) {
+	i := 0
+	for ; i < len(r.missedSlots); i++ {
+		if r.missedSlots[i]+params.BeaconConfig().SlotsPerEpoch > slot {
+			break
+		}
+	}
+	r.missedSlots = r.missedSlots[i:]
+}
+
+func (r *relay) getHeader(ctx context.Context, slot primitives.Slot, parentHash [32]byte, pubKey [48]byte) (builder.SignedBid, error) {
+	bid, err := r.client.GetHeader(ctx, slot, parentHash, pubKey)
+	if err == nil && (bid == nil || bid.IsNil()) {
+		err = errNilBid
+	}
+	r.Lock()
+	defer r.Unlock()
+	switch {
+	case err == nil, errors.Is(err, builder.ErrNoContent):
+		// The relay is healthy but may have no bid for the slot.
+		r.consecutiveFailures = 0
+	default:
+		r.consecutiveFailures++
+	}
+	return bid, err
+}
+
+func (r *relay) checkStatus(ctx context.Context) error {
+	err := r.client.Status(ctx)
+	r.Lock()
+	defer r.Unlock()
+	r.statusErr = err
+	if err == nil {
+		r.consecutiveFailures = 0
+	}
+	return err
+}
+
+// payloadRevealed records whether the relay revealed the payload of the bid it won at the slot.
+func (r *relay) payloadRevealed(slot primitives.Slot, revealed bool) {
+	r.Lock()
+	defer r.Unlock()
+	if revealed {
+		r.consecutiveMissed = 0
+		return
+	}
+	r.consecutiveMissed++
+	r.missedSlots = append(r.missedSlots, slot)
+}
@@ -0,0 +1,238 @@
+package builder
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/api/client/builder"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	v1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+// testRelay is a relay client returning a bid for a payload of block hash `hash` and value `value`.
+type testRelay struct {
+	url       string
+	hash      byte
+	value     byte
+	err       error
+	statusErr error
+	submitErr error
+	submitted int
+	regs      int
+	regErr    error
+}
+
+func (r *testRelay) NodeURL() string {
+	return r.url
+}
+
+func (r *testRelay) GetHeader(context.Context, primitives.Slot, [32]byte, [48]byte) (builder.SignedBid, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	value := make([]byte, 32)
+	value[0] = r.value
+	return builder.WrappedSignedBuilderBid(&eth.SignedBuilderBid{
+		Message: &eth.BuilderBid{
+			Header: &v1.ExecutionPayloadHeader{BlockHash: []byte{r.hash}},
+			Value:  value,
+		},
+	})
+}
+
+func (r *testRelay) RegisterValidator(context.Context, []*eth.SignedValidatorRegistrationV1) error {
+	r.regs++
+	return r.regErr
+}
+
+func (r *testRelay) SubmitBlindedBlock(context.Context, interfaces.ReadOnlySignedBeaconBlock) (interfaces.ExecutionData, *v1.BlobsBundle, error) {
+	r.submitted++
+	if r.submitErr != nil {
+		return nil, nil, r.submitErr
+	}
+	p, err := blocks.WrappedExecutionPayload(&v1.ExecutionPayload{BlockHash: []byte{r.hash}})
+	return p, nil, err
+}
+
+func (r *testRelay) Status(context.Context) error {
+	return r.statusErr
+}
+
+func blindedBlock(t *testing.T, slot primitives.Slot, hash byte) interfaces.ReadOnlySignedBeaconBlock {
+	b := util.NewBlindedBeaconBlockBellatrix()
+	b.Block.Slot = slot
+	b.Block.Body.ExecutionPayloadHeader.BlockHash = []byte{hash}
+	sb, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	return sb
+}
+
+func bidHash(t *testing.T, signedBid builder.SignedBid) byte {
+	bid, err := signedBid.Message()
+	require.NoError(t, err)
+	header, err := bid.Header()
+	require.NoError(t, err)
+	return header.BlockHash()[0]
+}
+
+func TestGetHeader_MultipleRelays(t *testing.T) {
+	ctx := context.Background()
+	a := &testRelay{url: "https://0xabcd@a.example.com", hash: 'a', value: 1}
+	b := &testRelay{url: "https://b.example.com", hash: 'b', value: 3}
+	c := &testRelay{url: "https://c.example.com", hash: 'c', value: 2}
+	d := &testRelay{url: "https://d.example.com", err: errors.New("timeout")}
+	s, err := NewService(ctx, WithBuilderClient(a), WithBuilderClient(b), WithBuilderClient(c), WithBuilderClient(d))
+	require.NoError(t, err)
+	assert.Equal(t, "a.example.com", s.relays[0].name)
+
+	bid, err := s.GetHeader(ctx, 1, [32]byte{}, [48]byte{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, byte('b'), bidHash(t, bid))
+
+	// The highest bid is not valid.
+	validate := func(signedBid builder.SignedBid) error {
+		if bidHash(t, signedBid) == 'b' {
+			return errors.New("invalid bid")
+		}
+		return nil
+	}
+	bid, err = s.GetHeader(ctx, 2, [32]byte{}, [48]byte{}, validate)
+	require.NoError(t, err)
+	assert.Equal(t, byte('c'), bidHash(t, bid))
+
+	// The blinded block is submitted to the relay whose bid was chosen.
+	payload, _, err := s.SubmitBlindedBlock(ctx, blindedBlock(t, 2, 'c'))
+	require.NoError(t, err)
+	assert.Equal(t, byte('c'), payload.BlockHash()[0])
+	assert.Equal(t, 0, a.submitted)
+	assert.Equal(t, 0, b.submitted)
+	assert.Equal(t, 1, c.submitted)
+
+	// The blinded block is submitted to all the relays if the bid is not known.
+	a.submitErr = errors.New("unknown payload")
+	b.submitErr = errors.New("unknown payload")
+	c.submitErr = errors.New("unknown payload")
+	payload, _, err = s.SubmitBlindedBlock(ctx, blindedBlock(t, 3, 'x'))
+	require.NoError(t, err)
+	assert.Equal(t, byte(0), payload.BlockHash()[0])
+
+	_, err = s.GetHeader(ctx, 4, [32]byte{}, [48]byte{}, func(builder.SignedBid) error {
+		return errors.New("invalid bid")
+	})
+	require.ErrorContains(t, "no valid bid from 4 relays", err)
+}
+
+func TestGetHeader_CircuitBreakers(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("status", func(t *testing.T) {
+		a := &testRelay{url: "https://a.example.com", hash: 'a', value: 1, statusErr: errors.New("down")}
+		b := &testRelay{url: "https://b.example.com", hash: 'b', value: 2, statusErr: errors.New("down")}
+		s, err := NewService(ctx, WithBuilderClient(a), WithBuilderClient(b))
+		require.NoError(t, err)
+		_, err = s.GetHeader(ctx, 1, [32]byte{}, [48]byte{}, nil)
+		require.ErrorIs(t, err, ErrNoRelayAvailable)
+
+		a.statusErr = nil
+		require.NoError(t, s.relays[0].checkStatus(ctx))
+		bid, err := s.GetHeader(ctx, 1, [32]byte{}, [48]byte{}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, byte('a'), bidHash(t, bid))
+	})
+
+	t.Run("consecutive failures", func(t *testing.T) {
+		a := &testRelay{url: "https://a.example.com", err: errors.New("timeout")}
+		s, err := NewService(ctx, WithBuilderClient(a))
+		require.NoError(t, err)
+		for i := 0; i < maxRelayConsecutiveFailures; i++ {
+			_, err = s.GetHeader(ctx, 1, [32]byte{}, [48]byte{}, nil)
+			require.ErrorContains(t, "timeout", err)
+		}
+		_, err = s.GetHeader(ctx, 1, [32]byte{}, [48]byte{}, nil)
+		require.ErrorIs(t, err, ErrNoRelayAvailable)
+
+		// A healthy relay without bid is not broken.
+		a.err = builder.ErrNoContent
+		require.NoError(t, s.relays[0].checkStatus(ctx))
+		for i := 0; i < maxRelayConsecutiveFailures; i++ {
+			_, err = s.GetHeader(ctx, 1, [32]byte{}, [48]byte{}, nil)
+			require.ErrorIs(t, err, builder.ErrNoContent)
+		}
+		assert.Equal(t, true, s.relays[0].available(1))
+	})
+
+	t.Run("missed slots", func(t *testing.T) {
+		a := &testRelay{url: "https://a.example.com", hash: 'a', value: 2, submitErr: errors.New("timeout")}
+		b := &testRelay{url: "https://b.example.com", hash: 'b', value: 1}
+		s, err := NewService(ctx, WithBuilderClient(a), WithBuilderClient(b))
+		require.NoError(t, err)
+		maxMissed := params.BeaconConfig().MaxBuilderConsecutiveMissedSlots
+		var slot primitives.Slot
+		for ; slot < maxMissed; slot++ {
+			bid, err := s.GetHeader(ctx, slot, [32]byte{}, [48]byte{}, nil)
+			require.NoError(t, err)
+			require.Equal(t, byte('a'), bidHash(t, bid))
+			_, _, err = s.SubmitBlindedBlock(ctx, blindedBlock(t, slot, 'a'))
+			require.ErrorContains(t, "did not reveal the payload", err)
+		}
+		bid, err := s.GetHeader(ctx, slot, [32]byte{}, [48]byte{}, nil)
+		require.NoError(t, err)
+		require.Equal(t, byte('b'), bidHash(t, bid))
+
+		// The breaker is reset once the relay did not miss a slot for an epoch.
+		bid, err = s.GetHeader(ctx, slot+params.BeaconConfig().SlotsPerEpoch, [32]byte{}, [48]byte{}, nil)
+		require.NoError(t, err)
+		require.Equal(t, byte('a'), bidHash(t, bid))
+	})
+}
+
+func TestRegisterValidator_MultipleRelays(t *testing.T) {
+	ctx := context.Background()
+	a := &testRelay{url: "https://a.example.com", regErr: errors.New("down")}
+	b := &testRelay{url: "https://b.example.com"}
+	require.NoError(t, (&Service{relays: []*relay{newRelay(a), newRelay(b)}}).registerValidatorWithRelays(ctx, nil))
+	assert.Equal(t, 1, a.regs)
+	assert.Equal(t, 1, b.regs)
+
+	b.regErr = errors.New("down")
+	require.ErrorContains(t, "down", (&Service{relays: []*relay{newRelay(a), newRelay(b)}}).registerValidatorWithRelays(ctx, nil))
+}
+
+func TestGetHeader_BidLog(t *testing.T) {
+	ctx := context.Background()
+	a := &testRelay{url: "https://a.example.com", hash: 'a', value: 1}
+	b := &testRelay{url: "https://b.example.com", hash: 'b', value: 3, submitErr: errors.New("timeout")}
+	c := &testRelay{url: "https://c.example.com", err: errors.New("timeout")}
+	bidLog := cache.NewBuilderBidLog(8)
+	s, err := NewService(ctx, WithBuilderClient(a), WithBuilderClient(b), WithBuilderClient(c), WithBidLog(bidLog))
+	require.NoError(t, err)
+
+	_, err = s.GetHeader(ctx, 1, [32]byte{}, [48]byte{}, nil)
+	require.NoError(t, err)
+	_, _, err = s.SubmitBlindedBlock(ctx, blindedBlock(t, 1, 'b'))
+	require.ErrorContains(t, "did not reveal the payload", err)
+
+	record, ok := bidLog.Get(1)
+	require.Equal(t, true, ok)
+	require.Equal(t, 3, len(record.Bids))
+	assert.Equal(t, "a.example.com", record.Bids[0].Relay)
+	assert.DeepEqual(t, []byte{'a'}, record.Bids[0].BlockHash)
+	assert.Equal(t, "1", record.Bids[0].Value)
+	assert.Equal(t, "", record.Bids[0].Error)
+	assert.Equal(t, "3", record.Bids[1].Value)
+	assert.Equal(t, "timeout", record.Bids[2].Error)
+	assert.Equal(t, 0, len(record.Bids[2].BlockHash))
+	assert.Equal(t, "b.example.com", record.WinningRelay)
+	require.NotNil(t, record.Reveal)
+	assert.Equal(t, "b.example.com", record.Reveal.Relay)
+	assert.Equal(t, "timeout", record.Reveal.Error)
+}
@@ -2,7 +2,9 @@ package builder
 
 import (
 	"context"
+	"math/big"
 	"reflect"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -10,6 +12,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
@@ -23,10 +26,19 @@ import (
 // ErrNoBuilder is used when builder endpoint is not configured.
 var ErrNoBuilder = errors.New("builder endpoint not configured")
 
+// ErrNoRelayAvailable is used when the circuit breakers of all the configured relays are active.
+var ErrNoRelayAvailable = errors.New("no builder relay available")
+
+// getHeaderTimeout is the maximum amount of time allowed for the relays to respond to a header request.
+const getHeaderTimeout = time.Second
+
+// BidValidator returns an error if the bid of a relay can't be used for the block being proposed.
+type BidValidator = func(builder.SignedBid) error
+
 // BlockBuilder defines the interface for interacting with the block builder
 type BlockBuilder interface {
 	SubmitBlindedBlock(ctx context.Context, block interfaces.ReadOnlySignedBeaconBlock) (interfaces.ExecutionData, *v1.BlobsBundle, error)
-	GetHeader(ctx context.Context, slot primitives.Slot, parentHash [32]byte, pubKey [48]byte) (builder.SignedBid, error)
+	GetHeader(ctx context.Context, slot primitives.Slot, parentHash [32]byte, pubKey [48]byte, validate BidValidator) (builder.SignedBid, error)
 	RegisterValidator(ctx context.Context, reg []*ethpb.SignedValidatorRegistrationV1) error
 	RegistrationByValidatorID(ctx context.Context, id primitives.ValidatorIndex) (*ethpb.ValidatorRegistrationV1, error)
 	Configured() bool
@@ -34,45 +46,61 @@ type BlockBuilder interface {
 
 // config defines a config struct for dependencies into the service.
 type config struct {
-	builderClient builder.BuilderClient
-	beaconDB      db.HeadAccessDatabase
-	headFetcher   blockchain.HeadFetcher
+	builderClients []builder.BuilderClient
+	beaconDB       db.HeadAccessDatabase
+	headFetcher    blockchain.HeadFetcher
+	bidLog         *cache.BuilderBidLog
 }
 
 // Service defines a service that provides a client for interacting with the beacon chain and MEV relay network.
 type Service struct {
 	cfg               *config
-	c                 builder.BuilderClient
+	relays            []*relay
 	ctx               context.Context
 	cancel            context.CancelFunc
 	registrationCache *cache.RegistrationCache
+	winnersLock       sync.Mutex
+	winners           map[[32]byte]winningBid
+}
+
+// winningBid is the relay whose bid was chosen for the payload of a block.
+type winningBid struct {
+	relay *relay
+	slot  primitives.Slot
 }
 
 // NewService instantiates a new service.
 func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 	ctx, cancel := context.WithCancel(ctx)
 	s := &Service{
-		ctx:    ctx,
-		cancel: cancel,
-		cfg:    &config{},
+		ctx:     ctx,
+		cancel:  cancel,
+		cfg:     &config{},
+		winners: make(map[[32]byte]winningBid),
 	}
 	for _, opt := range opts {
 		if err := opt(s); err != nil {
 			return nil, err
 		}
 	}
-	if s.cfg.builderClient != nil && !reflect.ValueOf(s.cfg.builderClient).IsNil() {
-		s.c = s.cfg.builderClient
+	for _, c := range s.cfg.builderClients {
+		if c == nil || reflect.ValueOf(c).IsNil() {
+			continue
+		}
+		r := newRelay(c)
+		s.relays = append(s.relays, r)
 
 		// Is the builder up?
-		if err := s.c.Status(ctx); err != nil {
-			log.WithError(err).Error("Failed to check builder status")
+		if err := r.checkStatus(ctx); err != nil {
+			log.WithError(err).WithField("relay", r.name).Error("Failed to check builder status")
 		} else {
-			log.WithField("endpoint", s.c.NodeURL()).Info("Builder has been configured")
-			log.Warn("Outsourcing block construction to external builders adds non-trivial delay to block propagation time.  " +
-				"Builder-constructed blocks or fallback blocks may get orphaned. Use at your own risk!")
+			log.WithField("endpoint", r.name).Info("Builder has been configured")
 		}
 	}
+	if len(s.relays) > 0 {
+		log.Warn("Outsourcing block construction to external builders adds non-trivial delay to block propagation time.  " +
+			"Builder-constructed blocks or fallback blocks may get orphaned. Use at your own risk!")
+	}
 	return s, nil
 }
 
@@ -87,7 +115,8 @@ func (s *Service) Stop() error {
 	return nil
 }
 
-// SubmitBlindedBlock submits a blinded block to the builder relay network.
+// SubmitBlindedBlock submits a blinded block to the relay whose bid was chosen for its payload, or to all the
+// relays if that relay is not known.
 func (s *Service) SubmitBlindedBlock(ctx context.Context, b interfaces.ReadOnlySignedBeaconBlock) (interfaces.ExecutionData, *v1.BlobsBundle, error) {
 	ctx, span := trace.StartSpan(ctx, "builder.SubmitBlindedBlock")
 	defer span.End()
@@ -95,42 +124,253 @@ func (s *Service) SubmitBlindedBlock(ctx context.Context, b interfaces.ReadOnlyS
 	defer func() {
 		submitBlindedBlockLatency.Observe(float64(time.Since(start).Milliseconds()))
 	}()
-	if s.c == nil {
+	if len(s.relays) == 0 {
 		return nil, nil, ErrNoBuilder
 	}
+	if b == nil || b.IsNil() {
+		return nil, nil, errors.New("nil blinded block")
+	}
+
+	w, ok := s.winner(b)
+	if !ok {
+		payload, bundle, name, err := s.submitBlindedBlockToAll(ctx, b)
+		s.logReveal(ctx, b.Block().Slot(), name, start, err)
+		return payload, bundle, err
+	}
+	span.SetAttributes(trace.StringAttribute("relay", w.relay.name))
+	payload, bundle, err := w.relay.client.SubmitBlindedBlock(ctx, b)
+	w.relay.payloadRevealed(w.slot, err == nil)
+	s.logReveal(ctx, w.slot, w.relay.name, start, err)
+	if err != nil {
+		relayMissedSlotsCount.WithLabelValues(w.relay.name).Inc()
+		tracing.AnnotateError(span, err)
+		return nil, nil, errors.Wrapf(err, "relay %s did not reveal the payload", w.relay.name)
+	}
+	return payload, bundle, nil
+}
+
+// winner returns the relay whose bid was chosen for the payload of the block.
+func (s *Service) winner(b interfaces.ReadOnlySignedBeaconBlock) (winningBid, bool) {
+	if len(s.relays) == 1 {
+		return winningBid{relay: s.relays[0], slot: b.Block().Slot()}, true
+	}
+	execution, err := b.Block().Body().Execution()
+	if err != nil {
+		return winningBid{}, false
+	}
+	s.winnersLock.Lock()
+	defer s.winnersLock.Unlock()
+	w, ok := s.winners[bytesutil.ToBytes32(execution.BlockHash())]
+	return w, ok
+}
+
+// logReveal records in the bid log whether the relay revealed the payload of the bid selected for the slot.
+func (s *Service) logReveal(ctx context.Context, slot primitives.Slot, relayName string, start time.Time, err error) {
+	reveal := &cache.PayloadReveal{Relay: relayName, Time: time.Now(), Latency: time.Since(start)}
+	if err != nil {
+		reveal.Error = err.Error()
+	}
+	if err := s.cfg.bidLog.SetReveal(ctx, slot, reveal); err != nil {
+		log.WithError(err).Error("Could not record payload reveal in the builder bid log")
+	}
+}
 
-	return s.c.SubmitBlindedBlock(ctx, b)
+// submitBlindedBlockToAll submits the blinded block to every relay, and returns the first payload revealed along
+// with the name of the relay which revealed it.
+func (s *Service) submitBlindedBlockToAll(ctx context.Context, b interfaces.ReadOnlySignedBeaconBlock) (interfaces.ExecutionData, *v1.BlobsBundle, string, error) {
+	type result struct {
+		payload interfaces.ExecutionData
+		bundle  *v1.BlobsBundle
+		relay   string
+		err     error
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := make(chan result, len(s.relays))
+	for _, r := range s.relays {
+		go func(r *relay) {
+			payload, bundle, err := r.client.SubmitBlindedBlock(ctx, b)
+			results <- result{payload: payload, bundle: bundle, relay: r.name, err: errors.Wrapf(err, "relay %s", r.name)}
+		}(r)
+	}
+	var err error
+	for range s.relays {
+		res := <-results
+		if res.err == nil {
+			return res.payload, res.bundle, res.relay, nil
+		}
+		if err == nil {
+			err = res.err
+		}
+	}
+	return nil, nil, "", errors.Wrap(err, "no relay revealed the payload")
 }
 
-// GetHeader retrieves the header for a given slot and parent hash from the builder relay network.
-func (s *Service) GetHeader(ctx context.Context, slot primitives.Slot, parentHash [32]byte, pubKey [48]byte) (builder.SignedBid, error) {
+// GetHeader requests headers for a given slot and parent hash from all the available relays in parallel, and
+// returns the bid of the highest value which is valid.
+func (s *Service) GetHeader(ctx context.Context, slot primitives.Slot, parentHash [32]byte, pubKey [48]byte, validate BidValidator) (builder.SignedBid, error) {
 	ctx, span := trace.StartSpan(ctx, "builder.GetHeader")
 	defer span.End()
 	start := time.Now()
 	defer func() {
 		getHeaderLatency.Observe(float64(time.Since(start).Milliseconds()))
 	}()
-	if s.c == nil {
+	if len(s.relays) == 0 {
 		tracing.AnnotateError(span, ErrNoBuilder)
 		return nil, ErrNoBuilder
 	}
 
-	h, err := s.c.GetHeader(ctx, slot, parentHash, pubKey)
-	tracing.AnnotateError(span, err)
-	return h, err
+	relays := make([]*relay, 0, len(s.relays))
+	for _, r := range s.relays {
+		if b := r.breaker(slot); b != "" {
+			relayCircuitBreakerCount.WithLabelValues(r.name, b).Inc()
+			continue
+		}
+		relays = append(relays, r)
+	}
+	if len(relays) == 0 {
+		tracing.AnnotateError(span, ErrNoRelayAvailable)
+		return nil, ErrNoRelayAvailable
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, getHeaderTimeout)
+	defer cancel()
+	type result struct {
+		bid      builder.SignedBid
+		value    *big.Int
+		received time.Time
+		err      error
+	}
+	results := make([]result, len(relays))
+	var wg sync.WaitGroup
+	for i, r := range relays {
+		wg.Add(1)
+		go func(i int, r *relay) {
+			defer wg.Done()
+			bid, err := r.getHeader(ctx, slot, parentHash, pubKey)
+			received := time.Now()
+			relayGetHeaderLatency.WithLabelValues(r.name).Observe(float64(received.Sub(start).Milliseconds()))
+			if err == nil && validate != nil {
+				err = validate(bid)
+			}
+			var value *big.Int
+			if err == nil {
+				value, err = bidValue(bid)
+			}
+			if err != nil {
+				relayBidsCount.WithLabelValues(r.name, "error").Inc()
+				log.WithError(err).WithFields(log.Fields{"relay": r.name, "slot": slot}).Debug("Could not get bid from relay")
+			}
+			results[i] = result{bid: bid, value: value, received: received, err: err}
+		}(i, r)
+	}
+	wg.Wait()
+
+	best := -1
+	for i, res := range results {
+		if res.err == nil && (best < 0 || res.value.Cmp(results[best].value) > 0) {
+			best = i
+		}
+	}
+	if s.cfg.bidLog != nil {
+		bids := make([]*cache.BuilderBid, len(results))
+		for i, res := range results {
+			bids[i] = bidLogEntry(relays[i], res.bid, res.received, res.received.Sub(start), res.err)
+		}
+		winningRelay := ""
+		if best >= 0 {
+			winningRelay = relays[best].name
+		}
+		if err := s.cfg.bidLog.AddBids(ctx, slot, bids, winningRelay); err != nil {
+			log.WithError(err).Error("Could not record bids in the builder bid log")
+		}
+	}
+	if best < 0 {
+		err := results[0].err
+		if len(results) > 1 {
+			err = errors.Wrapf(err, "no valid bid from %d relays, first error", len(results))
+		}
+		tracing.AnnotateError(span, err)
+		return nil, err
+	}
+	for i, res := range results {
+		if res.err == nil && i != best {
+			relayBidsCount.WithLabelValues(relays[i].name, "lost").Inc()
+		}
+	}
+	relayBidsCount.WithLabelValues(relays[best].name, "won").Inc()
+	span.SetAttributes(trace.StringAttribute("relay", relays[best].name))
+	if err := s.saveWinner(results[best].bid, relays[best], slot); err != nil {
+		return nil, err
+	}
+	return results[best].bid, nil
+}
+
+// bidLogEntry returns the record of the outcome of a header request to a relay for the bid log. The content of
+// the bid is recorded even when it could not be used.
+func bidLogEntry(r *relay, signedBid builder.SignedBid, received time.Time, latency time.Duration, err error) *cache.BuilderBid {
+	entry := &cache.BuilderBid{Relay: r.name, Received: received, Latency: latency}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	if signedBid == nil || signedBid.IsNil() {
+		return entry
+	}
+	bid, msgErr := signedBid.Message()
+	if msgErr != nil || bid == nil || bid.IsNil() {
+		return entry
+	}
+	entry.BuilderPubkey = bytesutil.SafeCopyBytes(bid.Pubkey())
+	entry.Value = primitives.WeiToBigInt(bid.Value()).String()
+	if header, headerErr := bid.Header(); headerErr == nil && header != nil && !header.IsNil() {
+		entry.BlockHash = bytesutil.SafeCopyBytes(header.BlockHash())
+	}
+	return entry
+}
+
+func bidValue(signedBid builder.SignedBid) (*big.Int, error) {
+	bid, err := signedBid.Message()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get bid")
+	}
+	if bid == nil || bid.IsNil() {
+		return nil, errNilBid
+	}
+	return primitives.WeiToBigInt(bid.Value()), nil
+}
+
+// saveWinner records the relay of the bid chosen for the payload, to submit the blinded block to it.
+func (s *Service) saveWinner(signedBid builder.SignedBid, r *relay, slot primitives.Slot) error {
+	bid, err := signedBid.Message()
+	if err != nil {
+		return errors.Wrap(err, "could not get bid")
+	}
+	header, err := bid.Header()
+	if err != nil {
+		return errors.Wrap(err, "could not get bid header")
+	}
+	s.winnersLock.Lock()
+	defer s.winnersLock.Unlock()
+	for h, w := range s.winners {
+		if w.slot+params.BeaconConfig().SlotsPerEpoch < slot {
+			delete(s.winners, h)
+		}
+	}
+	s.winners[bytesutil.ToBytes32(header.BlockHash())] = winningBid{relay: r, slot: slot}
+	return nil
 }
 
 // Status retrieves the status of the builder relay network.
 func (s *Service) Status() error {
 	// Return early if builder isn't initialized in service.
-	if s.c == nil {
+	if len(s.relays) == 0 {
 		return nil
 	}
 
 	return nil
 }
 
-// RegisterValidator registers a validator with the builder relay network.
+// RegisterValidator registers a validator with every relay of the builder relay network.
 // It also saves the registration object to the DB.
 func (s *Service) RegisterValidator(ctx context.Context, reg []*ethpb.SignedValidatorRegistrationV1) error {
 	ctx, span := trace.StartSpan(ctx, "builder.RegisterValidator")
@@ -139,7 +379,7 @@ func (s *Service) RegisterValidator(ctx context.Context, reg []*ethpb.SignedVali
 	defer func() {
 		registerValidatorLatency.Observe(float64(time.Since(start).Milliseconds()))
 	}()
-	if s.c == nil {
+	if len(s.relays) == 0 {
 		return ErrNoBuilder
 	}
 
@@ -164,7 +404,7 @@ func (s *Service) RegisterValidator(ctx context.Context, reg []*ethpb.SignedVali
 		valid = append(valid, r)
 		indexToRegistration[nx] = r.Message
 	}
-	if err := s.c.RegisterValidator(ctx, valid); err != nil {
+	if err := s.registerValidatorWithRelays(ctx, valid); err != nil {
 		return errors.Wrap(err, "could not register validator(s)")
 	}
 
@@ -179,6 +419,30 @@ func (s *Service) RegisterValidator(ctx context.Context, reg []*ethpb.SignedVali
 	}
 }
 
+// registerValidatorWithRelays sends the registrations to all the relays in parallel. It returns an error only if
+// no relay accepted them, as the validators can still use the other relays.
+func (s *Service) registerValidatorWithRelays(ctx context.Context, reg []*ethpb.SignedValidatorRegistrationV1) error {
+	errs := make([]error, len(s.relays))
+	var wg sync.WaitGroup
+	for i, r := range s.relays {
+		wg.Add(1)
+		go func(i int, r *relay) {
+			defer wg.Done()
+			errs[i] = r.client.RegisterValidator(ctx, reg)
+			if errs[i] != nil {
+				log.WithError(errs[i]).WithField("relay", r.name).Error("Failed to register validator(s) with relay")
+			}
+		}(i, r)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err == nil {
+			return nil
+		}
+	}
+	return errs[0]
+}
+
 // RegistrationByValidatorID returns either the values from the cache or db.
 func (s *Service) RegistrationByValidatorID(ctx context.Context, id primitives.ValidatorIndex) (*ethpb.ValidatorRegistrationV1, error) {
 	if s.registrationCache != nil {
@@ -193,7 +457,7 @@ func (s *Service) RegistrationByValidatorID(ctx context.Context, id primitives.V
 
 // Configured returns true if the user has configured a builder client.
 func (s *Service) Configured() bool {
-	return s.c != nil && !reflect.ValueOf(s.c).IsNil()
+	return len(s.relays) > 0
 }
 
 func (s *Service) pollRelayerStatus(ctx context.Context) {
@@ -202,9 +466,9 @@ func (s *Service) pollRelayerStatus(ctx context.Context) {
 	for {
 		select {
 		case <-ticker.C:
-			if s.c != nil {
-				if err := s.c.Status(ctx); err != nil {
-					log.WithError(err).Error("Failed to call relayer status endpoint, perhaps mev-boost or relayers are down")
+			for _, r := range s.relays {
+				if err := r.checkStatus(ctx); err != nil {
+					log.WithError(err).WithField("relay", r.name).Error("Failed to call relayer status endpoint, perhaps mev-boost or relayers are down")
 				}
 			}
 		case <-ctx.Done():
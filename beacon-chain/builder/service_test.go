@@ -59,7 +59,7 @@ func Test_BuilderMethodsWithouClient(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, false, s.Configured())
 
-	_, err = s.GetHeader(context.Background(), 0, [32]byte{}, [48]byte{})
+	_, err = s.GetHeader(context.Background(), 0, [32]byte{}, [48]byte{}, nil)
 	assert.ErrorContains(t, ErrNoBuilder.Error(), err)
 
 	_, _, err = s.SubmitBlindedBlock(context.Background(), nil)
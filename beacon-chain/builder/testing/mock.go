@@ -72,7 +72,18 @@ func (s *MockBuilderService) SubmitBlindedBlock(_ context.Context, b interfaces.
 }
 
 // GetHeader for mocking.
-func (s *MockBuilderService) GetHeader(_ context.Context, slot primitives.Slot, _ [32]byte, _ [48]byte) (builder.SignedBid, error) {
+func (s *MockBuilderService) GetHeader(_ context.Context, slot primitives.Slot, _ [32]byte, _ [48]byte, validate func(builder.SignedBid) error) (builder.SignedBid, error) {
+	bid, err := s.getHeader(slot)
+	if err != nil || validate == nil {
+		return bid, err
+	}
+	if err := validate(bid); err != nil {
+		return nil, err
+	}
+	return bid, nil
+}
+
+func (s *MockBuilderService) getHeader(slot primitives.Slot) (builder.SignedBid, error) {
 	if slots.ToEpoch(slot) >= params.BeaconConfig().ElectraForkEpoch || s.BidElectra != nil {
 		return builder.WrappedSignedBuilderBidElectra(s.BidElectra)
 	}
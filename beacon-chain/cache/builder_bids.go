@@ -0,0 +1,172 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+// Payload sources which can be selected for a proposal.
+const (
+	BuilderBidSelectionBuilder = "builder"
+	BuilderBidSelectionLocal   = "local"
+)
+
+// BuilderBid is the outcome of a header request to a relay. Error is set when the relay returned no bid,
+// or a bid which could not be used for the proposal.
+type BuilderBid struct {
+	Relay         string        `json:"relay"`
+	BuilderPubkey []byte        `json:"builder_pubkey"`
+	Value         string        `json:"value"`
+	BlockHash     []byte        `json:"block_hash"`
+	Received      time.Time     `json:"received"`
+	Latency       time.Duration `json:"latency"`
+	Error         string        `json:"error"`
+}
+
+// PayloadReveal is the outcome of the submission of the signed blinded block to the relay whose bid was selected.
+type PayloadReveal struct {
+	Relay   string        `json:"relay"`
+	Time    time.Time     `json:"time"`
+	Latency time.Duration `json:"latency"`
+	Error   string        `json:"error"`
+}
+
+// BuilderBidRecord is the audit record of a proposal: the bids received from the relays, the local payload,
+// and which of them was selected and why.
+type BuilderBidRecord struct {
+	Slot          primitives.Slot           `json:"slot"`
+	ProposerIndex primitives.ValidatorIndex `json:"proposer_index"`
+	Bids          []*BuilderBid             `json:"bids"`
+	// WinningRelay is the relay of the highest valid bid, which competed with the local payload.
+	WinningRelay   string         `json:"winning_relay"`
+	LocalValue     string         `json:"local_value"`
+	LocalBlockHash []byte         `json:"local_block_hash"`
+	Selected       string         `json:"selected"`
+	Reason         string         `json:"reason"`
+	Reveal         *PayloadReveal `json:"reveal"`
+}
+
+func (r *BuilderBidRecord) copy() *BuilderBidRecord {
+	cp := *r
+	cp.Bids = make([]*BuilderBid, len(r.Bids))
+	for i, b := range r.Bids {
+		bid := *b
+		cp.Bids[i] = &bid
+	}
+	if r.Reveal != nil {
+		reveal := *r.Reveal
+		cp.Reveal = &reveal
+	}
+	return &cp
+}
+
+// BuilderBidDB persists the records of the builder bid log.
+type BuilderBidDB interface {
+	SaveBuilderBids(ctx context.Context, slot primitives.Slot, enc []byte) error
+	DeleteBuilderBids(ctx context.Context, slot primitives.Slot) error
+	BuilderBids(ctx context.Context) ([][]byte, error)
+}
+
+// BuilderBidLog keeps the audit records of the proposals of the recent slots for which the builder was
+// consulted. Records older than the retention window are evicted as newer slots are recorded, and are
+// persisted once a database has been loaded. All methods are no-ops on a nil log.
+type BuilderBidLog struct {
+	records *slotStore[*BuilderBidRecord]
+}
+
+// NewBuilderBidLog returns a log which keeps the records of the given number of recent slots.
+// A retention of zero disables the log.
+func NewBuilderBidLog(retention primitives.Slot) *BuilderBidLog {
+	return &BuilderBidLog{
+		records: newSlotStore("builder bids", retention, encodeBuilderBidRecord, decodeBuilderBidRecord),
+	}
+}
+
+func encodeBuilderBidRecord(r *BuilderBidRecord) ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func decodeBuilderBidRecord(enc []byte) (primitives.Slot, *BuilderBidRecord, error) {
+	r := &BuilderBidRecord{}
+	if err := json.Unmarshal(enc, r); err != nil {
+		return 0, nil, err
+	}
+	return r.Slot, r, nil
+}
+
+// Load restores the records persisted in the database, and persists the records updated from now on,
+// until the context is canceled.
+func (l *BuilderBidLog) Load(ctx context.Context, db BuilderBidDB) error {
+	if l == nil {
+		return nil
+	}
+	encs, err := db.BuilderBids(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not read builder bids")
+	}
+	return l.records.load(ctx, encs, db.SaveBuilderBids, db.DeleteBuilderBids)
+}
+
+// AddBids records the bids received from the relays for the proposal at the slot, and the relay of the
+// highest valid bid, if any.
+func (l *BuilderBidLog) AddBids(ctx context.Context, slot primitives.Slot, bids []*BuilderBid, winningRelay string) error {
+	return l.update(ctx, slot, func(r *BuilderBidRecord) {
+		r.Bids = make([]*BuilderBid, len(bids))
+		for i, b := range bids {
+			bid := *b
+			r.Bids[i] = &bid
+		}
+		r.WinningRelay = winningRelay
+	})
+}
+
+// SetSelection records the local payload of the proposal at the slot, and the payload selected for it.
+func (l *BuilderBidLog) SetSelection(ctx context.Context, slot primitives.Slot, proposer primitives.ValidatorIndex, localValue string, localBlockHash []byte, selected, reason string) error {
+	return l.update(ctx, slot, func(r *BuilderBidRecord) {
+		r.ProposerIndex = proposer
+		r.LocalValue = localValue
+		r.LocalBlockHash = localBlockHash
+		r.Selected = selected
+		r.Reason = reason
+	})
+}
+
+// SetReveal records whether the relay revealed the payload of the bid selected for the proposal at the slot.
+func (l *BuilderBidLog) SetReveal(ctx context.Context, slot primitives.Slot, reveal *PayloadReveal) error {
+	return l.update(ctx, slot, func(r *BuilderBidRecord) {
+		cp := *reveal
+		r.Reveal = &cp
+	})
+}
+
+// Get returns a copy of the record of the proposal at the slot.
+func (l *BuilderBidLog) Get(slot primitives.Slot) (*BuilderBidRecord, bool) {
+	if l == nil {
+		return nil, false
+	}
+	l.records.Lock()
+	defer l.records.Unlock()
+	r, ok := l.records.values[slot]
+	if !ok {
+		return nil, false
+	}
+	return r.copy(), true
+}
+
+func (l *BuilderBidLog) update(_ context.Context, slot primitives.Slot, f func(r *BuilderBidRecord)) error {
+	if l == nil {
+		return nil
+	}
+	l.records.update(slot, func(r *BuilderBidRecord, ok bool) (*BuilderBidRecord, bool) {
+		if !ok {
+			r = &BuilderBidRecord{Slot: slot}
+		}
+		f(r)
+		return r, true
+	})
+	return nil
+}
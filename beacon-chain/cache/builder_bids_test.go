@@ -0,0 +1,126 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+type mapBuilderBidDB struct {
+	sync.Mutex
+	bids map[primitives.Slot][]byte
+}
+
+func newMapBuilderBidDB() *mapBuilderBidDB {
+	return &mapBuilderBidDB{bids: make(map[primitives.Slot][]byte)}
+}
+
+func (m *mapBuilderBidDB) SaveBuilderBids(_ context.Context, slot primitives.Slot, enc []byte) error {
+	m.Lock()
+	defer m.Unlock()
+	m.bids[slot] = enc
+	return nil
+}
+
+func (m *mapBuilderBidDB) DeleteBuilderBids(_ context.Context, slot primitives.Slot) error {
+	m.Lock()
+	defer m.Unlock()
+	delete(m.bids, slot)
+	return nil
+}
+
+func (m *mapBuilderBidDB) BuilderBids(context.Context) ([][]byte, error) {
+	m.Lock()
+	defer m.Unlock()
+	encs := make([][]byte, 0, len(m.bids))
+	for _, enc := range m.bids {
+		encs = append(encs, enc)
+	}
+	return encs, nil
+}
+
+func (m *mapBuilderBidDB) has(slot primitives.Slot) bool {
+	m.Lock()
+	defer m.Unlock()
+	_, ok := m.bids[slot]
+	return ok
+}
+
+func (m *mapBuilderBidDB) len() int {
+	m.Lock()
+	defer m.Unlock()
+	return len(m.bids)
+}
+
+func TestBuilderBidLog(t *testing.T) {
+	ctx := context.Background()
+	l := NewBuilderBidLog(4)
+	db := newMapBuilderBidDB()
+	require.NoError(t, l.Load(ctx, db))
+	now := time.Now().UTC()
+
+	bids := []*BuilderBid{
+		{Relay: "a", BuilderPubkey: []byte{1}, Value: "10", BlockHash: []byte{2}, Received: now, Latency: time.Millisecond},
+		{Relay: "b", Error: "no bid"},
+	}
+	require.NoError(t, l.AddBids(ctx, 10, bids, "a"))
+	// The log keeps its own copy of the bids.
+	bids[0].Value = "11"
+	require.NoError(t, l.SetSelection(ctx, 10, 3, "5", []byte{4}, BuilderBidSelectionBuilder, "builder bid is higher"))
+	require.NoError(t, l.SetReveal(ctx, 10, &PayloadReveal{Relay: "a", Time: now, Latency: time.Second}))
+
+	r, ok := l.Get(10)
+	require.Equal(t, true, ok)
+	assert.Equal(t, primitives.ValidatorIndex(3), r.ProposerIndex)
+	require.Equal(t, 2, len(r.Bids))
+	assert.Equal(t, "10", r.Bids[0].Value)
+	assert.Equal(t, "no bid", r.Bids[1].Error)
+	assert.Equal(t, "a", r.WinningRelay)
+	assert.Equal(t, "5", r.LocalValue)
+	assert.Equal(t, BuilderBidSelectionBuilder, r.Selected)
+	assert.Equal(t, time.Second, r.Reveal.Latency)
+	r.Bids[0].Value = "12"
+	r, _ = l.Get(10)
+	assert.Equal(t, "10", r.Bids[0].Value)
+
+	// Records outside the retention window are evicted, from the database too.
+	require.NoError(t, l.SetSelection(ctx, 13, 4, "5", nil, BuilderBidSelectionLocal, "no bid"))
+	require.NoError(t, l.records.flush(ctx))
+	assert.Equal(t, 2, db.len())
+	require.NoError(t, l.SetSelection(ctx, 14, 5, "5", nil, BuilderBidSelectionLocal, "no bid"))
+	_, ok = l.Get(10)
+	assert.Equal(t, false, ok)
+	// Records are persisted in the background.
+	for i := 0; db.len() != 2 || !db.has(14); i++ {
+		require.Equal(t, true, i < 100, "builder bids not persisted")
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Records are restored from the database.
+	restored := NewBuilderBidLog(4)
+	require.NoError(t, restored.Load(ctx, db))
+	r, ok = restored.Get(13)
+	require.Equal(t, true, ok)
+	assert.Equal(t, primitives.ValidatorIndex(4), r.ProposerIndex)
+	_, ok = restored.Get(14)
+	assert.Equal(t, true, ok)
+}
+
+func TestBuilderBidLog_Disabled(t *testing.T) {
+	ctx := context.Background()
+	l := NewBuilderBidLog(0)
+	require.NoError(t, l.AddBids(ctx, 1, []*BuilderBid{{Relay: "a"}}, "a"))
+	_, ok := l.Get(1)
+	assert.Equal(t, false, ok)
+
+	var nilLog *BuilderBidLog
+	require.NoError(t, nilLog.Load(ctx, newMapBuilderBidDB()))
+	require.NoError(t, nilLog.SetReveal(ctx, 1, &PayloadReveal{}))
+	_, ok = nilLog.Get(1)
+	assert.Equal(t, false, ok)
+}
@@ -0,0 +1,222 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+// Maximum number of rejected blob sidecars kept with a single record.
+const invalidBlockMaxBlobs = 16
+
+// InvalidBlockStage is the processing stage at which a block or blob sidecar was rejected.
+type InvalidBlockStage string
+
+const (
+	// InvalidBlockStageGossip is a block or blob sidecar rejected by gossip validation.
+	InvalidBlockStageGossip InvalidBlockStage = "gossip"
+	// InvalidBlockStageStateTransition is a block which failed the consensus state transition.
+	InvalidBlockStageStateTransition InvalidBlockStage = "state_transition"
+	// InvalidBlockStageExecution is a block whose payload was deemed INVALID by the execution engine.
+	InvalidBlockStageExecution InvalidBlockStage = "execution"
+	// InvalidBlockStageDataAvailability is a block whose blob data could not be made available.
+	InvalidBlockStageDataAvailability InvalidBlockStage = "data_availability"
+)
+
+// InvalidBlock is a block, or the blob sidecars of a block, rejected by the node.
+// Block and Blobs hold the ssz encoded signed block and blob sidecars, when they are known.
+type InvalidBlock struct {
+	Root          [32]byte                  `json:"root"`
+	ParentRoot    [32]byte                  `json:"parent_root"`
+	Slot          primitives.Slot           `json:"slot"`
+	ProposerIndex primitives.ValidatorIndex `json:"proposer_index"`
+	Peer          string                    `json:"peer"`
+	Stage         InvalidBlockStage         `json:"stage"`
+	Error         string                    `json:"error"`
+	Time          time.Time                 `json:"time"`
+	Version       int                       `json:"version"`
+	Block         []byte                    `json:"block"`
+	Blobs         [][]byte                  `json:"blobs"`
+}
+
+// InvalidatesDescendants returns true if the record marks the block itself as invalid, in which case
+// all its descendants are invalid too. Blocks which failed data availability may become available
+// later, and rejected blob sidecars do not say anything about the validity of their block.
+func (b *InvalidBlock) InvalidatesDescendants() bool {
+	if b.Stage == InvalidBlockStageDataAvailability {
+		return false
+	}
+	return len(b.Block) > 0 || len(b.Blobs) == 0
+}
+
+func (b *InvalidBlock) copy() *InvalidBlock {
+	cp := *b
+	cp.Blobs = make([][]byte, len(b.Blobs))
+	copy(cp.Blobs, b.Blobs)
+	return &cp
+}
+
+// InvalidBlockDB persists the records of the invalid block registry.
+type InvalidBlockDB interface {
+	SaveInvalidBlock(ctx context.Context, root [32]byte, enc []byte) error
+	DeleteInvalidBlock(ctx context.Context, root [32]byte) error
+	InvalidBlocks(ctx context.Context) ([][]byte, error)
+}
+
+// InvalidBlockRegistry keeps the most recent blocks and blob sidecars rejected by the node, so that
+// they can be inspected and so that their descendants can be rejected without being processed.
+// Once the limit is reached, the oldest records are evicted. Records are persisted once a database
+// has been loaded. All methods are no-ops on a nil registry.
+type InvalidBlockRegistry struct {
+	sync.RWMutex
+	limit   int
+	db      InvalidBlockDB
+	records map[[32]byte]*InvalidBlock
+}
+
+// NewInvalidBlockRegistry returns a registry which keeps up to limit records.
+func NewInvalidBlockRegistry(limit int) *InvalidBlockRegistry {
+	return &InvalidBlockRegistry{
+		limit:   limit,
+		records: make(map[[32]byte]*InvalidBlock),
+	}
+}
+
+// Load restores the records persisted in the database, and persists the records added from now on.
+func (r *InvalidBlockRegistry) Load(ctx context.Context, db InvalidBlockDB) error {
+	if r == nil {
+		return nil
+	}
+	encs, err := db.InvalidBlocks(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not read invalid blocks")
+	}
+	r.Lock()
+	defer r.Unlock()
+	r.db = db
+	for _, enc := range encs {
+		b := &InvalidBlock{}
+		if err := json.Unmarshal(enc, b); err != nil {
+			return errors.Wrap(err, "could not decode invalid block")
+		}
+		r.records[b.Root] = b
+	}
+	return r.prune(ctx)
+}
+
+// Add records a rejected block or blob sidecar. The blob sidecars recorded for a root are kept
+// when the block is recorded, and a record which only carries blob sidecars adds them to the
+// existing record for the same root.
+func (r *InvalidBlockRegistry) Add(ctx context.Context, b *InvalidBlock) error {
+	if r == nil || r.limit == 0 {
+		return nil
+	}
+	r.Lock()
+	defer r.Unlock()
+	b = b.copy()
+	if old, ok := r.records[b.Root]; ok {
+		if len(b.Block) == 0 && len(b.Blobs) > 0 && len(old.Blobs) >= invalidBlockMaxBlobs {
+			return nil
+		}
+		blobs := append(old.Blobs[:len(old.Blobs):len(old.Blobs)], b.Blobs...)
+		switch {
+		case len(b.Block) == 0 && len(b.Blobs) > 0:
+			// Rejected blob sidecars do not change the outcome recorded for their block.
+			b = old.copy()
+		case len(b.Block) == 0:
+			cp := old.copy()
+			cp.Stage, cp.Error, cp.Time = b.Stage, b.Error, b.Time
+			b = cp
+		}
+		if len(blobs) > invalidBlockMaxBlobs {
+			blobs = blobs[:invalidBlockMaxBlobs]
+		}
+		b.Blobs = blobs
+	}
+	r.records[b.Root] = b
+	if r.db != nil {
+		enc, err := json.Marshal(b)
+		if err != nil {
+			return err
+		}
+		if err := r.db.SaveInvalidBlock(ctx, b.Root, enc); err != nil {
+			return errors.Wrap(err, "could not save invalid block")
+		}
+	}
+	return r.prune(ctx)
+}
+
+// IsInvalid returns true if the block with the given root is known to be invalid.
+func (r *InvalidBlockRegistry) IsInvalid(root [32]byte) bool {
+	if r == nil {
+		return false
+	}
+	r.RLock()
+	defer r.RUnlock()
+	b, ok := r.records[root]
+	return ok && b.InvalidatesDescendants()
+}
+
+// Get returns a copy of the record for the given root.
+func (r *InvalidBlockRegistry) Get(root [32]byte) (*InvalidBlock, bool) {
+	if r == nil {
+		return nil, false
+	}
+	r.RLock()
+	defer r.RUnlock()
+	b, ok := r.records[root]
+	if !ok {
+		return nil, false
+	}
+	return b.copy(), true
+}
+
+// List returns a copy of all records, most recent first.
+func (r *InvalidBlockRegistry) List() []*InvalidBlock {
+	if r == nil {
+		return nil
+	}
+	r.RLock()
+	defer r.RUnlock()
+	list := make([]*InvalidBlock, 0, len(r.records))
+	for _, b := range r.records {
+		list = append(list, b.copy())
+	}
+	sortInvalidBlocks(list)
+	return list
+}
+
+// prune evicts the oldest records above the limit. Requires a lock on the registry.
+func (r *InvalidBlockRegistry) prune(ctx context.Context) error {
+	if len(r.records) <= r.limit {
+		return nil
+	}
+	list := make([]*InvalidBlock, 0, len(r.records))
+	for _, b := range r.records {
+		list = append(list, b)
+	}
+	sortInvalidBlocks(list)
+	for _, b := range list[r.limit:] {
+		delete(r.records, b.Root)
+		if r.db != nil {
+			if err := r.db.DeleteInvalidBlock(ctx, b.Root); err != nil {
+				return errors.Wrap(err, "could not delete invalid block")
+			}
+		}
+	}
+	return nil
+}
+
+func sortInvalidBlocks(list []*InvalidBlock) {
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].Time.Equal(list[j].Time) {
+			return list[i].Time.After(list[j].Time)
+		}
+		return list[i].Slot > list[j].Slot
+	})
+}
@@ -0,0 +1,90 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+type mapInvalidBlockDB map[[32]byte][]byte
+
+func (m mapInvalidBlockDB) SaveInvalidBlock(_ context.Context, root [32]byte, enc []byte) error {
+	m[root] = enc
+	return nil
+}
+
+func (m mapInvalidBlockDB) DeleteInvalidBlock(_ context.Context, root [32]byte) error {
+	delete(m, root)
+	return nil
+}
+
+func (m mapInvalidBlockDB) InvalidBlocks(context.Context) ([][]byte, error) {
+	encs := make([][]byte, 0, len(m))
+	for _, enc := range m {
+		encs = append(encs, enc)
+	}
+	return encs, nil
+}
+
+func TestInvalidBlockRegistry(t *testing.T) {
+	ctx := context.Background()
+	r := NewInvalidBlockRegistry(4)
+	now := time.Now()
+	r1, r2, r3 := [32]byte{1}, [32]byte{2}, [32]byte{3}
+
+	// Rejected blob sidecars do not invalidate their block.
+	require.NoError(t, r.Add(ctx, &InvalidBlock{Root: r1, Slot: 10, Stage: InvalidBlockStageGossip, Error: "bad proof", Time: now, Blobs: [][]byte{{1}}}))
+	assert.Equal(t, false, r.IsInvalid(r1))
+	require.NoError(t, r.Add(ctx, &InvalidBlock{Root: r1, Slot: 10, Stage: InvalidBlockStageExecution, Error: "invalid payload", Time: now.Add(time.Second), Block: []byte{2}}))
+	assert.Equal(t, true, r.IsInvalid(r1))
+	// More blob sidecars keep the outcome of the block.
+	require.NoError(t, r.Add(ctx, &InvalidBlock{Root: r1, Slot: 10, Stage: InvalidBlockStageGossip, Error: "bad proof", Time: now.Add(2 * time.Second), Blobs: [][]byte{{3}}}))
+	b, ok := r.Get(r1)
+	require.Equal(t, true, ok)
+	assert.Equal(t, InvalidBlockStageExecution, b.Stage)
+	assert.Equal(t, "invalid payload", b.Error)
+	assert.DeepEqual(t, []byte{2}, b.Block)
+	assert.DeepEqual(t, [][]byte{{1}, {3}}, b.Blobs)
+
+	// Blocks which failed data availability may still become valid.
+	require.NoError(t, r.Add(ctx, &InvalidBlock{Root: r2, Slot: 11, Stage: InvalidBlockStageDataAvailability, Time: now.Add(3 * time.Second), Block: []byte{4}}))
+	assert.Equal(t, false, r.IsInvalid(r2))
+	// Invalid ancestors reported by the execution engine are recorded by root.
+	require.NoError(t, r.Add(ctx, &InvalidBlock{Root: r3, Stage: InvalidBlockStageExecution, Time: now.Add(4 * time.Second)}))
+	assert.Equal(t, true, r.IsInvalid(r3))
+
+	list := r.List()
+	require.Equal(t, 3, len(list))
+	assert.Equal(t, r3, list[0].Root)
+	assert.Equal(t, r1, list[2].Root)
+	_, ok = r.Get([32]byte{4})
+	assert.Equal(t, false, ok)
+
+	var disabled *InvalidBlockRegistry
+	require.NoError(t, disabled.Add(ctx, &InvalidBlock{Root: r1}))
+	assert.Equal(t, false, disabled.IsInvalid(r1))
+	assert.Equal(t, 0, len(disabled.List()))
+}
+
+func TestInvalidBlockRegistry_Persistence(t *testing.T) {
+	ctx := context.Background()
+	db := mapInvalidBlockDB{}
+	r := NewInvalidBlockRegistry(2)
+	require.NoError(t, r.Load(ctx, db))
+	now := time.Now()
+	for i := byte(1); i <= 3; i++ {
+		require.NoError(t, r.Add(ctx, &InvalidBlock{Root: [32]byte{i}, Stage: InvalidBlockStageStateTransition, Time: now.Add(time.Duration(i) * time.Second)}))
+	}
+	// The oldest record is evicted.
+	require.Equal(t, 2, len(db))
+	assert.Equal(t, false, r.IsInvalid([32]byte{1}))
+
+	restored := NewInvalidBlockRegistry(1)
+	require.NoError(t, restored.Load(ctx, db))
+	assert.Equal(t, true, restored.IsInvalid([32]byte{3}))
+	assert.Equal(t, false, restored.IsInvalid([32]byte{2}))
+	require.Equal(t, 1, len(db))
+}
@@ -0,0 +1,63 @@
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+// Reorg is a change of head to a block which does not descend from the previous head.
+type Reorg struct {
+	// Slot is the slot of the new head.
+	Slot primitives.Slot
+	// Depth is the number of slots from the common ancestor to the furthest of the two heads.
+	Depth uint64
+	// Distance is the number of slots from the common ancestor to the old head plus to the new head.
+	Distance    uint64
+	OldHeadRoot [32]byte
+	NewHeadRoot [32]byte
+	Time        time.Time
+}
+
+// ReorgCache keeps the most recent reorgs observed by the node. Once the limit is reached, the oldest
+// reorgs are evicted. All methods are no-ops on a nil cache.
+type ReorgCache struct {
+	sync.Mutex
+	limit  int
+	reorgs []Reorg
+}
+
+// NewReorgCache returns a cache which keeps up to limit reorgs.
+func NewReorgCache(limit int) *ReorgCache {
+	return &ReorgCache{limit: limit}
+}
+
+// Add records a reorg.
+func (c *ReorgCache) Add(r Reorg) {
+	if c == nil || c.limit == 0 {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.reorgs = append(c.reorgs, r)
+	if len(c.reorgs) > c.limit {
+		c.reorgs = append(c.reorgs[:0:0], c.reorgs[len(c.reorgs)-c.limit:]...)
+	}
+}
+
+// Since returns the reorgs to a head at or after the given slot, in the order they occurred.
+func (c *ReorgCache) Since(slot primitives.Slot) []Reorg {
+	if c == nil {
+		return nil
+	}
+	c.Lock()
+	defer c.Unlock()
+	var reorgs []Reorg
+	for _, r := range c.reorgs {
+		if r.Slot >= slot {
+			reorgs = append(reorgs, r)
+		}
+	}
+	return reorgs
+}
@@ -0,0 +1,31 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestReorgCache(t *testing.T) {
+	c := NewReorgCache(3)
+	for slot := primitives.Slot(1); slot <= 4; slot++ {
+		c.Add(Reorg{Slot: slot, Depth: uint64(slot)})
+	}
+
+	// The oldest reorg is evicted.
+	reorgs := c.Since(0)
+	require.Equal(t, 3, len(reorgs))
+	assert.Equal(t, primitives.Slot(2), reorgs[0].Slot)
+	assert.Equal(t, primitives.Slot(4), reorgs[2].Slot)
+
+	reorgs = c.Since(3)
+	require.Equal(t, 2, len(reorgs))
+	assert.Equal(t, uint64(3), reorgs[0].Depth)
+	assert.Equal(t, 0, len(c.Since(5)))
+
+	var disabled *ReorgCache
+	disabled.Add(Reorg{Slot: 1})
+	assert.Equal(t, 0, len(disabled.Since(0)))
+}
@@ -0,0 +1,163 @@
+package cache
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	log "github.com/sirupsen/logrus"
+)
+
+// slotStore keeps values keyed by slot for the most recent slots. Values older than the retention window,
+// counted back from the highest slot stored, are pruned. Once a database has been loaded, the values are
+// persisted in the background, so that updating a value does not wait for the database.
+// Readers of the values must hold the lock of the store.
+type slotStore[T any] struct {
+	sync.Mutex
+	name      string
+	retention primitives.Slot
+	highest   primitives.Slot
+	values    map[primitives.Slot]T
+	encode    func(T) ([]byte, error)
+	decode    func([]byte) (primitives.Slot, T, error)
+	save      func(context.Context, primitives.Slot, []byte) error
+	delete    func(context.Context, primitives.Slot) error
+	// saved and deleted are the slots whose values are yet to be saved to, or deleted from, the database.
+	saved   map[primitives.Slot]bool
+	deleted map[primitives.Slot]bool
+	persist chan struct{}
+}
+
+func newSlotStore[T any](
+	name string,
+	retention primitives.Slot,
+	encode func(T) ([]byte, error),
+	decode func([]byte) (primitives.Slot, T, error),
+) *slotStore[T] {
+	return &slotStore[T]{
+		name:      name,
+		retention: retention,
+		values:    make(map[primitives.Slot]T),
+		encode:    encode,
+		decode:    decode,
+		saved:     make(map[primitives.Slot]bool),
+		deleted:   make(map[primitives.Slot]bool),
+		persist:   make(chan struct{}, 1),
+	}
+}
+
+// load restores the values read from the database, and persists the values updated from now on with the
+// save and delete functions, until the context is canceled.
+func (s *slotStore[T]) load(
+	ctx context.Context,
+	encs [][]byte,
+	save func(context.Context, primitives.Slot, []byte) error,
+	del func(context.Context, primitives.Slot) error,
+) error {
+	s.Lock()
+	defer s.Unlock()
+	for _, enc := range encs {
+		slot, v, err := s.decode(enc)
+		if err != nil {
+			return errors.Wrapf(err, "could not decode %s", s.name)
+		}
+		s.values[slot] = v
+		if slot > s.highest {
+			s.highest = slot
+		}
+	}
+	s.save, s.delete = save, del
+	s.prune()
+	go s.persistLoop(ctx)
+	return nil
+}
+
+// persistLoop writes the updated values to the database, and deletes the pruned ones from it.
+func (s *slotStore[T]) persistLoop(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.persist:
+			if err := s.flush(ctx); err != nil {
+				log.WithError(err).Errorf("Could not persist %s", s.name)
+			}
+		}
+	}
+}
+
+// flush writes the values of the slots updated since the last flush to the database, and deletes the pruned
+// slots from it.
+func (s *slotStore[T]) flush(ctx context.Context) error {
+	s.Lock()
+	saves := make(map[primitives.Slot][]byte, len(s.saved))
+	for slot := range s.saved {
+		enc, err := s.encode(s.values[slot])
+		if err != nil {
+			s.Unlock()
+			return errors.Wrapf(err, "could not encode %s", s.name)
+		}
+		saves[slot] = enc
+	}
+	deletes := s.deleted
+	s.saved, s.deleted = make(map[primitives.Slot]bool), make(map[primitives.Slot]bool)
+	s.Unlock()
+
+	for slot, enc := range saves {
+		if err := s.save(ctx, slot, enc); err != nil {
+			return errors.Wrapf(err, "could not save %s", s.name)
+		}
+	}
+	for slot := range deletes {
+		if err := s.delete(ctx, slot); err != nil {
+			return errors.Wrapf(err, "could not delete %s", s.name)
+		}
+	}
+	return nil
+}
+
+// update calls f with the value of the slot, and whether there is one, and stores the value it returns unless
+// it also returns false. Slots outside the retention window are ignored.
+func (s *slotStore[T]) update(slot primitives.Slot, f func(v T, ok bool) (T, bool)) {
+	if s.retention == 0 {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	if slot+s.retention <= s.highest {
+		return
+	}
+	if slot > s.highest {
+		s.highest = slot
+		s.prune()
+	}
+	v, ok := s.values[slot]
+	v, ok = f(v, ok)
+	if !ok {
+		return
+	}
+	s.values[slot] = v
+	if s.save != nil {
+		s.saved[slot] = true
+		delete(s.deleted, slot)
+		select {
+		case s.persist <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// prune removes the values which fell out of the retention window. Requires a lock on the store.
+func (s *slotStore[T]) prune() {
+	for slot := range s.values {
+		if slot+s.retention > s.highest {
+			continue
+		}
+		delete(s.values, slot)
+		if s.save != nil {
+			delete(s.saved, slot)
+			s.deleted[slot] = true
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+// Maximum number of distinct blocks for which timings are recorded in a single slot.
+// This bounds the memory used by the cache when a slot sees many competing blocks.
+const slotTimingBlocksPerSlot = 8
+
+// Names of the stages of a block import recorded by the slot timing cache.
+// The signature verification stage is recorded for blocks imported in a batch, whose signatures are
+// verified after their state transitions. Otherwise it is part of the state transition stage.
+const (
+	ImportStagePreState              = "pre_state"
+	ImportStageStateTransition       = "state_transition"
+	ImportStageSignatureVerification = "signature_verification"
+	ImportStageExecution             = "execution"
+	ImportStageDataAvailability      = "data_availability"
+	ImportStagePostState             = "post_state"
+	ImportStageForkchoice            = "forkchoice"
+	ImportStageHeadUpdate            = "head_update"
+)
+
+// ImportStage is the span of time a block spent in a stage of its import.
+type ImportStage struct {
+	Name  string    `json:"name"`
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// SlotTiming records when a block and its blob sidecars were seen and processed by the node.
+// Zero times mean that the event has not been observed.
+type SlotTiming struct {
+	Slot      primitives.Slot `json:"slot"`
+	BlockRoot [32]byte        `json:"block_root"`
+	// BlockArrival is the time the block was first received via gossip, from BlockPeer.
+	BlockArrival time.Time `json:"block_arrival"`
+	BlockPeer    string    `json:"block_peer"`
+	// BlobArrivals maps blob sidecar indices to the time they were first received via gossip.
+	BlobArrivals map[uint64]time.Time `json:"blob_arrivals"`
+	// NewPayload is the time the execution engine returned from the newPayload call.
+	NewPayload time.Time `json:"new_payload"`
+	Imported   time.Time `json:"imported"`
+	Head       time.Time `json:"head"`
+	// ImportStages are the stages of the block import, in the order they completed.
+	// Some stages, like the execution stage, overlap with others.
+	ImportStages []ImportStage `json:"import_stages"`
+}
+
+func (t *SlotTiming) copy() SlotTiming {
+	cp := *t
+	cp.BlobArrivals = make(map[uint64]time.Time, len(t.BlobArrivals))
+	for i, at := range t.BlobArrivals {
+		cp.BlobArrivals[i] = at
+	}
+	cp.ImportStages = make([]ImportStage, len(t.ImportStages))
+	copy(cp.ImportStages, t.ImportStages)
+	return cp
+}
+
+// SlotTimingDB persists the timings of the slot timing cache.
+type SlotTimingDB interface {
+	SaveSlotTimings(ctx context.Context, slot primitives.Slot, enc []byte) error
+	DeleteSlotTimings(ctx context.Context, slot primitives.Slot) error
+	SlotTimings(ctx context.Context) ([][]byte, error)
+}
+
+// SlotTimingCache keeps the arrival and processing timings of the blocks in the most recent slots.
+// Timings older than the retention window, counted back from the highest slot recorded, are pruned,
+// and are persisted once a database has been loaded. All methods are no-ops on a nil cache,
+// so that recording can be disabled.
+type SlotTimingCache struct {
+	timings *slotStore[map[[32]byte]*SlotTiming]
+}
+
+// NewSlotTimingCache returns a cache which keeps timings for the given number of slots.
+func NewSlotTimingCache(retention primitives.Slot) *SlotTimingCache {
+	return &SlotTimingCache{
+		timings: newSlotStore("slot timings", retention, encodeSlotTimings, decodeSlotTimings),
+	}
+}
+
+func encodeSlotTimings(inner map[[32]byte]*SlotTiming) ([]byte, error) {
+	timings := make([]*SlotTiming, 0, len(inner))
+	for _, st := range inner {
+		timings = append(timings, st)
+	}
+	return json.Marshal(timings)
+}
+
+func decodeSlotTimings(enc []byte) (primitives.Slot, map[[32]byte]*SlotTiming, error) {
+	var timings []*SlotTiming
+	if err := json.Unmarshal(enc, &timings); err != nil {
+		return 0, nil, err
+	}
+	var slot primitives.Slot
+	inner := make(map[[32]byte]*SlotTiming, len(timings))
+	for _, st := range timings {
+		if st.BlobArrivals == nil {
+			st.BlobArrivals = make(map[uint64]time.Time)
+		}
+		slot = st.Slot
+		inner[st.BlockRoot] = st
+	}
+	return slot, inner, nil
+}
+
+// Load restores the timings persisted in the database, and persists the timings recorded from now on,
+// until the context is canceled.
+func (c *SlotTimingCache) Load(ctx context.Context, db SlotTimingDB) error {
+	if c == nil {
+		return nil
+	}
+	encs, err := db.SlotTimings(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not read slot timings")
+	}
+	return c.timings.load(ctx, encs, db.SaveSlotTimings, db.DeleteSlotTimings)
+}
+
+// SetBlockArrival records the first time a block was received via gossip and the peer which sent it.
+func (c *SlotTimingCache) SetBlockArrival(slot primitives.Slot, root [32]byte, peer string, t time.Time) {
+	c.update(slot, root, func(st *SlotTiming) {
+		if st.BlockArrival.IsZero() {
+			st.BlockArrival = t
+			st.BlockPeer = peer
+		}
+	})
+}
+
+// SetBlobArrival records the first time a blob sidecar with the given index was received via gossip.
+func (c *SlotTimingCache) SetBlobArrival(slot primitives.Slot, root [32]byte, index uint64, t time.Time) {
+	c.update(slot, root, func(st *SlotTiming) {
+		if _, ok := st.BlobArrivals[index]; !ok {
+			st.BlobArrivals[index] = t
+		}
+	})
+}
+
+// SetNewPayload records the time the execution engine returned from the newPayload call for a block.
+func (c *SlotTimingCache) SetNewPayload(slot primitives.Slot, root [32]byte, t time.Time) {
+	c.update(slot, root, func(st *SlotTiming) {
+		if st.NewPayload.IsZero() {
+			st.NewPayload = t
+		}
+	})
+}
+
+// SetImported records the time a block was imported.
+func (c *SlotTimingCache) SetImported(slot primitives.Slot, root [32]byte, t time.Time) {
+	c.update(slot, root, func(st *SlotTiming) {
+		if st.Imported.IsZero() {
+			st.Imported = t
+		}
+	})
+}
+
+// SetHead records the first time a block became the head of the chain.
+func (c *SlotTimingCache) SetHead(slot primitives.Slot, root [32]byte, t time.Time) {
+	c.update(slot, root, func(st *SlotTiming) {
+		if st.Head.IsZero() {
+			st.Head = t
+		}
+	})
+}
+
+// AddImportStage records the span of time a block spent in a stage of its import. A stage is recorded once per block.
+func (c *SlotTimingCache) AddImportStage(slot primitives.Slot, root [32]byte, name string, start, end time.Time) {
+	c.update(slot, root, func(st *SlotTiming) {
+		for _, s := range st.ImportStages {
+			if s.Name == name {
+				return
+			}
+		}
+		st.ImportStages = append(st.ImportStages, ImportStage{Name: name, Start: start, End: end})
+	})
+}
+
+// Timing returns a copy of the timings recorded for a block.
+func (c *SlotTimingCache) Timing(slot primitives.Slot, root [32]byte) (SlotTiming, bool) {
+	if c == nil {
+		return SlotTiming{}, false
+	}
+	c.timings.Lock()
+	defer c.timings.Unlock()
+	st, ok := c.timings.values[slot][root]
+	if !ok {
+		return SlotTiming{}, false
+	}
+	return st.copy(), true
+}
+
+// Timings returns a copy of the timings recorded for the blocks of a slot, ordered by block root.
+func (c *SlotTimingCache) Timings(slot primitives.Slot) []SlotTiming {
+	if c == nil {
+		return nil
+	}
+	c.timings.Lock()
+	defer c.timings.Unlock()
+	inner := c.timings.values[slot]
+	timings := make([]SlotTiming, 0, len(inner))
+	for _, st := range inner {
+		timings = append(timings, st.copy())
+	}
+	sort.Slice(timings, func(i, j int) bool {
+		return bytes.Compare(timings[i].BlockRoot[:], timings[j].BlockRoot[:]) < 0
+	})
+	return timings
+}
+
+// BlockPeer returns the peer which first sent the block via gossip, or an empty string if it is not known.
+func (c *SlotTimingCache) BlockPeer(slot primitives.Slot, root [32]byte) string {
+	if c == nil {
+		return ""
+	}
+	c.timings.Lock()
+	defer c.timings.Unlock()
+	st, ok := c.timings.values[slot][root]
+	if !ok {
+		return ""
+	}
+	return st.BlockPeer
+}
+
+// Retention returns the number of slots for which timings are kept.
+func (c *SlotTimingCache) Retention() primitives.Slot {
+	if c == nil {
+		return 0
+	}
+	return c.timings.retention
+}
+
+func (c *SlotTimingCache) update(slot primitives.Slot, root [32]byte, f func(*SlotTiming)) {
+	if c == nil {
+		return
+	}
+	c.timings.update(slot, func(inner map[[32]byte]*SlotTiming, ok bool) (map[[32]byte]*SlotTiming, bool) {
+		if !ok {
+			inner = make(map[[32]byte]*SlotTiming)
+		}
+		st, ok := inner[root]
+		if !ok {
+			if len(inner) >= slotTimingBlocksPerSlot {
+				return inner, false
+			}
+			st = &SlotTiming{Slot: slot, BlockRoot: root, BlobArrivals: make(map[uint64]time.Time)}
+			inner[root] = st
+		}
+		f(st)
+		return inner, true
+	})
+}
@@ -0,0 +1,186 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestSlotTimingCache(t *testing.T) {
+	c := NewSlotTimingCache(4)
+	now := time.Now()
+	r1, r2 := [32]byte{1}, [32]byte{2}
+
+	c.SetBlockArrival(10, r2, "peer1", now)
+	c.SetBlockArrival(10, r2, "peer2", now.Add(time.Second))
+	c.SetBlobArrival(10, r2, 1, now.Add(time.Millisecond))
+	c.SetBlobArrival(10, r2, 1, now.Add(time.Second))
+	c.SetNewPayload(10, r2, now.Add(2*time.Millisecond))
+	c.SetImported(10, r2, now.Add(3*time.Millisecond))
+	c.SetHead(10, r2, now.Add(4*time.Millisecond))
+	c.SetHead(10, r2, now.Add(time.Second))
+	c.SetImported(10, r1, now)
+	c.AddImportStage(10, r2, ImportStagePreState, now, now.Add(time.Millisecond))
+	c.AddImportStage(10, r2, ImportStageStateTransition, now.Add(time.Millisecond), now.Add(2*time.Millisecond))
+	c.AddImportStage(10, r2, ImportStagePreState, now, now.Add(time.Second))
+
+	timings := c.Timings(10)
+	require.Equal(t, 2, len(timings))
+	assert.Equal(t, r1, timings[0].BlockRoot)
+	assert.Equal(t, true, timings[0].BlockArrival.IsZero())
+	st := timings[1]
+	assert.Equal(t, primitives.Slot(10), st.Slot)
+	assert.Equal(t, r2, st.BlockRoot)
+	// Only the first arrival is kept.
+	assert.Equal(t, now, st.BlockArrival)
+	assert.Equal(t, "peer1", st.BlockPeer)
+	assert.Equal(t, "peer1", c.BlockPeer(10, r2))
+	assert.Equal(t, "", c.BlockPeer(10, r1))
+	require.Equal(t, 1, len(st.BlobArrivals))
+	assert.Equal(t, now.Add(time.Millisecond), st.BlobArrivals[1])
+	assert.Equal(t, now.Add(2*time.Millisecond), st.NewPayload)
+	assert.Equal(t, now.Add(3*time.Millisecond), st.Imported)
+	assert.Equal(t, now.Add(4*time.Millisecond), st.Head)
+	// Stages are kept in the order they were recorded, once per block.
+	require.Equal(t, 2, len(st.ImportStages))
+	assert.Equal(t, ImportStage{Name: ImportStagePreState, Start: now, End: now.Add(time.Millisecond)}, st.ImportStages[0])
+	assert.Equal(t, ImportStageStateTransition, st.ImportStages[1].Name)
+
+	single, ok := c.Timing(10, r2)
+	require.Equal(t, true, ok)
+	assert.Equal(t, r2, single.BlockRoot)
+	_, ok = c.Timing(10, [32]byte{3})
+	assert.Equal(t, false, ok)
+
+	// Timings returned are copies.
+	st.BlobArrivals[2] = now
+	st.ImportStages[0].Name = "other"
+	assert.Equal(t, 1, len(c.Timings(10)[1].BlobArrivals))
+	assert.Equal(t, ImportStagePreState, c.Timings(10)[1].ImportStages[0].Name)
+	assert.Equal(t, 0, len(c.Timings(11)))
+}
+
+func TestSlotTimingCache_Prune(t *testing.T) {
+	c := NewSlotTimingCache(4)
+	now := time.Now()
+	c.SetImported(10, [32]byte{1}, now)
+	c.SetImported(13, [32]byte{1}, now)
+	require.Equal(t, 1, len(c.Timings(10)))
+
+	c.SetImported(14, [32]byte{1}, now)
+	assert.Equal(t, 0, len(c.Timings(10)))
+	assert.Equal(t, 1, len(c.Timings(13)))
+	// Timings older than the window are not recorded.
+	c.SetImported(9, [32]byte{1}, now)
+	assert.Equal(t, 0, len(c.Timings(9)))
+
+	// The number of blocks per slot is bounded.
+	for i := 0; i < 2*slotTimingBlocksPerSlot; i++ {
+		c.SetBlockArrival(14, [32]byte{byte(i)}, "", now)
+	}
+	assert.Equal(t, slotTimingBlocksPerSlot, len(c.Timings(14)))
+
+	var disabled *SlotTimingCache
+	disabled.SetImported(14, [32]byte{}, now)
+	assert.Equal(t, 0, len(disabled.Timings(14)))
+	assert.Equal(t, primitives.Slot(0), disabled.Retention())
+	assert.Equal(t, "", disabled.BlockPeer(14, [32]byte{}))
+	_, ok := disabled.Timing(14, [32]byte{})
+	assert.Equal(t, false, ok)
+}
+
+type mapSlotTimingDB struct {
+	sync.Mutex
+	timings map[primitives.Slot][]byte
+}
+
+func newMapSlotTimingDB() *mapSlotTimingDB {
+	return &mapSlotTimingDB{timings: make(map[primitives.Slot][]byte)}
+}
+
+func (m *mapSlotTimingDB) SaveSlotTimings(_ context.Context, slot primitives.Slot, enc []byte) error {
+	m.Lock()
+	defer m.Unlock()
+	m.timings[slot] = enc
+	return nil
+}
+
+func (m *mapSlotTimingDB) DeleteSlotTimings(_ context.Context, slot primitives.Slot) error {
+	m.Lock()
+	defer m.Unlock()
+	delete(m.timings, slot)
+	return nil
+}
+
+func (m *mapSlotTimingDB) SlotTimings(context.Context) ([][]byte, error) {
+	m.Lock()
+	defer m.Unlock()
+	encs := make([][]byte, 0, len(m.timings))
+	for _, enc := range m.timings {
+		encs = append(encs, enc)
+	}
+	return encs, nil
+}
+
+func (m *mapSlotTimingDB) has(slot primitives.Slot) bool {
+	m.Lock()
+	defer m.Unlock()
+	_, ok := m.timings[slot]
+	return ok
+}
+
+func (m *mapSlotTimingDB) len() int {
+	m.Lock()
+	defer m.Unlock()
+	return len(m.timings)
+}
+
+func TestSlotTimingCache_Persistence(t *testing.T) {
+	ctx := context.Background()
+	c := NewSlotTimingCache(4)
+	db := newMapSlotTimingDB()
+	require.NoError(t, c.Load(ctx, db))
+	now := time.Unix(1700000000, 0).UTC()
+	root := [32]byte{1}
+
+	c.SetBlockArrival(10, root, "peer", now)
+	c.SetBlobArrival(10, root, 1, now.Add(time.Millisecond))
+	c.AddImportStage(10, root, ImportStagePreState, now, now.Add(time.Millisecond))
+	c.SetImported(13, root, now)
+	require.NoError(t, c.timings.flush(ctx))
+	assert.Equal(t, 2, db.len())
+
+	// Timings outside the retention window are pruned, from the database too.
+	c.SetImported(14, root, now)
+	// Timings are persisted in the background.
+	for i := 0; db.len() != 2 || !db.has(14); i++ {
+		require.Equal(t, true, i < 100, "slot timings not persisted")
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, false, db.has(10))
+
+	// Timings are restored from the database.
+	restored := NewSlotTimingCache(4)
+	require.NoError(t, restored.Load(ctx, db))
+	st, ok := restored.Timing(13, root)
+	require.Equal(t, true, ok)
+	assert.Equal(t, true, st.Imported.Equal(now))
+	_, ok = restored.Timing(14, root)
+	assert.Equal(t, true, ok)
+	// Timings older than the retention window of the highest slot restored are not recorded.
+	restored.SetImported(10, root, now)
+	assert.Equal(t, 0, len(restored.Timings(10)))
+
+	// Timings persisted beyond a lower retention are pruned when they are restored.
+	shorter := NewSlotTimingCache(1)
+	require.NoError(t, shorter.Load(ctx, db))
+	assert.Equal(t, 0, len(shorter.Timings(13)))
+	require.NoError(t, shorter.timings.flush(ctx))
+	assert.Equal(t, false, db.has(13))
+	assert.Equal(t, true, db.has(14))
+}
@@ -6,6 +6,7 @@ package state
 import (
 	"time"
 
+	executiontypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/execution/types"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 )
@@ -33,6 +34,8 @@ const (
 	LightClientOptimisticUpdate
 	// PayloadAttributes events are fired upon a missed slot or new head.
 	PayloadAttributes
+	// ExecutionClientStatusChanged is sent when the execution client changes state, for instance when it starts syncing.
+	ExecutionClientStatusChanged
 )
 
 // BlockProcessedData is the data sent with BlockProcessed events.
@@ -68,3 +71,11 @@ type InitializedData struct {
 	// GenesisValidatorsRoot represents state.validators.HashTreeRoot().
 	GenesisValidatorsRoot []byte
 }
+
+// ExecutionClientStatusChangedData is the data sent with ExecutionClientStatusChanged events.
+type ExecutionClientStatusChangedData struct {
+	// Previous is the state of the execution client before the change.
+	Previous executiontypes.ExecutionClientState
+	// Status is the new status of the execution client.
+	Status executiontypes.ExecutionClientStatus
+}
@@ -27,6 +27,18 @@ type LazilyPersistentStore struct {
 	store    *filesystem.BlobStorage
 	cache    *cache
 	verifier BlobBatchVerifier
+	archive  bool
+}
+
+// LazilyPersistentStoreOption is a functional option for the LazilyPersistentStore.
+type LazilyPersistentStoreOption func(*LazilyPersistentStore)
+
+// WithArchive makes the store check and persist the blobs of all blocks since deneb, rather than only those
+// within MIN_EPOCHS_FOR_BLOB_SIDECARS_REQUESTS. This is used to backfill the blobs of archive nodes.
+func WithArchive() LazilyPersistentStoreOption {
+	return func(s *LazilyPersistentStore) {
+		s.archive = true
+	}
 }
 
 var _ AvailabilityStore = &LazilyPersistentStore{}
@@ -42,12 +54,16 @@ type BlobBatchVerifier interface {
 
 // NewLazilyPersistentStore creates a new LazilyPersistentStore. This constructor should always be used
 // when creating a LazilyPersistentStore because it needs to initialize the cache under the hood.
-func NewLazilyPersistentStore(store *filesystem.BlobStorage, verifier BlobBatchVerifier) *LazilyPersistentStore {
-	return &LazilyPersistentStore{
+func NewLazilyPersistentStore(store *filesystem.BlobStorage, verifier BlobBatchVerifier, opts ...LazilyPersistentStoreOption) *LazilyPersistentStore {
+	s := &LazilyPersistentStore{
 		store:    store,
 		cache:    newCache(),
 		verifier: verifier,
 	}
+	for _, o := range opts {
+		o(s)
+	}
+	return s
 }
 
 // Persist adds blobs to the working blob cache. Blobs stored in this cache will be persisted
@@ -65,7 +81,7 @@ func (s *LazilyPersistentStore) Persist(current primitives.Slot, sc ...blocks.RO
 			}
 		}
 	}
-	if !params.WithinDAPeriod(slots.ToEpoch(sc[0].Slot()), slots.ToEpoch(current)) {
+	if !s.archive && !params.WithinDAPeriod(slots.ToEpoch(sc[0].Slot()), slots.ToEpoch(current)) {
 		return nil
 	}
 	key := keyFromSidecar(sc[0])
@@ -81,6 +97,10 @@ func (s *LazilyPersistentStore) Persist(current primitives.Slot, sc ...blocks.RO
 // IsDataAvailable returns nil if all the commitments in the given block are persisted to the db and have been verified.
 // BlobSidecars already in the db are assumed to have been previously verified against the block.
 func (s *LazilyPersistentStore) IsDataAvailable(ctx context.Context, current primitives.Slot, b blocks.ROBlock) error {
+	if s.archive {
+		// Check the block as if it was at the current slot, so that it is always within the DA period.
+		current = b.Block().Slot()
+	}
 	blockCommitments, err := commitmentsToCheck(b, current)
 	if err != nil {
 		return errors.Wrapf(err, "could not check data availability for block %#x", b.Root())
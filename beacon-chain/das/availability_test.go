@@ -176,6 +176,25 @@ func TestLazyPersistOnceCommitted(t *testing.T) {
 	require.NoError(t, as.Persist(1, more...))
 }
 
+func TestLazilyPersistent_Archive(t *testing.T) {
+	ctx := context.Background()
+	blk, scs := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 1, 3)
+	current, err := slots.EpochStart(params.BeaconConfig().MinEpochsForBlobsSidecarsRequest + 2)
+	require.NoError(t, err)
+
+	// Without archive, a block outside the retention period doesn't need its blobs.
+	as := NewLazilyPersistentStore(filesystem.NewEphemeralBlobStorage(t), &mockBlobBatchVerifier{t: t, scs: scs})
+	require.NoError(t, as.IsDataAvailable(ctx, current, blk))
+
+	store := filesystem.NewEphemeralBlobStorage(t, filesystem.WithArchive())
+	as = NewLazilyPersistentStore(store, &mockBlobBatchVerifier{t: t, scs: scs}, WithArchive())
+	require.NoError(t, as.Persist(current, scs[0]))
+	require.ErrorIs(t, as.IsDataAvailable(ctx, current, blk), errMissingSidecar)
+	require.NoError(t, as.Persist(current, scs...))
+	require.NoError(t, as.IsDataAvailable(ctx, current, blk))
+	require.Equal(t, true, store.Summary(blk.Root()).AllAvailable(3))
+}
+
 type mockBlobBatchVerifier struct {
 	t        *testing.T
 	scs      []blocks.ROBlob
@@ -47,6 +47,16 @@ func WithBlobRetentionEpochs(e primitives.Epoch) BlobStorageOption {
 	}
 }
 
+// WithArchive is an option that keeps the blobs of the archived range, regardless of the retention period, so that
+// blobs backfilled beyond the retention period are not pruned. The end of the archived range is set with
+// SetArchiveEnd, and no blobs are pruned until then.
+func WithArchive() BlobStorageOption {
+	return func(b *BlobStorage) error {
+		b.archive = true
+		return nil
+	}
+}
+
 // WithSaveFsync is an option that causes Save to call fsync before renaming part files for improved durability.
 func WithSaveFsync(fsync bool) BlobStorageOption {
 	return func(b *BlobStorage) error {
@@ -94,11 +104,11 @@ func NewBlobStorage(opts ...BlobStorageOption) (*BlobStorage, error) {
 		b.fs = afero.NewBasePathFs(afero.NewOsFs(), b.base)
 	}
 	b.cache = newBlobStorageCache()
-	pruner := newBlobPruner(b.retentionEpochs)
+	b.pruner = newBlobPruner(b.retentionEpochs, b.archive)
 	if b.layoutName == "" {
 		b.layoutName = LayoutNameFlat
 	}
-	layout, err := newLayout(b.layoutName, b.fs, b.cache, pruner)
+	layout, err := newLayout(b.layoutName, b.fs, b.cache, b.pruner)
 	if err != nil {
 		return nil, err
 	}
@@ -110,11 +120,13 @@ func NewBlobStorage(opts ...BlobStorageOption) (*BlobStorage, error) {
 type BlobStorage struct {
 	base            string
 	retentionEpochs primitives.Epoch
+	archive         bool
 	layoutName      string
 	fsync           bool
 	fs              afero.Fs
 	layout          fsLayout
 	cache           *blobStorageSummaryCache
+	pruner          *blobPruner
 }
 
 // WarmCache runs the prune routine with an expiration of slot of 0, so nothing will be pruned, but the pruner's cache
@@ -136,6 +148,19 @@ func (bs *BlobStorage) WarmCache() {
 	log.WithField("elapsed", time.Since(start)).Info("Blob filesystem cache warm-up complete.")
 }
 
+// Archive returns true if the storage keeps the blobs of the archived range, regardless of the retention period.
+func (bs *BlobStorage) Archive() bool {
+	return bs.archive
+}
+
+// SetArchiveEnd sets the end of the archived range of an archive, which holds the blobs of the epochs before end.
+// The blobs of later epochs are pruned after the retention period.
+func (bs *BlobStorage) SetArchiveEnd(end primitives.Epoch) {
+	if bs.pruner != nil {
+		bs.pruner.setArchiveEnd(end)
+	}
+}
+
 // If any blob storage directories are found for layouts besides the configured layout, migrate them.
 func (bs *BlobStorage) migrateLayouts() error {
 	for _, name := range LayoutNames {
@@ -297,7 +322,11 @@ func (bs *BlobStorage) Clear() error {
 }
 
 // WithinRetentionPeriod checks if the requested epoch is within the blob retention period.
+// The epochs of the archived range are always within the retention period of an archive.
 func (bs *BlobStorage) WithinRetentionPeriod(requested, current primitives.Epoch) bool {
+	if bs.pruner != nil && bs.pruner.archived(requested) {
+		return true
+	}
 	if requested > math.MaxUint64-bs.retentionEpochs {
 		// If there is an overflow, then the retention period was set to an extremely large number.
 		return true
@@ -210,6 +210,16 @@ func TestConfig_WithinRetentionPeriod(t *testing.T) {
 		storage := &BlobStorage{retentionEpochs: math.MaxUint64}
 		require.Equal(t, true, storage.WithinRetentionPeriod(1, 1))
 	})
+
+	t.Run("archive", func(t *testing.T) {
+		storage := &BlobStorage{retentionEpochs: retention, archive: true, pruner: newBlobPruner(retention, true)}
+		// Every epoch is kept until the end of the archived range is known.
+		require.Equal(t, true, storage.WithinRetentionPeriod(3, retention+4))
+		storage.SetArchiveEnd(2)
+		require.Equal(t, true, storage.WithinRetentionPeriod(1, retention+4))
+		require.Equal(t, false, storage.WithinRetentionPeriod(3, retention+4))
+		require.Equal(t, true, storage.WithinRetentionPeriod(4, retention+4))
+	})
 }
 
 func TestLayoutNames(t *testing.T) {
@@ -82,7 +82,7 @@ type fsLayout interface {
 	dirIdent(root [32]byte) (blobIdent, error)
 	summary(root [32]byte) BlobStorageSummary
 	notify(ident blobIdent) error
-	pruneBefore(before primitives.Epoch) (*pruneSummary, error)
+	pruneRange(from, before primitives.Epoch) (*pruneSummary, error)
 	remove(ident blobIdent) (int, error)
 	blockParentDirs(ident blobIdent) []string
 }
@@ -237,7 +237,8 @@ func (s pruneSummary) LogFields() logrus.Fields {
 	}
 }
 
-func pruneBefore(before primitives.Epoch, l fsLayout) (map[primitives.Epoch]*pruneSummary, error) {
+// pruneRange removes the blobs of the epochs in the range [from, before).
+func pruneRange(from, before primitives.Epoch, l fsLayout) (map[primitives.Epoch]*pruneSummary, error) {
 	sums := make(map[primitives.Epoch]*pruneSummary)
 	iter, err := l.iterateIdents(before)
 	if err != nil {
@@ -258,7 +259,7 @@ func pruneBefore(before primitives.Epoch, l fsLayout) (map[primitives.Epoch]*pru
 			log.WithError(err).Error("encountered unhandled error during pruning")
 			return nil, errors.Wrap(errPruneFailed, err.Error())
 		}
-		if ident.epoch >= before {
+		if ident.epoch >= before || ident.epoch < from {
 			continue
 		}
 		if lastIdent.root != ident.root {
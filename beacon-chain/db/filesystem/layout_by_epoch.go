@@ -109,8 +109,8 @@ func (l *periodicEpochLayout) partPath(n blobIdent, entropy string) string {
 	return path.Join(l.dir(n), n.partFname(entropy))
 }
 
-func (l *periodicEpochLayout) pruneBefore(before primitives.Epoch) (*pruneSummary, error) {
-	sums, err := pruneBefore(before, l)
+func (l *periodicEpochLayout) pruneRange(from, before primitives.Epoch) (*pruneSummary, error) {
+	sums, err := pruneRange(from, before, l)
 	if err != nil {
 		return nil, err
 	}
@@ -95,8 +95,8 @@ func (l *flatLayout) notify(ident blobIdent) error {
 	return nil
 }
 
-func (l *flatLayout) pruneBefore(before primitives.Epoch) (*pruneSummary, error) {
-	sums, err := pruneBefore(before, l)
+func (l *flatLayout) pruneRange(from, before primitives.Epoch) (*pruneSummary, error) {
+	sums, err := pruneRange(from, before, l)
 	if err != nil {
 		return nil, err
 	}
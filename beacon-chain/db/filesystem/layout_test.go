@@ -8,7 +8,7 @@ import (
 )
 
 type mockLayout struct {
-	pruneBeforeFunc func(primitives.Epoch) (*pruneSummary, error)
+	pruneRangeFunc func(from, before primitives.Epoch) (*pruneSummary, error)
 }
 
 var _ fsLayout = &mockLayout{}
@@ -53,8 +53,8 @@ func (*mockLayout) notify(blobIdent) error {
 	return nil
 }
 
-func (m *mockLayout) pruneBefore(before primitives.Epoch) (*pruneSummary, error) {
-	return m.pruneBeforeFunc(before)
+func (m *mockLayout) pruneRange(from, before primitives.Epoch) (*pruneSummary, error) {
+	return m.pruneRangeFunc(from, before)
 }
 
 func (*mockLayout) remove(ident blobIdent) (int, error) {
@@ -16,17 +16,22 @@ var errNotBlobSSZ = errors.New("not a blob ssz file")
 
 // blobPruner keeps track of the tail end of the retention period, based only the blobs it has seen via the notify method.
 // If the retention period advances in response to notify being called,
-// the pruner will invoke the pruneBefore method of the given layout in a new goroutine.
+// the pruner will invoke the pruneRange method of the given layout in a new goroutine.
 // The details of pruning are left entirely to the layout, with the pruner's only responsibility being to
 // schedule just one pruning operation at a time, for each forward movement of the minimum retention epoch.
+// An archive pruner never prunes the epochs before the end of the archived range, and doesn't prune at all
+// until that end is known.
 type blobPruner struct {
 	mu              sync.Mutex
 	prunedBefore    atomic.Uint64
 	retentionPeriod primitives.Epoch
+	archive         bool
+	archiveEnd      atomic.Uint64
+	archiveEndSet   atomic.Bool
 }
 
-func newBlobPruner(retain primitives.Epoch) *blobPruner {
-	p := &blobPruner{retentionPeriod: retain + retentionBuffer}
+func newBlobPruner(retain primitives.Epoch, archive bool) *blobPruner {
+	p := &blobPruner{retentionPeriod: retain + retentionBuffer, archive: archive}
 	return p
 }
 
@@ -34,9 +39,15 @@ func newBlobPruner(retain primitives.Epoch) *blobPruner {
 // This is useful for tests, but at runtime fsLayouts or BlobStorage should not wait for completion.
 func (p *blobPruner) notify(latest primitives.Epoch, layout fsLayout) chan struct{} {
 	done := make(chan struct{})
+	from, ok := p.pruneFrom()
+	if !ok {
+		close(done)
+		return done
+	}
 	floor := periodFloor(latest, p.retentionPeriod)
-	if primitives.Epoch(p.prunedBefore.Swap(uint64(floor))) >= floor {
-		// Only trigger pruning if the atomic swap changed the previous value of prunedBefore.
+	if primitives.Epoch(p.prunedBefore.Swap(uint64(floor))) >= floor || floor <= from {
+		// Only trigger pruning if the atomic swap changed the previous value of prunedBefore,
+		// and there are epochs outside of the archived range to prune.
 		close(done)
 		return done
 	}
@@ -44,11 +55,12 @@ func (p *blobPruner) notify(latest primitives.Epoch, layout fsLayout) chan struc
 		p.mu.Lock()
 		start := time.Now()
 		defer p.mu.Unlock()
-		sum, err := layout.pruneBefore(floor)
+		sum, err := layout.pruneRange(from, floor)
 		if err != nil {
 			log.WithError(err).WithFields(sum.LogFields()).Warn("Encountered errors during blob pruning.")
 		}
 		log.WithFields(logrus.Fields{
+			"fromEpoch":    from,
 			"upToEpoch":    floor,
 			"duration":     time.Since(start).String(),
 			"filesRemoved": sum.blobsPruned,
@@ -59,6 +71,30 @@ func (p *blobPruner) notify(latest primitives.Epoch, layout fsLayout) chan struc
 	return done
 }
 
+// setArchiveEnd sets the end of the archived range, whose epochs are before the given epoch.
+func (p *blobPruner) setArchiveEnd(end primitives.Epoch) {
+	p.archiveEnd.Store(uint64(end))
+	p.archiveEndSet.Store(true)
+}
+
+// pruneFrom returns the first epoch which may be pruned, or false when nothing may be pruned yet
+// because the end of the archived range isn't known.
+func (p *blobPruner) pruneFrom() (primitives.Epoch, bool) {
+	if !p.archive {
+		return 0, true
+	}
+	if !p.archiveEndSet.Load() {
+		return 0, false
+	}
+	return primitives.Epoch(p.archiveEnd.Load()), true
+}
+
+// archived returns true if the epoch is within the archived range.
+func (p *blobPruner) archived(epoch primitives.Epoch) bool {
+	from, ok := p.pruneFrom()
+	return p.archive && (!ok || epoch < from)
+}
+
 func periodFloor(latest, period primitives.Epoch) primitives.Epoch {
 	if latest < period {
 		return 0
@@ -19,18 +19,22 @@ type prunerScenario struct {
 	prunedBefore    primitives.Epoch
 	retentionPeriod primitives.Epoch
 	latest          primitives.Epoch
+	archive         bool
+	archiveEnd      *primitives.Epoch
 	expected        pruneExpectation
 }
 
 type pruneExpectation struct {
 	called  bool
+	from    primitives.Epoch
 	arg     primitives.Epoch
 	summary *pruneSummary
 	err     error
 }
 
-func (e *pruneExpectation) record(before primitives.Epoch) (*pruneSummary, error) {
+func (e *pruneExpectation) record(from, before primitives.Epoch) (*pruneSummary, error) {
 	e.called = true
+	e.from = from
 	e.arg = before
 	if e.summary == nil {
 		e.summary = &pruneSummary{}
@@ -40,6 +44,9 @@ func (e *pruneExpectation) record(before primitives.Epoch) (*pruneSummary, error
 
 func TestPrunerNotify(t *testing.T) {
 	defaultRetention := params.BeaconConfig().MinEpochsForBlobsSidecarsRequest
+	epoch := func(e primitives.Epoch) *primitives.Epoch {
+		return &e
+	}
 	cases := []prunerScenario{
 		{
 			name:            "last epoch of period",
@@ -83,16 +90,46 @@ func TestPrunerNotify(t *testing.T) {
 			latest:          defaultRetention + 1,
 			expected:        pruneExpectation{called: true, arg: 1},
 		},
+		{
+			name:            "archive - unknown archive end",
+			retentionPeriod: defaultRetention,
+			prunedBefore:    0,
+			latest:          defaultRetention + 1,
+			archive:         true,
+			expected:        pruneExpectation{called: false},
+		},
+		{
+			name:            "archive - within archived range",
+			retentionPeriod: defaultRetention,
+			prunedBefore:    0,
+			latest:          defaultRetention + 3,
+			archive:         true,
+			archiveEnd:      epoch(5),
+			expected:        pruneExpectation{called: false},
+		},
+		{
+			name:            "archive - triggers after archived range",
+			retentionPeriod: defaultRetention,
+			prunedBefore:    0,
+			latest:          defaultRetention + 3,
+			archive:         true,
+			archiveEnd:      epoch(1),
+			expected:        pruneExpectation{called: true, from: 1, arg: 3},
+		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
 			actual := &pruneExpectation{}
-			l := &mockLayout{pruneBeforeFunc: actual.record}
-			pruner := &blobPruner{retentionPeriod: c.retentionPeriod}
+			l := &mockLayout{pruneRangeFunc: actual.record}
+			pruner := &blobPruner{retentionPeriod: c.retentionPeriod, archive: c.archive}
+			if c.archiveEnd != nil {
+				pruner.setArchiveEnd(*c.archiveEnd)
+			}
 			pruner.prunedBefore.Store(uint64(c.prunedBefore))
 			done := pruner.notify(c.latest, l)
 			<-done
 			require.Equal(t, c.expected.called, actual.called)
+			require.Equal(t, c.expected.from, actual.from)
 			require.Equal(t, c.expected.arg, actual.arg)
 		})
 	}
@@ -140,12 +177,13 @@ func testRoots(n int) [][32]byte {
 	return roots
 }
 
-func TestLayoutPruneBefore(t *testing.T) {
+func TestLayoutPruneRange(t *testing.T) {
 	roots := testRoots(10)
 	cases := []struct {
 		name        string
 		pruned      []testIdent
 		remain      []testIdent
+		pruneFrom   primitives.Epoch
 		pruneBefore primitives.Epoch
 		err         error
 		sum         pruneSummary
@@ -176,13 +214,28 @@ func TestLayoutPruneBefore(t *testing.T) {
 			},
 			sum: pruneSummary{blobsPruned: 4},
 		},
+		{
+			name:        "expected pruned from epoch",
+			pruneFrom:   2,
+			pruneBefore: 3,
+			pruned: []testIdent{
+				{offset: 0, blobIdent: blobIdent{root: roots[2], epoch: 2, index: 0}},
+				{offset: 31, blobIdent: blobIdent{root: roots[3], epoch: 2, index: 3}},
+			},
+			remain: []testIdent{
+				{offset: 0, blobIdent: blobIdent{root: roots[0], epoch: 1, index: 0}},
+				{offset: 31, blobIdent: blobIdent{root: roots[1], epoch: 1, index: 5}},
+				{offset: 0, blobIdent: blobIdent{root: roots[4], epoch: 3, index: 2}},
+			},
+			sum: pruneSummary{blobsPruned: 2},
+		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
 			fs, bs := NewEphemeralBlobStorageAndFs(t, WithLayout(LayoutNameByEpoch))
 			pruned := testSetupBlobIdentPaths(t, fs, bs, c.pruned)
 			remain := testSetupBlobIdentPaths(t, fs, bs, c.remain)
-			sum, err := bs.layout.pruneBefore(c.pruneBefore)
+			sum, err := bs.layout.pruneRange(c.pruneFrom, c.pruneBefore)
 			if c.err != nil {
 				require.ErrorIs(t, err, c.err)
 				return
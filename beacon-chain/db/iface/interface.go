@@ -64,6 +64,22 @@ type ReadOnlyDatabase interface {
 	// origin checkpoint sync support
 	OriginCheckpointBlockRoot(ctx context.Context) ([32]byte, error)
 	BackfillStatus(context.Context) (*dbval.BackfillStatus, error)
+	BackfillMinimumSlot(context.Context) (primitives.Slot, error)
+
+	// Fork choice store persistence.
+	ForkChoiceSnapshot(ctx context.Context) ([]byte, error)
+
+	// Invalid block registry persistence.
+	InvalidBlocks(ctx context.Context) ([][]byte, error)
+
+	// Builder bid log persistence.
+	BuilderBids(ctx context.Context) ([][]byte, error)
+
+	// Slot timing persistence.
+	SlotTimings(ctx context.Context) ([][]byte, error)
+
+	// Operation pool persistence.
+	OperationPoolSnapshot(ctx context.Context) ([]byte, error)
 }
 
 // NoHeadAccessDatabase defines a struct without access to chain head data.
@@ -102,6 +118,9 @@ type NoHeadAccessDatabase interface {
 
 	CleanUpDirtyStates(ctx context.Context, slotsPerArchivedPoint primitives.Slot) error
 	DeleteHistoricalDataBeforeSlot(ctx context.Context, slot primitives.Slot) error
+
+	// Operation pool persistence.
+	SaveOperationPoolSnapshot(ctx context.Context, snapshot []byte) error
 }
 
 // HeadAccessDatabase defines a struct with access to reading chain head data.
@@ -120,7 +139,24 @@ type HeadAccessDatabase interface {
 	// Support for checkpoint sync and backfill.
 	SaveOrigin(ctx context.Context, serState, serBlock []byte) error
 	SaveBackfillStatus(context.Context, *dbval.BackfillStatus) error
+	SaveBackfillMinimumSlot(context.Context, primitives.Slot) error
 	BackfillFinalizedIndex(ctx context.Context, blocks []blocks.ROBlock, finalizedChildRoot [32]byte) error
+
+	// Fork choice store persistence.
+	SaveForkChoiceSnapshot(ctx context.Context, snapshot []byte) error
+	DeleteForkChoiceSnapshot(ctx context.Context) error
+
+	// Invalid block registry persistence.
+	SaveInvalidBlock(ctx context.Context, root [32]byte, enc []byte) error
+	DeleteInvalidBlock(ctx context.Context, root [32]byte) error
+
+	// Builder bid log persistence.
+	SaveBuilderBids(ctx context.Context, slot primitives.Slot, enc []byte) error
+	DeleteBuilderBids(ctx context.Context, slot primitives.Slot) error
+
+	// Slot timing persistence.
+	SaveSlotTimings(ctx context.Context, slot primitives.Slot, enc []byte) error
+	DeleteSlotTimings(ctx context.Context, slot primitives.Slot) error
 }
 
 // SlasherDatabase interface for persisting data related to detecting slashable offenses on Ethereum.
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
 	"github.com/prysmaticlabs/prysm/v5/proto/dbval"
 	bolt "go.etcd.io/bbolt"
@@ -42,3 +44,31 @@ func (s *Store) BackfillStatus(ctx context.Context) (*dbval.BackfillStatus, erro
 	})
 	return bf, err
 }
+
+// SaveBackfillMinimumSlot writes the slot at which backfill stops, when it is set at runtime, so that it
+// still applies after a restart.
+func (s *Store) SaveBackfillMinimumSlot(ctx context.Context, slot primitives.Slot) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveBackfillMinimumSlot")
+	defer span.End()
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(blocksBucket)
+		return bucket.Put(backfillMinimumSlotKey, bytesutil.SlotToBytesBigEndian(slot))
+	})
+}
+
+// BackfillMinimumSlot retrieves the slot at which backfill stops, as saved by SaveBackfillMinimumSlot.
+func (s *Store) BackfillMinimumSlot(ctx context.Context) (primitives.Slot, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.BackfillMinimumSlot")
+	defer span.End()
+	var slot primitives.Slot
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(blocksBucket)
+		b := bucket.Get(backfillMinimumSlotKey)
+		if len(b) == 0 {
+			return errors.Wrap(ErrNotFound, "backfill minimum slot not found")
+		}
+		slot = bytesutil.BytesToSlotBigEndian(b)
+		return nil
+	})
+	return slot, err
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v5/proto/dbval"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
@@ -33,3 +34,15 @@ func TestBackfillRoundtrip(t *testing.T) {
 	require.DeepEqual(t, b.LowRoot, dbub.LowRoot)
 	require.DeepEqual(t, b.LowParentRoot, dbub.LowParentRoot)
 }
+
+func TestBackfillMinimumSlot(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	_, err := db.BackfillMinimumSlot(ctx)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, db.SaveBackfillMinimumSlot(ctx, 100))
+	slot, err := db.BackfillMinimumSlot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, primitives.Slot(100), slot)
+}
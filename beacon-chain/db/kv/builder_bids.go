@@ -0,0 +1,50 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/golang/snappy"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	bolt "go.etcd.io/bbolt"
+)
+
+// SaveBuilderBids saves the serialized audit record of the builder bids for the proposal at a slot.
+func (s *Store) SaveBuilderBids(ctx context.Context, slot primitives.Slot, enc []byte) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveBuilderBids")
+	defer span.End()
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(builderBidsBucket)
+		return bucket.Put(bytesutil.SlotToBytesBigEndian(slot), snappy.Encode(nil, enc))
+	})
+}
+
+// BuilderBids retrieves all the audit records of builder bids saved by SaveBuilderBids, by increasing slot.
+func (s *Store) BuilderBids(ctx context.Context) ([][]byte, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.BuilderBids")
+	defer span.End()
+	var encs [][]byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(builderBidsBucket)
+		return bucket.ForEach(func(_, v []byte) error {
+			enc, err := snappy.Decode(nil, v)
+			if err != nil {
+				return err
+			}
+			encs = append(encs, enc)
+			return nil
+		})
+	})
+	return encs, err
+}
+
+// DeleteBuilderBids removes the audit record of the builder bids for the proposal at a slot.
+func (s *Store) DeleteBuilderBids(ctx context.Context, slot primitives.Slot) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.DeleteBuilderBids")
+	defer span.End()
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(builderBidsBucket)
+		return bucket.Delete(bytesutil.SlotToBytesBigEndian(slot))
+	})
+}
@@ -0,0 +1,28 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestBuilderBidsRoundtrip(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	encs, err := db.BuilderBids(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(encs))
+
+	require.NoError(t, db.SaveBuilderBids(ctx, 256, []byte("second")))
+	require.NoError(t, db.SaveBuilderBids(ctx, 2, []byte("first")))
+	require.NoError(t, db.SaveBuilderBids(ctx, 2, []byte("replaced")))
+	encs, err = db.BuilderBids(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, [][]byte{[]byte("replaced"), []byte("second")}, encs)
+
+	require.NoError(t, db.DeleteBuilderBids(ctx, 2))
+	encs, err = db.BuilderBids(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, [][]byte{[]byte("second")}, encs)
+}
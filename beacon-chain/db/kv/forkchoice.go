@@ -0,0 +1,49 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	bolt "go.etcd.io/bbolt"
+)
+
+// SaveForkChoiceSnapshot saves the serialized fork choice store, so that it can be restored after a restart.
+func (s *Store) SaveForkChoiceSnapshot(ctx context.Context, snapshot []byte) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveForkChoiceSnapshot")
+	defer span.End()
+	enc := snappy.Encode(nil, snapshot)
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(chainMetadataBucket)
+		return bucket.Put(forkChoiceSnapshotKey, enc)
+	})
+}
+
+// ForkChoiceSnapshot retrieves the serialized fork choice store saved by SaveForkChoiceSnapshot.
+func (s *Store) ForkChoiceSnapshot(ctx context.Context) ([]byte, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.ForkChoiceSnapshot")
+	defer span.End()
+	var enc []byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(chainMetadataBucket)
+		enc = bucket.Get(forkChoiceSnapshotKey)
+		if len(enc) == 0 {
+			return errors.Wrap(ErrNotFound, "fork choice snapshot not found")
+		}
+		var err error
+		enc, err = snappy.Decode(nil, enc)
+		return err
+	})
+	return enc, err
+}
+
+// DeleteForkChoiceSnapshot removes the saved fork choice store, so that it is not restored more than once.
+func (s *Store) DeleteForkChoiceSnapshot(ctx context.Context) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.DeleteForkChoiceSnapshot")
+	defer span.End()
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(chainMetadataBucket)
+		return bucket.Delete(forkChoiceSnapshotKey)
+	})
+}
@@ -0,0 +1,25 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestForkChoiceSnapshotRoundtrip(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	_, err := db.ForkChoiceSnapshot(ctx)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	snapshot := []byte("forkchoice snapshot")
+	require.NoError(t, db.SaveForkChoiceSnapshot(ctx, snapshot))
+	got, err := db.ForkChoiceSnapshot(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, snapshot, got)
+
+	require.NoError(t, db.DeleteForkChoiceSnapshot(ctx))
+	_, err = db.ForkChoiceSnapshot(ctx)
+	require.ErrorIs(t, err, ErrNotFound)
+}
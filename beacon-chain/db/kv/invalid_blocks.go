@@ -0,0 +1,48 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/golang/snappy"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	bolt "go.etcd.io/bbolt"
+)
+
+// SaveInvalidBlock saves the serialized record of a rejected block, keyed by its root.
+func (s *Store) SaveInvalidBlock(ctx context.Context, root [32]byte, enc []byte) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveInvalidBlock")
+	defer span.End()
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(invalidBlocksBucket)
+		return bucket.Put(root[:], snappy.Encode(nil, enc))
+	})
+}
+
+// InvalidBlocks retrieves all the records of rejected blocks saved by SaveInvalidBlock.
+func (s *Store) InvalidBlocks(ctx context.Context) ([][]byte, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.InvalidBlocks")
+	defer span.End()
+	var encs [][]byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(invalidBlocksBucket)
+		return bucket.ForEach(func(_, v []byte) error {
+			enc, err := snappy.Decode(nil, v)
+			if err != nil {
+				return err
+			}
+			encs = append(encs, enc)
+			return nil
+		})
+	})
+	return encs, err
+}
+
+// DeleteInvalidBlock removes the record of a rejected block.
+func (s *Store) DeleteInvalidBlock(ctx context.Context, root [32]byte) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.DeleteInvalidBlock")
+	defer span.End()
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(invalidBlocksBucket)
+		return bucket.Delete(root[:])
+	})
+}
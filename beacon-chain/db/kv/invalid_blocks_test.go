@@ -0,0 +1,28 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestInvalidBlocksRoundtrip(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	encs, err := db.InvalidBlocks(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(encs))
+
+	require.NoError(t, db.SaveInvalidBlock(ctx, [32]byte{1}, []byte("first")))
+	require.NoError(t, db.SaveInvalidBlock(ctx, [32]byte{2}, []byte("second")))
+	require.NoError(t, db.SaveInvalidBlock(ctx, [32]byte{1}, []byte("replaced")))
+	encs, err = db.InvalidBlocks(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, [][]byte{[]byte("replaced"), []byte("second")}, encs)
+
+	require.NoError(t, db.DeleteInvalidBlock(ctx, [32]byte{1}))
+	encs, err = db.InvalidBlocks(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, [][]byte{[]byte("second")}, encs)
+}
@@ -0,0 +1,40 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	bolt "go.etcd.io/bbolt"
+)
+
+// SaveOperationPoolSnapshot saves the serialized operation pools, so that the pending operations can be restored
+// after a restart.
+func (s *Store) SaveOperationPoolSnapshot(ctx context.Context, snapshot []byte) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveOperationPoolSnapshot")
+	defer span.End()
+	enc := snappy.Encode(nil, snapshot)
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(chainMetadataBucket)
+		return bucket.Put(operationPoolSnapshotKey, enc)
+	})
+}
+
+// OperationPoolSnapshot retrieves the serialized operation pools saved by SaveOperationPoolSnapshot.
+func (s *Store) OperationPoolSnapshot(ctx context.Context) ([]byte, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.OperationPoolSnapshot")
+	defer span.End()
+	var enc []byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(chainMetadataBucket)
+		enc = bucket.Get(operationPoolSnapshotKey)
+		if len(enc) == 0 {
+			return errors.Wrap(ErrNotFound, "operation pool snapshot not found")
+		}
+		var err error
+		enc, err = snappy.Decode(nil, enc)
+		return err
+	})
+	return enc, err
+}
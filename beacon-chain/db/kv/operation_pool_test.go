@@ -0,0 +1,27 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestOperationPoolSnapshotRoundtrip(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	_, err := db.OperationPoolSnapshot(ctx)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	snapshot := []byte("operation pool snapshot")
+	require.NoError(t, db.SaveOperationPoolSnapshot(ctx, snapshot))
+	got, err := db.OperationPoolSnapshot(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, snapshot, got)
+
+	snapshot = []byte("newer operation pool snapshot")
+	require.NoError(t, db.SaveOperationPoolSnapshot(ctx, snapshot))
+	got, err = db.OperationPoolSnapshot(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, snapshot, got)
+}
@@ -22,6 +22,15 @@ var (
 	lightClientBootstrapBucket     = []byte("light-client-bootstrap")
 	lightClientSyncCommitteeBucket = []byte("light-client-sync-committee")
 
+	// Blocks and blob sidecars rejected by the node, kept for inspection.
+	invalidBlocksBucket = []byte("invalid-blocks")
+
+	// Builder bids received for the recent proposals of the node, kept for auditing.
+	builderBidsBucket = []byte("builder-bids")
+
+	// Arrival and processing timings of the blocks of the recent slots, kept for inspection.
+	slotTimingsBucket = []byte("slot-timings")
+
 	// Deprecated: This bucket was migrated in PR 6461. Do not use, except for migrations.
 	slotsHasObjectBucket = []byte("slots-has-objects")
 	// Deprecated: This bucket was migrated in PR 6461. Do not use, except for migrations.
@@ -62,6 +71,12 @@ var (
 	originCheckpointBlockRootKey = []byte("origin-checkpoint-block-root")
 	// tracking data about an ongoing backfill
 	backfillStatusKey = []byte("backfill-status")
+	// minimum slot of backfill set at runtime
+	backfillMinimumSlotKey = []byte("backfill-minimum-slot")
+	// fork choice store saved on shutdown
+	forkChoiceSnapshotKey = []byte("forkchoice-snapshot")
+	// pending operations of the operation pools, saved periodically and on shutdown
+	operationPoolSnapshotKey = []byte("operation-pool-snapshot")
 
 	// Deprecated: This index key was migrated in PR 6461. Do not use, except for migrations.
 	lastArchivedIndexKey = []byte("last-archived")
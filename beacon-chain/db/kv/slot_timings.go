@@ -0,0 +1,50 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/golang/snappy"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	bolt "go.etcd.io/bbolt"
+)
+
+// SaveSlotTimings saves the serialized arrival and processing timings of the blocks of a slot.
+func (s *Store) SaveSlotTimings(ctx context.Context, slot primitives.Slot, enc []byte) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveSlotTimings")
+	defer span.End()
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(slotTimingsBucket)
+		return bucket.Put(bytesutil.SlotToBytesBigEndian(slot), snappy.Encode(nil, enc))
+	})
+}
+
+// SlotTimings retrieves all the slot timings saved by SaveSlotTimings, by increasing slot.
+func (s *Store) SlotTimings(ctx context.Context) ([][]byte, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SlotTimings")
+	defer span.End()
+	var encs [][]byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(slotTimingsBucket)
+		return bucket.ForEach(func(_, v []byte) error {
+			enc, err := snappy.Decode(nil, v)
+			if err != nil {
+				return err
+			}
+			encs = append(encs, enc)
+			return nil
+		})
+	})
+	return encs, err
+}
+
+// DeleteSlotTimings removes the timings of the blocks of a slot.
+func (s *Store) DeleteSlotTimings(ctx context.Context, slot primitives.Slot) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.DeleteSlotTimings")
+	defer span.End()
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(slotTimingsBucket)
+		return bucket.Delete(bytesutil.SlotToBytesBigEndian(slot))
+	})
+}
@@ -0,0 +1,28 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestSlotTimingsRoundtrip(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	encs, err := db.SlotTimings(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(encs))
+
+	require.NoError(t, db.SaveSlotTimings(ctx, 256, []byte("second")))
+	require.NoError(t, db.SaveSlotTimings(ctx, 2, []byte("first")))
+	require.NoError(t, db.SaveSlotTimings(ctx, 2, []byte("replaced")))
+	encs, err = db.SlotTimings(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, [][]byte{[]byte("replaced"), []byte("second")}, encs)
+
+	require.NoError(t, db.DeleteSlotTimings(ctx, 2))
+	encs, err = db.SlotTimings(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, [][]byte{[]byte("second")}, encs)
+}
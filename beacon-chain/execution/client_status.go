@@ -0,0 +1,157 @@
+package execution
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/execution/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	syncingMethod   = "eth_syncing"
+	peerCountMethod = "net_peerCount"
+	// time allowed to the execution client to report its status.
+	clientStatusTimeout = 5 * time.Second
+)
+
+var (
+	executionClientSyncingGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "execution_client_syncing",
+		Help: "1 if the execution client reports it is syncing, 0 otherwise",
+	})
+	executionClientPeersGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "execution_client_peer_count",
+		Help: "The number of peers reported by the execution client",
+	})
+)
+
+// syncProgress is the object returned by eth_syncing while the execution client is syncing.
+type syncProgress struct {
+	CurrentBlock hexutil.Uint64 `json:"currentBlock"`
+	HighestBlock hexutil.Uint64 `json:"highestBlock"`
+}
+
+// ExecutionClientStatus returns the last polled sync status and peer count of the execution client.
+func (s *Service) ExecutionClientStatus() types.ExecutionClientStatus {
+	s.clientStatusLock.RLock()
+	defer s.clientStatusLock.RUnlock()
+	return s.clientStatus
+}
+
+// pollExecutionClientStatus updates the status of the execution client every slot until the context is canceled.
+// It runs apart from the main loop of the service, so that a slow execution client does not hold it up.
+func (s *Service) pollExecutionClientStatus(ctx context.Context) {
+	s.updateExecutionClientStatus(ctx)
+	ticker := time.NewTicker(time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.updateExecutionClientStatus(ctx)
+		}
+	}
+}
+
+// updateExecutionClientStatus polls the status of the execution client, and notifies the
+// state feed when the execution client changes state.
+func (s *Service) updateExecutionClientStatus(ctx context.Context) {
+	status := s.fetchExecutionClientStatus(ctx)
+
+	s.clientStatusLock.Lock()
+	previous := s.clientStatus.State
+	s.clientStatus = status
+	s.clientStatusLock.Unlock()
+
+	if status.State == types.ExecutionClientSyncing {
+		executionClientSyncingGauge.Set(1)
+	} else {
+		executionClientSyncingGauge.Set(0)
+	}
+	if status.PeerCountKnown {
+		executionClientPeersGauge.Set(float64(status.PeerCount))
+	}
+	if previous == status.State {
+		return
+	}
+
+	fields := logrus.Fields{
+		"previous": previous,
+		"state":    status.State,
+	}
+	if status.State == types.ExecutionClientSyncing {
+		fields["currentBlock"] = status.CurrentBlock
+		fields["highestBlock"] = status.HighestBlock
+	}
+	if status.PeerCountKnown {
+		fields["peers"] = status.PeerCount
+	}
+	if status.Err != nil {
+		fields["error"] = status.Err
+	}
+	log.WithFields(fields).Info("Execution client changed state")
+
+	if s.cfg.stateNotifier != nil {
+		s.cfg.stateNotifier.StateFeed().Send(&feed.Event{
+			Type: statefeed.ExecutionClientStatusChanged,
+			Data: &statefeed.ExecutionClientStatusChangedData{
+				Previous: previous,
+				Status:   status,
+			},
+		})
+	}
+}
+
+// fetchExecutionClientStatus requests the sync status and peer count of the execution client. The
+// execution client is considered offline if it does not report its sync status, while a missing peer
+// count is tolerated as net_peerCount is not required on the engine endpoint.
+func (s *Service) fetchExecutionClientStatus(ctx context.Context) types.ExecutionClientStatus {
+	status := types.ExecutionClientStatus{Updated: time.Now()}
+	ctx, cancel := context.WithTimeout(ctx, clientStatusTimeout)
+	defer cancel()
+
+	var progress json.RawMessage
+	if err := s.rpcClient.CallContext(ctx, &progress, syncingMethod); err != nil {
+		status.State = types.ExecutionClientOffline
+		status.Err = errors.Wrap(err, "could not get sync status")
+		return status
+	}
+	var peers hexutil.Uint64
+	if err := s.rpcClient.CallContext(ctx, &peers, peerCountMethod); err != nil {
+		log.WithError(err).Debug("Could not get execution client peer count")
+	} else {
+		status.PeerCount = uint64(peers)
+		status.PeerCountKnown = true
+	}
+
+	if bytes.Equal(bytes.TrimSpace(progress), []byte("false")) {
+		s.latestEth1DataLock.RLock()
+		height := s.latestEth1Data.BlockHeight
+		s.latestEth1DataLock.RUnlock()
+		status.State = types.ExecutionClientSynced
+		status.CurrentBlock = height
+		status.HighestBlock = height
+		return status
+	}
+	p := &syncProgress{}
+	if err := json.Unmarshal(progress, p); err != nil {
+		status.State = types.ExecutionClientOffline
+		status.Err = errors.Wrap(err, "could not decode sync status")
+		return status
+	}
+	status.State = types.ExecutionClientSyncing
+	status.CurrentBlock = uint64(p.CurrentBlock)
+	status.HighestBlock = uint64(p.HighestBlock)
+	return status
+}
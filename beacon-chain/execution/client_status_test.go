@@ -0,0 +1,92 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	gethRPC "github.com/ethereum/go-ethereum/rpc"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/execution/types"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+// statusRPCClient answers each method with a raw JSON result, or an error if the method has no result.
+type statusRPCClient struct {
+	results map[string]string
+}
+
+func (*statusRPCClient) Close() {}
+
+func (*statusRPCClient) BatchCall([]gethRPC.BatchElem) error {
+	return errors.New("not implemented")
+}
+
+func (c *statusRPCClient) CallContext(_ context.Context, result interface{}, method string, _ ...interface{}) error {
+	res, ok := c.results[method]
+	if !ok {
+		return errors.New("method not found")
+	}
+	return json.Unmarshal([]byte(res), result)
+}
+
+func TestUpdateExecutionClientStatus(t *testing.T) {
+	ctx := context.Background()
+	client := &statusRPCClient{results: map[string]string{
+		syncingMethod:   `{"startingBlock":"0x0","currentBlock":"0x64","highestBlock":"0xc8"}`,
+		peerCountMethod: `"0x3"`,
+	}}
+	notifier := &goodNotifier{}
+	s := &Service{
+		cfg:            &config{stateNotifier: notifier},
+		rpcClient:      client,
+		latestEth1Data: &ethpb.LatestETH1Data{BlockHeight: 300},
+		clientStatus:   types.ExecutionClientStatus{State: types.ExecutionClientUnknown},
+	}
+	events := make(chan *feed.Event, 4)
+	sub := notifier.StateFeed().Subscribe(events)
+	defer sub.Unsubscribe()
+
+	s.updateExecutionClientStatus(ctx)
+	status := s.ExecutionClientStatus()
+	assert.Equal(t, types.ExecutionClientSyncing, status.State)
+	assert.Equal(t, uint64(100), status.CurrentBlock)
+	assert.Equal(t, uint64(200), status.HighestBlock)
+	assert.Equal(t, true, status.PeerCountKnown)
+	assert.Equal(t, uint64(3), status.PeerCount)
+	ev := <-events
+	require.Equal(t, statefeed.ExecutionClientStatusChanged, int(ev.Type))
+	data, ok := ev.Data.(*statefeed.ExecutionClientStatusChangedData)
+	require.Equal(t, true, ok)
+	assert.Equal(t, types.ExecutionClientUnknown, data.Previous)
+	assert.Equal(t, types.ExecutionClientSyncing, data.Status.State)
+
+	// No event is sent while the state does not change.
+	client.results[syncingMethod] = `{"startingBlock":"0x0","currentBlock":"0x96","highestBlock":"0xc8"}`
+	s.updateExecutionClientStatus(ctx)
+	assert.Equal(t, uint64(150), s.ExecutionClientStatus().CurrentBlock)
+	assert.Equal(t, 0, len(events))
+
+	// The peer count is optional.
+	client.results[syncingMethod] = `false`
+	delete(client.results, peerCountMethod)
+	s.updateExecutionClientStatus(ctx)
+	status = s.ExecutionClientStatus()
+	assert.Equal(t, types.ExecutionClientSynced, status.State)
+	assert.Equal(t, uint64(300), status.CurrentBlock)
+	assert.Equal(t, false, status.PeerCountKnown)
+	ev = <-events
+	assert.Equal(t, types.ExecutionClientSyncing, ev.Data.(*statefeed.ExecutionClientStatusChangedData).Previous)
+
+	delete(client.results, syncingMethod)
+	s.updateExecutionClientStatus(ctx)
+	status = s.ExecutionClientStatus()
+	assert.Equal(t, types.ExecutionClientOffline, status.State)
+	require.ErrorContains(t, "method not found", status.Err)
+	ev = <-events
+	assert.Equal(t, types.ExecutionClientOffline, ev.Data.(*statefeed.ExecutionClientStatusChangedData).Status.State)
+}
@@ -13,6 +13,7 @@ import (
 	gethRPC "github.com/ethereum/go-ethereum/rpc"
 	"github.com/holiman/uint256"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/kzg"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/execution/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
@@ -83,6 +84,8 @@ const (
 	ExchangeCapabilities = "engine_exchangeCapabilities"
 	// GetBlobsV1 request string for JSON-RPC.
 	GetBlobsV1 = "engine_getBlobsV1"
+	// GetBlobsV2 request string for JSON-RPC, returning the cell proofs of the blobs.
+	GetBlobsV2 = "engine_getBlobsV2"
 	// Defines the seconds before timing out engine endpoints with non-block execution semantics.
 	defaultEngineTimeout = time.Second
 )
@@ -134,38 +137,35 @@ func (s *Service) NewPayload(ctx context.Context, payload interfaces.ExecutionDa
 	d := time.Now().Add(time.Duration(params.BeaconConfig().ExecutionEngineTimeoutValue) * time.Second)
 	ctx, cancel := context.WithDeadline(ctx, d)
 	defer cancel()
-	result := &pb.PayloadStatus{}
-
+	var method string
+	var args []interface{}
 	switch payloadPb := payload.Proto().(type) {
 	case *pb.ExecutionPayload:
-		err := s.rpcClient.CallContext(ctx, result, NewPayloadMethod, payloadPb)
-		if err != nil {
-			return nil, handleRPCError(err)
-		}
+		method, args = NewPayloadMethod, []interface{}{payloadPb}
 	case *pb.ExecutionPayloadCapella:
-		err := s.rpcClient.CallContext(ctx, result, NewPayloadMethodV2, payloadPb)
-		if err != nil {
-			return nil, handleRPCError(err)
-		}
+		method, args = NewPayloadMethodV2, []interface{}{payloadPb}
 	case *pb.ExecutionPayloadDeneb:
 		if executionRequests == nil {
-			err := s.rpcClient.CallContext(ctx, result, NewPayloadMethodV3, payloadPb, versionedHashes, parentBlockRoot)
-			if err != nil {
-				return nil, handleRPCError(err)
-			}
+			method, args = NewPayloadMethodV3, []interface{}{payloadPb, versionedHashes, parentBlockRoot}
 		} else {
 			flattenedRequests, err := pb.EncodeExecutionRequests(executionRequests)
 			if err != nil {
 				return nil, errors.Wrap(err, "failed to encode execution requests")
 			}
-			err = s.rpcClient.CallContext(ctx, result, NewPayloadMethodV4, payloadPb, versionedHashes, parentBlockRoot, flattenedRequests)
-			if err != nil {
-				return nil, handleRPCError(err)
-			}
+			method, args = NewPayloadMethodV4, []interface{}{payloadPb, versionedHashes, parentBlockRoot, flattenedRequests}
 		}
 	default:
 		return nil, errors.New("unknown execution data type")
 	}
+	result, err := callEngines(ctx, s, method, func(ctx context.Context, _ int, client RPCClient) (*pb.PayloadStatus, error) {
+		result := &pb.PayloadStatus{}
+		return result, client.CallContext(ctx, result, method, args...)
+	}, func(result *pb.PayloadStatus) bool {
+		return result.Status == pb.PayloadStatus_SYNCING
+	})
+	if err != nil {
+		return nil, handleRPCError(err)
+	}
 	if result.ValidationError != "" {
 		log.WithError(errors.New(result.ValidationError)).Error("Got a validation error in newPayload")
 	}
@@ -197,42 +197,45 @@ func (s *Service) ForkchoiceUpdated(
 	d := time.Now().Add(time.Duration(params.BeaconConfig().ExecutionEngineTimeoutValue) * time.Second)
 	ctx, cancel := context.WithDeadline(ctx, d)
 	defer cancel()
-	result := &ForkchoiceUpdatedResponse{}
 
 	if attrs == nil {
 		return nil, nil, errors.New("nil payload attributer")
 	}
+	var method string
+	var a interface{}
+	var err error
 	switch attrs.Version() {
 	case version.Bellatrix:
-		a, err := attrs.PbV1()
-		if err != nil {
-			return nil, nil, err
-		}
-		err = s.rpcClient.CallContext(ctx, result, ForkchoiceUpdatedMethod, state, a)
-		if err != nil {
-			return nil, nil, handleRPCError(err)
-		}
+		method = ForkchoiceUpdatedMethod
+		a, err = attrs.PbV1()
 	case version.Capella:
-		a, err := attrs.PbV2()
-		if err != nil {
-			return nil, nil, err
-		}
-		err = s.rpcClient.CallContext(ctx, result, ForkchoiceUpdatedMethodV2, state, a)
-		if err != nil {
-			return nil, nil, handleRPCError(err)
-		}
+		method = ForkchoiceUpdatedMethodV2
+		a, err = attrs.PbV2()
 	case version.Deneb, version.Electra, version.Fulu:
-		a, err := attrs.PbV3()
-		if err != nil {
-			return nil, nil, err
-		}
-		err = s.rpcClient.CallContext(ctx, result, ForkchoiceUpdatedMethodV3, state, a)
-		if err != nil {
-			return nil, nil, handleRPCError(err)
-		}
+		method = ForkchoiceUpdatedMethodV3
+		a, err = attrs.PbV3()
 	default:
 		return nil, nil, fmt.Errorf("unknown payload attribute version: %v", attrs.Version())
 	}
+	if err != nil {
+		return nil, nil, err
+	}
+	// Every engine builds its own payload, keep track of their payload ids to retrieve the payload from any of them.
+	ids := &enginePayloadIDs{ids: make(map[int]pb.PayloadIDBytes)}
+	result, err := callEngines(ctx, s, method, func(ctx context.Context, index int, client RPCClient) (*ForkchoiceUpdatedResponse, error) {
+		result := &ForkchoiceUpdatedResponse{}
+		if err := client.CallContext(ctx, result, method, state, a); err != nil {
+			return result, err
+		}
+		ids.set(index, result.PayloadId)
+		return result, nil
+	}, func(result *ForkchoiceUpdatedResponse) bool {
+		return result.Status != nil && result.Status.Status == pb.PayloadStatus_SYNCING
+	})
+	if err != nil {
+		return nil, nil, handleRPCError(err)
+	}
+	s.payloadBuilds.add(result.PayloadId, ids)
 
 	if result.Status == nil {
 		return nil, nil, ErrNilResponse
@@ -281,15 +284,41 @@ func (s *Service) GetPayload(ctx context.Context, payloadId [8]byte, slot primit
 	defer cancel()
 
 	method, result := getPayloadMethodAndMessage(slot)
-	err := s.rpcClient.CallContext(ctx, result, method, pb.PayloadIDBytes(payloadId))
-	if err != nil {
-		return nil, handleRPCError(err)
+	ids := s.payloadBuilds.get(pb.PayloadIDBytes(payloadId))
+	if len(s.engines) < 2 || ids == nil {
+		err := s.rpcClient.CallContext(ctx, result, method, pb.PayloadIDBytes(payloadId))
+		if err != nil {
+			return nil, handleRPCError(err)
+		}
+		return blocks.NewGetPayloadResponse(result)
 	}
-	res, err := blocks.NewGetPayloadResponse(result)
-	if err != nil {
-		return nil, err
+	// Retrieve the payload from the active engine, and from the other engines if it fails.
+	var firstErr error
+	for _, i := range s.engineOrder() {
+		id, ok := ids.get(i)
+		if !ok {
+			continue
+		}
+		e := s.engines[i]
+		_, result = getPayloadMethodAndMessage(slot)
+		start := time.Now()
+		err := s.engineClient(e).CallContext(ctx, result, method, id)
+		engineLatency.WithLabelValues(e.name, method).Observe(float64(time.Since(start).Milliseconds()))
+		if err == nil {
+			return blocks.NewGetPayloadResponse(result)
+		}
+		if isEngineFailure(err) {
+			e.setHealthy(false)
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+		log.WithError(err).WithField("endpoint", e.name).Warn("Could not get payload from execution engine")
+	}
+	if firstErr == nil {
+		return nil, errors.New("no execution engine returned a payload id for this payload")
 	}
-	return res, nil
+	return nil, handleRPCError(firstErr)
 }
 
 func (s *Service) ExchangeCapabilities(ctx context.Context) ([]string, error) {
@@ -499,6 +528,26 @@ func (s *Service) GetBlobs(ctx context.Context, versionedHashes []common.Hash) (
 	return result, handleRPCError(err)
 }
 
+// GetBlobsV2 returns the blobs and the proofs of their cells from the execution engine for the given versioned
+// hashes. The execution engine returns either all the blobs or none of them, in which case the result is nil.
+func (s *Service) GetBlobsV2(ctx context.Context, versionedHashes []common.Hash) ([]*pb.BlobAndCellProofs, error) {
+	ctx, span := trace.StartSpan(ctx, "powchain.engine-api-client.GetBlobsV2")
+	defer span.End()
+	// If the execution engine does not support `GetBlobsV2`, return early to prevent encountering an error later.
+	if !s.capabilityCache.has(GetBlobsV2) {
+		return nil, nil
+	}
+
+	var result []*pb.BlobAndCellProofs
+	if err := s.rpcClient.CallContext(ctx, &result, GetBlobsV2, versionedHashes); err != nil {
+		return nil, handleRPCError(err)
+	}
+	if len(result) != 0 && len(result) != len(versionedHashes) {
+		return nil, errors.Errorf("execution engine returned %d blobs, requested %d", len(result), len(versionedHashes))
+	}
+	return result, nil
+}
+
 // ReconstructFullBlock takes in a blinded beacon block and reconstructs
 // a beacon block with a full execution payload via the engine API.
 func (s *Service) ReconstructFullBlock(
@@ -544,10 +593,12 @@ func (s *Service) ReconstructBlobSidecars(ctx context.Context, block interfaces.
 	// Collect KZG hashes for non-existing blobs
 	var kzgHashes []common.Hash
 	var kzgIndexes []int
+	var missingCommitments [][]byte
 	for i, commitment := range kzgCommitments {
 		if !hasIndex(uint64(i)) {
 			kzgHashes = append(kzgHashes, primitives.ConvertKzgCommitmentToVersionedHash(commitment))
 			kzgIndexes = append(kzgIndexes, i)
+			missingCommitments = append(missingCommitments, commitment)
 		}
 	}
 	if len(kzgHashes) == 0 {
@@ -555,7 +606,7 @@ func (s *Service) ReconstructBlobSidecars(ctx context.Context, block interfaces.
 	}
 
 	// Fetch blobs from EL
-	blobs, err := s.GetBlobs(ctx, kzgHashes)
+	blobs, err := s.getBlobsAndProofs(ctx, block.Version(), kzgHashes, missingCommitments)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not get blobs")
 	}
@@ -609,6 +660,31 @@ func (s *Service) ReconstructBlobSidecars(ctx context.Context, block interfaces.
 	return verifiedBlobs, nil
 }
 
+// getBlobsAndProofs fetches the blobs and their proofs from the execution engine, with the version of
+// engine_getBlobs of the fork of the block. From Fulu, the execution engine returns the proofs of the
+// cells of the blobs instead of the blob proofs, so the blob proofs are computed from the blobs.
+func (s *Service) getBlobsAndProofs(ctx context.Context, v int, versionedHashes []common.Hash, commitments [][]byte) ([]*pb.BlobAndProof, error) {
+	if v < version.Fulu {
+		return s.GetBlobs(ctx, versionedHashes)
+	}
+	blobs, err := s.GetBlobsV2(ctx, versionedHashes)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*pb.BlobAndProof, len(blobs))
+	for i, blob := range blobs {
+		if blob == nil {
+			continue
+		}
+		proof, err := kzg.ComputeBlobKZGProof(blob.Blob, commitments[i])
+		if err != nil {
+			return nil, errors.Wrap(err, "could not compute blob KZG proof")
+		}
+		result[i] = &pb.BlobAndProof{Blob: blob.Blob, KzgProof: proof}
+	}
+	return result, nil
+}
+
 func fullPayloadFromPayloadBody(
 	header interfaces.ExecutionData, body *pb.ExecutionPayloadBody, bVersion int,
 ) (interfaces.ExecutionData, error) {
@@ -13,6 +13,7 @@ import (
 	"strings"
 	"testing"
 
+	GoKZG "github.com/crate-crypto/go-kzg-4844"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -20,6 +21,7 @@ import (
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/holiman/uint256"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/kzg"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
 	mocks "github.com/prysmaticlabs/prysm/v5/beacon-chain/execution/testing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
@@ -2476,6 +2478,138 @@ func TestReconstructBlobSidecars(t *testing.T) {
 	})
 }
 
+func TestReconstructBlobSidecars_Fulu(t *testing.T) {
+	require.NoError(t, kzg.Start())
+	kzgCtx, err := GoKZG.NewContext4096Secure()
+	require.NoError(t, err)
+
+	b := util.NewBeaconBlockFulu()
+	blobs := make([]pb.BlobAndCellProofsJson, 2)
+	for i := range blobs {
+		blob := util.GetRandBlob(int64(i))
+		commitment, err := kzgCtx.BlobToKZGCommitment(&blob, 0)
+		require.NoError(t, err)
+		b.Block.Body.BlobKzgCommitments = append(b.Block.Body.BlobKzgCommitments, commitment[:])
+		blobs[i] = pb.BlobAndCellProofsJson{Blob: blob[:], KzgProofs: []hexutil.Bytes{[]byte("cellproof")}}
+	}
+	r, err := b.Block.HashTreeRoot()
+	require.NoError(t, err)
+	sb, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+
+	var methods []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		defer func() {
+			require.NoError(t, r.Body.Close())
+		}()
+		var req struct {
+			Method string `json:"method"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		methods = append(methods, req.Method)
+		respJSON := map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"result":  blobs,
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(respJSON))
+	}))
+	defer srv.Close()
+	rpcClient, err := rpc.DialHTTP(srv.URL)
+	require.NoError(t, err)
+	defer rpcClient.Close()
+	client := &Service{
+		rpcClient:       rpcClient,
+		capabilityCache: &capabilityCache{capabilities: map[string]interface{}{GetBlobsV1: nil, GetBlobsV2: nil}},
+		blobVerifier: func(b blocks.ROBlob, reqs []verification.Requirement) verification.BlobVerifier {
+			return &verification.MockBlobVerifier{
+				CbVerifiedROBlob: func() (blocks.VerifiedROBlob, error) {
+					return blocks.NewVerifiedROBlob(b), nil
+				},
+			}
+		},
+	}
+
+	verifiedBlobs, err := client.ReconstructBlobSidecars(context.Background(), sb, r, mockSummary(t, make([]bool, 6)))
+	require.NoError(t, err)
+	require.DeepEqual(t, []string{GetBlobsV2}, methods)
+	require.Equal(t, 2, len(verifiedBlobs))
+	// The blob proofs are computed from the blobs, as the execution engine only returns cell proofs.
+	for _, blob := range verifiedBlobs {
+		require.NoError(t, kzg.Verify(blob.ROBlob))
+	}
+}
+
+func TestGetBlobsV2(t *testing.T) {
+	ctx := context.Background()
+	hashes := []common.Hash{{'a'}, {'b'}}
+	newServer := func(result interface{}) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			defer func() {
+				require.NoError(t, r.Body.Close())
+			}()
+			respJSON := map[string]interface{}{
+				"jsonrpc": "2.0",
+				"id":      1,
+				"result":  result,
+			}
+			require.NoError(t, json.NewEncoder(w).Encode(respJSON))
+		}))
+	}
+	blobs := []pb.BlobAndCellProofsJson{
+		{Blob: []byte("blob1"), KzgProofs: []hexutil.Bytes{[]byte("proof1"), []byte("proof2")}},
+		{Blob: []byte("blob2"), KzgProofs: []hexutil.Bytes{[]byte("proof3"), []byte("proof4")}},
+	}
+
+	t.Run("not supported", func(t *testing.T) {
+		client := &Service{capabilityCache: &capabilityCache{}}
+		result, err := client.GetBlobsV2(ctx, hashes)
+		require.NoError(t, err)
+		require.Equal(t, 0, len(result))
+	})
+	t.Run("all blobs", func(t *testing.T) {
+		srv := newServer(blobs)
+		defer srv.Close()
+		rpcClient, err := rpc.DialHTTP(srv.URL)
+		require.NoError(t, err)
+		defer rpcClient.Close()
+		client := &Service{rpcClient: rpcClient, capabilityCache: &capabilityCache{capabilities: map[string]interface{}{GetBlobsV2: nil}}}
+
+		result, err := client.GetBlobsV2(ctx, hashes)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(result))
+		require.Equal(t, fieldparams.BlobLength, len(result[1].Blob))
+		require.DeepEqual(t, []byte("blob2"), result[1].Blob[:5])
+		require.Equal(t, 2, len(result[1].KzgProofs))
+		require.DeepEqual(t, []byte("proof4"), result[1].KzgProofs[1][:6])
+	})
+	t.Run("missing blobs", func(t *testing.T) {
+		srv := newServer(nil)
+		defer srv.Close()
+		rpcClient, err := rpc.DialHTTP(srv.URL)
+		require.NoError(t, err)
+		defer rpcClient.Close()
+		client := &Service{rpcClient: rpcClient, capabilityCache: &capabilityCache{capabilities: map[string]interface{}{GetBlobsV2: nil}}}
+
+		result, err := client.GetBlobsV2(ctx, hashes)
+		require.NoError(t, err)
+		require.Equal(t, 0, len(result))
+	})
+	t.Run("wrong number of blobs", func(t *testing.T) {
+		srv := newServer(blobs[:1])
+		defer srv.Close()
+		rpcClient, err := rpc.DialHTTP(srv.URL)
+		require.NoError(t, err)
+		defer rpcClient.Close()
+		client := &Service{rpcClient: rpcClient, capabilityCache: &capabilityCache{capabilities: map[string]interface{}{GetBlobsV2: nil}}}
+
+		_, err = client.GetBlobsV2(ctx, hashes)
+		require.ErrorContains(t, "returned 1 blobs, requested 2", err)
+	})
+}
+
 func createRandomKzgCommitments(t *testing.T, num int) [][]byte {
 	kzgCommitments := make([][]byte, num)
 	for i := range kzgCommitments {
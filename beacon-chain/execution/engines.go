@@ -0,0 +1,279 @@
+package execution
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	gethRPC "github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/io/logs"
+	"github.com/prysmaticlabs/prysm/v5/network"
+	pb "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	"github.com/sirupsen/logrus"
+)
+
+// Maximum number of payload builds for which the payload ids returned by every engine are kept.
+const payloadBuildsLimit = 16
+
+// engine is an execution client serving the engine API. The primary engine has no client of its own
+// and uses the connection of the service, which is re-established when it is lost.
+type engine struct {
+	sync.RWMutex
+	name    string
+	client  RPCClient
+	healthy bool
+}
+
+func newEngine(endpoint network.Endpoint, client RPCClient) *engine {
+	e := &engine{
+		name:    logs.MaskCredentialsLogging(endpoint.Url),
+		client:  client,
+		healthy: true,
+	}
+	engineHealthy.WithLabelValues(e.name).Set(1)
+	return e
+}
+
+func (e *engine) isHealthy() bool {
+	e.RLock()
+	defer e.RUnlock()
+	return e.healthy
+}
+
+// setHealthy records whether the engine responds in time and is synced.
+func (e *engine) setHealthy(healthy bool) {
+	e.Lock()
+	changed := e.healthy != healthy
+	e.healthy = healthy
+	e.Unlock()
+	if healthy {
+		engineHealthy.WithLabelValues(e.name).Set(1)
+	} else {
+		engineHealthy.WithLabelValues(e.name).Set(0)
+	}
+	if !changed {
+		return
+	}
+	if healthy {
+		log.WithField("endpoint", e.name).Info("Execution engine is healthy again")
+	} else {
+		log.WithField("endpoint", e.name).Warn("Execution engine is unavailable or syncing")
+	}
+}
+
+// setupBackupEngines dials the backup execution endpoints. Engine API calls are then sent to the primary
+// endpoint and to every backup endpoint.
+func (s *Service) setupBackupEngines(ctx context.Context) error {
+	if len(s.cfg.backupHttpEndpoints) == 0 {
+		return nil
+	}
+	engines := []*engine{newEngine(s.cfg.currHttpEndpoint, nil)}
+	for _, endpoint := range s.cfg.backupHttpEndpoints {
+		client, err := s.newRPCClientWithAuth(ctx, endpoint)
+		if err != nil {
+			for _, e := range engines[1:] {
+				e.client.Close()
+			}
+			return errors.Wrapf(err, "could not dial backup execution endpoint %s", logs.MaskCredentialsLogging(endpoint.Url))
+		}
+		e := newEngine(endpoint, s.withJournal(client, logs.MaskCredentialsLogging(endpoint.Url)))
+		if err := ensureCorrectExecutionChain(ctx, ethclient.NewClient(client)); err != nil {
+			log.WithError(err).WithField("endpoint", e.name).Warn("Could not verify the chain ID of backup execution endpoint")
+		}
+		engines = append(engines, e)
+	}
+	s.engines = engines
+	s.payloadBuilds = newPayloadBuilds()
+	log.WithField("backups", len(engines)-1).Info("Fanning out engine API calls to backup execution endpoints")
+	return nil
+}
+
+func (s *Service) closeBackupEngines() {
+	for _, e := range s.engines {
+		if e.client != nil {
+			e.client.Close()
+		}
+	}
+}
+
+func (s *Service) engineClient(e *engine) RPCClient {
+	if e.client == nil {
+		return s.rpcClient
+	}
+	return e.client
+}
+
+// engineOrder returns the indices of the engines in the order in which their responses are used: the active
+// engine first, which is the first healthy engine in the configured order, followed by the others.
+// The primary engine is active when no engine is healthy.
+func (s *Service) engineOrder() []int {
+	active := 0
+	for i, e := range s.engines {
+		if e.isHealthy() {
+			active = i
+			break
+		}
+	}
+	order := make([]int, 0, len(s.engines))
+	order = append(order, active)
+	for i := range s.engines {
+		if i != active {
+			order = append(order, i)
+		}
+	}
+	return order
+}
+
+// isEngineFailure returns true if the engine could not be reached or did not respond in time,
+// as opposed to the engine responding with an error.
+func isEngineFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	var rpcErr gethRPC.Error
+	return !errors.As(err, &rpcErr)
+}
+
+type engineResponse[T any] struct {
+	index   int
+	res     T
+	err     error
+	healthy bool
+}
+
+// callEngines performs an engine API call against every engine. The response of the active engine is used,
+// unless the engine failed or is syncing, in which case the first response of a healthy engine is used.
+// The calls are not interrupted once a response is used, so that the health of every engine is kept up to date.
+func callEngines[T any](
+	ctx context.Context,
+	s *Service,
+	method string,
+	call func(ctx context.Context, index int, client RPCClient) (T, error),
+	syncing func(T) bool,
+) (T, error) {
+	if len(s.engines) < 2 {
+		return call(ctx, 0, s.rpcClient)
+	}
+	callCtx := context.WithoutCancel(ctx)
+	cancel := func() {}
+	if d, ok := ctx.Deadline(); ok {
+		callCtx, cancel = context.WithDeadline(callCtx, d)
+	}
+	responses := make(chan engineResponse[T], len(s.engines))
+	var wg sync.WaitGroup
+	for i, e := range s.engines {
+		wg.Add(1)
+		go func(i int, e *engine) {
+			defer wg.Done()
+			start := time.Now()
+			res, err := call(callCtx, i, s.engineClient(e))
+			engineLatency.WithLabelValues(e.name, method).Observe(float64(time.Since(start).Milliseconds()))
+			healthy := !isEngineFailure(err) && (err != nil || !syncing(res))
+			e.setHealthy(healthy)
+			responses <- engineResponse[T]{index: i, res: res, err: err, healthy: healthy}
+		}(i, e)
+	}
+	go func() {
+		wg.Wait()
+		cancel()
+	}()
+
+	active := s.engineOrder()[0]
+	var activeResp, fallback *engineResponse[T]
+	for range s.engines {
+		var r engineResponse[T]
+		select {
+		case r = <-responses:
+		case <-ctx.Done():
+			// The active engine did not respond in time, use a healthy backup response if one already arrived.
+			if fallback != nil {
+				return useFallback(s, method, fallback)
+			}
+			var zero T
+			return zero, ctx.Err()
+		}
+		switch {
+		case r.index == active && r.healthy:
+			return r.res, r.err
+		case r.index == active:
+			activeResp = &r
+		case r.healthy && fallback == nil:
+			fallback = &r
+		}
+		if activeResp != nil && fallback != nil {
+			return useFallback(s, method, fallback)
+		}
+	}
+	return activeResp.res, activeResp.err
+}
+
+func useFallback[T any](s *Service, method string, fallback *engineResponse[T]) (T, error) {
+	name := s.engines[fallback.index].name
+	engineFailoverCount.WithLabelValues(name, method).Inc()
+	log.WithFields(logrus.Fields{
+		"method":   method,
+		"endpoint": name,
+	}).Warn("Using the response of a backup execution engine")
+	return fallback.res, fallback.err
+}
+
+// enginePayloadIDs are the payload ids returned by every engine for the same payload build.
+type enginePayloadIDs struct {
+	sync.Mutex
+	ids map[int]pb.PayloadIDBytes
+}
+
+func (p *enginePayloadIDs) set(index int, id *pb.PayloadIDBytes) {
+	if id == nil {
+		return
+	}
+	p.Lock()
+	defer p.Unlock()
+	p.ids[index] = *id
+}
+
+func (p *enginePayloadIDs) get(index int) (pb.PayloadIDBytes, bool) {
+	p.Lock()
+	defer p.Unlock()
+	id, ok := p.ids[index]
+	return id, ok
+}
+
+// payloadBuilds keeps the payload ids of the most recent payload builds, keyed by the payload id returned
+// by forkchoice updated, so that a payload can be retrieved from another engine than the one which returned it.
+type payloadBuilds struct {
+	sync.Mutex
+	keys   []pb.PayloadIDBytes
+	builds map[pb.PayloadIDBytes]*enginePayloadIDs
+}
+
+func newPayloadBuilds() *payloadBuilds {
+	return &payloadBuilds{builds: make(map[pb.PayloadIDBytes]*enginePayloadIDs)}
+}
+
+func (p *payloadBuilds) add(id *pb.PayloadIDBytes, ids *enginePayloadIDs) {
+	if p == nil || id == nil {
+		return
+	}
+	p.Lock()
+	defer p.Unlock()
+	if _, ok := p.builds[*id]; !ok {
+		p.keys = append(p.keys, *id)
+	}
+	p.builds[*id] = ids
+	if len(p.keys) > payloadBuildsLimit {
+		delete(p.builds, p.keys[0])
+		p.keys = p.keys[1:]
+	}
+}
+
+func (p *payloadBuilds) get(id pb.PayloadIDBytes) *enginePayloadIDs {
+	if p == nil {
+		return nil
+	}
+	p.Lock()
+	defer p.Unlock()
+	return p.builds[id]
+}
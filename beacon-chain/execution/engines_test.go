@@ -0,0 +1,189 @@
+package execution
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	gethRPC "github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	payloadattribute "github.com/prysmaticlabs/prysm/v5/consensus-types/payload-attribute"
+	"github.com/prysmaticlabs/prysm/v5/network"
+	pb "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// engineClientMock answers engine API calls with the configured status, payload id and payload.
+type engineClientMock struct {
+	sync.Mutex
+	status    pb.PayloadStatus_Status
+	payloadID *pb.PayloadIDBytes
+	payload   *pb.ExecutionPayload
+	err       error
+	hang      bool
+	args      [][]interface{}
+}
+
+func (*engineClientMock) Close() {}
+
+func (*engineClientMock) BatchCall([]gethRPC.BatchElem) error {
+	return nil
+}
+
+func (c *engineClientMock) CallContext(ctx context.Context, result interface{}, _ string, args ...interface{}) error {
+	if c.hang {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.args = append(c.args, args)
+	if c.err != nil {
+		return c.err
+	}
+	status := &pb.PayloadStatus{Status: c.status, LatestValidHash: []byte{byte(c.status)}}
+	switch r := result.(type) {
+	case *pb.PayloadStatus:
+		proto.Merge(r, status)
+	case *ForkchoiceUpdatedResponse:
+		r.Status = status
+		r.PayloadId = c.payloadID
+	case *pb.ExecutionPayload:
+		proto.Merge(r, c.payload)
+	}
+	return nil
+}
+
+func (c *engineClientMock) lastArgs() []interface{} {
+	c.Lock()
+	defer c.Unlock()
+	return c.args[len(c.args)-1]
+}
+
+func engineService(clients ...*engineClientMock) *Service {
+	s := &Service{rpcClient: clients[0], payloadBuilds: newPayloadBuilds()}
+	s.engines = []*engine{newEngine(network.HttpEndpoint("http://primary"), nil)}
+	for _, c := range clients[1:] {
+		s.engines = append(s.engines, newEngine(network.HttpEndpoint("http://backup"), c))
+	}
+	return s
+}
+
+// waitFor waits for the calls to the engines which are not awaited by the service.
+func waitFor(t *testing.T, cond func() bool) {
+	for i := 0; i < 100; i++ {
+		if cond() {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("condition not met")
+}
+
+func TestCallEngines_Failover(t *testing.T) {
+	ctx := context.Background()
+	payload, err := blocks.WrappedExecutionPayload(&pb.ExecutionPayload{})
+	require.NoError(t, err)
+
+	t.Run("primary used when healthy", func(t *testing.T) {
+		s := engineService(&engineClientMock{status: pb.PayloadStatus_VALID}, &engineClientMock{status: pb.PayloadStatus_INVALID})
+		lvh, err := s.NewPayload(ctx, payload, nil, nil, nil)
+		require.NoError(t, err)
+		require.DeepEqual(t, []byte{byte(pb.PayloadStatus_VALID)}, lvh)
+	})
+	t.Run("primary syncing", func(t *testing.T) {
+		primary := &engineClientMock{status: pb.PayloadStatus_SYNCING}
+		s := engineService(primary, &engineClientMock{status: pb.PayloadStatus_VALID})
+		lvh, err := s.NewPayload(ctx, payload, nil, nil, nil)
+		require.NoError(t, err)
+		require.DeepEqual(t, []byte{byte(pb.PayloadStatus_VALID)}, lvh)
+		require.Equal(t, false, s.engines[0].isHealthy())
+		require.DeepEqual(t, []int{1, 0}, s.engineOrder())
+
+		// The primary is active again once it is synced.
+		primary.Lock()
+		primary.status = pb.PayloadStatus_VALID
+		primary.Unlock()
+		_, err = s.NewPayload(ctx, payload, nil, nil, nil)
+		require.NoError(t, err)
+		waitFor(t, s.engines[0].isHealthy)
+		require.DeepEqual(t, []int{0, 1}, s.engineOrder())
+	})
+	t.Run("primary unreachable", func(t *testing.T) {
+		s := engineService(&engineClientMock{err: context.DeadlineExceeded}, &engineClientMock{status: pb.PayloadStatus_INVALID})
+		lvh, err := s.NewPayload(ctx, payload, nil, nil, nil)
+		require.ErrorIs(t, err, ErrInvalidPayloadStatus)
+		require.DeepEqual(t, []byte{byte(pb.PayloadStatus_INVALID)}, lvh)
+	})
+	t.Run("primary hangs", func(t *testing.T) {
+		s := engineService(&engineClientMock{hang: true}, &engineClientMock{status: pb.PayloadStatus_VALID})
+		ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		lvh, err := s.NewPayload(ctx, payload, nil, nil, nil)
+		require.NoError(t, err)
+		require.DeepEqual(t, []byte{byte(pb.PayloadStatus_VALID)}, lvh)
+	})
+	t.Run("all engines hang", func(t *testing.T) {
+		s := engineService(&engineClientMock{hang: true}, &engineClientMock{hang: true})
+		ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		_, err := s.NewPayload(ctx, payload, nil, nil, nil)
+		require.ErrorIs(t, err, ErrHTTPTimeout)
+	})
+	t.Run("all engines syncing", func(t *testing.T) {
+		s := engineService(&engineClientMock{status: pb.PayloadStatus_SYNCING}, &engineClientMock{status: pb.PayloadStatus_SYNCING})
+		_, err := s.NewPayload(ctx, payload, nil, nil, nil)
+		require.ErrorIs(t, err, ErrAcceptedSyncingPayloadStatus)
+	})
+	t.Run("engine error is not a failure", func(t *testing.T) {
+		rpcErr := &customError{code: -32602}
+		s := engineService(&engineClientMock{err: rpcErr}, &engineClientMock{status: pb.PayloadStatus_VALID})
+		_, err := s.NewPayload(ctx, payload, nil, nil, nil)
+		require.ErrorIs(t, err, ErrInvalidParams)
+		require.Equal(t, true, s.engines[0].isHealthy())
+	})
+}
+
+func TestGetPayload_FromBackupEngine(t *testing.T) {
+	ctx := context.Background()
+	primaryID, backupID := &pb.PayloadIDBytes{1}, &pb.PayloadIDBytes{2}
+	primary := &engineClientMock{status: pb.PayloadStatus_VALID, payloadID: primaryID}
+	backup := &engineClientMock{
+		status:    pb.PayloadStatus_VALID,
+		payloadID: backupID,
+		payload:   &pb.ExecutionPayload{BlockNumber: 10},
+	}
+	s := engineService(primary, backup)
+
+	id, _, err := s.ForkchoiceUpdated(ctx, &pb.ForkchoiceState{}, payloadattribute.EmptyWithVersion(version.Bellatrix))
+	require.NoError(t, err)
+	require.DeepEqual(t, primaryID, id)
+	waitFor(t, func() bool {
+		_, ok := s.payloadBuilds.get(*primaryID).get(1)
+		return ok
+	})
+
+	// The primary dies before the payload is retrieved.
+	primary.Lock()
+	primary.err = errors.New("connection refused")
+	primary.Unlock()
+	resp, err := s.GetPayload(ctx, *primaryID, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), resp.ExecutionData.BlockNumber())
+	require.DeepEqual(t, []interface{}{*backupID}, backup.lastArgs())
+	require.Equal(t, false, s.engines[0].isHealthy())
+}
+
+func TestPayloadBuilds_Limit(t *testing.T) {
+	p := newPayloadBuilds()
+	for i := 0; i <= payloadBuildsLimit; i++ {
+		p.add(&pb.PayloadIDBytes{byte(i)}, &enginePayloadIDs{})
+	}
+	require.Equal(t, payloadBuildsLimit, len(p.builds))
+	require.Equal(t, true, p.get(pb.PayloadIDBytes{0}) == nil)
+	require.Equal(t, true, p.get(pb.PayloadIDBytes{payloadBuildsLimit}) != nil)
+}
@@ -0,0 +1,248 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	gethRPC "github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+)
+
+const (
+	// JournalFileName is the name of the file engine API calls are appended to. Rotated files are named
+	// after it with the time of the rotation, so that sorting the file names orders the journal.
+	JournalFileName = "engine-journal.jsonl"
+
+	journalMaxFileSize = 128 << 20
+	journalMaxFiles    = 8
+	journalQueueSize   = 256
+)
+
+// JournalEntry is an engine API call recorded in the journal.
+type JournalEntry struct {
+	Time      time.Time         `json:"time"`
+	Endpoint  string            `json:"endpoint"`
+	Method    string            `json:"method"`
+	Params    []json.RawMessage `json:"params"`
+	LatencyMs int64             `json:"latencyMs"`
+	Result    json.RawMessage   `json:"result,omitempty"`
+	Error     *JournalError     `json:"error,omitempty"`
+}
+
+// JournalError is the error of a journaled call. The code is zero if the engine could not be reached.
+type JournalError struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+// engineJournal appends engine API calls to a file in the journal directory, rotating it once it exceeds the
+// maximum file size. Entries are written in the background and dropped if the writer falls behind, so that
+// journaling never delays engine API calls.
+type engineJournal struct {
+	dir         string
+	maxFileSize int64
+	maxFiles    int
+	entries     chan *JournalEntry
+	done        chan struct{}
+	f           *os.File
+	size        int64
+	dropped     uint64
+	lock        sync.Mutex
+	closed      bool
+}
+
+func newEngineJournal(dir string) (*engineJournal, error) {
+	if err := file.MkdirAll(dir); err != nil {
+		return nil, errors.Wrap(err, "could not create engine journal directory")
+	}
+	j := &engineJournal{
+		dir:         dir,
+		maxFileSize: journalMaxFileSize,
+		maxFiles:    journalMaxFiles,
+		entries:     make(chan *JournalEntry, journalQueueSize),
+		done:        make(chan struct{}),
+	}
+	if err := j.open(); err != nil {
+		return nil, err
+	}
+	go j.run()
+	log.WithField("path", filepath.Join(dir, JournalFileName)).Info("Journaling engine API calls")
+	return j, nil
+}
+
+func (j *engineJournal) open() error {
+	f, err := os.OpenFile(filepath.Join(j.dir, JournalFileName), os.O_APPEND|os.O_CREATE|os.O_WRONLY, params.BeaconIoConfig().ReadWritePermissions)
+	if err != nil {
+		return errors.Wrap(err, "could not open engine journal")
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return errors.Wrap(err, "could not stat engine journal")
+	}
+	j.f, j.size = f, info.Size()
+	return nil
+}
+
+func (j *engineJournal) record(e *JournalEntry) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	if j.closed {
+		return
+	}
+	select {
+	case j.entries <- e:
+	default:
+		j.dropped++
+	}
+}
+
+func (j *engineJournal) run() {
+	defer close(j.done)
+	for e := range j.entries {
+		if err := j.write(e); err != nil {
+			log.WithError(err).Error("Could not write engine journal entry")
+		}
+	}
+	if err := j.f.Close(); err != nil {
+		log.WithError(err).Error("Could not close engine journal")
+	}
+}
+
+func (j *engineJournal) write(e *JournalEntry) error {
+	j.lock.Lock()
+	if j.dropped > 0 {
+		log.WithField("entries", j.dropped).Warn("Engine journal writer fell behind, entries were dropped")
+		j.dropped = 0
+	}
+	j.lock.Unlock()
+	enc, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if j.size > 0 && j.size+int64(len(enc))+1 > j.maxFileSize {
+		if err := j.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := j.f.Write(append(enc, '\n'))
+	j.size += int64(n)
+	return err
+}
+
+// rotate renames the current file after the time of the rotation, removes the oldest files above the
+// maximum number of files, and starts a new file.
+func (j *engineJournal) rotate() error {
+	if err := j.f.Close(); err != nil {
+		return errors.Wrap(err, "could not close engine journal")
+	}
+	name := strings.TrimSuffix(JournalFileName, ".jsonl") + "." + time.Now().UTC().Format("20060102T150405.000000000") + ".jsonl"
+	if err := os.Rename(filepath.Join(j.dir, JournalFileName), filepath.Join(j.dir, name)); err != nil {
+		return errors.Wrap(err, "could not rotate engine journal")
+	}
+	rotated, err := JournalFiles(j.dir)
+	if err != nil {
+		return err
+	}
+	// The current file is not part of the rotated files, which are sorted from the oldest.
+	rotated = rotated[:len(rotated)-1]
+	for len(rotated) >= j.maxFiles {
+		if err := os.Remove(rotated[0]); err != nil {
+			return errors.Wrap(err, "could not remove engine journal file")
+		}
+		rotated = rotated[1:]
+	}
+	return j.open()
+}
+
+func (j *engineJournal) close() {
+	if j == nil {
+		return
+	}
+	j.lock.Lock()
+	if !j.closed {
+		j.closed = true
+		close(j.entries)
+	}
+	j.lock.Unlock()
+	<-j.done
+}
+
+// JournalFiles returns the files of the engine journal in the directory, from the oldest to the current file.
+func JournalFiles(dir string) ([]string, error) {
+	prefix := strings.TrimSuffix(JournalFileName, ".jsonl") + "."
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read engine journal directory")
+	}
+	var files []string
+	current := false
+	for _, e := range entries {
+		switch {
+		case e.Name() == JournalFileName:
+			current = true
+		case strings.HasPrefix(e.Name(), prefix) && strings.HasSuffix(e.Name(), ".jsonl"):
+			files = append(files, filepath.Join(dir, e.Name()))
+		}
+	}
+	sort.Strings(files)
+	if current {
+		files = append(files, filepath.Join(dir, JournalFileName))
+	}
+	return files, nil
+}
+
+// journalClient records the engine API calls made with the client. Other calls are not recorded.
+type journalClient struct {
+	RPCClient
+	endpoint string
+	journal  *engineJournal
+}
+
+// withJournal returns the client recording its engine API calls in the journal, if journaling is enabled.
+func (s *Service) withJournal(client RPCClient, endpoint string) RPCClient {
+	if s.journal == nil {
+		return client
+	}
+	return &journalClient{RPCClient: client, endpoint: endpoint, journal: s.journal}
+}
+
+// CallContext decodes the raw result of the call, as the rpc client does, so that the journal holds the
+// response of the engine as is.
+func (c *journalClient) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	if !strings.HasPrefix(method, "engine_") {
+		return c.RPCClient.CallContext(ctx, result, method, args...)
+	}
+	entry := &JournalEntry{Time: time.Now(), Endpoint: c.endpoint, Method: method, Params: make([]json.RawMessage, len(args))}
+	for i, arg := range args {
+		enc, err := json.Marshal(arg)
+		if err != nil {
+			enc, _ = json.Marshal(fmt.Sprintf("could not encode param: %v", err))
+		}
+		entry.Params[i] = enc
+	}
+	var raw json.RawMessage
+	err := c.RPCClient.CallContext(ctx, &raw, method, args...)
+	entry.LatencyMs = time.Since(entry.Time).Milliseconds()
+	if err == nil && result != nil && len(raw) > 0 {
+		err = json.Unmarshal(raw, result)
+	}
+	entry.Result = raw
+	if err != nil {
+		entry.Error = &JournalError{Message: err.Error()}
+		var rpcErr gethRPC.Error
+		if errors.As(err, &rpcErr) {
+			entry.Error.Code = rpcErr.ErrorCode()
+		}
+	}
+	c.journal.record(entry)
+	return err
+}
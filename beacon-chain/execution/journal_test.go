@@ -0,0 +1,99 @@
+package execution
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	gethRPC "github.com/ethereum/go-ethereum/rpc"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+type journalTestAPI struct{}
+
+func (journalTestAPI) ExchangeCapabilities(methods []string) []string {
+	return methods
+}
+
+func (journalTestAPI) ChainId() string {
+	return "0x1"
+}
+
+func readJournal(t *testing.T, path string) []*JournalEntry {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, f.Close())
+	}()
+	var entries []*JournalEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		e := &JournalEntry{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), e))
+		entries = append(entries, e)
+	}
+	require.NoError(t, scanner.Err())
+	return entries
+}
+
+func TestJournalClient_RecordsEngineCalls(t *testing.T) {
+	ctx := context.Background()
+	server := gethRPC.NewServer()
+	require.NoError(t, server.RegisterName("engine", journalTestAPI{}))
+	require.NoError(t, server.RegisterName("eth", journalTestAPI{}))
+	defer server.Stop()
+
+	dir := t.TempDir()
+	journal, err := newEngineJournal(dir)
+	require.NoError(t, err)
+	s := &Service{journal: journal}
+	client := s.withJournal(gethRPC.DialInProc(server), "http://localhost:8551")
+
+	var capabilities []string
+	require.NoError(t, client.CallContext(ctx, &capabilities, ExchangeCapabilities, []string{NewPayloadMethodV3}))
+	require.DeepEqual(t, []string{NewPayloadMethodV3}, capabilities)
+	err = client.CallContext(ctx, nil, "engine_unknownMethodV1")
+	require.ErrorContains(t, "does not exist", err)
+	var chainID string
+	require.NoError(t, client.CallContext(ctx, &chainID, "eth_chainId"))
+	journal.close()
+
+	entries := readJournal(t, filepath.Join(dir, JournalFileName))
+	require.Equal(t, 2, len(entries))
+	assert.Equal(t, ExchangeCapabilities, entries[0].Method)
+	assert.Equal(t, "http://localhost:8551", entries[0].Endpoint)
+	assert.Equal(t, `["engine_newPayloadV3"]`, string(entries[0].Params[0]))
+	assert.Equal(t, `["engine_newPayloadV3"]`, string(entries[0].Result))
+	assert.Equal(t, true, entries[0].Error == nil)
+	assert.Equal(t, "engine_unknownMethodV1", entries[1].Method)
+	require.NotNil(t, entries[1].Error)
+	assert.Equal(t, -32601, entries[1].Error.Code)
+}
+
+func TestEngineJournal_Rotate(t *testing.T) {
+	dir := t.TempDir()
+	journal, err := newEngineJournal(dir)
+	require.NoError(t, err)
+	journal.maxFileSize = 1
+	journal.maxFiles = 2
+	for _, method := range []string{"a", "b", "c", "d"} {
+		require.NoError(t, journal.write(&JournalEntry{Method: method}))
+	}
+	journal.close()
+
+	files, err := JournalFiles(dir)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(files))
+	assert.Equal(t, filepath.Join(dir, JournalFileName), files[2])
+	var methods []string
+	for _, f := range files {
+		for _, e := range readJournal(t, f) {
+			methods = append(methods, e.Method)
+		}
+	}
+	require.DeepEqual(t, []string{"b", "c", "d"}, methods)
+}
@@ -27,6 +27,22 @@ var (
 			Buckets: []float64{25, 50, 100, 200, 500, 1000, 2000, 4000},
 		},
 	)
+	engineLatency = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "execution_engine_latency_milliseconds",
+			Help:    "Captures RPC latency of engine API calls in milliseconds, per execution endpoint",
+			Buckets: []float64{25, 50, 100, 200, 500, 1000, 2000, 4000},
+		},
+		[]string{"endpoint", "method"},
+	)
+	engineHealthy = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "execution_engine_healthy",
+		Help: "1 if the execution endpoint responds in time and is synced, 0 otherwise",
+	}, []string{"endpoint"})
+	engineFailoverCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "execution_engine_failover_count",
+		Help: "The number of engine API responses used from a backup execution endpoint because the active endpoint failed or was syncing",
+	}, []string{"endpoint", "method"})
 	errParseCount = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "execution_parse_error_count",
 		Help: "The number of errors that occurred while parsing execution payload",
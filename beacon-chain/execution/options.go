@@ -38,6 +38,29 @@ func WithHttpEndpointAndJWTSecret(endpointString string, secret []byte) Option {
 	}
 }
 
+// WithBackupHttpEndpoint adds a backup execution node, authenticated with its own JWT secret. Engine API calls
+// are sent to the backup nodes as well, and their responses are used when the primary node fails or is syncing.
+func WithBackupHttpEndpoint(endpointString string, secret []byte) Option {
+	return func(s *Service) error {
+		hEndpoint := network.HttpEndpoint(endpointString)
+		if len(secret) > 0 {
+			hEndpoint.Auth.Method = authorization.Bearer
+			hEndpoint.Auth.Value = string(secret)
+		}
+		s.cfg.backupHttpEndpoints = append(s.cfg.backupHttpEndpoints, hEndpoint)
+		return nil
+	}
+}
+
+// WithEngineJournal records every engine API call, with its response and latency, in rotating files
+// in the directory.
+func WithEngineJournal(dir string) Option {
+	return func(s *Service) error {
+		s.cfg.engineJournalDir = dir
+		return nil
+	}
+}
+
 // WithHeaders adds headers to the execution node JSON-RPC requests.
 func WithHeaders(headers []string) Option {
 	return func(s *Service) error {
@@ -24,7 +24,7 @@ func (s *Service) setupExecutionClientConnections(ctx context.Context, currEndpo
 	}
 	// Attach the clients to the service struct.
 	fetcher := ethclient.NewClient(client)
-	s.rpcClient = client
+	s.rpcClient = s.withJournal(client, logs.MaskCredentialsLogging(currEndpoint.Url))
 	s.httpLogger = fetcher
 
 	depositContractCaller, err := contracts.NewDepositContractCaller(s.cfg.depositContractAddr, fetcher)
@@ -81,6 +81,7 @@ type ChainInfoFetcher interface {
 	ExecutionClientConnected() bool
 	ExecutionClientEndpoint() string
 	ExecutionClientConnectionErr() error
+	ExecutionClientStatus() types.ExecutionClientStatus
 }
 
 // POWBlockFetcher defines a struct that can retrieve mainchain blocks.
@@ -127,6 +128,8 @@ type config struct {
 	eth1HeaderReqLimit      uint64
 	beaconNodeStatsUpdater  BeaconNodeStatsUpdater
 	currHttpEndpoint        network.Endpoint
+	backupHttpEndpoints     []network.Endpoint
+	engineJournalDir        string
 	headers                 []string
 	finalizedStateAtStartup state.BeaconState
 	jwtId                   string
@@ -149,6 +152,9 @@ type Service struct {
 	eth1HeadTicker          *time.Ticker
 	httpLogger              bind.ContractFilterer
 	rpcClient               RPCClient
+	engines                 []*engine
+	payloadBuilds           *payloadBuilds
+	journal                 *engineJournal
 	headerCache             *headerCache // cache to store block hash/block height.
 	latestEth1Data          *ethpb.LatestETH1Data
 	depositContractCaller   *contracts.DepositContractCaller
@@ -160,6 +166,8 @@ type Service struct {
 	verifierWaiter          *verification.InitializerWaiter
 	blobVerifier            verification.NewBlobVerifier
 	capabilityCache         *capabilityCache
+	clientStatusLock        sync.RWMutex
+	clientStatus            types.ExecutionClientStatus
 }
 
 // NewService sets up a new instance with an ethclient when given a web3 endpoint as a string in the config.
@@ -198,6 +206,7 @@ func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 		preGenesisState:         genState,
 		eth1HeadTicker:          time.NewTicker(time.Duration(params.BeaconConfig().SecondsPerETH1Block) * time.Second),
 		capabilityCache:         &capabilityCache{},
+		clientStatus:            types.ExecutionClientStatus{State: types.ExecutionClientUnknown},
 	}
 
 	for _, opt := range opts {
@@ -218,9 +227,19 @@ func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 
 // Start the powchain service's main event loop.
 func (s *Service) Start() {
+	if s.cfg.engineJournalDir != "" {
+		journal, err := newEngineJournal(s.cfg.engineJournalDir)
+		if err != nil {
+			log.WithError(err).Error("Could not set up the engine API journal")
+		}
+		s.journal = journal
+	}
 	if err := s.setupExecutionClientConnections(s.ctx, s.cfg.currHttpEndpoint); err != nil {
 		log.WithError(err).Error("Could not connect to execution endpoint")
 	}
+	if err := s.setupBackupEngines(s.ctx); err != nil {
+		log.WithError(err).Error("Could not connect to backup execution endpoints")
+	}
 	// If the chain has not started already and we don't have access to eth1 nodes, we will not be
 	// able to generate the genesis state.
 	if !s.chainStartData.Chainstarted && s.cfg.currHttpEndpoint.Url == "" {
@@ -248,6 +267,7 @@ func (s *Service) Start() {
 	s.pollConnectionStatus(s.ctx)
 
 	go s.run(s.ctx.Done())
+	go s.pollExecutionClientStatus(s.ctx)
 }
 
 // Stop the web3 service's main event loop and associated goroutines.
@@ -258,6 +278,8 @@ func (s *Service) Stop() error {
 	if s.rpcClient != nil {
 		s.rpcClient.Close()
 	}
+	s.closeBackupEngines()
+	s.journal.close()
 	return nil
 }
 
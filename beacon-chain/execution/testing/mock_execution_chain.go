@@ -35,6 +35,7 @@ type Chain struct {
 	GenesisState      state.BeaconState
 	CurrEndpoint      string
 	CurrError         error
+	ClientStatus      types.ExecutionClientStatus
 	Endpoints         []string
 	Errors            []error
 }
@@ -131,6 +132,11 @@ func (m *Chain) ExecutionClientConnectionErr() error {
 	return m.CurrError
 }
 
+// ExecutionClientStatus --
+func (m *Chain) ExecutionClientStatus() types.ExecutionClientStatus {
+	return m.ClientStatus
+}
+
 func (m *Chain) ETH1Endpoints() []string {
 	return m.Endpoints
 }
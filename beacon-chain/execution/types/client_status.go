@@ -0,0 +1,33 @@
+package types
+
+import "time"
+
+// ExecutionClientState is the state of the execution client as reported by its own RPC API.
+type ExecutionClientState string
+
+const (
+	// ExecutionClientUnknown is the state of the execution client before it has been polled.
+	ExecutionClientUnknown ExecutionClientState = "unknown"
+	// ExecutionClientOffline is the state of an execution client which could not be reached.
+	ExecutionClientOffline ExecutionClientState = "offline"
+	// ExecutionClientSyncing is the state of an execution client which reports it is syncing.
+	ExecutionClientSyncing ExecutionClientState = "syncing"
+	// ExecutionClientSynced is the state of an execution client which reports it is not syncing.
+	ExecutionClientSynced ExecutionClientState = "synced"
+)
+
+// ExecutionClientStatus is the sync status and peer count of the execution client, as reported
+// by the eth_syncing and net_peerCount methods.
+type ExecutionClientStatus struct {
+	State        ExecutionClientState
+	CurrentBlock uint64
+	HighestBlock uint64
+	// PeerCount is only meaningful when PeerCountKnown is set, as not all execution clients
+	// expose net_peerCount on the engine endpoint.
+	PeerCount      uint64
+	PeerCountKnown bool
+	// Updated is the time at which the status was last polled.
+	Updated time.Time
+	// Err is the error which made the execution client offline.
+	Err error
+}
@@ -11,3 +11,5 @@ var errInvalidOptimisticStatus = errors.New("invalid optimistic status")
 var errInvalidNilCheckpoint = errors.New("invalid nil checkpoint")
 var errInvalidUnrealizedJustifiedEpoch = errors.New("invalid unrealized justified epoch")
 var errInvalidUnrealizedFinalizedEpoch = errors.New("invalid unrealized finalized epoch")
+var errInvalidSnapshot = errors.New("invalid fork choice snapshot")
+var errSnapshotMismatch = errors.New("fork choice snapshot does not match the database")
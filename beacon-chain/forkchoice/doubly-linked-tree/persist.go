@@ -0,0 +1,378 @@
+package doublylinkedtree
+
+import (
+	"context"
+	"encoding/binary"
+	"sort"
+
+	"github.com/pkg/errors"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+)
+
+// snapshotVersion is the version of the fork choice snapshot encoding, which is bumped whenever
+// the encoding changes so that snapshots written by older versions are discarded.
+const snapshotVersion = 1
+
+// Snapshot serializes the fork choice store: its nodes, votes, balances, checkpoints and proposer boost state.
+// The store can be restored from the returned bytes with Restore.
+// This function requires a read lock in forkchoice.
+func (f *ForkChoice) Snapshot(ctx context.Context) ([]byte, error) {
+	_, span := trace.StartSpan(ctx, "doublyLinkedForkchoice.Snapshot")
+	defer span.End()
+
+	s := f.store
+	if s.treeRootNode == nil {
+		return nil, ErrNilNode
+	}
+	w := &snapshotWriter{}
+	w.uint64(snapshotVersion)
+	for _, cp := range []*forkchoicetypes.Checkpoint{
+		s.justifiedCheckpoint,
+		s.unrealizedJustifiedCheckpoint,
+		s.unrealizedFinalizedCheckpoint,
+		s.prevJustifiedCheckpoint,
+		s.finalizedCheckpoint,
+	} {
+		w.checkpoint(cp)
+	}
+	w.root(s.proposerBoostRoot)
+	w.root(s.previousProposerBoostRoot)
+	w.uint64(s.previousProposerBoostScore)
+	w.uint64(s.committeeWeight)
+	w.root(s.originRoot)
+	w.uint64(s.genesisTime)
+	w.node(s.headNode)
+	w.node(s.highestReceivedNode)
+	w.uint64(uint64(len(s.receivedBlocksLastEpoch)))
+	for _, slot := range s.receivedBlocksLastEpoch {
+		w.uint64(uint64(slot))
+	}
+	w.bool(s.allTipsAreInvalid)
+	slashed := make([]primitives.ValidatorIndex, 0, len(s.slashedIndices))
+	for idx := range s.slashedIndices {
+		slashed = append(slashed, idx)
+	}
+	sort.Slice(slashed, func(i, j int) bool {
+		return slashed[i] < slashed[j]
+	})
+	w.uint64(uint64(len(slashed)))
+	for _, idx := range slashed {
+		w.uint64(uint64(idx))
+	}
+
+	// Nodes are written parents first, so that the tree can be rebuilt in a single pass.
+	nodes := make([]*Node, 0, len(s.nodeByRoot))
+	queue := []*Node{s.treeRootNode}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		nodes = append(nodes, n)
+		queue = append(queue, n.children...)
+	}
+	w.uint64(uint64(len(nodes)))
+	for _, n := range nodes {
+		w.uint64(uint64(n.slot))
+		w.root(n.root)
+		w.root(n.payloadHash)
+		w.node(n.parent)
+		w.node(n.target)
+		w.node(n.bestDescendant)
+		w.uint64(uint64(n.justifiedEpoch))
+		w.uint64(uint64(n.unrealizedJustifiedEpoch))
+		w.uint64(uint64(n.finalizedEpoch))
+		w.uint64(uint64(n.unrealizedFinalizedEpoch))
+		w.uint64(n.balance)
+		w.uint64(n.weight)
+		w.bool(n.optimistic)
+		w.uint64(n.timestamp)
+	}
+
+	w.uint64(uint64(len(f.votes)))
+	for _, v := range f.votes {
+		w.root(v.currentRoot)
+		w.root(v.nextRoot)
+		w.uint64(uint64(v.nextEpoch))
+	}
+	w.uint64s(f.balances)
+	w.uint64s(f.justifiedBalances)
+	w.uint64(f.numActiveValidators)
+	return w.buf, nil
+}
+
+// Restore replaces the fork choice store with the one serialized in the given snapshot. The snapshot is only
+// restored if its head and finalized checkpoint match the given ones, which are expected to come from the database,
+// so that a snapshot which doesn't match the chain is never used.
+// This function requires a lock in forkchoice.
+func (f *ForkChoice) Restore(ctx context.Context, snapshot []byte, head [32]byte, finalized *forkchoicetypes.Checkpoint) error {
+	_, span := trace.StartSpan(ctx, "doublyLinkedForkchoice.Restore")
+	defer span.End()
+
+	if finalized == nil {
+		return errInvalidNilCheckpoint
+	}
+	r := &snapshotReader{buf: snapshot}
+	if v := r.uint64(); r.err == nil && v != snapshotVersion {
+		return errors.Wrapf(errInvalidSnapshot, "unsupported version %d", v)
+	}
+	s := &Store{
+		nodeByRoot:     make(map[[fieldparams.RootLength]byte]*Node),
+		nodeByPayload:  make(map[[fieldparams.RootLength]byte]*Node),
+		slashedIndices: make(map[primitives.ValidatorIndex]bool),
+		clock:          f.store.clock,
+	}
+	s.justifiedCheckpoint = r.checkpoint()
+	s.unrealizedJustifiedCheckpoint = r.checkpoint()
+	s.unrealizedFinalizedCheckpoint = r.checkpoint()
+	s.prevJustifiedCheckpoint = r.checkpoint()
+	s.finalizedCheckpoint = r.checkpoint()
+	s.proposerBoostRoot = r.root()
+	s.previousProposerBoostRoot = r.root()
+	s.previousProposerBoostScore = r.uint64()
+	s.committeeWeight = r.uint64()
+	s.originRoot = r.root()
+	s.genesisTime = r.uint64()
+	headRoot, hasHead := r.node()
+	highestRoot, hasHighest := r.node()
+	if n := r.uint64(); r.err == nil && n != uint64(len(s.receivedBlocksLastEpoch)) {
+		return errors.Wrapf(errInvalidSnapshot, "snapshot tracks %d slots per epoch, expected %d", n, len(s.receivedBlocksLastEpoch))
+	}
+	for i := range s.receivedBlocksLastEpoch {
+		s.receivedBlocksLastEpoch[i] = primitives.Slot(r.uint64())
+	}
+	s.allTipsAreInvalid = r.bool()
+	for i, n := uint64(0), r.length(8); i < n; i++ {
+		s.slashedIndices[primitives.ValidatorIndex(r.uint64())] = true
+	}
+	if r.err != nil {
+		return r.err
+	}
+	if *s.finalizedCheckpoint != *finalized {
+		return errors.Wrapf(errSnapshotMismatch, "snapshot finalized checkpoint %d %#x, expected %d %#x",
+			s.finalizedCheckpoint.Epoch, s.finalizedCheckpoint.Root, finalized.Epoch, finalized.Root)
+	}
+	if !hasHead || headRoot != head {
+		return errors.Wrapf(errSnapshotMismatch, "snapshot head %#x, expected %#x", headRoot, head)
+	}
+
+	// The node pointers are resolved once all the nodes are known, as the best descendant of a node comes after it.
+	type nodeLinks struct {
+		target, bestDescendant [32]byte
+		hasTarget, hasBest     bool
+	}
+	count := r.length(nodeSnapshotSize)
+	nodes := make([]*Node, 0, count)
+	links := make([]nodeLinks, 0, count)
+	for i := uint64(0); i < count; i++ {
+		n := &Node{
+			slot:        primitives.Slot(r.uint64()),
+			root:        r.root(),
+			payloadHash: r.root(),
+		}
+		parentRoot, hasParent := r.node()
+		var l nodeLinks
+		l.target, l.hasTarget = r.node()
+		l.bestDescendant, l.hasBest = r.node()
+		n.justifiedEpoch = primitives.Epoch(r.uint64())
+		n.unrealizedJustifiedEpoch = primitives.Epoch(r.uint64())
+		n.finalizedEpoch = primitives.Epoch(r.uint64())
+		n.unrealizedFinalizedEpoch = primitives.Epoch(r.uint64())
+		n.balance = r.uint64()
+		n.weight = r.uint64()
+		n.optimistic = r.bool()
+		n.timestamp = r.uint64()
+		if r.err != nil {
+			return r.err
+		}
+		if _, ok := s.nodeByRoot[n.root]; ok {
+			return errors.Wrapf(errInvalidSnapshot, "duplicate node %#x", n.root)
+		}
+		switch {
+		case i == 0 && !hasParent:
+			s.treeRootNode = n
+		case i > 0 && hasParent:
+			parent, ok := s.nodeByRoot[parentRoot]
+			if !ok {
+				return errors.Wrapf(errInvalidSnapshot, "unknown parent %#x of node %#x", parentRoot, n.root)
+			}
+			n.parent = parent
+			parent.children = append(parent.children, n)
+		default:
+			return errors.Wrapf(errInvalidSnapshot, "node %#x is not linked to the tree root", n.root)
+		}
+		s.nodeByRoot[n.root] = n
+		s.nodeByPayload[n.payloadHash] = n
+		nodes = append(nodes, n)
+		links = append(links, l)
+	}
+	if s.treeRootNode == nil {
+		return errors.Wrap(errInvalidSnapshot, "no tree root node")
+	}
+	for i, n := range nodes {
+		var err error
+		if n.target, err = s.snapshotNode(links[i].target, links[i].hasTarget); err != nil {
+			return err
+		}
+		if n.bestDescendant, err = s.snapshotNode(links[i].bestDescendant, links[i].hasBest); err != nil {
+			return err
+		}
+	}
+	var err error
+	if s.headNode, err = s.snapshotNode(headRoot, hasHead); err != nil {
+		return err
+	}
+	if s.highestReceivedNode, err = s.snapshotNode(highestRoot, hasHighest); err != nil {
+		return err
+	}
+
+	votes := make([]Vote, r.length(2*fieldparams.RootLength+8))
+	for i := range votes {
+		votes[i] = Vote{currentRoot: r.root(), nextRoot: r.root(), nextEpoch: primitives.Epoch(r.uint64())}
+	}
+	balances := r.uint64s()
+	justifiedBalances := r.uint64s()
+	numActiveValidators := r.uint64()
+	if r.err != nil {
+		return r.err
+	}
+	if len(r.buf) != 0 {
+		return errors.Wrapf(errInvalidSnapshot, "%d trailing bytes", len(r.buf))
+	}
+
+	f.store = s
+	f.votes = votes
+	f.balances = balances
+	f.justifiedBalances = justifiedBalances
+	f.numActiveValidators = numActiveValidators
+	nodeCount.Set(float64(len(s.nodeByRoot)))
+	return nil
+}
+
+// snapshotNode returns the node of a restored store referenced by root, or nil if the reference was empty.
+func (s *Store) snapshotNode(root [32]byte, ok bool) (*Node, error) {
+	if !ok {
+		return nil, nil
+	}
+	n, found := s.nodeByRoot[root]
+	if !found {
+		return nil, errors.Wrapf(errInvalidSnapshot, "unknown node %#x", root)
+	}
+	return n, nil
+}
+
+// nodeSnapshotSize is the minimum encoded size of a node, used to bound the node count read from a snapshot.
+const nodeSnapshotSize = 8 + 2*fieldparams.RootLength + 3 + 6*8 + 1 + 8
+
+type snapshotWriter struct {
+	buf []byte
+}
+
+func (w *snapshotWriter) uint64(v uint64) {
+	w.buf = binary.LittleEndian.AppendUint64(w.buf, v)
+}
+
+func (w *snapshotWriter) bool(v bool) {
+	if v {
+		w.buf = append(w.buf, 1)
+	} else {
+		w.buf = append(w.buf, 0)
+	}
+}
+
+func (w *snapshotWriter) root(r [32]byte) {
+	w.buf = append(w.buf, r[:]...)
+}
+
+func (w *snapshotWriter) checkpoint(cp *forkchoicetypes.Checkpoint) {
+	if cp == nil {
+		cp = &forkchoicetypes.Checkpoint{}
+	}
+	w.uint64(uint64(cp.Epoch))
+	w.root(cp.Root)
+}
+
+// node writes a reference to a node, which may be nil.
+func (w *snapshotWriter) node(n *Node) {
+	w.bool(n != nil)
+	if n != nil {
+		w.root(n.root)
+	}
+}
+
+func (w *snapshotWriter) uint64s(vs []uint64) {
+	w.uint64(uint64(len(vs)))
+	for _, v := range vs {
+		w.uint64(v)
+	}
+}
+
+// snapshotReader reads the values written by snapshotWriter. Once a read fails, err is set and all the following
+// reads return zero values.
+type snapshotReader struct {
+	buf []byte
+	err error
+}
+
+func (r *snapshotReader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if len(r.buf) < n {
+		r.err = errors.Wrap(errInvalidSnapshot, "unexpected end of snapshot")
+		return nil
+	}
+	b := r.buf[:n]
+	r.buf = r.buf[n:]
+	return b
+}
+
+func (r *snapshotReader) uint64() uint64 {
+	b := r.next(8)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(b)
+}
+
+func (r *snapshotReader) bool() bool {
+	b := r.next(1)
+	return b != nil && b[0] == 1
+}
+
+func (r *snapshotReader) root() [32]byte {
+	var root [32]byte
+	copy(root[:], r.next(fieldparams.RootLength))
+	return root
+}
+
+func (r *snapshotReader) checkpoint() *forkchoicetypes.Checkpoint {
+	return &forkchoicetypes.Checkpoint{Epoch: primitives.Epoch(r.uint64()), Root: r.root()}
+}
+
+func (r *snapshotReader) node() ([32]byte, bool) {
+	if !r.bool() {
+		return [32]byte{}, false
+	}
+	return r.root(), true
+}
+
+// length reads the length of a list of items encoded in at least itemSize bytes, checking that the snapshot
+// is large enough to hold them before anything is allocated.
+func (r *snapshotReader) length(itemSize int) uint64 {
+	n := r.uint64()
+	if r.err == nil && n > uint64(len(r.buf)/itemSize) {
+		r.err = errors.Wrapf(errInvalidSnapshot, "list of %d items exceeds the snapshot size", n)
+		return 0
+	}
+	return n
+}
+
+func (r *snapshotReader) uint64s() []uint64 {
+	vs := make([]uint64, r.length(8))
+	for i := range vs {
+		vs[i] = r.uint64()
+	}
+	return vs
+}
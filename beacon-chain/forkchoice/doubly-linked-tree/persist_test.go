@@ -0,0 +1,94 @@
+package doublylinkedtree
+
+import (
+	"context"
+	"testing"
+
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestForkChoice_SnapshotRestore(t *testing.T) {
+	ctx := context.Background()
+	f := setup(0, 0)
+	// 0 <- 1 <- 2 <- 3
+	//       \
+	//        <- 4
+	for _, n := range []struct {
+		slot   uint64
+		parent uint64
+	}{{1, 0}, {2, 1}, {3, 2}, {4, 1}} {
+		parent := indexToHash(n.parent)
+		if n.parent == 0 {
+			parent = params.BeaconConfig().ZeroHash
+		}
+		st, roblock, err := prepareForkchoiceState(ctx, 0, indexToHash(n.slot), parent, indexToHash(n.slot+10), 0, 0)
+		require.NoError(t, err)
+		require.NoError(t, f.InsertNode(ctx, st, roblock))
+	}
+	require.NoError(t, f.SetOptimisticToValid(ctx, indexToHash(2)))
+	f.justifiedBalances = []uint64{10, 20, 30}
+	f.ProcessAttestation(ctx, []uint64{0}, indexToHash(3), 1)
+	f.ProcessAttestation(ctx, []uint64{1, 2}, indexToHash(4), 1)
+	f.InsertSlashedIndex(ctx, 2)
+	head, err := f.Head(ctx)
+	require.NoError(t, err)
+	require.Equal(t, indexToHash(4), head)
+
+	snapshot, err := f.Snapshot(ctx)
+	require.NoError(t, err)
+	fc := f.FinalizedCheckpoint()
+
+	restored := New()
+	restored.SetBalancesByRooter(f.balancesByRoot)
+	require.NoError(t, restored.Restore(ctx, snapshot, head, fc))
+	require.Equal(t, f.NodeCount(), restored.NodeCount())
+	want, err := f.ForkChoiceDump(ctx)
+	require.NoError(t, err)
+	got, err := restored.ForkChoiceDump(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, want, got)
+	require.DeepEqual(t, f.votes, restored.votes)
+	require.DeepEqual(t, f.balances, restored.balances)
+	require.DeepEqual(t, f.store.slashedIndices, restored.store.slashedIndices)
+	optimistic, err := restored.IsOptimistic(indexToHash(2))
+	require.NoError(t, err)
+	require.Equal(t, false, optimistic)
+	require.Equal(t, indexToHash(4), restored.CachedHeadRoot())
+	require.Equal(t, restored.store.nodeByRoot[indexToHash(4)], restored.store.nodeByPayload[indexToHash(14)])
+
+	// The restored store keeps accounting votes.
+	restored.ProcessAttestation(ctx, []uint64{1}, indexToHash(3), 2)
+	head, err = restored.Head(ctx)
+	require.NoError(t, err)
+	require.Equal(t, indexToHash(3), head)
+}
+
+func TestForkChoice_RestoreMismatch(t *testing.T) {
+	ctx := context.Background()
+	f := setup(1, 1)
+	st, roblock, err := prepareForkchoiceState(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, params.BeaconConfig().ZeroHash, 1, 1)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, roblock))
+	head, err := f.Head(ctx)
+	require.NoError(t, err)
+	snapshot, err := f.Snapshot(ctx)
+	require.NoError(t, err)
+	fc := f.FinalizedCheckpoint()
+
+	restored := New()
+	err = restored.Restore(ctx, snapshot, indexToHash(2), fc)
+	require.ErrorIs(t, err, errSnapshotMismatch)
+	err = restored.Restore(ctx, snapshot, head, &forkchoicetypes.Checkpoint{Epoch: 2, Root: fc.Root})
+	require.ErrorIs(t, err, errSnapshotMismatch)
+	err = restored.Restore(ctx, snapshot[:len(snapshot)-1], head, fc)
+	require.ErrorIs(t, err, errInvalidSnapshot)
+	err = restored.Restore(ctx, append(snapshot, 0), head, fc)
+	require.ErrorIs(t, err, errInvalidSnapshot)
+	// A failed restore leaves the store untouched.
+	require.Equal(t, 0, restored.NodeCount())
+
+	require.NoError(t, restored.Restore(ctx, snapshot, head, fc))
+	require.Equal(t, 2, restored.NodeCount())
+}
@@ -504,3 +504,29 @@ func TestForkChoice_missingProposerBoostRoots(t *testing.T) {
 	require.Equal(t, blk.Root(), headRoot)
 	require.Equal(t, [32]byte{'p'}, f.store.proposerBoostRoot)
 }
+
+func TestForkChoice_SetClock(t *testing.T) {
+	ctx := context.Background()
+	f := setup(0, 0)
+	genesis := time.Unix(1606824023, 0)
+	f.SetGenesisTime(uint64(genesis.Unix()))
+	secondsPerSlot := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	now := genesis.Add(secondsPerSlot + time.Second)
+	f.SetClock(func() time.Time { return now })
+	require.Equal(t, primitives.Slot(1), f.store.currentSlot())
+
+	// A block arriving early in its slot according to the clock is boosted.
+	st, blk, err := prepareForkchoiceState(ctx, 1, [32]byte{'a'}, params.BeaconConfig().ZeroHash, [32]byte{'A'}, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, blk))
+	require.Equal(t, blk.Root(), f.store.proposerBoostRoot)
+	require.Equal(t, uint64(now.Unix()), f.store.nodeByRoot[blk.Root()].timestamp)
+
+	// A late block is not.
+	require.NoError(t, f.NewSlot(ctx, 2))
+	now = genesis.Add(2*secondsPerSlot + secondsPerSlot/2)
+	st, blk, err = prepareForkchoiceState(ctx, 2, [32]byte{'b'}, [32]byte{'a'}, [32]byte{'B'}, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, blk))
+	require.Equal(t, [32]byte{}, f.store.proposerBoostRoot)
+}
@@ -25,6 +25,7 @@ type ForkChoicer interface {
 	AttestationProcessor // to track new attestation for fork choice.
 	Getter               // to retrieve fork choice information.
 	Setter               // to set fork choice information.
+	Persister            // to save and restore fork choice across restarts.
 }
 
 // RLocker represents forkchoice's internal RWMutex read-only lock/unlock methods.
@@ -51,6 +52,12 @@ type AttestationProcessor interface {
 	ProcessAttestation(context.Context, []uint64, [32]byte, primitives.Epoch)
 }
 
+// Persister saves the fork choice store, so that it can be restored after a restart.
+type Persister interface {
+	Snapshot(context.Context) ([]byte, error)
+	Restore(ctx context.Context, snapshot []byte, head [32]byte, finalized *forkchoicetypes.Checkpoint) error
+}
+
 // Getter returns fork choice related information.
 type Getter interface {
 	FastGetter
@@ -73,6 +73,12 @@ import (
 
 const testSkipPowFlag = "test-skip-pow"
 
+// Number of rejected blocks kept by the invalid block registry.
+const invalidBlocksLimit = 64
+
+// Number of recent reorgs kept for the chain health summary.
+const reorgCacheLimit = 256
+
 // Used as a struct to keep cli flag options for configuring services
 // for the beacon node. We keep this as a separate struct to not pollute the actual BeaconNode
 // struct, as it is merely used to pass down configuration options into the appropriate services.
@@ -103,6 +109,11 @@ type BeaconNode struct {
 	depositCache            cache.DepositCache
 	trackedValidatorsCache  *cache.TrackedValidatorsCache
 	payloadIDCache          *cache.PayloadIDCache
+	slotTimingCache         *cache.SlotTimingCache
+	invalidBlocks           *cache.InvalidBlockRegistry
+	builderBids             *cache.BuilderBidLog
+	reorgCache              *cache.ReorgCache
+	syncAPIFallback         *regularsync.APIFallback
 	stateFeed               *event.Feed
 	blockFeed               *event.Feed
 	opFeed                  *event.Feed
@@ -154,6 +165,10 @@ func New(cliCtx *cli.Context, cancel context.CancelFunc, opts ...Option) (*Beaco
 		blsToExecPool:           blstoexec.NewPool(),
 		trackedValidatorsCache:  cache.NewTrackedValidatorsCache(),
 		payloadIDCache:          cache.NewPayloadIDCache(),
+		slotTimingCache:         cache.NewSlotTimingCache(primitives.Slot(cliCtx.Uint64(flags.SlotTimingsRetention.Name))),
+		invalidBlocks:           cache.NewInvalidBlockRegistry(invalidBlocksLimit),
+		builderBids:             cache.NewBuilderBidLog(primitives.Slot(cliCtx.Uint64(flags.BuilderBidsRetention.Name))),
+		reorgCache:              cache.NewReorgCache(reorgCacheLimit),
 		slasherBlockHeadersFeed: new(event.Feed),
 		slasherAttestationsFeed: new(event.Feed),
 		serviceFlagOpts:         &serviceFlagOpts{},
@@ -191,6 +206,13 @@ func New(cliCtx *cli.Context, cancel context.CancelFunc, opts ...Option) (*Beaco
 		return nil, errors.Wrap(err, "could not start modules")
 	}
 
+	if urls := cliCtx.StringSlice(flags.SyncFallbackAPIURLs.Name); len(urls) > 0 {
+		beacon.syncAPIFallback, err = regularsync.NewAPIFallback(urls, cliCtx.Duration(flags.SyncFallbackStallPeriod.Name))
+		if err != nil {
+			return nil, errors.Wrap(err, "could not configure sync fallback api")
+		}
+	}
+
 	beacon.verifyInitWaiter = verification.NewInitializerWaiter(
 		beacon.clockWaiter, forkchoice.NewROForkChoice(beacon.forkChoicer), beacon.stateGen)
 
@@ -277,6 +299,15 @@ func startBaseServices(cliCtx *cli.Context, beacon *BeaconNode, depositAddress s
 		return nil, errors.Wrap(err, "could not start DB")
 	}
 	beacon.BlobStorage.WarmCache()
+	if err := beacon.invalidBlocks.Load(ctx, beacon.db); err != nil {
+		return nil, errors.Wrap(err, "could not load invalid blocks")
+	}
+	if err := beacon.builderBids.Load(ctx, beacon.db); err != nil {
+		return nil, errors.Wrap(err, "could not load builder bids")
+	}
+	if err := beacon.slotTimingCache.Load(ctx, beacon.db); err != nil {
+		return nil, errors.Wrap(err, "could not load slot timings")
+	}
 
 	log.Debugln("Starting Slashing DB")
 	if err := beacon.startSlasherDB(cliCtx); err != nil {
@@ -572,6 +603,9 @@ func (b *BeaconNode) startDB(cliCtx *cli.Context, depositAddress string) error {
 	}
 
 	if b.CheckpointInitializer != nil {
+		if bs, ok := b.CheckpointInitializer.(checkpoint.BlobStorageSetter); ok {
+			bs.SetBlobStorage(b.BlobStorage)
+		}
 		log.Info("Checkpoint sync - Downloading origin state and block")
 		if err := b.CheckpointInitializer.Initialize(b.ctx, d); err != nil {
 			return err
@@ -671,8 +705,16 @@ func (b *BeaconNode) registerP2P(cliCtx *cli.Context) error {
 		return errors.Wrapf(err, "could not register p2p service")
 	}
 
+	noDiscovery := cliCtx.Bool(cmd.NoDiscovery.Name)
+	if cliCtx.String(cmd.P2PPeerAllowList.Name) != "" && !noDiscovery {
+		// Peers outside the allowlist are rejected anyway, so do not advertise the node through discovery.
+		log.Warnf("Disabling discovery because --%s is set, set --%s to silence this warning",
+			cmd.P2PPeerAllowList.Name, cmd.NoDiscovery.Name)
+		noDiscovery = true
+	}
+
 	svc, err := p2p.NewService(b.ctx, &p2p.Config{
-		NoDiscovery:          cliCtx.Bool(cmd.NoDiscovery.Name),
+		NoDiscovery:          noDiscovery,
 		StaticPeers:          slice.SplitCommaSeparated(cliCtx.StringSlice(cmd.StaticPeers.Name)),
 		Discv5BootStrapAddrs: p2p.ParseBootStrapAddrs(bootstrapNodeAddrs),
 		RelayNodeAddr:        cliCtx.String(cmd.RelayNode.Name),
@@ -690,6 +732,8 @@ func (b *BeaconNode) registerP2P(cliCtx *cli.Context) error {
 		QueueSize:            cliCtx.Uint(cmd.PubsubQueueSize.Name),
 		AllowListCIDR:        cliCtx.String(cmd.P2PAllowList.Name),
 		DenyListCIDR:         slice.SplitCommaSeparated(cliCtx.StringSlice(cmd.P2PDenyList.Name)),
+		BanListFile:          cliCtx.String(cmd.P2PBanFile.Name),
+		PeerAllowListFile:    cliCtx.String(cmd.P2PPeerAllowList.Name),
 		EnableUPnP:           cliCtx.Bool(cmd.EnableUPnPFlag.Name),
 		StateNotifier:        b,
 		DB:                   b.db,
@@ -774,6 +818,9 @@ func (b *BeaconNode) registerBlockchainService(fc forkchoice.ForkChoicer, gs *st
 		blockchain.WithBlobStorage(b.BlobStorage),
 		blockchain.WithTrackedValidatorsCache(b.trackedValidatorsCache),
 		blockchain.WithPayloadIDCache(b.payloadIDCache),
+		blockchain.WithSlotTimingCache(b.slotTimingCache),
+		blockchain.WithInvalidBlockRegistry(b.invalidBlocks),
+		blockchain.WithReorgCache(b.reorgCache),
 		blockchain.WithSyncChecker(b.syncChecker),
 	)
 
@@ -859,6 +906,9 @@ func (b *BeaconNode) registerSyncService(initialSyncComplete chan struct{}, bFil
 		regularsync.WithBlobStorage(b.BlobStorage),
 		regularsync.WithVerifierWaiter(b.verifyInitWaiter),
 		regularsync.WithAvailableBlocker(bFillStore),
+		regularsync.WithSlotTimingCache(b.slotTimingCache),
+		regularsync.WithInvalidBlockRegistry(b.invalidBlocks),
+		regularsync.WithAPIFallback(b.syncAPIFallback),
 	)
 	return b.services.RegisterService(rs)
 }
@@ -872,6 +922,10 @@ func (b *BeaconNode) registerInitialSyncService(complete chan struct{}) error {
 	opts := []initialsync.Option{
 		initialsync.WithVerifierWaiter(b.verifyInitWaiter),
 		initialsync.WithSyncChecker(b.syncChecker),
+		initialsync.WithAPIFallback(b.syncAPIFallback),
+	}
+	if dir := b.cliCtx.String(flags.InitialSyncArchiveDir.Name); dir != "" {
+		opts = append(opts, initialsync.WithBlockArchive(dir))
 	}
 	is := initialsync.NewService(b.ctx, &initialsync.Config{
 		DB:                  b.db,
@@ -933,6 +987,16 @@ func (b *BeaconNode) registerRPCService(router *http.ServeMux) error {
 		return err
 	}
 
+	var regularSyncService *regularsync.Service
+	if err := b.services.FetchService(&regularSyncService); err != nil {
+		return err
+	}
+
+	var backfillService *backfill.Service
+	if err := b.services.FetchService(&backfillService); err != nil {
+		return err
+	}
+
 	var slasherService *slasher.Service
 	if features.Get().EnableSlasher {
 		if err := b.services.FetchService(&slasherService); err != nil {
@@ -991,6 +1055,8 @@ func (b *BeaconNode) registerRPCService(router *http.ServeMux) error {
 		ChainStartFetcher:         chainStartFetcher,
 		MockEth1Votes:             mockEth1DataVotes,
 		SyncService:               syncService,
+		GossipStatsProvider:       regularSyncService,
+		BackfillController:        backfillService,
 		DepositFetcher:            depositFetcher,
 		PendingDepositFetcher:     b.depositCache,
 		BlockNotifier:             b,
@@ -1005,6 +1071,10 @@ func (b *BeaconNode) registerRPCService(router *http.ServeMux) error {
 		BlobStorage:               b.BlobStorage,
 		TrackedValidatorsCache:    b.trackedValidatorsCache,
 		PayloadIDCache:            b.payloadIDCache,
+		SlotTimingCache:           b.slotTimingCache,
+		InvalidBlocks:             b.invalidBlocks,
+		BuilderBids:               b.builderBids,
+		ReorgCache:                b.reorgCache,
 	})
 
 	return b.services.RegisterService(rpcService)
@@ -1099,7 +1169,7 @@ func (b *BeaconNode) registerBuilderService(cliCtx *cli.Context) error {
 	}
 
 	opts := b.serviceFlagOpts.builderOpts
-	opts = append(opts, builder.WithHeadFetcher(chainService), builder.WithDatabase(b.db))
+	opts = append(opts, builder.WithHeadFetcher(chainService), builder.WithDatabase(b.db), builder.WithBidLog(b.builderBids))
 
 	// make cache the default.
 	if !cliCtx.Bool(features.DisableRegistrationCache.Name) {
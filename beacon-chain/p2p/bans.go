@@ -0,0 +1,100 @@
+package p2p
+
+import (
+	"github.com/libp2p/go-libp2p/core/event"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
+	"github.com/sirupsen/logrus"
+)
+
+// Name of the file in the data directory where bans added at runtime are persisted.
+const bansPath = "peer-bans.json"
+
+// agentVersion returns the agent version the peer advertised during identify,
+// or an empty string if it is not known yet.
+func (s *Service) agentVersion(pid peer.ID) string {
+	if s.host == nil {
+		return ""
+	}
+	rawAgent, err := s.host.Peerstore().Get(pid, "AgentVersion")
+	if err != nil {
+		return ""
+	}
+	agent, ok := rawAgent.(string)
+	if !ok {
+		return ""
+	}
+	return agent
+}
+
+// loadBanFile reads the operator provided ban file and replaces the
+// file defined bans in the peer status tracker.
+func (s *Service) loadBanFile() error {
+	bans, err := peers.ReadBansFile(s.cfg.BanListFile)
+	if err != nil {
+		return err
+	}
+	s.peers.SetFileBans(bans)
+	log.WithFields(logrus.Fields{
+		"path": s.cfg.BanListFile,
+		"bans": len(bans),
+	}).Info("Loaded peer ban file")
+	return nil
+}
+
+// watchBanFile reloads the ban file whenever it changes on disk, and disconnects
+// from any connected peer which is banned by the new contents.
+func (s *Service) watchBanFile() {
+	s.watchFile(s.cfg.BanListFile, func() {
+		if err := s.loadBanFile(); err != nil {
+			log.WithError(err).Error("Could not reload peer ban file")
+			return
+		}
+		s.DisconnectBannedPeers()
+	})
+}
+
+// DisconnectBannedPeers disconnects from all connected peers which match an active ban.
+func (s *Service) DisconnectBannedPeers() {
+	for _, pid := range s.peers.Connected() {
+		s.disconnectIfBanned(pid)
+	}
+}
+
+// disconnectBannedAgents disconnects from peers as soon as identify reveals an agent version matching
+// an active ban. Identify runs concurrently with the status handshake, so the agent version of a peer
+// may not be known yet when its connection is accepted.
+func (s *Service) disconnectBannedAgents(sub event.Subscription) {
+	defer func() {
+		if err := sub.Close(); err != nil {
+			log.WithError(err).Debug("Could not close peer identification subscription")
+		}
+	}()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case e, ok := <-sub.Out():
+			if !ok {
+				return
+			}
+			evt, ok := e.(event.EvtPeerIdentificationCompleted)
+			if !ok {
+				continue
+			}
+			s.disconnectIfBanned(evt.Peer)
+		}
+	}
+}
+
+// disconnectIfBanned disconnects from the peer if it matches an active ban.
+func (s *Service) disconnectIfBanned(pid peer.ID) {
+	err := s.peers.IsBanned(pid)
+	if err == nil {
+		return
+	}
+	log.WithError(err).WithField("peer", pid).Debug("Disconnecting from banned peer")
+	if err := s.Disconnect(pid); err != nil {
+		log.WithError(err).WithField("peer", pid).Debug("Could not disconnect from banned peer")
+	}
+}
@@ -0,0 +1,51 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/event"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestService_DisconnectBannedAgents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h1, err := libp2p.New(libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, h1.Close())
+	}()
+	h2, err := libp2p.New(libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"), libp2p.UserAgent("banned/v1.0.0"))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, h2.Close())
+	}()
+
+	s := &Service{ctx: ctx, host: h1}
+	s.peers = peers.NewStatus(ctx, &peers.StatusConfig{
+		ScorerParams: &scorers.Config{},
+		AgentVersion: s.agentVersion,
+	})
+	ban, err := peers.NewAgentBan("^banned/", "", time.Time{})
+	require.NoError(t, err)
+	require.NoError(t, s.peers.AddBan(ban))
+
+	sub, err := h1.EventBus().Subscribe(new(event.EvtPeerIdentificationCompleted))
+	require.NoError(t, err)
+	go s.disconnectBannedAgents(sub)
+
+	// The peer is disconnected once identify reveals its agent version, without waiting for a handshake.
+	require.NoError(t, h2.Connect(ctx, peer.AddrInfo{ID: h1.ID(), Addrs: h1.Addrs()}))
+	for i := 0; h1.Network().Connectedness(h2.ID()) == network.Connected; i++ {
+		require.Equal(t, true, i < 100, "banned agent not disconnected")
+		time.Sleep(10 * time.Millisecond)
+	}
+}
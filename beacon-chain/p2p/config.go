@@ -35,6 +35,8 @@ type Config struct {
 	QueueSize            uint
 	AllowListCIDR        string
 	DenyListCIDR         []string
+	BanListFile          string
+	PeerAllowListFile    string
 	StateNotifier        statefeed.Notifier
 	DB                   db.ReadOnlyDatabase
 	ClockWaiter          startup.ClockWaiter
@@ -25,7 +25,16 @@ const (
 )
 
 // InterceptPeerDial tests whether we're permitted to Dial the specified peer.
-func (*Service) InterceptPeerDial(_ peer.ID) (allow bool) {
+func (s *Service) InterceptPeerDial(pid peer.ID) (allow bool) {
+	if !s.peerAllowed(pid) {
+		log.WithField("peer", pid).Trace("Not dialing peer outside of the allowlist")
+		return false
+	}
+	// Do not dial banned peers.
+	if err := s.peers.IsBanned(pid); err != nil {
+		log.WithError(err).WithField("peer", pid).Trace("Not dialing banned peer")
+		return false
+	}
 	return true
 }
 
@@ -63,7 +72,19 @@ func (s *Service) InterceptAccept(n network.ConnMultiaddrs) (allow bool) {
 
 // InterceptSecured tests whether a given connection, now authenticated,
 // is allowed.
-func (*Service) InterceptSecured(_ network.Direction, _ peer.ID, _ network.ConnMultiaddrs) (allow bool) {
+func (s *Service) InterceptSecured(_ network.Direction, pid peer.ID, n network.ConnMultiaddrs) (allow bool) {
+	// The remote peer ID is only known once the security handshake is done,
+	// so this is the earliest point at which inbound banned peers can be rejected.
+	if !s.peerAllowed(pid) {
+		log.WithFields(logrus.Fields{"peer": pid,
+			"multiaddr": n.RemoteMultiaddr()}).Trace("Not accepting connection from peer outside of the allowlist")
+		return false
+	}
+	if err := s.peers.IsBanned(pid); err != nil {
+		log.WithError(err).WithFields(logrus.Fields{"peer": pid,
+			"multiaddr": n.RemoteMultiaddr()}).Trace("Not accepting connection from banned peer")
+		return false
+	}
 	return true
 }
 
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
@@ -98,6 +99,30 @@ func TestService_InterceptBannedIP(t *testing.T) {
 	}
 }
 
+func TestService_InterceptBannedPeer(t *testing.T) {
+	s := &Service{
+		peers: peers.NewStatus(context.Background(), &peers.StatusConfig{
+			PeerLimit:    20,
+			ScorerParams: &scorers.Config{},
+		}),
+	}
+	pid, err := peer.Decode("16Uiu2HAm1n583t4huDMMqEUUBuQs6bLts21mxCfX3tiqu9JfHvRJ")
+	require.NoError(t, err)
+	multiAddress, err := ma.NewMultiaddr("/ip4/1.2.3.4/tcp/13000")
+	require.NoError(t, err)
+	conn := &maEndpoints{raddr: multiAddress}
+
+	assert.Equal(t, true, s.InterceptPeerDial(pid))
+	assert.Equal(t, true, s.InterceptSecured(network.DirInbound, pid, conn))
+
+	ban, err := peers.NewPeerBan(pid, "", time.Time{})
+	require.NoError(t, err)
+	require.NoError(t, s.peers.AddBan(ban))
+	assert.Equal(t, false, s.InterceptPeerDial(pid))
+	assert.Equal(t, false, s.InterceptSecured(network.DirInbound, pid, conn))
+	assert.Equal(t, false, s.InterceptAddrDial(pid, multiAddress))
+}
+
 func TestService_RejectInboundConnectionBeforeStarted(t *testing.T) {
 	limit := 1
 	s := &Service{
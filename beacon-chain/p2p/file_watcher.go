@@ -0,0 +1,58 @@
+package p2p
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/prysmaticlabs/prysm/v5/async"
+)
+
+// Interval over which bursts of file system events for a watched file are coalesced.
+const fileWatchDebounceInterval = time.Second
+
+// watchFile calls onChange whenever the file at the given path is written, created or
+// replaced, until the service context is done. The parent directory is watched so that
+// files replaced by editors or configuration management are picked up as well.
+func (s *Service) watchFile(path string, onChange func()) {
+	path = filepath.Clean(path)
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.WithError(err).Error("Could not initialize file watcher")
+		return
+	}
+	defer func() {
+		if err := watcher.Close(); err != nil {
+			log.WithError(err).Error("Could not close file watcher")
+		}
+	}()
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		log.WithError(err).Errorf("Could not watch directory of file %s", path)
+		return
+	}
+	ctx, cancel := context.WithCancel(s.ctx)
+	defer cancel()
+	fileChangesChan := make(chan interface{}, 100)
+	defer close(fileChangesChan)
+
+	go async.Debounce(ctx, fileWatchDebounceInterval, fileChangesChan, func(_ interface{}) {
+		onChange()
+	})
+	for {
+		select {
+		case event := <-watcher.Events:
+			if filepath.Clean(event.Name) != path {
+				continue
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+				continue
+			}
+			fileChangesChan <- event
+		case err := <-watcher.Errors:
+			log.WithError(err).Errorf("Could not watch for changes of file %s", path)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
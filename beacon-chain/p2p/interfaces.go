@@ -85,6 +85,7 @@ type PeerManager interface {
 	RefreshPersistentSubnets()
 	FindPeersWithSubnet(ctx context.Context, topic string, subIndex uint64, threshold int) (bool, error)
 	AddPingMethod(reqFunc func(ctx context.Context, id peer.ID) error)
+	DisconnectBannedPeers()
 }
 
 // Sender abstracts the sending functionality from libp2p.
@@ -0,0 +1,216 @@
+package p2p
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// peerAllowlist is the set of peers the node exclusively connects to when running in
+// peer allowlist mode. Peers are identified by libp2p peer ID or by discv5 node ID.
+type peerAllowlist struct {
+	sync.RWMutex
+	peerIDs map[peer.ID]bool
+	nodeIDs map[enode.ID]bool
+	// dialable holds the entries for which an address is known, which we keep connected to.
+	dialable []peer.AddrInfo
+}
+
+// readPeerAllowlist parses a peer allowlist file. The file contains one entry per line, which
+// is either a peer ID, a multiaddr ending in /p2p/<peer ID>, an ENR or a hex encoded node ID.
+// Empty lines and lines starting with `#` are ignored.
+func readPeerAllowlist(path string) (*peerAllowlist, error) {
+	enc, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read peer allowlist %s", path)
+	}
+	list := &peerAllowlist{
+		peerIDs: make(map[peer.ID]bool),
+		nodeIDs: make(map[enode.ID]bool),
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(enc))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if err := list.addEntry(line); err != nil {
+			return nil, errors.Wrapf(err, "invalid entry on line %d of peer allowlist %s", lineNum, path)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "could not read peer allowlist %s", path)
+	}
+	return list, nil
+}
+
+func (a *peerAllowlist) addEntry(entry string) error {
+	switch {
+	case strings.HasPrefix(entry, "enr:"):
+		node, err := enode.Parse(enode.ValidSchemes, entry)
+		if err != nil {
+			return errors.Wrap(err, "could not parse ENR")
+		}
+		a.nodeIDs[node.ID()] = true
+		info, _, err := convertToAddrInfo(node)
+		if err != nil {
+			return errors.Wrap(err, "could not convert ENR to peer info")
+		}
+		if info != nil {
+			a.peerIDs[info.ID] = true
+			a.dialable = append(a.dialable, *info)
+		}
+	case strings.HasPrefix(entry, "/"):
+		addr, err := ma.NewMultiaddr(entry)
+		if err != nil {
+			return errors.Wrap(err, "could not parse multiaddr")
+		}
+		info, err := peer.AddrInfoFromP2pAddr(addr)
+		if err != nil {
+			return errors.Wrap(err, "could not derive peer info from multiaddr")
+		}
+		a.peerIDs[info.ID] = true
+		a.dialable = append(a.dialable, *info)
+	case isHexNodeID(entry):
+		raw, err := hex.DecodeString(strings.TrimPrefix(entry, "0x"))
+		if err != nil {
+			return errors.Wrap(err, "could not decode node ID")
+		}
+		a.nodeIDs[enode.ID(raw)] = true
+	default:
+		pid, err := peer.Decode(entry)
+		if err != nil {
+			return errors.Wrap(err, "could not decode peer ID")
+		}
+		a.peerIDs[pid] = true
+	}
+	return nil
+}
+
+func isHexNodeID(entry string) bool {
+	entry = strings.TrimPrefix(entry, "0x")
+	if len(entry) != 2*len(enode.ID{}) {
+		return false
+	}
+	_, err := hex.DecodeString(entry)
+	return err == nil
+}
+
+// allowed returns true if the peer is on the allowlist, either by peer ID or by node ID.
+func (a *peerAllowlist) allowed(pid peer.ID) bool {
+	a.RLock()
+	defer a.RUnlock()
+	if a.peerIDs[pid] {
+		return true
+	}
+	if len(a.nodeIDs) == 0 {
+		return false
+	}
+	nodeID, err := ConvertPeerIDToNodeID(pid)
+	if err != nil {
+		return false
+	}
+	return a.nodeIDs[nodeID]
+}
+
+// replace swaps the contents of the allowlist for the ones of another list.
+func (a *peerAllowlist) replace(other *peerAllowlist) {
+	a.Lock()
+	defer a.Unlock()
+	a.peerIDs = other.peerIDs
+	a.nodeIDs = other.nodeIDs
+	a.dialable = other.dialable
+}
+
+func (a *peerAllowlist) dialablePeers() []peer.AddrInfo {
+	a.RLock()
+	defer a.RUnlock()
+	return append([]peer.AddrInfo{}, a.dialable...)
+}
+
+func (a *peerAllowlist) size() int {
+	a.RLock()
+	defer a.RUnlock()
+	return len(a.peerIDs) + len(a.nodeIDs)
+}
+
+// peerAllowed returns true if we are allowed to connect to the given peer. Without
+// a peer allowlist, any peer is allowed.
+func (s *Service) peerAllowed(pid peer.ID) bool {
+	if s.peerAllowlist == nil {
+		return true
+	}
+	return s.peerAllowlist.allowed(pid)
+}
+
+// loadPeerAllowlist reads the peer allowlist file and replaces the current allowlist.
+func (s *Service) loadPeerAllowlist() error {
+	list, err := readPeerAllowlist(s.cfg.PeerAllowListFile)
+	if err != nil {
+		return err
+	}
+	if s.peerAllowlist == nil {
+		s.peerAllowlist = list
+	} else {
+		s.peerAllowlist.replace(list)
+	}
+	log.WithFields(logrus.Fields{
+		"path":  s.cfg.PeerAllowListFile,
+		"peers": list.size(),
+	}).Info("Loaded peer allowlist")
+	return nil
+}
+
+// watchPeerAllowlist reloads the peer allowlist whenever it changes on disk, disconnects from
+// peers which are no longer allowed and dials the ones which were added.
+func (s *Service) watchPeerAllowlist() {
+	s.watchFile(s.cfg.PeerAllowListFile, func() {
+		if err := s.loadPeerAllowlist(); err != nil {
+			log.WithError(err).Error("Could not reload peer allowlist")
+			return
+		}
+		for _, pid := range s.peers.Connected() {
+			if s.peerAllowed(pid) {
+				continue
+			}
+			log.WithField("peer", pid).Debug("Disconnecting from peer removed from the allowlist")
+			if err := s.Disconnect(pid); err != nil {
+				log.WithError(err).WithField("peer", pid).Debug("Could not disconnect from peer")
+			}
+		}
+		s.connectWithAllowlistedPeers()
+	})
+}
+
+// connectWithAllowlistedPeers dials all allowlisted peers with a known address that
+// we are not currently connected to.
+func (s *Service) connectWithAllowlistedPeers() {
+	if s.peerAllowlist == nil {
+		return
+	}
+	for _, info := range s.peerAllowlist.dialablePeers() {
+		if info.ID == s.host.ID() || s.host.Network().Connectedness(info.ID) == network.Connected {
+			continue
+		}
+		if len(info.Addrs) > 0 {
+			s.peers.Add(nil, info.ID, info.Addrs[0], network.DirUnknown)
+		}
+		// make each dial non-blocking
+		go func(info peer.AddrInfo) {
+			if err := connectWithTimeout(s.ctx, s.host, &info); err != nil {
+				log.WithError(err).Tracef("Could not connect with allowlisted peer %s", info.String())
+			}
+		}(info)
+	}
+}
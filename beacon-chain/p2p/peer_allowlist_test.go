@@ -0,0 +1,98 @@
+package p2p
+
+import (
+	"context"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+const (
+	allowlistPeer1 = "16Uiu2HAm1n583t4huDMMqEUUBuQs6bLts21mxCfX3tiqu9JfHvRJ"
+	allowlistPeer2 = "16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR"
+)
+
+func writePeerAllowlist(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "allowlist.txt")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestReadPeerAllowlist(t *testing.T) {
+	pid1, err := peer.Decode(allowlistPeer1)
+	require.NoError(t, err)
+	pid2, err := peer.Decode(allowlistPeer2)
+	require.NoError(t, err)
+	nodeID, err := ConvertPeerIDToNodeID(pid2)
+	require.NoError(t, err)
+
+	path := writePeerAllowlist(t, "# sentry peers\n"+
+		"/ip4/10.0.0.1/tcp/13000/p2p/"+allowlistPeer1+"\n"+
+		"\n"+
+		"  0x"+hex.EncodeToString(nodeID[:])+"  \n")
+	list, err := readPeerAllowlist(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, list.size())
+	assert.Equal(t, true, list.allowed(pid1))
+	// Peers listed by node ID are matched through their public key.
+	assert.Equal(t, true, list.allowed(pid2))
+
+	dialable := list.dialablePeers()
+	require.Equal(t, 1, len(dialable))
+	assert.Equal(t, pid1, dialable[0].ID)
+	assert.Equal(t, "/ip4/10.0.0.1/tcp/13000", dialable[0].Addrs[0].String())
+
+	path = writePeerAllowlist(t, allowlistPeer1+"\n")
+	list, err = readPeerAllowlist(path)
+	require.NoError(t, err)
+	assert.Equal(t, true, list.allowed(pid1))
+	assert.Equal(t, false, list.allowed(pid2))
+	assert.Equal(t, 0, len(list.dialablePeers()))
+}
+
+func TestReadPeerAllowlist_Invalid(t *testing.T) {
+	path := writePeerAllowlist(t, allowlistPeer1+"\nnot-a-peer\n")
+	_, err := readPeerAllowlist(path)
+	require.ErrorContains(t, "invalid entry on line 2", err)
+
+	_, err = readPeerAllowlist(filepath.Join(t.TempDir(), "missing.txt"))
+	require.ErrorContains(t, "could not read peer allowlist", err)
+}
+
+func TestService_InterceptPeerAllowlist(t *testing.T) {
+	pid1, err := peer.Decode(allowlistPeer1)
+	require.NoError(t, err)
+	pid2, err := peer.Decode(allowlistPeer2)
+	require.NoError(t, err)
+	multiAddress, err := ma.NewMultiaddr("/ip4/1.2.3.4/tcp/13000")
+	require.NoError(t, err)
+	conn := &maEndpoints{raddr: multiAddress}
+
+	s := &Service{
+		cfg: &Config{PeerAllowListFile: writePeerAllowlist(t, allowlistPeer1)},
+		peers: peers.NewStatus(context.Background(), &peers.StatusConfig{
+			PeerLimit:    20,
+			ScorerParams: &scorers.Config{},
+		}),
+	}
+	require.NoError(t, s.loadPeerAllowlist())
+	assert.Equal(t, true, s.InterceptPeerDial(pid1))
+	assert.Equal(t, true, s.InterceptSecured(network.DirInbound, pid1, conn))
+	assert.Equal(t, false, s.InterceptPeerDial(pid2))
+	assert.Equal(t, false, s.InterceptSecured(network.DirInbound, pid2, conn))
+
+	// Reloading the allowlist replaces its contents.
+	s.cfg.PeerAllowListFile = writePeerAllowlist(t, allowlistPeer2)
+	require.NoError(t, s.loadPeerAllowlist())
+	assert.Equal(t, false, s.InterceptPeerDial(pid1))
+	assert.Equal(t, true, s.InterceptPeerDial(pid2))
+}
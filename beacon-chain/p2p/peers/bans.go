@@ -0,0 +1,296 @@
+package peers
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	prysmTime "github.com/prysmaticlabs/prysm/v5/time"
+)
+
+// BanSource describes where a peer ban was defined.
+type BanSource string
+
+const (
+	// BanSourceAPI is used for bans added at runtime, which are persisted across restarts.
+	BanSourceAPI BanSource = "api"
+	// BanSourceFile is used for bans loaded from the operator provided ban file.
+	BanSourceFile BanSource = "file"
+)
+
+var (
+	// ErrInvalidBan is returned when a ban does not match on exactly one of peer ID or agent version.
+	ErrInvalidBan = errors.New("ban must specify exactly one of peer id or agent pattern")
+	// ErrPeerBanned is returned when a peer matches one of the active bans.
+	ErrPeerBanned = errors.New("peer is banned")
+)
+
+// Ban is a rule which prevents connections to a peer, matched either by its peer ID
+// or by a regular expression over the agent version the peer advertises.
+type Ban struct {
+	PeerID  peer.ID   `json:"peer_id,omitempty"`
+	Agent   string    `json:"agent,omitempty"`
+	Reason  string    `json:"reason,omitempty"`
+	Created time.Time `json:"created"`
+	// Expiry is the time after which the ban is lifted. The zero value never expires.
+	Expiry  time.Time `json:"expiry"`
+	Source  BanSource `json:"-"`
+	agentRe *regexp.Regexp
+}
+
+// NewPeerBan creates a ban on a single peer ID.
+func NewPeerBan(pid peer.ID, reason string, expiry time.Time) (*Ban, error) {
+	b := &Ban{PeerID: pid, Reason: reason, Expiry: expiry}
+	if err := b.init(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// NewAgentBan creates a ban on all peers whose agent version matches the given regular expression.
+func NewAgentBan(pattern, reason string, expiry time.Time) (*Ban, error) {
+	b := &Ban{Agent: pattern, Reason: reason, Expiry: expiry}
+	if err := b.init(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// init validates the ban and compiles its agent pattern, if any.
+func (b *Ban) init() error {
+	if (b.PeerID == "") == (b.Agent == "") {
+		return ErrInvalidBan
+	}
+	if b.Agent != "" {
+		re, err := regexp.Compile(b.Agent)
+		if err != nil {
+			return errors.Wrapf(err, "could not compile agent pattern %q", b.Agent)
+		}
+		b.agentRe = re
+	}
+	if b.Created.IsZero() {
+		b.Created = prysmTime.Now()
+	}
+	return nil
+}
+
+// Expired returns true if the ban has an expiry which is before the given time.
+func (b *Ban) Expired(now time.Time) bool {
+	return !b.Expiry.IsZero() && now.After(b.Expiry)
+}
+
+func (b *Ban) key() string {
+	if b.PeerID != "" {
+		return "peer:" + b.PeerID.String()
+	}
+	return "agent:" + b.Agent
+}
+
+func (b *Ban) copy() *Ban {
+	cp := *b
+	return &cp
+}
+
+// banList holds the set of active bans, keyed by source. It has its own lock, so
+// that bans can be checked without holding the peer store lock.
+type banList struct {
+	sync.RWMutex
+	bans map[BanSource]map[string]*Ban
+	// path is where the bans added via the API are persisted, if non-empty.
+	path string
+}
+
+func newBanList() *banList {
+	return &banList{
+		bans: map[BanSource]map[string]*Ban{
+			BanSourceAPI:  make(map[string]*Ban),
+			BanSourceFile: make(map[string]*Ban),
+		},
+	}
+}
+
+// match returns the first active ban matching either the peer ID or the agent version.
+func (l *banList) match(pid peer.ID, agent string, now time.Time) *Ban {
+	l.RLock()
+	defer l.RUnlock()
+	peerKey := "peer:" + pid.String()
+	for _, bans := range l.bans {
+		if b, ok := bans[peerKey]; ok && !b.Expired(now) {
+			return b
+		}
+		if agent == "" {
+			continue
+		}
+		for _, b := range bans {
+			if b.agentRe != nil && !b.Expired(now) && b.agentRe.MatchString(agent) {
+				return b
+			}
+		}
+	}
+	return nil
+}
+
+// persist writes all non-expired API bans to disk. The caller must hold the lock.
+func (l *banList) persist(now time.Time) error {
+	if l.path == "" {
+		return nil
+	}
+	bans := make([]*Ban, 0, len(l.bans[BanSourceAPI]))
+	for _, b := range l.bans[BanSourceAPI] {
+		if !b.Expired(now) {
+			bans = append(bans, b)
+		}
+	}
+	enc, err := json.MarshalIndent(bans, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "could not marshal peer bans")
+	}
+	return file.WriteFile(l.path, enc)
+}
+
+// ReadBansFile reads a list of bans from a JSON file. Each entry must specify either a
+// `peer_id` or an `agent` regular expression, along with an optional `reason` and `expiry`.
+func ReadBansFile(path string) ([]*Ban, error) {
+	enc, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read ban file %s", path)
+	}
+	var bans []*Ban
+	if err := json.Unmarshal(enc, &bans); err != nil {
+		return nil, errors.Wrapf(err, "could not decode ban file %s", path)
+	}
+	for _, b := range bans {
+		if err := b.init(); err != nil {
+			return nil, errors.Wrapf(err, "invalid ban in %s", path)
+		}
+	}
+	return bans, nil
+}
+
+// LoadBans configures the file where bans added at runtime are persisted, and restores any
+// bans previously saved to it. A missing file is not an error.
+func (p *Status) LoadBans(path string) error {
+	p.bans.Lock()
+	defer p.bans.Unlock()
+	p.bans.path = path
+	exists, err := file.Exists(path, file.Regular)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+	bans, err := ReadBansFile(path)
+	if err != nil {
+		return err
+	}
+	now := prysmTime.Now()
+	for _, b := range bans {
+		if b.Expired(now) {
+			continue
+		}
+		b.Source = BanSourceAPI
+		p.bans.bans[BanSourceAPI][b.key()] = b
+	}
+	return nil
+}
+
+// AddBan adds a runtime ban, replacing any existing runtime ban with the same target, and
+// persists the updated ban list.
+func (p *Status) AddBan(b *Ban) error {
+	if err := b.init(); err != nil {
+		return err
+	}
+	b.Source = BanSourceAPI
+
+	p.bans.Lock()
+	defer p.bans.Unlock()
+	p.bans.bans[BanSourceAPI][b.key()] = b
+	return p.bans.persist(prysmTime.Now())
+}
+
+// RemovePeerBan lifts the runtime ban on the given peer ID. It returns false if no such ban exists.
+func (p *Status) RemovePeerBan(pid peer.ID) (bool, error) {
+	return p.removeBan("peer:" + pid.String())
+}
+
+// RemoveAgentBan lifts the runtime ban with the given agent pattern. It returns false if no such ban exists.
+func (p *Status) RemoveAgentBan(pattern string) (bool, error) {
+	return p.removeBan("agent:" + pattern)
+}
+
+func (p *Status) removeBan(key string) (bool, error) {
+	p.bans.Lock()
+	defer p.bans.Unlock()
+	if _, ok := p.bans.bans[BanSourceAPI][key]; !ok {
+		return false, nil
+	}
+	delete(p.bans.bans[BanSourceAPI], key)
+	return true, p.bans.persist(prysmTime.Now())
+}
+
+// SetFileBans replaces all bans previously loaded from the ban file with the provided ones.
+func (p *Status) SetFileBans(bans []*Ban) {
+	fileBans := make(map[string]*Ban, len(bans))
+	for _, b := range bans {
+		b.Source = BanSourceFile
+		fileBans[b.key()] = b
+	}
+
+	p.bans.Lock()
+	defer p.bans.Unlock()
+	p.bans.bans[BanSourceFile] = fileBans
+}
+
+// Bans returns copies of all active bans, sorted by creation time.
+func (p *Status) Bans() []*Ban {
+	p.bans.RLock()
+	defer p.bans.RUnlock()
+	now := prysmTime.Now()
+	bans := make([]*Ban, 0, len(p.bans.bans[BanSourceAPI])+len(p.bans.bans[BanSourceFile]))
+	for _, source := range []BanSource{BanSourceAPI, BanSourceFile} {
+		for _, b := range p.bans.bans[source] {
+			if !b.Expired(now) {
+				bans = append(bans, b.copy())
+			}
+		}
+	}
+	sort.Slice(bans, func(i, j int) bool {
+		return bans[i].Created.Before(bans[j].Created)
+	})
+	return bans
+}
+
+// IsBanned returns an error if the peer matches any active ban, either by peer ID or by its
+// agent version. Trusted peers are never considered banned.
+func (p *Status) IsBanned(pid peer.ID) error {
+	p.store.RLock()
+	defer p.store.RUnlock()
+	return p.isBanned(pid)
+}
+
+// isBanned is the lock-free version of IsBanned.
+func (p *Status) isBanned(pid peer.ID) error {
+	if p.store.IsTrustedPeer(pid) {
+		return nil
+	}
+	var agent string
+	if p.agentVersion != nil {
+		agent = p.agentVersion(pid)
+	}
+	b := p.bans.match(pid, agent, prysmTime.Now())
+	if b == nil {
+		return nil
+	}
+	if b.PeerID != "" {
+		return errors.Wrapf(ErrPeerBanned, "peer id banned (source=%s, reason=%q)", b.Source, b.Reason)
+	}
+	return errors.Wrapf(ErrPeerBanned, "agent %q matches %q (source=%s, reason=%q)", agent, b.Agent, b.Source, b.Reason)
+}
@@ -0,0 +1,146 @@
+package peers_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	libp2ptest "github.com/libp2p/go-libp2p/p2p/host/peerstore/test"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func newBanTestStatus(agents map[peer.ID]string) *peers.Status {
+	return peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit:    30,
+		ScorerParams: &scorers.Config{},
+		AgentVersion: func(pid peer.ID) string {
+			return agents[pid]
+		},
+	})
+}
+
+func TestStatus_PeerBan(t *testing.T) {
+	p := newBanTestStatus(nil)
+	ids := libp2ptest.GeneratePeerIDs(2)
+	id, other := ids[0], ids[1]
+
+	require.NoError(t, p.IsBanned(id))
+	ban, err := peers.NewPeerBan(id, "spam", time.Time{})
+	require.NoError(t, err)
+	require.NoError(t, p.AddBan(ban))
+	require.ErrorIs(t, p.IsBanned(id), peers.ErrPeerBanned)
+	require.ErrorIs(t, p.IsBad(id), peers.ErrPeerBanned)
+	require.NoError(t, p.IsBanned(other))
+
+	// Trusted peers are exempt from bans.
+	p.SetTrustedPeers([]peer.ID{id})
+	require.NoError(t, p.IsBanned(id))
+	p.DeleteTrustedPeers([]peer.ID{id})
+
+	removed, err := p.RemovePeerBan(id)
+	require.NoError(t, err)
+	assert.Equal(t, true, removed)
+	require.NoError(t, p.IsBanned(id))
+	removed, err = p.RemovePeerBan(id)
+	require.NoError(t, err)
+	assert.Equal(t, false, removed)
+}
+
+func TestStatus_AgentBan(t *testing.T) {
+	ids := libp2ptest.GeneratePeerIDs(3)
+	bad, good, unknown := ids[0], ids[1], ids[2]
+	p := newBanTestStatus(map[peer.ID]string{
+		bad:  "Prysm/v5.1.0/abcdef",
+		good: "Prysm/v5.2.0/abcdef",
+	})
+
+	ban, err := peers.NewAgentBan(`^Prysm/v5\.1\.`, "broken release", time.Time{})
+	require.NoError(t, err)
+	require.NoError(t, p.AddBan(ban))
+	require.ErrorIs(t, p.IsBanned(bad), peers.ErrPeerBanned)
+	require.NoError(t, p.IsBanned(good))
+	// Peers whose agent is not known yet can not be matched.
+	require.NoError(t, p.IsBanned(unknown))
+
+	removed, err := p.RemoveAgentBan(`^Prysm/v5\.1\.`)
+	require.NoError(t, err)
+	assert.Equal(t, true, removed)
+	require.NoError(t, p.IsBanned(bad))
+}
+
+func TestStatus_BanExpiry(t *testing.T) {
+	p := newBanTestStatus(nil)
+	id := libp2ptest.GeneratePeerIDs(1)[0]
+	ban, err := peers.NewPeerBan(id, "", time.Now().Add(-time.Second))
+	require.NoError(t, err)
+	require.NoError(t, p.AddBan(ban))
+	require.NoError(t, p.IsBanned(id))
+	assert.Equal(t, 0, len(p.Bans()))
+}
+
+func TestStatus_InvalidBan(t *testing.T) {
+	_, err := peers.NewAgentBan("(", "", time.Time{})
+	require.ErrorContains(t, "could not compile agent pattern", err)
+	_, err = peers.NewPeerBan("", "", time.Time{})
+	require.ErrorIs(t, err, peers.ErrInvalidBan)
+	p := newBanTestStatus(nil)
+	require.ErrorIs(t, p.AddBan(&peers.Ban{PeerID: libp2ptest.GeneratePeerIDs(1)[0], Agent: "b"}), peers.ErrInvalidBan)
+}
+
+func TestStatus_LoadBans(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bans.json")
+	id := libp2ptest.GeneratePeerIDs(1)[0]
+
+	p := newBanTestStatus(nil)
+	require.NoError(t, p.LoadBans(path))
+	ban, err := peers.NewPeerBan(id, "spam", time.Time{})
+	require.NoError(t, err)
+	require.NoError(t, p.AddBan(ban))
+	ban, err = peers.NewAgentBan("lighthouse", "", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, p.AddBan(ban))
+
+	restored := newBanTestStatus(nil)
+	require.NoError(t, restored.LoadBans(path))
+	bans := restored.Bans()
+	require.Equal(t, 2, len(bans))
+	assert.Equal(t, id, bans[0].PeerID)
+	assert.Equal(t, "spam", bans[0].Reason)
+	assert.Equal(t, peers.BanSourceAPI, bans[0].Source)
+	assert.Equal(t, "lighthouse", bans[1].Agent)
+	require.ErrorIs(t, restored.IsBanned(id), peers.ErrPeerBanned)
+}
+
+func TestStatus_FileBans(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bans.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[{"peer_id":"16Uiu2HAm1n583t4huDMMqEUUBuQs6bLts21mxCfX3tiqu9JfHvRJ","reason":"spam"},{"agent":"^teku"}]`), 0600))
+	bans, err := peers.ReadBansFile(path)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(bans))
+
+	id, err := peer.Decode("16Uiu2HAm1n583t4huDMMqEUUBuQs6bLts21mxCfX3tiqu9JfHvRJ")
+	require.NoError(t, err)
+	p := newBanTestStatus(nil)
+	p.SetFileBans(bans)
+	require.ErrorIs(t, p.IsBanned(id), peers.ErrPeerBanned)
+	for _, b := range p.Bans() {
+		assert.Equal(t, peers.BanSourceFile, b.Source)
+	}
+
+	// File bans can not be lifted through the runtime API, only by replacing them.
+	removed, err := p.RemovePeerBan(id)
+	require.NoError(t, err)
+	assert.Equal(t, false, removed)
+	p.SetFileBans(nil)
+	require.NoError(t, p.IsBanned(id))
+
+	require.NoError(t, os.WriteFile(path, []byte(`[{"peer_id":"16Uiu2HAm1n583t4huDMMqEUUBuQs6bLts21mxCfX3tiqu9JfHvRJ","agent":"^teku"}]`), 0600))
+	_, err = peers.ReadBansFile(path)
+	require.ErrorIs(t, err, peers.ErrInvalidBan)
+}
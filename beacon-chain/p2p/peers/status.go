@@ -92,6 +92,9 @@ type Status struct {
 	store     *peerdata.Store
 	ipTracker map[string]uint64
 	rand      *rand.Rand
+	bans      *banList
+	// agentVersion looks up the agent version a peer advertised, used to enforce agent bans.
+	agentVersion func(peer.ID) string
 }
 
 // StatusConfig represents peer status service params.
@@ -100,6 +103,8 @@ type StatusConfig struct {
 	PeerLimit int
 	// ScorerParams holds peer scorer configuration params.
 	ScorerParams *scorers.Config
+	// AgentVersion returns the agent version advertised by a peer, or an empty string if it is unknown.
+	AgentVersion func(peer.ID) string
 }
 
 // NewStatus creates a new status entity.
@@ -114,7 +119,9 @@ func NewStatus(ctx context.Context, config *StatusConfig) *Status {
 		ipTracker: map[string]uint64{},
 		// Random generator used to calculate dial backoff period.
 		// It is ok to use deterministic generator, no need for true entropy.
-		rand: rand.NewDeterministicGenerator(),
+		rand:         rand.NewDeterministicGenerator(),
+		bans:         newBanList(),
+		agentVersion: config.AgentVersion,
 	}
 }
 
@@ -359,6 +366,10 @@ func (p *Status) isBad(pid peer.ID) error {
 		return nil
 	}
 
+	if err := p.isBanned(pid); err != nil {
+		return err
+	}
+
 	if err := p.isfromBadIP(pid); err != nil {
 		return errors.Wrap(err, "peer is from a bad IP")
 	}
@@ -6,6 +6,7 @@ package p2p
 import (
 	"context"
 	"crypto/ecdsa"
+	"path"
 	"sync"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/ethereum/go-ethereum/p2p/enr"
 	"github.com/libp2p/go-libp2p"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/event"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -66,6 +68,7 @@ type Service struct {
 	cfg                   *Config
 	peers                 *peers.Status
 	addrFilter            *multiaddr.Filters
+	peerAllowlist         *peerAllowlist
 	ipLimiter             *leakybucket.Collector
 	privKey               *ecdsa.PrivateKey
 	metaData              metadata.Metadata
@@ -123,6 +126,12 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 		subnetsLock:  make(map[uint64]*sync.RWMutex),
 	}
 
+	if cfg.PeerAllowListFile != "" {
+		if err := s.loadPeerAllowlist(); err != nil {
+			return nil, errors.Wrap(err, "failed to load peer allowlist")
+		}
+	}
+
 	ipAddr := prysmnetwork.IPAddr()
 
 	opts, err := s.buildOptions(ipAddr, s.privKey)
@@ -167,7 +176,18 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 				DecayInterval: time.Hour,
 			},
 		},
+		AgentVersion: s.agentVersion,
 	})
+	if s.cfg.DataDir != "" {
+		if err := s.peers.LoadBans(path.Join(s.cfg.DataDir, bansPath)); err != nil {
+			return nil, errors.Wrap(err, "failed to load persisted peer bans")
+		}
+	}
+	if s.cfg.BanListFile != "" {
+		if err := s.loadBanFile(); err != nil {
+			return nil, errors.Wrap(err, "failed to load peer ban file")
+		}
+	}
 
 	// Initialize Data maps.
 	types.InitializeDataMaps()
@@ -187,6 +207,14 @@ func (s *Service) Start() {
 	s.awaitStateInitialized()
 	s.isPreGenesis = false
 
+	// Subscribe before connecting to peers, so that no identification is missed.
+	idSub, err := s.host.EventBus().Subscribe(new(event.EvtPeerIdentificationCompleted))
+	if err != nil {
+		log.WithError(err).Error("Could not subscribe to peer identification events")
+	} else {
+		go s.disconnectBannedAgents(idSub)
+	}
+
 	var relayNodes []string
 	if s.cfg.RelayNodeAddr != "" {
 		relayNodes = append(relayNodes, s.cfg.RelayNodeAddr)
@@ -229,6 +257,7 @@ func (s *Service) Start() {
 		s.peers.SetTrustedPeers(pids)
 		s.connectWithAllTrustedPeers(addrs)
 	}
+	s.connectWithAllowlistedPeers()
 	// Initialize metadata according to the
 	// current epoch.
 	s.RefreshPersistentSubnets()
@@ -236,6 +265,7 @@ func (s *Service) Start() {
 	// Periodic functions.
 	async.RunEvery(s.ctx, params.BeaconConfig().TtfbTimeoutDuration(), func() {
 		ensurePeerConnections(s.ctx, s.host, s.peers, relayNodes...)
+		s.connectWithAllowlistedPeers()
 	})
 	async.RunEvery(s.ctx, 30*time.Minute, s.Peers().Prune)
 	async.RunEvery(s.ctx, time.Duration(params.BeaconConfig().RespTimeout)*time.Second, s.updateMetrics)
@@ -278,6 +308,12 @@ func (s *Service) Start() {
 		logExternalDNSAddr(s.host.ID(), p2pHostDNS, p2pTCPPort)
 	}
 	go s.forkWatcher()
+	if s.cfg.BanListFile != "" {
+		go s.watchBanFile()
+	}
+	if s.cfg.PeerAllowListFile != "" {
+		go s.watchPeerAllowlist()
+	}
 }
 
 // Stop the p2p service and terminate all peer connections.
@@ -68,6 +68,9 @@ func (*FakeP2P) FindPeersWithSubnet(_ context.Context, _ string, _ uint64, _ int
 // RefreshPersistentSubnets mocks the p2p func.
 func (*FakeP2P) RefreshPersistentSubnets() {}
 
+// DisconnectBannedPeers mocks the p2p func.
+func (*FakeP2P) DisconnectBannedPeers() {}
+
 // LeaveTopic -- fake.
 func (*FakeP2P) LeaveTopic(_ string) error {
 	return nil
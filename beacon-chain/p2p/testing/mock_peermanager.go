@@ -50,6 +50,9 @@ func (m *MockPeerManager) DiscoveryAddresses() ([]multiaddr.Multiaddr, error) {
 // RefreshPersistentSubnets .
 func (*MockPeerManager) RefreshPersistentSubnets() {}
 
+// DisconnectBannedPeers .
+func (*MockPeerManager) DisconnectBannedPeers() {}
+
 // FindPeersWithSubnet .
 func (*MockPeerManager) FindPeersWithSubnet(_ context.Context, _ string, _ uint64, _ int) (bool, error) {
 	return true, nil
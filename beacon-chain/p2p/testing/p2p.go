@@ -394,6 +394,9 @@ func (*TestP2P) FindPeersWithSubnet(_ context.Context, _ string, _ uint64, _ int
 // RefreshPersistentSubnets mocks the p2p func.
 func (*TestP2P) RefreshPersistentSubnets() {}
 
+// DisconnectBannedPeers mocks the p2p func.
+func (*TestP2P) DisconnectBannedPeers() {}
+
 // ForkDigest mocks the p2p func.
 func (p *TestP2P) ForkDigest() ([4]byte, error) {
 	return p.Digest, nil
@@ -101,7 +101,7 @@ func (s *Service) endpoints(
 	endpoints = append(endpoints, s.prysmNodeEndpoints()...)
 	endpoints = append(endpoints, s.prysmValidatorEndpoints(stater, coreService)...)
 	if enableDebug {
-		endpoints = append(endpoints, s.debugEndpoints(stater)...)
+		endpoints = append(endpoints, s.debugEndpoints(blocker, stater)...)
 	}
 	return endpoints
 }
@@ -201,25 +201,26 @@ func (s *Service) validatorEndpoints(
 	rewardFetcher rewards.BlockRewardsFetcher,
 ) []endpoint {
 	server := &validator.Server{
-		HeadFetcher:            s.cfg.HeadFetcher,
-		TimeFetcher:            s.cfg.GenesisTimeFetcher,
-		SyncChecker:            s.cfg.SyncService,
-		OptimisticModeFetcher:  s.cfg.OptimisticModeFetcher,
-		AttestationCache:       s.cfg.AttestationCache,
-		AttestationsPool:       s.cfg.AttestationsPool,
-		PeerManager:            s.cfg.PeerManager,
-		Broadcaster:            s.cfg.Broadcaster,
-		V1Alpha1Server:         validatorServer,
-		Stater:                 stater,
-		SyncCommitteePool:      s.cfg.SyncCommitteeObjectPool,
-		ChainInfoFetcher:       s.cfg.ChainInfoFetcher,
-		BeaconDB:               s.cfg.BeaconDB,
-		BlockBuilder:           s.cfg.BlockBuilder,
-		OperationNotifier:      s.cfg.OperationNotifier,
-		TrackedValidatorsCache: s.cfg.TrackedValidatorsCache,
-		PayloadIDCache:         s.cfg.PayloadIDCache,
-		CoreService:            coreService,
-		BlockRewardFetcher:     rewardFetcher,
+		HeadFetcher:               s.cfg.HeadFetcher,
+		TimeFetcher:               s.cfg.GenesisTimeFetcher,
+		SyncChecker:               s.cfg.SyncService,
+		OptimisticModeFetcher:     s.cfg.OptimisticModeFetcher,
+		AttestationCache:          s.cfg.AttestationCache,
+		AttestationsPool:          s.cfg.AttestationsPool,
+		PeerManager:               s.cfg.PeerManager,
+		Broadcaster:               s.cfg.Broadcaster,
+		V1Alpha1Server:            validatorServer,
+		Stater:                    stater,
+		SyncCommitteePool:         s.cfg.SyncCommitteeObjectPool,
+		ChainInfoFetcher:          s.cfg.ChainInfoFetcher,
+		BeaconDB:                  s.cfg.BeaconDB,
+		BlockBuilder:              s.cfg.BlockBuilder,
+		OperationNotifier:         s.cfg.OperationNotifier,
+		TrackedValidatorsCache:    s.cfg.TrackedValidatorsCache,
+		PayloadIDCache:            s.cfg.PayloadIDCache,
+		CoreService:               coreService,
+		BlockRewardFetcher:        rewardFetcher,
+		ExecutionChainInfoFetcher: s.cfg.ExecutionChainInfoFetcher,
 	}
 
 	const namespace = "validator"
@@ -970,16 +971,20 @@ func (s *Service) lightClientEndpoints(blocker lookup.Blocker, stater lookup.Sta
 	}
 }
 
-func (s *Service) debugEndpoints(stater lookup.Stater) []endpoint {
+func (s *Service) debugEndpoints(blocker lookup.Blocker, stater lookup.Stater) []endpoint {
 	server := &debug.Server{
 		BeaconDB:              s.cfg.BeaconDB,
 		HeadFetcher:           s.cfg.HeadFetcher,
+		TimeFetcher:           s.cfg.GenesisTimeFetcher,
+		Blocker:               blocker,
 		Stater:                stater,
 		OptimisticModeFetcher: s.cfg.OptimisticModeFetcher,
 		ForkFetcher:           s.cfg.ForkFetcher,
 		ForkchoiceFetcher:     s.cfg.ForkchoiceFetcher,
 		FinalizationFetcher:   s.cfg.FinalizationFetcher,
 		ChainInfoFetcher:      s.cfg.ChainInfoFetcher,
+		InvalidBlocks:         s.cfg.InvalidBlocks,
+		SlotTimingCache:       s.cfg.SlotTimingCache,
 	}
 
 	const namespace = "debug"
@@ -1011,6 +1016,33 @@ func (s *Service) debugEndpoints(stater lookup.Stater) []endpoint {
 			handler: server.GetForkChoice,
 			methods: []string{http.MethodGet},
 		},
+		{
+			template: "/prysm/v1/debug/invalid_blocks",
+			name:     namespace + ".GetInvalidBlocks",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetInvalidBlocks,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/debug/invalid_blocks/{block_root}",
+			name:     namespace + ".GetInvalidBlock",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType, api.OctetStreamMediaType}),
+			},
+			handler: server.GetInvalidBlock,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/debug/block_import/{block_id}",
+			name:     namespace + ".GetBlockImport",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetBlockImport,
+			methods: []string{http.MethodGet},
+		},
 	}
 }
 
@@ -1056,6 +1088,15 @@ func (s *Service) prysmBeaconEndpoints(
 		CoreService:           coreService,
 		Broadcaster:           s.cfg.Broadcaster,
 		BlobReceiver:          s.cfg.BlobReceiver,
+		SlotTimingCache:       s.cfg.SlotTimingCache,
+		BuilderBids:           s.cfg.BuilderBids,
+		ReorgCache:            s.cfg.ReorgCache,
+		AttestationCache:      s.cfg.AttestationCache,
+		AttestationsPool:      s.cfg.AttestationsPool,
+		SyncCommitteePool:     s.cfg.SyncCommitteeObjectPool,
+		SlashingsPool:         s.cfg.SlashingsPool,
+		VoluntaryExitsPool:    s.cfg.ExitPool,
+		BLSChangesPool:        s.cfg.BLSChangesPool,
 	}
 
 	const namespace = "prysm.beacon"
@@ -1106,6 +1147,33 @@ func (s *Service) prysmBeaconEndpoints(
 			handler: server.GetChainHead,
 			methods: []string{http.MethodGet},
 		},
+		{
+			template: "/prysm/v1/beacon/chain_health",
+			name:     namespace + ".GetChainHealth",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetChainHealth,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/beacon/slot_timings/{slot}",
+			name:     namespace + ".GetSlotTimings",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetSlotTimings,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/builder/bids/{slot}",
+			name:     namespace + ".GetBuilderBids",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetBuilderBids,
+			methods: []string{http.MethodGet},
+		},
 		{
 			template: "/prysm/v1/beacon/blobs",
 			name:     namespace + ".PublishBlobs",
@@ -1116,6 +1184,51 @@ func (s *Service) prysmBeaconEndpoints(
 			handler: server.PublishBlobs,
 			methods: []string{http.MethodPost},
 		},
+		{
+			template: "/prysm/v1/beacon/pool/attestations",
+			name:     namespace + ".GetPoolAttestations",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetPoolAttestations,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/beacon/pool/sync_committees",
+			name:     namespace + ".GetPoolSyncCommittees",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetPoolSyncCommittees,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/beacon/pool/voluntary_exits",
+			name:     namespace + ".GetPoolVoluntaryExits",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetPoolVoluntaryExits,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/beacon/pool/slashings",
+			name:     namespace + ".GetPoolSlashings",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetPoolSlashings,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/beacon/pool/bls_to_execution_changes",
+			name:     namespace + ".GetPoolBLSToExecutionChanges",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetPoolBLSToExecutionChanges,
+			methods: []string{http.MethodGet},
+		},
 	}
 }
 
@@ -1130,6 +1243,8 @@ func (s *Service) prysmNodeEndpoints() []endpoint {
 		MetadataProvider:          s.cfg.MetadataProvider,
 		HeadFetcher:               s.cfg.HeadFetcher,
 		ExecutionChainInfoFetcher: s.cfg.ExecutionChainInfoFetcher,
+		GossipStatsProvider:       s.cfg.GossipStatsProvider,
+		BackfillController:        s.cfg.BackfillController,
 	}
 
 	const namespace = "prysm.node"
@@ -1190,6 +1305,89 @@ func (s *Service) prysmNodeEndpoints() []endpoint {
 			handler: server.RemoveTrustedPeer,
 			methods: []string{http.MethodDelete},
 		},
+		{
+			template: "/prysm/v1/node/peer_bans",
+			name:     namespace + ".ListPeerBans",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.ListPeerBans,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/node/peer_bans",
+			name:     namespace + ".AddPeerBan",
+			middleware: []middleware.Middleware{
+				middleware.ContentTypeHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.AddPeerBan,
+			methods: []string{http.MethodPost},
+		},
+		{
+			template: "/prysm/v1/node/peer_bans",
+			name:     namespace + ".RemovePeerBan",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.RemovePeerBan,
+			methods: []string{http.MethodDelete},
+		},
+		{
+			template: "/prysm/v1/node/gossip_stats",
+			name:     namespace + ".GetGossipStats",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetGossipStats,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/node/backfill",
+			name:     namespace + ".GetBackfillStatus",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetBackfillStatus,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/node/backfill",
+			name:     namespace + ".UpdateBackfill",
+			middleware: []middleware.Middleware{
+				middleware.ContentTypeHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.UpdateBackfill,
+			methods: []string{http.MethodPost},
+		},
+		{
+			template: "/prysm/v1/node/backfill/pause",
+			name:     namespace + ".PauseBackfill",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.PauseBackfill,
+			methods: []string{http.MethodPost},
+		},
+		{
+			template: "/prysm/v1/node/backfill/resume",
+			name:     namespace + ".ResumeBackfill",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.ResumeBackfill,
+			methods: []string{http.MethodPost},
+		},
+		{
+			template: "/prysm/v1/node/execution_status",
+			name:     namespace + ".GetExecutionStatus",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetExecutionStatus,
+			methods: []string{http.MethodGet},
+		},
 	}
 }
 
@@ -73,9 +73,12 @@ func Test_endpoints(t *testing.T) {
 	}
 
 	debugRoutes := map[string][]string{
-		"/eth/v2/debug/beacon/states/{state_id}": {http.MethodGet},
-		"/eth/v2/debug/beacon/heads":             {http.MethodGet},
-		"/eth/v1/debug/fork_choice":              {http.MethodGet},
+		"/eth/v2/debug/beacon/states/{state_id}":      {http.MethodGet},
+		"/eth/v2/debug/beacon/heads":                  {http.MethodGet},
+		"/eth/v1/debug/fork_choice":                   {http.MethodGet},
+		"/prysm/v1/debug/invalid_blocks":              {http.MethodGet},
+		"/prysm/v1/debug/invalid_blocks/{block_root}": {http.MethodGet},
+		"/prysm/v1/debug/block_import/{block_id}":     {http.MethodGet},
 	}
 
 	eventsRoutes := map[string][]string{
@@ -120,7 +123,15 @@ func Test_endpoints(t *testing.T) {
 		"/eth/v1/beacon/states/{state_id}/validator_count":   {http.MethodGet},
 		"/prysm/v1/beacon/states/{state_id}/validator_count": {http.MethodGet},
 		"/prysm/v1/beacon/chain_head":                        {http.MethodGet},
+		"/prysm/v1/beacon/chain_health":                      {http.MethodGet},
 		"/prysm/v1/beacon/blobs":                             {http.MethodPost},
+		"/prysm/v1/beacon/slot_timings/{slot}":               {http.MethodGet},
+		"/prysm/v1/builder/bids/{slot}":                      {http.MethodGet},
+		"/prysm/v1/beacon/pool/attestations":                 {http.MethodGet},
+		"/prysm/v1/beacon/pool/sync_committees":              {http.MethodGet},
+		"/prysm/v1/beacon/pool/voluntary_exits":              {http.MethodGet},
+		"/prysm/v1/beacon/pool/slashings":                    {http.MethodGet},
+		"/prysm/v1/beacon/pool/bls_to_execution_changes":     {http.MethodGet},
 	}
 
 	prysmNodeRoutes := map[string][]string{
@@ -128,6 +139,12 @@ func Test_endpoints(t *testing.T) {
 		"/prysm/v1/node/trusted_peers":           {http.MethodGet, http.MethodPost},
 		"/prysm/node/trusted_peers/{peer_id}":    {http.MethodDelete},
 		"/prysm/v1/node/trusted_peers/{peer_id}": {http.MethodDelete},
+		"/prysm/v1/node/peer_bans":               {http.MethodGet, http.MethodPost, http.MethodDelete},
+		"/prysm/v1/node/gossip_stats":            {http.MethodGet},
+		"/prysm/v1/node/backfill":                {http.MethodGet, http.MethodPost},
+		"/prysm/v1/node/backfill/pause":          {http.MethodPost},
+		"/prysm/v1/node/backfill/resume":         {http.MethodPost},
+		"/prysm/v1/node/execution_status":        {http.MethodGet},
 	}
 
 	prysmValidatorRoutes := map[string][]string{
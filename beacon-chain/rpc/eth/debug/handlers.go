@@ -5,15 +5,20 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/prysmaticlabs/prysm/v5/api"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/helpers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
 	"github.com/prysmaticlabs/prysm/v5/network/httputil"
 	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
 )
 
 const errMsgStateFromConsensus = "Could not convert consensus state to response"
@@ -173,37 +178,147 @@ func (s *Server) GetForkChoice(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	nodes := make([]*structs.ForkChoiceNode, len(dump.ForkChoiceNodes))
-	for i, n := range dump.ForkChoiceNodes {
-		nodes[i] = &structs.ForkChoiceNode{
-			Slot:               fmt.Sprintf("%d", n.Slot),
-			BlockRoot:          hexutil.Encode(n.BlockRoot),
-			ParentRoot:         hexutil.Encode(n.ParentRoot),
-			JustifiedEpoch:     fmt.Sprintf("%d", n.JustifiedEpoch),
-			FinalizedEpoch:     fmt.Sprintf("%d", n.FinalizedEpoch),
-			Weight:             fmt.Sprintf("%d", n.Weight),
-			ExecutionBlockHash: hexutil.Encode(n.ExecutionBlockHash),
-			Validity:           n.Validity.String(),
-			ExtraData: &structs.ForkChoiceNodeExtraData{
-				UnrealizedJustifiedEpoch: fmt.Sprintf("%d", n.UnrealizedJustifiedEpoch),
-				UnrealizedFinalizedEpoch: fmt.Sprintf("%d", n.UnrealizedFinalizedEpoch),
-				Balance:                  fmt.Sprintf("%d", n.Balance),
-				ExecutionOptimistic:      n.ExecutionOptimistic,
-				TimeStamp:                fmt.Sprintf("%d", n.Timestamp),
-			},
+	httputil.WriteJson(w, structs.ForkChoiceDumpFromConsensus(dump))
+}
+
+// GetInvalidBlocks returns the blocks and blob sidecars recently rejected by the node, most recent first.
+func (s *Server) GetInvalidBlocks(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "debug.GetInvalidBlocks")
+	defer span.End()
+
+	records := s.InvalidBlocks.List()
+	data := make([]*structs.InvalidBlock, len(records))
+	for i, b := range records {
+		data[i] = invalidBlockToStruct(b)
+	}
+	httputil.WriteJson(w, &structs.GetInvalidBlocksResponse{Data: data})
+}
+
+// GetInvalidBlock returns a block rejected by the node, along with its rejected blob sidecars.
+// When SSZ is requested, the response is the rejected block, or the blob sidecar at blob_index if given.
+func (s *Server) GetInvalidBlock(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "debug.GetInvalidBlock")
+	defer span.End()
+
+	_, root, ok := shared.HexFromRoute(w, r, "block_root", fieldparams.RootLength)
+	if !ok {
+		return
+	}
+	rawIndex, index, ok := shared.UintFromQuery(w, r, "blob_index", false)
+	if !ok {
+		return
+	}
+	b, ok := s.InvalidBlocks.Get(bytesutil.ToBytes32(root))
+	if !ok {
+		httputil.HandleError(w, fmt.Sprintf("No invalid block found for root %#x", root), http.StatusNotFound)
+		return
+	}
+
+	if httputil.RespondWithSsz(r) {
+		if rawIndex != "" {
+			if index >= uint64(len(b.Blobs)) {
+				httputil.HandleError(w, fmt.Sprintf("No invalid blob sidecar recorded at index %d", index), http.StatusNotFound)
+				return
+			}
+			httputil.WriteSsz(w, b.Blobs[index], "blob_sidecar.ssz")
+			return
+		}
+		if len(b.Block) == 0 {
+			httputil.HandleError(w, "The invalid block was not recorded, only its blob sidecars or root", http.StatusNotFound)
+			return
 		}
+		w.Header().Set(api.VersionHeader, version.String(b.Version))
+		httputil.WriteSsz(w, b.Block, "beacon_block.ssz")
+		return
 	}
-	resp := &structs.GetForkChoiceDumpResponse{
-		JustifiedCheckpoint: structs.CheckpointFromConsensus(dump.JustifiedCheckpoint),
-		FinalizedCheckpoint: structs.CheckpointFromConsensus(dump.FinalizedCheckpoint),
-		ForkChoiceNodes:     nodes,
-		ExtraData: &structs.ForkChoiceDumpExtraData{
-			UnrealizedJustifiedCheckpoint: structs.CheckpointFromConsensus(dump.UnrealizedJustifiedCheckpoint),
-			UnrealizedFinalizedCheckpoint: structs.CheckpointFromConsensus(dump.UnrealizedFinalizedCheckpoint),
-			ProposerBoostRoot:             hexutil.Encode(dump.ProposerBoostRoot),
-			PreviousProposerBoostRoot:     hexutil.Encode(dump.PreviousProposerBoostRoot),
-			HeadRoot:                      hexutil.Encode(dump.HeadRoot),
-		},
+
+	resp := &structs.GetInvalidBlockResponse{
+		Data:         invalidBlockToStruct(b),
+		BlobSidecars: make([]string, len(b.Blobs)),
+	}
+	if len(b.Block) > 0 {
+		resp.Version = version.String(b.Version)
+		resp.Block = hexutil.Encode(b.Block)
+		w.Header().Set(api.VersionHeader, resp.Version)
+	}
+	for i, blob := range b.Blobs {
+		resp.BlobSidecars[i] = hexutil.Encode(blob)
 	}
 	httputil.WriteJson(w, resp)
 }
+
+// GetBlockImport returns the time spent by a recent block in each stage of its import, including the execution
+// engine call and the wait for blob data availability, relative to the start of the block's slot.
+func (s *Server) GetBlockImport(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "debug.GetBlockImport")
+	defer span.End()
+
+	blockId := r.PathValue("block_id")
+	if blockId == "" {
+		httputil.HandleError(w, "block_id is required in URL params", http.StatusBadRequest)
+		return
+	}
+	if s.SlotTimingCache.Retention() == 0 {
+		httputil.HandleError(w, "Block import timings are not recorded by this node", http.StatusNotFound)
+		return
+	}
+	blk, err := s.Blocker.Block(ctx, []byte(blockId))
+	if !shared.WriteBlockFetchError(w, blk, err) {
+		return
+	}
+	root, err := blk.Block().HashTreeRoot()
+	if err != nil {
+		httputil.HandleError(w, "Could not compute block root: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	slot := blk.Block().Slot()
+	t, ok := s.SlotTimingCache.Timing(slot, root)
+	if !ok {
+		httputil.HandleError(w, fmt.Sprintf("No import timings found for block %#x", root), http.StatusNotFound)
+		return
+	}
+
+	startTime := slots.StartTime(uint64(s.TimeFetcher.GenesisTime().Unix()), slot)
+	sinceStart := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return fmt.Sprintf("%d", t.Sub(startTime).Milliseconds())
+	}
+	stages := make([]*structs.BlockImportStage, len(t.ImportStages))
+	for i, st := range t.ImportStages {
+		stages[i] = &structs.BlockImportStage{
+			Name:     st.Name,
+			Start:    fmt.Sprintf("%.3f", float64(st.Start.Sub(startTime))/float64(time.Millisecond)),
+			Duration: fmt.Sprintf("%.3f", float64(st.End.Sub(st.Start))/float64(time.Millisecond)),
+		}
+	}
+	httputil.WriteJson(w, &structs.GetBlockImportResponse{
+		Data: &structs.BlockImport{
+			Slot:          fmt.Sprintf("%d", slot),
+			BlockRoot:     hexutil.Encode(root[:]),
+			SlotStartTime: fmt.Sprintf("%d", startTime.Unix()),
+			BlockArrival:  sinceStart(t.BlockArrival),
+			BlockPeerId:   t.BlockPeer,
+			Stages:        stages,
+			Imported:      sinceStart(t.Imported),
+			Head:          sinceStart(t.Head),
+		},
+	})
+}
+
+func invalidBlockToStruct(b *cache.InvalidBlock) *structs.InvalidBlock {
+	return &structs.InvalidBlock{
+		BlockRoot:          hexutil.Encode(b.Root[:]),
+		ParentRoot:         hexutil.Encode(b.ParentRoot[:]),
+		Slot:               fmt.Sprintf("%d", b.Slot),
+		ProposerIndex:      fmt.Sprintf("%d", b.ProposerIndex),
+		PeerId:             b.Peer,
+		Stage:              string(b.Stage),
+		Error:              b.Error,
+		Time:               b.Time.UTC().Format(time.RFC3339Nano),
+		RejectsDescendants: b.InvalidatesDescendants(),
+		HasBlock:           len(b.Block) > 0,
+		BlobSidecarCount:   fmt.Sprintf("%d", len(b.Blobs)),
+	}
+}
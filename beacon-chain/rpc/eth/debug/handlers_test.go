@@ -7,15 +7,19 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/prysmaticlabs/prysm/v5/api"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	blockchainmock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
 	dbtest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
 	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
 	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/testutil"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
@@ -514,3 +518,194 @@ func TestGetForkChoice(t *testing.T) {
 	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
 	require.Equal(t, "2", resp.FinalizedCheckpoint.Epoch)
 }
+
+func TestGetInvalidBlocks(t *testing.T) {
+	ctx := context.Background()
+	registry := cache.NewInvalidBlockRegistry(8)
+	now := time.Now()
+	blk := util.NewBeaconBlockDeneb()
+	blk.Block.Slot = 12
+	blk.Block.ProposerIndex = 3
+	enc, err := blk.MarshalSSZ()
+	require.NoError(t, err)
+	root := [32]byte{'a'}
+	require.NoError(t, registry.Add(ctx, &cache.InvalidBlock{
+		Root:          root,
+		ParentRoot:    [32]byte{'b'},
+		Slot:          12,
+		ProposerIndex: 3,
+		Peer:          "peer",
+		Stage:         cache.InvalidBlockStageExecution,
+		Error:         "invalid payload",
+		Time:          now,
+		Version:       version.Deneb,
+		Block:         enc,
+		Blobs:         [][]byte{{1, 2}},
+	}))
+	require.NoError(t, registry.Add(ctx, &cache.InvalidBlock{Root: [32]byte{'c'}, Stage: cache.InvalidBlockStageDataAvailability, Time: now.Add(time.Second)}))
+	s := &Server{InvalidBlocks: registry}
+
+	t.Run("list", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/invalid_blocks", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetInvalidBlocks(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetInvalidBlocksResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, "data_availability", resp.Data[0].Stage)
+		assert.Equal(t, false, resp.Data[0].RejectsDescendants)
+		b := resp.Data[1]
+		assert.Equal(t, hexutil.Encode(root[:]), b.BlockRoot)
+		assert.Equal(t, "12", b.Slot)
+		assert.Equal(t, "3", b.ProposerIndex)
+		assert.Equal(t, "peer", b.PeerId)
+		assert.Equal(t, "execution", b.Stage)
+		assert.Equal(t, "invalid payload", b.Error)
+		assert.Equal(t, true, b.RejectsDescendants)
+		assert.Equal(t, true, b.HasBlock)
+		assert.Equal(t, "1", b.BlobSidecarCount)
+	})
+	t.Run("json", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/invalid_blocks/{block_root}", nil)
+		request.SetPathValue("block_root", hexutil.Encode(root[:]))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetInvalidBlock(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetInvalidBlockResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "deneb", resp.Version)
+		assert.Equal(t, hexutil.Encode(enc), resp.Block)
+		assert.DeepEqual(t, []string{"0x0102"}, resp.BlobSidecars)
+		assert.Equal(t, "12", resp.Data.Slot)
+	})
+	t.Run("ssz", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/invalid_blocks/{block_root}", nil)
+		request.SetPathValue("block_root", hexutil.Encode(root[:]))
+		request.Header.Set("Accept", api.OctetStreamMediaType)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetInvalidBlock(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "deneb", writer.Header().Get(api.VersionHeader))
+		assert.DeepEqual(t, enc, writer.Body.Bytes())
+	})
+	t.Run("ssz blob sidecar", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/invalid_blocks/{block_root}?blob_index=0", nil)
+		request.SetPathValue("block_root", hexutil.Encode(root[:]))
+		request.Header.Set("Accept", api.OctetStreamMediaType)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetInvalidBlock(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.DeepEqual(t, []byte{1, 2}, writer.Body.Bytes())
+
+		request = httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/invalid_blocks/{block_root}?blob_index=1", nil)
+		request.SetPathValue("block_root", hexutil.Encode(root[:]))
+		request.Header.Set("Accept", api.OctetStreamMediaType)
+		writer = httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetInvalidBlock(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+	})
+	t.Run("not found", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/invalid_blocks/{block_root}", nil)
+		request.SetPathValue("block_root", hexutil.Encode(make([]byte, 32)))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetInvalidBlock(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+
+		// Only the root of the block is known.
+		request = httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/invalid_blocks/{block_root}", nil)
+		request.SetPathValue("block_root", hexutil.Encode(bytesutil.PadTo([]byte{'c'}, 32)))
+		request.Header.Set("Accept", api.OctetStreamMediaType)
+		writer = httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetInvalidBlock(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+	})
+}
+
+func TestGetBlockImport(t *testing.T) {
+	genesis := time.Unix(1606824023, 0)
+	blk := util.NewBeaconBlock()
+	blk.Block.Slot = 2
+	wsb, err := blocks.NewSignedBeaconBlock(blk)
+	require.NoError(t, err)
+	root, err := blk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	slotStart := genesis.Add(2 * time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second)
+
+	timings := cache.NewSlotTimingCache(4)
+	timings.SetBlockArrival(2, root, "peer", slotStart.Add(time.Second))
+	timings.AddImportStage(2, root, cache.ImportStagePreState, slotStart.Add(time.Second), slotStart.Add(1010*time.Millisecond))
+	timings.AddImportStage(2, root, cache.ImportStageExecution, slotStart.Add(1010*time.Millisecond), slotStart.Add(1200500*time.Microsecond))
+	timings.SetImported(2, root, slotStart.Add(1300*time.Millisecond))
+	s := &Server{
+		TimeFetcher:     &blockchainmock.ChainService{Genesis: genesis},
+		Blocker:         &testutil.MockBlocker{BlockToReturn: wsb},
+		SlotTimingCache: timings,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/block_import/2", nil)
+		request.SetPathValue("block_id", "2")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetBlockImport(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetBlockImportResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		d := resp.Data
+		assert.Equal(t, "2", d.Slot)
+		assert.Equal(t, hexutil.Encode(root[:]), d.BlockRoot)
+		assert.Equal(t, "1000", d.BlockArrival)
+		assert.Equal(t, "peer", d.BlockPeerId)
+		assert.Equal(t, "1300", d.Imported)
+		assert.Equal(t, "", d.Head)
+		require.Equal(t, 2, len(d.Stages))
+		assert.DeepEqual(t, &structs.BlockImportStage{Name: "pre_state", Start: "1000.000", Duration: "10.000"}, d.Stages[0])
+		assert.DeepEqual(t, &structs.BlockImportStage{Name: "execution", Start: "1010.000", Duration: "190.500"}, d.Stages[1])
+	})
+	t.Run("not recorded", func(t *testing.T) {
+		other := util.NewBeaconBlock()
+		other.Block.Slot = 3
+		otherWsb, err := blocks.NewSignedBeaconBlock(other)
+		require.NoError(t, err)
+		s := &Server{
+			TimeFetcher:     s.TimeFetcher,
+			Blocker:         &testutil.MockBlocker{BlockToReturn: otherWsb},
+			SlotTimingCache: timings,
+		}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/block_import/3", nil)
+		request.SetPathValue("block_id", "3")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetBlockImport(writer, request)
+		require.Equal(t, http.StatusNotFound, writer.Code)
+		require.StringContains(t, "No import timings found", writer.Body.String())
+	})
+	t.Run("disabled", func(t *testing.T) {
+		s := &Server{Blocker: &testutil.MockBlocker{BlockToReturn: wsb}}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/block_import/2", nil)
+		request.SetPathValue("block_id", "2")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetBlockImport(writer, request)
+		require.Equal(t, http.StatusNotFound, writer.Code)
+		require.StringContains(t, "not recorded by this node", writer.Body.String())
+	})
+}
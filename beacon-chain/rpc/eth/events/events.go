@@ -69,6 +69,8 @@ const (
 	LightClientFinalityUpdateTopic = "light_client_finality_update"
 	// LightClientOptimisticUpdateTopic represents a new light client optimistic update event topic.
 	LightClientOptimisticUpdateTopic = "light_client_optimistic_update"
+	// ExecutionClientStatusTopic represents a change of state of the execution client, such as when it starts syncing.
+	ExecutionClientStatusTopic = "execution_client_status"
 )
 
 var (
@@ -104,13 +106,14 @@ var opsFeedEventTopics = map[feed.EventType]string{
 }
 
 var stateFeedEventTopics = map[feed.EventType]string{
-	statefeed.NewHead:                     HeadTopic,
-	statefeed.FinalizedCheckpoint:         FinalizedCheckpointTopic,
-	statefeed.LightClientFinalityUpdate:   LightClientFinalityUpdateTopic,
-	statefeed.LightClientOptimisticUpdate: LightClientOptimisticUpdateTopic,
-	statefeed.Reorg:                       ChainReorgTopic,
-	statefeed.BlockProcessed:              BlockTopic,
-	statefeed.PayloadAttributes:           PayloadAttributesTopic,
+	statefeed.NewHead:                      HeadTopic,
+	statefeed.FinalizedCheckpoint:          FinalizedCheckpointTopic,
+	statefeed.LightClientFinalityUpdate:    LightClientFinalityUpdateTopic,
+	statefeed.LightClientOptimisticUpdate:  LightClientOptimisticUpdateTopic,
+	statefeed.Reorg:                        ChainReorgTopic,
+	statefeed.BlockProcessed:               BlockTopic,
+	statefeed.PayloadAttributes:            PayloadAttributesTopic,
+	statefeed.ExecutionClientStatusChanged: ExecutionClientStatusTopic,
 }
 
 var topicsForStateFeed = topicsForFeed(stateFeedEventTopics)
@@ -446,6 +449,8 @@ func topicForEvent(event *feed.Event) string {
 		return BlockTopic
 	case payloadattribute.EventData:
 		return PayloadAttributesTopic
+	case *statefeed.ExecutionClientStatusChangedData:
+		return ExecutionClientStatusTopic
 	default:
 		return InvalidTopic
 	}
@@ -593,6 +598,22 @@ func (s *Server) lazyReaderForEvent(ctx context.Context, event *feed.Event, topi
 			}
 			return jsonMarshalReader(eventName, blk)
 		}, nil
+	case *statefeed.ExecutionClientStatusChangedData:
+		ev := &structs.ExecutionClientStatusEvent{
+			Previous:     string(v.Previous),
+			State:        string(v.Status.State),
+			CurrentBlock: fmt.Sprintf("%d", v.Status.CurrentBlock),
+			HighestBlock: fmt.Sprintf("%d", v.Status.HighestBlock),
+		}
+		if v.Status.PeerCountKnown {
+			ev.PeerCount = fmt.Sprintf("%d", v.Status.PeerCount)
+		}
+		if v.Status.Err != nil {
+			ev.Error = v.Status.Err.Error()
+		}
+		return func() io.Reader {
+			return jsonMarshalReader(eventName, ev)
+		}, nil
 	default:
 		return nil, errors.Wrapf(errUnhandledEventData, "event data type %T unsupported", v)
 	}
@@ -17,6 +17,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/operation"
 	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
+	executiontypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/execution/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
@@ -366,6 +367,7 @@ func TestStreamEvents_OperationsEvents(t *testing.T) {
 			FinalizedCheckpointTopic,
 			ChainReorgTopic,
 			BlockTopic,
+			ExecutionClientStatusTopic,
 		})
 		require.NoError(t, err)
 		request := topics.testHttpRequest(testSync.ctx, t)
@@ -418,6 +420,17 @@ func TestStreamEvents_OperationsEvents(t *testing.T) {
 					ExecutionOptimistic: false,
 				},
 			},
+			{
+				Type: statefeed.ExecutionClientStatusChanged,
+				Data: &statefeed.ExecutionClientStatusChangedData{
+					Previous: executiontypes.ExecutionClientSynced,
+					Status: executiontypes.ExecutionClientStatus{
+						State:        executiontypes.ExecutionClientSyncing,
+						CurrentBlock: 100,
+						HighestBlock: 200,
+					},
+				},
+			},
 		}
 
 		go func() {
@@ -123,6 +123,7 @@ func (s *Server) GetHealth(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		httputil.HandleError(w, "Could not check optimistic status: "+err.Error(), http.StatusInternalServerError)
 	}
+	shared.SetExecutionClientStateHeader(w, s.ExecutionChainInfoFetcher)
 	if s.SyncChecker.Synced() && !optimistic {
 		return
 	}
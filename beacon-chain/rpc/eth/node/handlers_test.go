@@ -14,8 +14,10 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/api"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	executiontypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/execution/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
 	mockp2p "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/testing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/testutil"
@@ -127,6 +129,18 @@ func TestGetHealth(t *testing.T) {
 	writer.Body = &bytes.Buffer{}
 	s.GetHealth(writer, request)
 	assert.Equal(t, http.StatusPartialContent, writer.Code)
+	assert.Equal(t, "", writer.Header().Get(api.ExecutionClientStateHeader))
+
+	// The state of the execution client tells why the node is optimistic.
+	s.ExecutionChainInfoFetcher = &testutil.MockExecutionChainInfoFetcher{
+		ClientStatus: executiontypes.ExecutionClientStatus{State: executiontypes.ExecutionClientSyncing},
+	}
+	request = httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/node/health", nil)
+	writer = httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.GetHealth(writer, request)
+	assert.Equal(t, http.StatusPartialContent, writer.Code)
+	assert.Equal(t, "syncing", writer.Header().Get(api.ExecutionClientStateHeader))
 }
 
 func TestGetIdentity(t *testing.T) {
@@ -0,0 +1,17 @@
+package shared
+
+import (
+	"net/http"
+
+	"github.com/prysmaticlabs/prysm/v5/api"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/execution"
+)
+
+// SetExecutionClientStateHeader reports the state of the execution client in the response headers,
+// so that callers can tell whether the node is optimistic because the execution client is syncing or offline.
+func SetExecutionClientStateHeader(w http.ResponseWriter, f execution.ChainInfoFetcher) {
+	if f == nil {
+		return
+	}
+	w.Header().Set(api.ExecutionClientStateHeader, string(f.ExecutionClientStatus().State))
+}
@@ -917,6 +917,7 @@ func (s *Server) GetAttesterDuties(w http.ResponseWriter, r *http.Request) {
 		httputil.HandleError(w, "Could not check optimistic status: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
+	shared.SetExecutionClientStateHeader(w, s.ExecutionChainInfoFetcher)
 
 	response := &structs.GetAttesterDutiesResponse{
 		DependentRoot:       hexutil.Encode(dependentRoot),
@@ -1041,6 +1042,7 @@ func (s *Server) GetProposerDuties(w http.ResponseWriter, r *http.Request) {
 		httputil.HandleError(w, "Could not check optimistic status: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
+	shared.SetExecutionClientStateHeader(w, s.ExecutionChainInfoFetcher)
 	if !sortProposerDuties(w, duties) {
 		return
 	}
@@ -1182,6 +1184,7 @@ func (s *Server) GetSyncCommitteeDuties(w http.ResponseWriter, r *http.Request)
 		httputil.HandleError(w, "Could not check optimistic status: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
+	shared.SetExecutionClientStateHeader(w, s.ExecutionChainInfoFetcher)
 
 	resp := &structs.GetSyncCommitteeDutiesResponse{
 		Data:                duties,
@@ -0,0 +1,85 @@
+package beacon
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// GetBuilderBids returns the bids received from the relays for a recent proposal of the node, the local payload,
+// which payload was selected and why, and whether the relay revealed the payload of the selected bid.
+func (s *Server) GetBuilderBids(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "beacon.GetBuilderBids")
+	defer span.End()
+
+	_, slot, ok := shared.UintFromRoute(w, r, "slot")
+	if !ok {
+		return
+	}
+	record, ok := s.BuilderBids.Get(primitives.Slot(slot))
+	if !ok {
+		httputil.HandleError(w, fmt.Sprintf("No builder bids found for slot %d", slot), http.StatusNotFound)
+		return
+	}
+
+	startTime := slots.StartTime(uint64(s.TimeFetcher.GenesisTime().Unix()), record.Slot)
+	sinceStart := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return strconv.FormatInt(t.Sub(startTime).Milliseconds(), 10)
+	}
+	bids := make([]*structs.BuilderBidRecord, len(record.Bids))
+	for i, b := range record.Bids {
+		bids[i] = &structs.BuilderBidRecord{
+			Relay:    b.Relay,
+			Value:    b.Value,
+			Received: sinceStart(b.Received),
+			Latency:  strconv.FormatInt(b.Latency.Milliseconds(), 10),
+			Error:    b.Error,
+		}
+		if len(b.BuilderPubkey) > 0 {
+			bids[i].BuilderPubkey = hexutil.Encode(b.BuilderPubkey)
+		}
+		if len(b.BlockHash) > 0 {
+			bids[i].BlockHash = hexutil.Encode(b.BlockHash)
+		}
+	}
+	data := &structs.BuilderBids{
+		Slot:          strconv.FormatUint(slot, 10),
+		ProposerIndex: strconv.FormatUint(uint64(record.ProposerIndex), 10),
+		Bids:          bids,
+		WinningRelay:  record.WinningRelay,
+		LocalValue:    record.LocalValue,
+		Selected:      record.Selected,
+		Reason:        record.Reason,
+		Reveal:        payloadReveal(record.Reveal, sinceStart),
+	}
+	if len(record.LocalBlockHash) > 0 {
+		data.LocalBlockHash = hexutil.Encode(record.LocalBlockHash)
+	}
+	httputil.WriteJson(w, &structs.GetBuilderBidsResponse{Data: data})
+}
+
+func payloadReveal(reveal *cache.PayloadReveal, sinceStart func(time.Time) string) *structs.PayloadReveal {
+	if reveal == nil {
+		return nil
+	}
+	return &structs.PayloadReveal{
+		Relay:    reveal.Relay,
+		Revealed: reveal.Error == "",
+		Time:     sinceStart(reveal.Time),
+		Latency:  strconv.FormatInt(reveal.Latency.Milliseconds(), 10),
+		Error:    reveal.Error,
+	}
+}
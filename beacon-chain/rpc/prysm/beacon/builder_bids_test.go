@@ -0,0 +1,89 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+func TestGetBuilderBids(t *testing.T) {
+	ctx := context.Background()
+	genesis := time.Now().Add(-time.Hour)
+	slot := primitives.Slot(10)
+	start := slots.StartTime(uint64(genesis.Unix()), slot)
+	bidLog := cache.NewBuilderBidLog(32)
+	require.NoError(t, bidLog.AddBids(ctx, slot, []*cache.BuilderBid{
+		{Relay: "a.example.com", BuilderPubkey: []byte{1}, Value: "100", BlockHash: []byte{2}, Received: start.Add(300 * time.Millisecond), Latency: 250 * time.Millisecond},
+		{Relay: "b.example.com", Received: start.Add(time.Second), Latency: time.Second, Error: "timeout"},
+	}, "a.example.com"))
+	require.NoError(t, bidLog.SetSelection(ctx, slot, 7, "50", []byte{3}, cache.BuilderBidSelectionBuilder, "builder bid higher than local value"))
+	require.NoError(t, bidLog.SetReveal(ctx, slot, &cache.PayloadReveal{Relay: "a.example.com", Time: start.Add(4 * time.Second), Latency: 400 * time.Millisecond}))
+
+	server := &Server{
+		TimeFetcher: &mock.ChainService{Genesis: genesis},
+		BuilderBids: bidLog,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/prysm/v1/builder/bids/{slot}", nil)
+		request.SetPathValue("slot", "10")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.GetBuilderBids(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetBuilderBidsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		d := resp.Data
+		assert.Equal(t, "10", d.Slot)
+		assert.Equal(t, "7", d.ProposerIndex)
+		require.Equal(t, 2, len(d.Bids))
+		assert.DeepEqual(t, &structs.BuilderBidRecord{
+			Relay:         "a.example.com",
+			BuilderPubkey: "0x01",
+			Value:         "100",
+			BlockHash:     "0x02",
+			Received:      "300",
+			Latency:       "250",
+		}, d.Bids[0])
+		assert.Equal(t, "timeout", d.Bids[1].Error)
+		assert.Equal(t, "", d.Bids[1].BlockHash)
+		assert.Equal(t, "a.example.com", d.WinningRelay)
+		assert.Equal(t, "50", d.LocalValue)
+		assert.Equal(t, "0x03", d.LocalBlockHash)
+		assert.Equal(t, cache.BuilderBidSelectionBuilder, d.Selected)
+		assert.Equal(t, "builder bid higher than local value", d.Reason)
+		assert.DeepEqual(t, &structs.PayloadReveal{Relay: "a.example.com", Revealed: true, Time: "4000", Latency: "400"}, d.Reveal)
+	})
+	t.Run("not found", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/prysm/v1/builder/bids/{slot}", nil)
+		request.SetPathValue("slot", "11")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.GetBuilderBids(writer, request)
+		require.Equal(t, http.StatusNotFound, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "No builder bids found for slot 11", e.Message)
+	})
+	t.Run("invalid slot", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/prysm/v1/builder/bids/{slot}", nil)
+		request.SetPathValue("slot", "foo")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.GetBuilderBids(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
@@ -0,0 +1,224 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/core"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+const (
+	// Number of epochs summarized when the epochs query parameter is not provided.
+	defaultChainHealthEpochs = 4
+	// Participation rates require replaying a state per epoch, which bounds the number of epochs summarized.
+	maxChainHealthEpochs = 16
+)
+
+// GetChainHealth summarizes the health of the chain over the most recent epochs: the slots without a canonical block
+// and their proposers, the participation rates, the distance to finality, the reorgs and orphaned blocks seen by the node,
+// and whether the head is optimistic.
+func (s *Server) GetChainHealth(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.GetChainHealth")
+	defer span.End()
+
+	rawEpochs, epochs, ok := shared.UintFromQuery(w, r, "epochs", false)
+	if !ok {
+		return
+	}
+	if rawEpochs == "" {
+		epochs = defaultChainHealthEpochs
+	}
+	if epochs == 0 || epochs > maxChainHealthEpochs {
+		httputil.HandleError(w, fmt.Sprintf("Number of epochs must be between 1 and %d", maxChainHealthEpochs), http.StatusBadRequest)
+		return
+	}
+
+	currentSlot := s.TimeFetcher.CurrentSlot()
+	currentEpoch := slots.ToEpoch(currentSlot)
+	startEpoch := primitives.Epoch(0)
+	if uint64(currentEpoch) >= epochs {
+		startEpoch = currentEpoch - primitives.Epoch(epochs) + 1
+	}
+	startSlot, err := slots.EpochStart(startEpoch)
+	if err != nil {
+		httputil.HandleError(w, "Could not get start slot: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	headRoot, err := s.HeadFetcher.HeadRoot(ctx)
+	if err != nil {
+		httputil.HandleError(w, "Could not get head root: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	optimistic, err := s.OptimisticModeFetcher.IsOptimistic(ctx)
+	if err != nil {
+		httputil.HandleError(w, "Could not check optimistic status: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	missed, orphaned, err := s.missedSlots(ctx, startSlot, currentSlot)
+	if err != nil {
+		httputil.HandleError(w, "Could not get missed slots: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	epochHealth, rpcError := s.epochHealth(ctx, startEpoch, currentEpoch, missed)
+	if rpcError != nil {
+		httputil.HandleError(w, rpcError.Err.Error(), core.ErrorReasonToHTTP(rpcError.Reason))
+		return
+	}
+
+	reorgs := s.ReorgCache.Since(startSlot)
+	var maxDepth uint64
+	chainReorgs := make([]*structs.ChainReorg, len(reorgs))
+	for i, reorg := range reorgs {
+		maxDepth = max(maxDepth, reorg.Depth)
+		chainReorgs[i] = &structs.ChainReorg{
+			Slot:         fmt.Sprintf("%d", reorg.Slot),
+			Depth:        fmt.Sprintf("%d", reorg.Depth),
+			Distance:     fmt.Sprintf("%d", reorg.Distance),
+			OldHeadBlock: hexutil.Encode(reorg.OldHeadRoot[:]),
+			NewHeadBlock: hexutil.Encode(reorg.NewHeadRoot[:]),
+			Time:         reorg.Time.UTC().Format(time.RFC3339Nano),
+		}
+	}
+
+	justified := s.FinalizationFetcher.CurrentJustifiedCheckpt()
+	finalized := s.FinalizationFetcher.FinalizedCheckpt()
+	var finalityDistance primitives.Epoch
+	if currentEpoch > finalized.Epoch {
+		finalityDistance = currentEpoch - finalized.Epoch
+	}
+	httputil.WriteJson(w, &structs.GetChainHealthResponse{
+		Data: &structs.ChainHealth{
+			CurrentSlot:         fmt.Sprintf("%d", currentSlot),
+			HeadSlot:            fmt.Sprintf("%d", s.HeadFetcher.HeadSlot()),
+			HeadBlockRoot:       hexutil.Encode(headRoot),
+			ExecutionOptimistic: optimistic,
+			JustifiedEpoch:      fmt.Sprintf("%d", justified.Epoch),
+			FinalizedEpoch:      fmt.Sprintf("%d", finalized.Epoch),
+			FinalityDistance:    fmt.Sprintf("%d", finalityDistance),
+			Epochs:              epochHealth,
+			ReorgCount:          fmt.Sprintf("%d", len(reorgs)),
+			MaxReorgDepth:       fmt.Sprintf("%d", maxDepth),
+			Reorgs:              chainReorgs,
+			OrphanedBlockCount:  fmt.Sprintf("%d", orphaned),
+		},
+	})
+}
+
+// missedSlots returns the slots in [start, end) without a canonical block, and the number of blocks of these
+// slots which are not canonical.
+func (s *Server) missedSlots(ctx context.Context, start, end primitives.Slot) ([]primitives.Slot, uint64, error) {
+	var missed []primitives.Slot
+	var orphaned uint64
+	for slot := max(start, 1); slot < end; slot++ {
+		_, roots, err := s.BeaconDB.BlockRootsBySlot(ctx, slot)
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "could not get block roots for slot %d", slot)
+		}
+		canonical := false
+		for _, root := range roots {
+			ok, err := s.ChainInfoFetcher.IsCanonical(ctx, root)
+			if err != nil {
+				return nil, 0, errors.Wrapf(err, "could not check if block %#x is canonical", root)
+			}
+			if ok {
+				canonical = true
+			} else {
+				orphaned++
+			}
+		}
+		if !canonical {
+			missed = append(missed, slot)
+		}
+	}
+	return missed, orphaned, nil
+}
+
+// epochHealth returns the missed slots and participation rates of the epochs in [start, current]. The participation
+// in an epoch is taken from the state at the end of the following epoch, so that late attestations are accounted for.
+// Only source and target votes are known for the current epoch.
+func (s *Server) epochHealth(
+	ctx context.Context,
+	start, current primitives.Epoch,
+	missed []primitives.Slot,
+) ([]*structs.EpochHealth, *core.RpcError) {
+	participation := make(map[primitives.Epoch]*ethpb.ValidatorParticipation)
+	getParticipation := func(e primitives.Epoch) (*ethpb.ValidatorParticipation, *core.RpcError) {
+		if p, ok := participation[e]; ok {
+			return p, nil
+		}
+		vp, rpcError := s.CoreService.ValidatorParticipation(ctx, e)
+		if rpcError != nil {
+			return nil, rpcError
+		}
+		participation[e] = vp.Participation
+		return vp.Participation, nil
+	}
+
+	var st state.BeaconState
+	health := make([]*structs.EpochHealth, 0, current-start+1)
+	for e := start; e <= current; e++ {
+		eh := &structs.EpochHealth{Epoch: fmt.Sprintf("%d", e), MissedSlots: make([]*structs.MissedSlot, 0)}
+		for _, slot := range missed {
+			if slots.ToEpoch(slot) != e {
+				continue
+			}
+			if st == nil || slots.ToEpoch(st.Slot()) != e {
+				epochStart, err := slots.EpochStart(e)
+				if err != nil {
+					return nil, &core.RpcError{Reason: core.Internal, Err: err}
+				}
+				st, err = s.Stater.StateBySlot(ctx, epochStart)
+				if err != nil {
+					return nil, &core.RpcError{Reason: core.Internal, Err: errors.Wrapf(err, "could not get state at slot %d", epochStart)}
+				}
+			}
+			proposer, err := helpers.BeaconProposerIndexAtSlot(ctx, st, slot)
+			if err != nil {
+				return nil, &core.RpcError{Reason: core.Internal, Err: errors.Wrapf(err, "could not get proposer of slot %d", slot)}
+			}
+			eh.MissedSlots = append(eh.MissedSlots, &structs.MissedSlot{
+				Slot:          fmt.Sprintf("%d", slot),
+				ProposerIndex: fmt.Sprintf("%d", proposer),
+			})
+		}
+		if e < current {
+			p, rpcError := getParticipation(e + 1)
+			if rpcError != nil {
+				return nil, rpcError
+			}
+			eh.SourceParticipationRate = participationRate(p.PreviousEpochAttestingGwei, p.PreviousEpochActiveGwei)
+			eh.TargetParticipationRate = participationRate(p.PreviousEpochTargetAttestingGwei, p.PreviousEpochActiveGwei)
+			eh.HeadParticipationRate = participationRate(p.PreviousEpochHeadAttestingGwei, p.PreviousEpochActiveGwei)
+		} else {
+			p, rpcError := getParticipation(e)
+			if rpcError != nil {
+				return nil, rpcError
+			}
+			eh.SourceParticipationRate = participationRate(p.CurrentEpochAttestingGwei, p.CurrentEpochActiveGwei)
+			eh.TargetParticipationRate = participationRate(p.CurrentEpochTargetAttestingGwei, p.CurrentEpochActiveGwei)
+		}
+		health = append(health, eh)
+	}
+	return health, nil
+}
+
+func participationRate(attesting, active uint64) string {
+	if active == 0 {
+		return fmt.Sprintf("%f", 0.0)
+	}
+	return fmt.Sprintf("%f", float64(attesting)/float64(active))
+}
@@ -0,0 +1,159 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	chainMock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	dbTest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/core"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/testutil"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestServer_GetChainHealth(t *testing.T) {
+	helpers.ClearCache()
+	ctx := context.Background()
+	beaconDB := dbTest.SetupDB(t)
+
+	validators := make([]*ethpb.Validator, 64)
+	balances := make([]uint64, len(validators))
+	for i := range validators {
+		validators[i] = &ethpb.Validator{
+			PublicKey:             bytesutil.ToBytes(uint64(i), 48),
+			WithdrawalCredentials: make([]byte, 32),
+			ExitEpoch:             params.BeaconConfig().FarFutureEpoch,
+			EffectiveBalance:      params.BeaconConfig().MaxEffectiveBalance,
+		}
+		balances[i] = params.BeaconConfig().MaxEffectiveBalance
+	}
+	genesisState, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, genesisState.SetValidators(validators))
+	require.NoError(t, genesisState.SetBalances(balances))
+	genesis := util.NewBeaconBlock()
+	util.SaveBlock(t, ctx, beaconDB, genesis)
+	gRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	gen := stategen.New(beaconDB, doublylinkedtree.New())
+	require.NoError(t, gen.SaveState(ctx, gRoot, genesisState))
+	require.NoError(t, beaconDB.SaveState(ctx, genesisState, gRoot))
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, gRoot))
+
+	currentSlot := primitives.Slot(4)
+	chain := &chainMock.ChainService{
+		Slot:                       &currentSlot,
+		State:                      genesisState,
+		Root:                       gRoot[:],
+		Optimistic:                 true,
+		FinalizedCheckPoint:        &ethpb.Checkpoint{Epoch: 0},
+		CurrentJustifiedCheckPoint: &ethpb.Checkpoint{Epoch: 0},
+	}
+	reorgs := cache.NewReorgCache(4)
+	reorgs.Add(cache.Reorg{Slot: 2, Depth: 1, Distance: 2, OldHeadRoot: [32]byte{'a'}, NewHeadRoot: gRoot})
+	s := &Server{
+		HeadFetcher:           chain,
+		TimeFetcher:           chain,
+		OptimisticModeFetcher: chain,
+		ChainInfoFetcher:      chain,
+		FinalizationFetcher:   chain,
+		BeaconDB:              beaconDB,
+		Stater:                &testutil.MockStater{StatesBySlot: map[primitives.Slot]state.BeaconState{0: genesisState}},
+		CoreService: &core.Service{
+			StateGen:           gen,
+			GenesisTimeFetcher: chain,
+			FinalizedFetcher:   chain,
+		},
+		ReorgCache: reorgs,
+	}
+	addDefaultReplayerBuilder(s, beaconDB)
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/beacon/chain_health?epochs=2", nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.GetChainHealth(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code, writer.Body.String())
+	resp := &structs.GetChainHealthResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	d := resp.Data
+	assert.Equal(t, "4", d.CurrentSlot)
+	assert.Equal(t, hexutil.Encode(gRoot[:]), d.HeadBlockRoot)
+	assert.Equal(t, true, d.ExecutionOptimistic)
+	assert.Equal(t, "0", d.FinalityDistance)
+	assert.Equal(t, "0", d.OrphanedBlockCount)
+	assert.Equal(t, "1", d.ReorgCount)
+	assert.Equal(t, "1", d.MaxReorgDepth)
+	require.Equal(t, 1, len(d.Reorgs))
+	assert.Equal(t, "2", d.Reorgs[0].Distance)
+
+	// Only the genesis epoch is covered.
+	require.Equal(t, 1, len(d.Epochs))
+	e := d.Epochs[0]
+	assert.Equal(t, "0", e.Epoch)
+	// The genesis slot and the current slot are never missed.
+	require.Equal(t, 3, len(e.MissedSlots))
+	assert.Equal(t, "1", e.MissedSlots[0].Slot)
+	assert.Equal(t, "3", e.MissedSlots[2].Slot)
+	proposer, err := helpers.BeaconProposerIndexAtSlot(ctx, genesisState, 3)
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("%d", proposer), e.MissedSlots[2].ProposerIndex)
+	// Attesting balances are at least one increment.
+	cfg := params.BeaconConfig()
+	assert.Equal(t, participationRate(cfg.EffectiveBalanceIncrement, 64*cfg.MaxEffectiveBalance), e.SourceParticipationRate)
+	// Head votes of the current epoch are not known yet.
+	assert.Equal(t, "", e.HeadParticipationRate)
+}
+
+func TestServer_MissedSlots(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbTest.SetupDB(t)
+	var roots [][32]byte
+	for _, slot := range []primitives.Slot{1, 2, 2, 4} {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = slot
+		b.Block.ProposerIndex = primitives.ValidatorIndex(len(roots))
+		util.SaveBlock(t, ctx, beaconDB, b)
+		root, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		roots = append(roots, root)
+	}
+	// One of the blocks of slot 2 is canonical, the block of slot 4 is orphaned.
+	s := &Server{
+		BeaconDB:         beaconDB,
+		ChainInfoFetcher: &chainMock.ChainService{CanonicalRoots: map[[32]byte]bool{roots[0]: true, roots[2]: true}},
+	}
+
+	missed, orphaned, err := s.missedSlots(ctx, 0, 6)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []primitives.Slot{3, 4, 5}, missed)
+	assert.Equal(t, uint64(2), orphaned)
+}
+
+func TestServer_GetChainHealth_InvalidEpochs(t *testing.T) {
+	s := &Server{}
+	for _, epochs := range []string{"0", "17", "foo"} {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/beacon/chain_health?epochs="+epochs, nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetChainHealth(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	}
+}
@@ -6,7 +6,9 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"sort"
 	"strconv"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/pkg/errors"
@@ -186,6 +188,59 @@ func (s *Server) GetChainHead(w http.ResponseWriter, r *http.Request) {
 	httputil.WriteJson(w, response)
 }
 
+// GetSlotTimings returns the times at which the blocks of a recent slot and their blob sidecars were received via gossip,
+// and when the blocks were processed by the execution engine, imported and became head.
+func (s *Server) GetSlotTimings(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "beacon.GetSlotTimings")
+	defer span.End()
+
+	_, slot, ok := shared.UintFromRoute(w, r, "slot")
+	if !ok {
+		return
+	}
+	if s.SlotTimingCache.Retention() == 0 {
+		httputil.HandleError(w, "Slot timings are not recorded by this node", http.StatusNotFound)
+		return
+	}
+	timings := s.SlotTimingCache.Timings(primitives.Slot(slot))
+	if len(timings) == 0 {
+		httputil.HandleError(w, fmt.Sprintf("No timings found for slot %d", slot), http.StatusNotFound)
+		return
+	}
+
+	startTime := slots.StartTime(uint64(s.TimeFetcher.GenesisTime().Unix()), primitives.Slot(slot))
+	sinceStart := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return strconv.FormatInt(t.Sub(startTime).Milliseconds(), 10)
+	}
+	data := make([]*structs.SlotTiming, len(timings))
+	for i, t := range timings {
+		indices := make([]uint64, 0, len(t.BlobArrivals))
+		for idx := range t.BlobArrivals {
+			indices = append(indices, idx)
+		}
+		sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+		blobs := make([]*structs.BlobArrival, len(indices))
+		for j, idx := range indices {
+			blobs[j] = &structs.BlobArrival{Index: strconv.FormatUint(idx, 10), Arrival: sinceStart(t.BlobArrivals[idx])}
+		}
+		data[i] = &structs.SlotTiming{
+			Slot:          strconv.FormatUint(slot, 10),
+			BlockRoot:     hexutil.Encode(t.BlockRoot[:]),
+			SlotStartTime: strconv.FormatInt(startTime.Unix(), 10),
+			BlockArrival:  sinceStart(t.BlockArrival),
+			BlockPeerId:   t.BlockPeer,
+			BlobArrivals:  blobs,
+			NewPayload:    sinceStart(t.NewPayload),
+			Imported:      sinceStart(t.Imported),
+			Head:          sinceStart(t.Head),
+		}
+	}
+	httputil.WriteJson(w, &structs.GetSlotTimingsResponse{Data: data})
+}
+
 func (s *Server) PublishBlobs(w http.ResponseWriter, r *http.Request) {
 	ctx, span := trace.StartSpan(r.Context(), "beacon.PublishBlobs")
 	defer span.End()
@@ -10,11 +10,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	chainMock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
 	dbTest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
 	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
@@ -1033,3 +1035,74 @@ func TestPublishBlobs(t *testing.T) {
 	assert.Equal(t, len(server.BlobReceiver.(*chainMock.ChainService).Blobs), 1)
 	assert.Equal(t, server.Broadcaster.(*mockp2p.MockBroadcaster).BroadcastCalled.Load(), true)
 }
+
+func TestGetSlotTimings(t *testing.T) {
+	genesis := time.Now().Add(-time.Hour)
+	slot := primitives.Slot(10)
+	start := slots.StartTime(uint64(genesis.Unix()), slot)
+	root := [32]byte{'a'}
+	timings := cache.NewSlotTimingCache(32)
+	timings.SetBlockArrival(slot, root, "peer", start.Add(1500*time.Millisecond))
+	timings.SetBlobArrival(slot, root, 2, start.Add(1700*time.Millisecond))
+	timings.SetBlobArrival(slot, root, 0, start.Add(1600*time.Millisecond))
+	timings.SetImported(slot, root, start.Add(2*time.Second))
+	timings.SetHead(slot, root, start.Add(2100*time.Millisecond))
+
+	server := &Server{
+		TimeFetcher:     &chainMock.ChainService{Genesis: genesis},
+		SlotTimingCache: timings,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/prysm/v1/beacon/slot_timings/{slot}", nil)
+		request.SetPathValue("slot", "10")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.GetSlotTimings(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetSlotTimingsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		st := resp.Data[0]
+		assert.Equal(t, "10", st.Slot)
+		assert.Equal(t, hexutil.Encode(root[:]), st.BlockRoot)
+		assert.Equal(t, fmt.Sprintf("%d", start.Unix()), st.SlotStartTime)
+		assert.Equal(t, "1500", st.BlockArrival)
+		assert.Equal(t, "peer", st.BlockPeerId)
+		require.Equal(t, 2, len(st.BlobArrivals))
+		assert.Equal(t, "0", st.BlobArrivals[0].Index)
+		assert.Equal(t, "1600", st.BlobArrivals[0].Arrival)
+		assert.Equal(t, "2", st.BlobArrivals[1].Index)
+		assert.Equal(t, "1700", st.BlobArrivals[1].Arrival)
+		assert.Equal(t, "", st.NewPayload)
+		assert.Equal(t, "2000", st.Imported)
+		assert.Equal(t, "2100", st.Head)
+	})
+	t.Run("unknown slot", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/prysm/v1/beacon/slot_timings/{slot}", nil)
+		request.SetPathValue("slot", "11")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.GetSlotTimings(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+		assert.StringContains(t, "No timings found for slot 11", writer.Body.String())
+	})
+	t.Run("invalid slot", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/prysm/v1/beacon/slot_timings/{slot}", nil)
+		request.SetPathValue("slot", "foo")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.GetSlotTimings(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		s := &Server{TimeFetcher: &chainMock.ChainService{Genesis: genesis}}
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/prysm/v1/beacon/slot_timings/{slot}", nil)
+		request.SetPathValue("slot", "10")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetSlotTimings(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+		assert.StringContains(t, "not recorded", writer.Body.String())
+	})
+}
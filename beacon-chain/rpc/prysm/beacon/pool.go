@@ -0,0 +1,581 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/prysm/v1alpha1/validator"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/features"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/attestation"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// GetPoolAttestations lists the aggregated and unaggregated attestations of the attestation pool, optionally
+// filtered by slot and committee index, along with their bit coverage and whether they would be included in the
+// next block. The valid attestations of the whole pool go through the selection of the proposer, which aggregates
+// them and packs the most profitable aggregates up to the block limit. An attestation is included when all of its
+// attesters are part of the selected aggregates.
+func (s *Server) GetPoolAttestations(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.GetPoolAttestations")
+	defer span.End()
+
+	rawSlot, slot, ok := shared.UintFromQuery(w, r, "slot", false)
+	if !ok {
+		return
+	}
+	rawCommitteeIndex, committeeIndex, ok := shared.UintFromQuery(w, r, "committee_index", false)
+	if !ok {
+		return
+	}
+	st, nextSlot, err := s.nextBlockState(ctx)
+	if err != nil {
+		httputil.HandleError(w, "Could not get state of the next block: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var atts []ethpb.Att
+	if features.Get().EnableExperimentalAttestationPool {
+		atts = s.AttestationCache.GetAll()
+	} else {
+		atts = s.AttestationsPool.AggregatedAttestations()
+		unaggregated, err := s.AttestationsPool.UnaggregatedAttestations()
+		if err != nil {
+			httputil.HandleError(w, "Could not get unaggregated attestations: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		atts = append(atts, unaggregated...)
+	}
+	inclusions, err := attestationInclusions(ctx, st, nextSlot, atts)
+	if err != nil {
+		httputil.HandleError(w, "Could not select attestations: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := make([]*structs.PoolAttestation, 0, len(atts))
+	for i, att := range atts {
+		if rawSlot != "" && att.GetData().Slot != primitives.Slot(slot) {
+			continue
+		}
+		if rawCommitteeIndex != "" && att.GetCommitteeIndex() != primitives.CommitteeIndex(committeeIndex) {
+			continue
+		}
+		enc, err := marshalAttestation(att)
+		if err != nil {
+			httputil.HandleError(w, "Could not marshal attestation: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		data = append(data, &structs.PoolAttestation{
+			Version:        version.String(att.Version()),
+			Aggregated:     att.IsAggregated(),
+			Slot:           fmt.Sprintf("%d", att.GetData().Slot),
+			CommitteeIndex: fmt.Sprintf("%d", att.GetCommitteeIndex()),
+			Participants:   fmt.Sprintf("%d", att.GetAggregationBits().Count()),
+			Bits:           fmt.Sprintf("%d", att.GetAggregationBits().Len()),
+			Attestation:    enc,
+			Inclusion:      inclusions[i],
+		})
+	}
+
+	httputil.WriteJson(w, &structs.GetPoolAttestationsResponse{
+		NextBlockSlot: fmt.Sprintf("%d", nextSlot),
+		Data:          data,
+	})
+}
+
+// GetPoolSyncCommittees lists the sync committee messages and contributions of the sync committee pool for a slot,
+// which defaults to the slot whose signatures are included in the next block. Only the contributions for the head
+// block are included, and of those only the ones which are part of the most profitable aggregate of their
+// subcommittee.
+func (s *Server) GetPoolSyncCommittees(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.GetPoolSyncCommittees")
+	defer span.End()
+
+	rawSlot, slot, ok := shared.UintFromQuery(w, r, "slot", false)
+	if !ok {
+		return
+	}
+	headRoot, err := s.HeadFetcher.HeadRoot(ctx)
+	if err != nil {
+		httputil.HandleError(w, "Could not get head root: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	nextSlot := s.nextBlockSlot()
+	// The sync aggregate of a block holds the signatures of the previous slot for its parent.
+	aggregateSlot := nextSlot - 1
+	if rawSlot == "" {
+		slot = uint64(aggregateSlot)
+	}
+
+	messages, err := s.SyncCommitteePool.SyncCommitteeMessages(primitives.Slot(slot))
+	if err != nil {
+		httputil.HandleError(w, "Could not get sync committee messages: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	contributions, err := s.SyncCommitteePool.SyncCommitteeContributions(primitives.Slot(slot))
+	if err != nil {
+		httputil.HandleError(w, "Could not get sync committee contributions: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	syncReason := func(sigSlot primitives.Slot, root []byte) string {
+		if sigSlot != aggregateSlot {
+			return fmt.Sprintf("the next block includes the sync committee signatures of slot %d", aggregateSlot)
+		}
+		if !bytes.Equal(root, headRoot) {
+			return "signature is not for the head block"
+		}
+		return ""
+	}
+
+	data := &structs.PoolSyncCommittee{
+		Messages:      make([]*structs.PoolSyncCommitteeMessage, 0, len(messages)),
+		Contributions: make([]*structs.PoolSyncCommitteeContribution, 0, len(contributions)),
+	}
+	for _, m := range messages {
+		reason := syncReason(m.Slot, m.BlockRoot)
+		if reason == "" {
+			reason = "sync committee messages are only included through the contributions of sync committee aggregators"
+		}
+		data.Messages = append(data.Messages, &structs.PoolSyncCommitteeMessage{
+			Message:   structs.SyncCommitteeMessageFromConsensus(m),
+			Inclusion: excluded(reason),
+		})
+	}
+
+	candidates := make([]*ethpb.SyncCommitteeContribution, 0, len(contributions))
+	for _, c := range contributions {
+		if syncReason(c.Slot, c.BlockRoot) == "" {
+			candidates = append(candidates, c)
+		}
+	}
+	best, err := bestContributionBits(candidates)
+	if err != nil {
+		httputil.HandleError(w, "Could not aggregate sync committee contributions: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	for _, c := range contributions {
+		inclusion := &structs.PoolInclusion{Included: true}
+		if reason := syncReason(c.Slot, c.BlockRoot); reason != "" {
+			inclusion = excluded(reason)
+		} else if covered, err := best[c.SubcommitteeIndex].Contains(c.AggregationBits); err != nil || !covered {
+			inclusion = excluded(fmt.Sprintf("not part of the most profitable aggregate of subcommittee %d", c.SubcommitteeIndex))
+		}
+		data.Contributions = append(data.Contributions, &structs.PoolSyncCommitteeContribution{
+			Contribution: structs.SyncCommitteeContributionFromConsensus(c),
+			Participants: fmt.Sprintf("%d", c.AggregationBits.Count()),
+			Bits:         fmt.Sprintf("%d", c.AggregationBits.Len()),
+			Inclusion:    inclusion,
+		})
+	}
+
+	httputil.WriteJson(w, &structs.GetPoolSyncCommitteesResponse{
+		NextBlockSlot: fmt.Sprintf("%d", nextSlot),
+		Data:          data,
+	})
+}
+
+// GetPoolVoluntaryExits lists the voluntary exits of the exit pool, optionally filtered by validator index, and
+// whether they would be included in the next block. Exits are included in pool order up to the block limit.
+func (s *Server) GetPoolVoluntaryExits(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.GetPoolVoluntaryExits")
+	defer span.End()
+
+	rawIndex, index, ok := shared.UintFromQuery(w, r, "validator_index", false)
+	if !ok {
+		return
+	}
+	st, nextSlot, err := s.nextBlockState(ctx)
+	if err != nil {
+		httputil.HandleError(w, "Could not get state of the next block: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	exits, err := s.VoluntaryExitsPool.PendingExits()
+	if err != nil {
+		httputil.HandleError(w, "Could not get exits from the pool: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	maxExits := params.BeaconConfig().MaxVoluntaryExits
+	included := uint64(0)
+	data := make([]*structs.PoolVoluntaryExit, 0, len(exits))
+	for _, exit := range exits {
+		var inclusion *structs.PoolInclusion
+		if exit.Exit.Epoch > slots.ToEpoch(nextSlot) {
+			inclusion = excluded(fmt.Sprintf("exit epoch %d is after the epoch of the next block", exit.Exit.Epoch))
+		} else if val, err := st.ValidatorAtIndexReadOnly(exit.Exit.ValidatorIndex); err != nil {
+			inclusion = excluded(err.Error())
+		} else if err := blocks.VerifyExitAndSignature(val, st, exit); err != nil {
+			inclusion = excluded(err.Error())
+		} else if included >= maxExits {
+			inclusion = excluded(fmt.Sprintf("block limit of %d voluntary exits is reached", maxExits))
+		} else {
+			inclusion = &structs.PoolInclusion{Included: true}
+			included++
+		}
+		if rawIndex != "" && exit.Exit.ValidatorIndex != primitives.ValidatorIndex(index) {
+			continue
+		}
+		data = append(data, &structs.PoolVoluntaryExit{
+			Exit:      structs.SignedExitFromConsensus(exit),
+			Inclusion: inclusion,
+		})
+	}
+
+	httputil.WriteJson(w, &structs.GetPoolVoluntaryExitsResponse{
+		NextBlockSlot: fmt.Sprintf("%d", nextSlot),
+		Data:          data,
+	})
+}
+
+// GetPoolSlashings lists the proposer and attester slashings of the slashing pool, and whether they would be
+// included in the next block. Slashings are included in pool order up to the block limits.
+func (s *Server) GetPoolSlashings(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.GetPoolSlashings")
+	defer span.End()
+
+	st, nextSlot, err := s.nextBlockState(ctx)
+	if err != nil {
+		httputil.HandleError(w, "Could not get state of the next block: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	proposerSlashings := s.SlashingsPool.PendingProposerSlashings(ctx, st, true /* return unlimited slashings */)
+	selectedProposerSlashings := make(map[*ethpb.ProposerSlashing]bool)
+	for _, sl := range s.SlashingsPool.PendingProposerSlashings(ctx, st, false) {
+		selectedProposerSlashings[sl] = true
+	}
+	attesterSlashings := s.SlashingsPool.PendingAttesterSlashings(ctx, st, true /* return unlimited slashings */)
+	selectedAttesterSlashings := make(map[ethpb.AttSlashing]bool)
+	for _, sl := range s.SlashingsPool.PendingAttesterSlashings(ctx, st, false) {
+		selectedAttesterSlashings[sl] = true
+	}
+	maxAttesterSlashings := params.BeaconConfig().MaxAttesterSlashings
+	if st.Version() >= version.Electra {
+		maxAttesterSlashings = params.BeaconConfig().MaxAttesterSlashingsElectra
+	}
+
+	data := &structs.PoolSlashings{
+		ProposerSlashings: make([]*structs.PoolProposerSlashing, 0, len(proposerSlashings)),
+		AttesterSlashings: make([]*structs.PoolAttesterSlashing, 0, len(attesterSlashings)),
+	}
+	for _, sl := range proposerSlashings {
+		inclusion := excluded(fmt.Sprintf("block limit of %d proposer slashings is reached", params.BeaconConfig().MaxProposerSlashings))
+		if selectedProposerSlashings[sl] {
+			inclusion = verificationInclusion(blocks.VerifyProposerSlashing(st, sl))
+		}
+		data.ProposerSlashings = append(data.ProposerSlashings, &structs.PoolProposerSlashing{
+			Slashing:  structs.ProposerSlashingFromConsensus(sl),
+			Inclusion: inclusion,
+		})
+	}
+	for _, sl := range attesterSlashings {
+		inclusion := excluded(fmt.Sprintf("block limit of %d attester slashings is reached", maxAttesterSlashings))
+		if selectedAttesterSlashings[sl] {
+			inclusion = verificationInclusion(blocks.VerifyAttesterSlashing(ctx, st, sl))
+		}
+		enc, err := marshalAttesterSlashing(sl)
+		if err != nil {
+			httputil.HandleError(w, "Could not marshal attester slashing: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		data.AttesterSlashings = append(data.AttesterSlashings, &structs.PoolAttesterSlashing{
+			Version:   version.String(sl.Version()),
+			Slashing:  enc,
+			Inclusion: inclusion,
+		})
+	}
+
+	httputil.WriteJson(w, &structs.GetPoolSlashingsResponse{
+		NextBlockSlot: fmt.Sprintf("%d", nextSlot),
+		Data:          data,
+	})
+}
+
+// GetPoolBLSToExecutionChanges lists the BLS to execution changes of the pool, optionally filtered by validator
+// index, and whether they would be included in the next block. The most recent changes are included first, up to
+// the block limit.
+func (s *Server) GetPoolBLSToExecutionChanges(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.GetPoolBLSToExecutionChanges")
+	defer span.End()
+
+	rawIndex, index, ok := shared.UintFromQuery(w, r, "validator_index", false)
+	if !ok {
+		return
+	}
+	st, nextSlot, err := s.nextBlockState(ctx)
+	if err != nil {
+		httputil.HandleError(w, "Could not get state of the next block: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	changes, err := s.BLSChangesPool.PendingBLSToExecChanges()
+	if err != nil {
+		httputil.HandleError(w, "Could not get BLS to execution changes from the pool: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	maxChanges := params.BeaconConfig().MaxBlsToExecutionChanges
+	included := uint64(0)
+	inclusions := make([]*structs.PoolInclusion, len(changes))
+	for i := len(changes) - 1; i >= 0; i-- {
+		if st.Version() < version.Capella {
+			inclusions[i] = excluded("BLS to execution changes are included from Capella")
+		} else if _, err := blocks.ValidateBLSToExecutionChange(st, changes[i]); err != nil {
+			inclusions[i] = excluded(err.Error())
+		} else if included >= maxChanges {
+			inclusions[i] = excluded(fmt.Sprintf("block limit of %d BLS to execution changes is reached", maxChanges))
+		} else {
+			inclusions[i] = &structs.PoolInclusion{Included: true}
+			included++
+		}
+	}
+	data := make([]*structs.PoolBLSToExecutionChange, 0, len(changes))
+	for i, change := range changes {
+		if rawIndex != "" && change.Message.ValidatorIndex != primitives.ValidatorIndex(index) {
+			continue
+		}
+		data = append(data, &structs.PoolBLSToExecutionChange{
+			Change:    structs.SignedBLSChangeFromConsensus(change),
+			Inclusion: inclusions[i],
+		})
+	}
+
+	httputil.WriteJson(w, &structs.GetPoolBLSToExecutionChangesResponse{
+		NextBlockSlot: fmt.Sprintf("%d", nextSlot),
+		Data:          data,
+	})
+}
+
+// nextBlockSlot is the slot of the next block to be proposed on top of the head, which is the current slot unless
+// its block is already the head.
+func (s *Server) nextBlockSlot() primitives.Slot {
+	nextSlot := s.TimeFetcher.CurrentSlot()
+	if headSlot := s.HeadFetcher.HeadSlot(); nextSlot <= headSlot {
+		nextSlot = headSlot + 1
+	}
+	return nextSlot
+}
+
+// nextBlockState returns the head state processed to the slot of the next block, against which the proposer
+// validates the operations of the pools.
+func (s *Server) nextBlockState(ctx context.Context) (state.BeaconState, primitives.Slot, error) {
+	headRoot, err := s.HeadFetcher.HeadRoot(ctx)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "could not get head root")
+	}
+	st, err := s.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "could not get head state")
+	}
+	nextSlot := s.nextBlockSlot()
+	if st.Slot() < nextSlot {
+		st, err = transition.ProcessSlotsUsingNextSlotCache(ctx, st, headRoot, nextSlot)
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "could not process slots up to %d", nextSlot)
+		}
+	}
+	return st, nextSlot, nil
+}
+
+// redundantAttestations tells which attestations have aggregation bits covered by another attestation with the
+// same data, which the proposer drops. Of identical attestations, only the first one is kept.
+func redundantAttestations(atts []ethpb.Att) ([]bool, error) {
+	redundant := make([]bool, len(atts))
+	byData := make(map[attestation.Id][]int, len(atts))
+	for i, att := range atts {
+		id, err := attestation.NewId(att, attestation.Data)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not create attestation ID")
+		}
+		byData[id] = append(byData[id], i)
+	}
+	for _, indices := range byData {
+		for _, i := range indices {
+			bits := atts[i].GetAggregationBits()
+			for _, j := range indices {
+				if i == j {
+					continue
+				}
+				other := atts[j].GetAggregationBits()
+				covered, err := other.Contains(bits)
+				if err != nil {
+					return nil, err
+				}
+				if covered && (j < i || other.Count() > bits.Count()) {
+					redundant[i] = true
+					break
+				}
+			}
+		}
+	}
+	return redundant, nil
+}
+
+// attestationInclusions tells whether each attestation would be included in the next block. The valid attestations
+// are selected the same way the proposer packs them, and an attestation is included when all of its attesters are
+// covered by the selected aggregates with the same data.
+func attestationInclusions(ctx context.Context, st state.BeaconState, nextSlot primitives.Slot, atts []ethpb.Att) ([]*structs.PoolInclusion, error) {
+	redundant, err := redundantAttestations(atts)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compare attestations")
+	}
+	postElectra := slots.ToEpoch(nextSlot) >= params.BeaconConfig().ElectraForkEpoch
+	inclusions := make([]*structs.PoolInclusion, len(atts))
+	candidates := make([]ethpb.Att, 0, len(atts))
+	for i, att := range atts {
+		switch {
+		case postElectra != (att.Version() >= version.Electra):
+			inclusions[i] = excluded(fmt.Sprintf("attestation is a %s attestation but the next block is a %s block", version.String(att.Version()), version.String(st.Version())))
+		case redundant[i]:
+			inclusions[i] = excluded("aggregation bits are covered by another attestation with the same data")
+		default:
+			if err := blocks.VerifyAttestationNoVerifySignature(ctx, st, att); err != nil {
+				inclusions[i] = excluded(err.Error())
+			} else {
+				candidates = append(candidates, att)
+			}
+		}
+	}
+
+	selected, err := validator.SelectAttestations(candidates, nextSlot)
+	if err != nil {
+		return nil, err
+	}
+	// Attesters of the selected aggregates, by attestation data root.
+	packed := make(map[[32]byte]map[uint64]bool, len(selected))
+	for _, att := range selected {
+		root, indices, err := attestingIndices(ctx, st, att)
+		if err != nil {
+			return nil, err
+		}
+		if packed[root] == nil {
+			packed[root] = make(map[uint64]bool, len(indices))
+		}
+		for _, idx := range indices {
+			packed[root][idx] = true
+		}
+	}
+	maxAtts := params.BeaconConfig().MaxAttestations
+	if postElectra {
+		maxAtts = params.BeaconConfig().MaxAttestationsElectra
+	}
+	for i, att := range atts {
+		if inclusions[i] != nil {
+			continue
+		}
+		root, indices, err := attestingIndices(ctx, st, att)
+		if err != nil {
+			return nil, err
+		}
+		inclusions[i] = &structs.PoolInclusion{Included: true}
+		for _, idx := range indices {
+			if !packed[root][idx] {
+				inclusions[i] = excluded(fmt.Sprintf("not part of the %d most profitable aggregates selected by the proposer", maxAtts))
+				break
+			}
+		}
+	}
+	return inclusions, nil
+}
+
+// attestingIndices returns the data root and the attesting indices of an attestation.
+func attestingIndices(ctx context.Context, st state.ReadOnlyBeaconState, att ethpb.Att) ([32]byte, []uint64, error) {
+	root, err := att.GetData().HashTreeRoot()
+	if err != nil {
+		return [32]byte{}, nil, errors.Wrap(err, "could not hash attestation data")
+	}
+	committees, err := helpers.AttestationCommittees(ctx, st, att)
+	if err != nil {
+		return [32]byte{}, nil, errors.Wrap(err, "could not get attestation committees")
+	}
+	indices, err := attestation.AttestingIndices(att, committees...)
+	if err != nil {
+		return [32]byte{}, nil, errors.Wrap(err, "could not get attesting indices")
+	}
+	return root, indices, nil
+}
+
+// bestContributionBits returns the aggregation bits of the most profitable aggregate of each subcommittee, merging
+// contributions without overlapping bits the same way the proposer aggregates them.
+func bestContributionBits(contributions []*ethpb.SyncCommitteeContribution) (map[uint64]bitfield.Bitvector128, error) {
+	aggregates := make(map[uint64][]bitfield.Bitvector128)
+	for _, c := range contributions {
+		merged := false
+		for i, agg := range aggregates[c.SubcommitteeIndex] {
+			overlaps, err := agg.Overlaps(c.AggregationBits)
+			if err != nil {
+				return nil, err
+			}
+			if overlaps {
+				continue
+			}
+			aggregates[c.SubcommitteeIndex][i], err = agg.Or(c.AggregationBits)
+			if err != nil {
+				return nil, err
+			}
+			merged = true
+			break
+		}
+		if !merged {
+			aggregates[c.SubcommitteeIndex] = append(aggregates[c.SubcommitteeIndex], bitfield.Bitvector128(bytes.Clone(c.AggregationBits)))
+		}
+	}
+	best := make(map[uint64]bitfield.Bitvector128, len(aggregates))
+	for subnet, aggs := range aggregates {
+		for _, agg := range aggs {
+			if b, ok := best[subnet]; !ok || agg.Count() > b.Count() {
+				best[subnet] = agg
+			}
+		}
+	}
+	return best, nil
+}
+
+func excluded(reason string) *structs.PoolInclusion {
+	return &structs.PoolInclusion{Reason: reason}
+}
+
+func verificationInclusion(err error) *structs.PoolInclusion {
+	if err != nil {
+		return excluded(err.Error())
+	}
+	return &structs.PoolInclusion{Included: true}
+}
+
+func marshalAttestation(att ethpb.Att) (json.RawMessage, error) {
+	switch a := att.(type) {
+	case *ethpb.Attestation:
+		return json.Marshal(structs.AttFromConsensus(a))
+	case *ethpb.AttestationElectra:
+		return json.Marshal(structs.AttElectraFromConsensus(a))
+	default:
+		return nil, fmt.Errorf("unsupported attestation type %T", att)
+	}
+}
+
+func marshalAttesterSlashing(sl ethpb.AttSlashing) (json.RawMessage, error) {
+	switch a := sl.(type) {
+	case *ethpb.AttesterSlashing:
+		return json.Marshal(structs.AttesterSlashingFromConsensus(a))
+	case *ethpb.AttesterSlashingElectra:
+		return json.Marshal(structs.AttesterSlashingElectraFromConsensus(a))
+	default:
+		return nil, fmt.Errorf("unsupported attester slashing type %T", sl)
+	}
+}
@@ -0,0 +1,440 @@
+package beacon
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	chainMock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/attestations"
+	blstoexecmock "github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/blstoexec/mock"
+	slashingsmock "github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/slashings/mock"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/synccommittee"
+	exitsmock "github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/voluntaryexits/mock"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/hash"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+// poolTestValidators returns active validators whose withdrawal credentials are BLS credentials of their public key.
+func poolTestValidators(n int) ([]*ethpb.Validator, []uint64) {
+	validators := make([]*ethpb.Validator, n)
+	balances := make([]uint64, n)
+	for i := range validators {
+		pubkey := bytesutil.ToBytes(uint64(i), 48)
+		creds := hash.Hash(pubkey)
+		creds[0] = params.BeaconConfig().BLSWithdrawalPrefixByte
+		validators[i] = &ethpb.Validator{
+			PublicKey:             pubkey,
+			WithdrawalCredentials: creds[:],
+			ExitEpoch:             params.BeaconConfig().FarFutureEpoch,
+			WithdrawableEpoch:     params.BeaconConfig().FarFutureEpoch,
+			EffectiveBalance:      params.BeaconConfig().MaxEffectiveBalance,
+		}
+		balances[i] = params.BeaconConfig().MaxEffectiveBalance
+	}
+	return validators, balances
+}
+
+func poolTestState(t *testing.T, slot primitives.Slot) state.BeaconState {
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	validators, balances := poolTestValidators(64)
+	require.NoError(t, st.SetValidators(validators))
+	require.NoError(t, st.SetBalances(balances))
+	require.NoError(t, st.SetSlot(slot))
+	return st
+}
+
+func TestServer_GetPoolAttestations(t *testing.T) {
+	helpers.ClearCache()
+	// With 64 validators there is a single committee of 2 validators per slot.
+	headSlot := primitives.Slot(33)
+	st := poolTestState(t, headSlot)
+
+	data := &ethpb.AttestationData{
+		Slot:            32,
+		BeaconBlockRoot: make([]byte, 32),
+		Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+		Target:          &ethpb.Checkpoint{Epoch: 1, Root: make([]byte, 32)},
+	}
+	aggregated := &ethpb.Attestation{AggregationBits: bitfield.Bitlist{0b111}, Data: data, Signature: make([]byte, 96)}
+	covered := &ethpb.Attestation{AggregationBits: bitfield.Bitlist{0b101}, Data: data, Signature: make([]byte, 96)}
+	expired := util.HydrateAttestation(&ethpb.Attestation{AggregationBits: bitfield.Bitlist{0b110}})
+
+	pool := attestations.NewPool()
+	require.NoError(t, pool.SaveAggregatedAttestation(aggregated))
+	require.NoError(t, pool.SaveUnaggregatedAttestations([]ethpb.Att{covered, expired}))
+	chain := &chainMock.ChainService{State: st, Slot: &headSlot}
+	s := &Server{
+		HeadFetcher:      chain,
+		TimeFetcher:      chain,
+		AttestationsPool: pool,
+	}
+
+	t.Run("all", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/beacon/pool/attestations", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetPoolAttestations(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetPoolAttestationsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "34", resp.NextBlockSlot)
+		require.Equal(t, 3, len(resp.Data))
+
+		bySlotAndBits := make(map[string]*structs.PoolAttestation)
+		for _, a := range resp.Data {
+			bySlotAndBits[a.Slot+"/"+a.Participants] = a
+		}
+		a, ok := bySlotAndBits["32/2"]
+		require.Equal(t, true, ok)
+		assert.Equal(t, true, a.Aggregated)
+		assert.Equal(t, "2", a.Bits)
+		assert.Equal(t, true, a.Inclusion.Included)
+		a, ok = bySlotAndBits["32/1"]
+		require.Equal(t, true, ok)
+		assert.Equal(t, false, a.Inclusion.Included)
+		assert.Equal(t, "aggregation bits are covered by another attestation with the same data", a.Inclusion.Reason)
+		a, ok = bySlotAndBits["0/1"]
+		require.Equal(t, true, ok)
+		assert.Equal(t, false, a.Inclusion.Included)
+		assert.NotEqual(t, "", a.Inclusion.Reason)
+		att := &structs.Attestation{}
+		require.NoError(t, json.Unmarshal(a.Attestation, att))
+		assert.Equal(t, "0x06", att.AggregationBits)
+	})
+	t.Run("filtered by slot", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/beacon/pool/attestations?slot=0", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetPoolAttestations(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetPoolAttestationsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, "0", resp.Data[0].Slot)
+	})
+	t.Run("invalid committee index", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/beacon/pool/attestations?committee_index=foo", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetPoolAttestations(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
+
+func TestServer_GetPoolAttestations_BlockLimit(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.MaxAttestations = 1
+	params.OverrideBeaconConfig(cfg)
+	helpers.ClearCache()
+	headSlot := primitives.Slot(33)
+	st := poolTestState(t, headSlot)
+
+	older := &ethpb.Attestation{
+		AggregationBits: bitfield.Bitlist{0b111},
+		Data: &ethpb.AttestationData{
+			Slot:            32,
+			BeaconBlockRoot: make([]byte, 32),
+			Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+			Target:          &ethpb.Checkpoint{Epoch: 1, Root: make([]byte, 32)},
+		},
+		Signature: make([]byte, 96),
+	}
+	newer := &ethpb.Attestation{
+		AggregationBits: bitfield.Bitlist{0b111},
+		Data: &ethpb.AttestationData{
+			Slot:            33,
+			BeaconBlockRoot: make([]byte, 32),
+			Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+			Target:          &ethpb.Checkpoint{Epoch: 1, Root: make([]byte, 32)},
+		},
+		Signature: make([]byte, 96),
+	}
+	pool := attestations.NewPool()
+	require.NoError(t, pool.SaveAggregatedAttestations([]ethpb.Att{older, newer}))
+	chain := &chainMock.ChainService{State: st, Slot: &headSlot}
+	s := &Server{
+		HeadFetcher:      chain,
+		TimeFetcher:      chain,
+		AttestationsPool: pool,
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/beacon/pool/attestations", nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.GetPoolAttestations(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &structs.GetPoolAttestationsResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.Equal(t, 2, len(resp.Data))
+	// The proposer packs the attestations of the most recent slots first.
+	for _, a := range resp.Data {
+		if a.Slot == "33" {
+			assert.Equal(t, true, a.Inclusion.Included)
+		} else {
+			assert.Equal(t, false, a.Inclusion.Included)
+			assert.Equal(t, "not part of the 1 most profitable aggregates selected by the proposer", a.Inclusion.Reason)
+		}
+	}
+}
+
+func TestServer_GetPoolSyncCommittees(t *testing.T) {
+	headSlot := primitives.Slot(33)
+	headRoot := bytesutil.PadTo([]byte("head"), 32)
+	otherRoot := bytesutil.PadTo([]byte("other"), 32)
+	chain := &chainMock.ChainService{State: poolTestState(t, headSlot), Root: headRoot, Slot: &headSlot}
+
+	pool := synccommittee.NewStore()
+	require.NoError(t, pool.SaveSyncCommitteeMessage(&ethpb.SyncCommitteeMessage{
+		Slot: headSlot, BlockRoot: headRoot, ValidatorIndex: 1, Signature: make([]byte, 96),
+	}))
+	contribution := func(subcommittee uint64, root []byte, indices ...uint64) *ethpb.SyncCommitteeContribution {
+		bits := ethpb.NewSyncCommitteeAggregationBits()
+		for _, i := range indices {
+			bits.SetBitAt(i, true)
+		}
+		return &ethpb.SyncCommitteeContribution{
+			Slot:              headSlot,
+			BlockRoot:         root,
+			SubcommitteeIndex: subcommittee,
+			AggregationBits:   bits,
+			Signature:         make([]byte, 96),
+		}
+	}
+	contributions := []*ethpb.SyncCommitteeContribution{
+		// The first two contributions are aggregated together, which covers the third one.
+		contribution(0, headRoot, 0, 1),
+		contribution(0, headRoot, 2),
+		contribution(0, headRoot, 0, 2),
+		contribution(0, otherRoot, 3),
+		// The second contribution is more profitable than the first one, which it does not cover.
+		contribution(1, headRoot, 0, 1),
+		contribution(1, headRoot, 1, 2, 3),
+	}
+	for _, c := range contributions {
+		require.NoError(t, pool.SaveSyncCommitteeContribution(c))
+	}
+	s := &Server{
+		HeadFetcher:       chain,
+		TimeFetcher:       chain,
+		SyncCommitteePool: pool,
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/beacon/pool/sync_committees", nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.GetPoolSyncCommittees(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &structs.GetPoolSyncCommitteesResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.Equal(t, "34", resp.NextBlockSlot)
+	require.Equal(t, 1, len(resp.Data.Messages))
+	assert.Equal(t, "1", resp.Data.Messages[0].Message.ValidatorIndex)
+	assert.Equal(t, false, resp.Data.Messages[0].Inclusion.Included)
+	require.Equal(t, len(contributions), len(resp.Data.Contributions))
+	included := []bool{true, true, true, false, false, true}
+	for i, c := range resp.Data.Contributions {
+		assert.Equal(t, included[i], c.Inclusion.Included, "contribution %d", i)
+	}
+	assert.Equal(t, "signature is not for the head block", resp.Data.Contributions[3].Inclusion.Reason)
+	assert.Equal(t, "not part of the most profitable aggregate of subcommittee 1", resp.Data.Contributions[4].Inclusion.Reason)
+	assert.Equal(t, "3", resp.Data.Contributions[5].Participants)
+
+	t.Run("other slot", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/beacon/pool/sync_committees?slot=32", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetPoolSyncCommittees(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetPoolSyncCommitteesResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, 0, len(resp.Data.Messages))
+		assert.Equal(t, 0, len(resp.Data.Contributions))
+	})
+}
+
+func TestServer_GetPoolVoluntaryExits(t *testing.T) {
+	helpers.ClearCache()
+	headSlot := primitives.Slot(33)
+	chain := &chainMock.ChainService{State: poolTestState(t, headSlot), Slot: &headSlot}
+	exits := []*ethpb.SignedVoluntaryExit{
+		{Exit: &ethpb.VoluntaryExit{Epoch: 5, ValidatorIndex: 1}, Signature: make([]byte, 96)},
+		{Exit: &ethpb.VoluntaryExit{Epoch: 0, ValidatorIndex: 1000}, Signature: make([]byte, 96)},
+	}
+	s := &Server{
+		HeadFetcher:        chain,
+		TimeFetcher:        chain,
+		VoluntaryExitsPool: &exitsmock.PoolMock{Exits: exits},
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/beacon/pool/voluntary_exits", nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.GetPoolVoluntaryExits(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &structs.GetPoolVoluntaryExitsResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.Equal(t, 2, len(resp.Data))
+	assert.Equal(t, false, resp.Data[0].Inclusion.Included)
+	assert.Equal(t, "exit epoch 5 is after the epoch of the next block", resp.Data[0].Inclusion.Reason)
+	assert.Equal(t, false, resp.Data[1].Inclusion.Included)
+	assert.NotEqual(t, "", resp.Data[1].Inclusion.Reason)
+
+	t.Run("filtered by validator index", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/beacon/pool/voluntary_exits?validator_index=1000", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetPoolVoluntaryExits(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetPoolVoluntaryExitsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, "1000", resp.Data[0].Exit.Message.ValidatorIndex)
+	})
+}
+
+func TestServer_GetPoolSlashings(t *testing.T) {
+	helpers.ClearCache()
+	headSlot := primitives.Slot(33)
+	chain := &chainMock.ChainService{State: poolTestState(t, headSlot), Slot: &headSlot}
+	proposerSlashing := &ethpb.ProposerSlashing{
+		Header_1: util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{Header: &ethpb.BeaconBlockHeader{Slot: 1}}),
+		Header_2: util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{Header: &ethpb.BeaconBlockHeader{Slot: 2}}),
+	}
+	// The attestations of the attester slashing are identical, which is not slashable.
+	attesterSlashing := &ethpb.AttesterSlashing{
+		Attestation_1: util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{1}}),
+		Attestation_2: util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{1}}),
+	}
+	s := &Server{
+		HeadFetcher: chain,
+		TimeFetcher: chain,
+		SlashingsPool: &slashingsmock.PoolMock{
+			PendingPropSlashings: []*ethpb.ProposerSlashing{proposerSlashing},
+			PendingAttSlashings:  []ethpb.AttSlashing{attesterSlashing},
+		},
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/beacon/pool/slashings", nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.GetPoolSlashings(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &structs.GetPoolSlashingsResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.Equal(t, 1, len(resp.Data.ProposerSlashings))
+	assert.Equal(t, "1", resp.Data.ProposerSlashings[0].Slashing.SignedHeader1.Message.Slot)
+	assert.Equal(t, false, resp.Data.ProposerSlashings[0].Inclusion.Included)
+	assert.Equal(t, "mismatched header slots, received 1 == 2", resp.Data.ProposerSlashings[0].Inclusion.Reason)
+	require.Equal(t, 1, len(resp.Data.AttesterSlashings))
+	assert.Equal(t, "phase0", resp.Data.AttesterSlashings[0].Version)
+	assert.Equal(t, false, resp.Data.AttesterSlashings[0].Inclusion.Included)
+	assert.NotEqual(t, "", resp.Data.AttesterSlashings[0].Inclusion.Reason)
+	sl := &structs.AttesterSlashing{}
+	require.NoError(t, json.Unmarshal(resp.Data.AttesterSlashings[0].Slashing, sl))
+	assert.DeepEqual(t, []string{"1"}, sl.Attestation1.AttestingIndices)
+}
+
+func TestServer_GetPoolBLSToExecutionChanges(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.MaxBlsToExecutionChanges = 1
+	params.OverrideBeaconConfig(cfg)
+
+	headSlot := primitives.Slot(33)
+	change := func(index primitives.ValidatorIndex) *ethpb.SignedBLSToExecutionChange {
+		return &ethpb.SignedBLSToExecutionChange{
+			Message: &ethpb.BLSToExecutionChange{
+				ValidatorIndex:     index,
+				FromBlsPubkey:      bytesutil.ToBytes(uint64(index), 48),
+				ToExecutionAddress: make([]byte, 20),
+			},
+			Signature: make([]byte, 96),
+		}
+	}
+	changes := []*ethpb.SignedBLSToExecutionChange{change(0), change(1), change(2)}
+	// The public key of the last change does not match the withdrawal credentials.
+	changes[2].Message.FromBlsPubkey = make([]byte, 48)
+
+	t.Run("phase0", func(t *testing.T) {
+		chain := &chainMock.ChainService{State: poolTestState(t, headSlot), Slot: &headSlot}
+		s := &Server{
+			HeadFetcher:    chain,
+			TimeFetcher:    chain,
+			BLSChangesPool: &blstoexecmock.PoolMock{Changes: changes},
+		}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/beacon/pool/bls_to_execution_changes", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetPoolBLSToExecutionChanges(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetPoolBLSToExecutionChangesResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 3, len(resp.Data))
+		for _, c := range resp.Data {
+			assert.Equal(t, "BLS to execution changes are included from Capella", c.Inclusion.Reason)
+		}
+	})
+	t.Run("capella", func(t *testing.T) {
+		st, err := util.NewBeaconStateCapella()
+		require.NoError(t, err)
+		validators, balances := poolTestValidators(64)
+		require.NoError(t, st.SetValidators(validators))
+		require.NoError(t, st.SetBalances(balances))
+		require.NoError(t, st.SetSlot(headSlot))
+		chain := &chainMock.ChainService{State: st, Slot: &headSlot}
+		s := &Server{
+			HeadFetcher:    chain,
+			TimeFetcher:    chain,
+			BLSChangesPool: &blstoexecmock.PoolMock{Changes: changes},
+		}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/beacon/pool/bls_to_execution_changes", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetPoolBLSToExecutionChanges(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetPoolBLSToExecutionChangesResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 3, len(resp.Data))
+		// The most recent valid change takes the only spot of the block.
+		assert.Equal(t, "block limit of 1 BLS to execution changes is reached", resp.Data[0].Inclusion.Reason)
+		assert.Equal(t, true, resp.Data[1].Inclusion.Included)
+		assert.Equal(t, false, resp.Data[2].Inclusion.Included)
+		assert.NotEqual(t, "", resp.Data[2].Inclusion.Reason)
+
+		request = httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/beacon/pool/bls_to_execution_changes?validator_index=1", nil)
+		writer = httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetPoolBLSToExecutionChanges(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp = &structs.GetPoolBLSToExecutionChangesResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, "1", resp.Data[0].Change.Message.ValidatorIndex)
+	})
+}
@@ -2,7 +2,13 @@ package beacon
 
 import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
 	beacondb "github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/attestations"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/blstoexec"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/slashings"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/synccommittee"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/voluntaryexits"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/core"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/lookup"
@@ -23,4 +29,13 @@ type Server struct {
 	CoreService           *core.Service
 	Broadcaster           p2p.Broadcaster
 	BlobReceiver          blockchain.BlobReceiver
+	SlotTimingCache       *cache.SlotTimingCache
+	BuilderBids           *cache.BuilderBidLog
+	ReorgCache            *cache.ReorgCache
+	AttestationCache      *cache.AttestationCache
+	AttestationsPool      attestations.Pool
+	SyncCommitteePool     synccommittee.Pool
+	SlashingsPool         slashings.PoolManager
+	VoluntaryExitsPool    voluntaryexits.PoolManager
+	BLSChangesPool        blstoexec.PoolManager
 }
@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	corenet "github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -13,6 +15,10 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/peerdata"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/backfill"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
 	"github.com/prysmaticlabs/prysm/v5/network/httputil"
 	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
@@ -129,6 +135,307 @@ func (s *Server) RemoveTrustedPeer(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
+// ListPeerBans retrieves all active peer bans, both those added at runtime and those loaded from the ban file.
+func (s *Server) ListPeerBans(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.ListPeerBans")
+	defer span.End()
+
+	bans := s.PeersFetcher.Peers().Bans()
+	resp := &structs.PeerBansResponse{Bans: make([]*structs.PeerBan, len(bans))}
+	for i, b := range bans {
+		ban := &structs.PeerBan{
+			Agent:     b.Agent,
+			Reason:    b.Reason,
+			Source:    string(b.Source),
+			CreatedAt: b.Created.UTC().Format(time.RFC3339),
+		}
+		if b.PeerID != "" {
+			ban.PeerId = b.PeerID.String()
+		}
+		if !b.Expiry.IsZero() {
+			ban.ExpiresAt = b.Expiry.UTC().Format(time.RFC3339)
+		}
+		resp.Bans[i] = ban
+	}
+	httputil.WriteJson(w, resp)
+}
+
+// AddPeerBan bans a peer ID, or all peers whose agent version matches a regular expression,
+// and disconnects from any connected peer matching the ban.
+func (s *Server) AddPeerBan(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.AddPeerBan")
+	defer span.End()
+
+	var req structs.AddPeerBanRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	switch {
+	case errors.Is(err, io.EOF):
+		httputil.HandleError(w, "No data submitted", http.StatusBadRequest)
+		return
+	case err != nil:
+		httputil.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var expiry time.Time
+	if req.Duration != "" {
+		duration, ok := shared.ValidateUint(w, "Duration", req.Duration)
+		if !ok {
+			return
+		}
+		if duration != 0 {
+			expiry = time.Now().Add(time.Duration(duration) * time.Second)
+		}
+	}
+
+	var ban *peers.Ban
+	switch {
+	case req.PeerId != "" && req.Agent != "":
+		httputil.HandleError(w, "Only one of peer_id and agent can be specified", http.StatusBadRequest)
+		return
+	case req.PeerId != "":
+		pid, err := peer.Decode(req.PeerId)
+		if err != nil {
+			httputil.HandleError(w, "Could not decode peer id: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		ban, err = peers.NewPeerBan(pid, req.Reason, expiry)
+		if err != nil {
+			httputil.HandleError(w, "Invalid ban: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	case req.Agent != "":
+		ban, err = peers.NewAgentBan(req.Agent, req.Reason, expiry)
+		if err != nil {
+			httputil.HandleError(w, "Invalid ban: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		httputil.HandleError(w, "One of peer_id and agent must be specified", http.StatusBadRequest)
+		return
+	}
+
+	if err := s.PeersFetcher.Peers().AddBan(ban); err != nil {
+		httputil.HandleError(w, "Could not add ban: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.PeerManager.DisconnectBannedPeers()
+	w.WriteHeader(http.StatusOK)
+}
+
+// RemovePeerBan lifts a runtime ban, identified by either the `peer_id` or the `agent` query parameter.
+// Bans loaded from the ban file can only be lifted by editing the file.
+func (s *Server) RemovePeerBan(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.RemovePeerBan")
+	defer span.End()
+
+	rawId := r.URL.Query().Get("peer_id")
+	agent := r.URL.Query().Get("agent")
+	var err error
+	switch {
+	case rawId != "" && agent != "":
+		httputil.HandleError(w, "Only one of peer_id and agent can be specified", http.StatusBadRequest)
+		return
+	case rawId != "":
+		pid, decodeErr := peer.Decode(rawId)
+		if decodeErr != nil {
+			httputil.HandleError(w, "Could not decode peer id: "+decodeErr.Error(), http.StatusBadRequest)
+			return
+		}
+		_, err = s.PeersFetcher.Peers().RemovePeerBan(pid)
+	case agent != "":
+		_, err = s.PeersFetcher.Peers().RemoveAgentBan(agent)
+	default:
+		httputil.HandleError(w, "One of peer_id and agent must be specified", http.StatusBadRequest)
+		return
+	}
+	// Removing a ban which does not exist is not an error.
+	if err != nil {
+		httputil.HandleError(w, "Could not remove ban: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// GetGossipStats retrieves the gossip messages which were rejected or ignored during validation,
+// aggregated per topic, reason and peer, along with recent examples of each failure.
+func (s *Server) GetGossipStats(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.GetGossipStats")
+	defer span.End()
+
+	stats := s.GossipStatsProvider.GossipStats()
+	data := &structs.GossipStats{
+		Since:    stats.Since.UTC().Format(time.RFC3339),
+		Failures: make([]*structs.GossipFailureStats, len(stats.Failures)),
+		Peers:    make([]*structs.GossipPeerStats, len(stats.Peers)),
+	}
+	for i, f := range stats.Failures {
+		data.Failures[i] = gossipFailureStats(f)
+	}
+	for i, p := range stats.Peers {
+		ps := &structs.GossipPeerStats{
+			PeerId:   p.PeerID.String(),
+			Agent:    p.Agent,
+			Rejected: strconv.FormatUint(p.Rejected, 10),
+			Ignored:  strconv.FormatUint(p.Ignored, 10),
+			Failures: make([]*structs.GossipFailureStats, len(p.Failures)),
+		}
+		for j, f := range p.Failures {
+			ps.Failures[j] = gossipFailureStats(f)
+		}
+		data.Peers[i] = ps
+	}
+	httputil.WriteJson(w, &structs.GossipStatsResponse{Data: data})
+}
+
+func gossipFailureStats(f sync.GossipFailureStats) *structs.GossipFailureStats {
+	stats := &structs.GossipFailureStats{
+		Topic:  f.Topic,
+		Result: f.Result,
+		Reason: f.Reason,
+		Count:  strconv.FormatUint(f.Count, 10),
+	}
+	for _, e := range f.Examples {
+		stats.Examples = append(stats.Examples, &structs.GossipFailureExample{
+			PeerId: e.PeerID.String(),
+			Agent:  e.Agent,
+			Error:  e.Error,
+			Time:   e.Time.UTC().Format(time.RFC3339),
+		})
+	}
+	return stats
+}
+
+// GetBackfillStatus retrieves the progress of backfill, along with the state of its workers.
+func (s *Server) GetBackfillStatus(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.GetBackfillStatus")
+	defer span.End()
+
+	p := s.BackfillController.Progress()
+	data := &structs.BackfillStatus{
+		Enabled:        p.Enabled,
+		Running:        p.Running,
+		Paused:         p.Paused,
+		Complete:       p.Complete,
+		LowSlot:        strconv.FormatUint(uint64(p.LowSlot), 10),
+		TargetSlot:     strconv.FormatUint(uint64(p.TargetSlot), 10),
+		RateLimit:      strconv.FormatUint(p.RateLimit, 10),
+		SlotsPerSecond: strconv.FormatFloat(p.SlotsPerSecond, 'f', 2, 64),
+		Workers:        make([]*structs.BackfillWorker, len(p.Workers)),
+	}
+	if p.ETA > 0 {
+		data.EtaSeconds = strconv.FormatInt(int64(p.ETA.Seconds()), 10)
+	}
+	for i, wk := range p.Workers {
+		bw := &structs.BackfillWorker{
+			Id:    strconv.Itoa(wk.ID),
+			State: wk.State,
+			Since: wk.Since.UTC().Format(time.RFC3339),
+		}
+		if wk.State != backfill.WorkerIdle {
+			bw.StartSlot = strconv.FormatUint(uint64(wk.Begin), 10)
+			bw.EndSlot = strconv.FormatUint(uint64(wk.End), 10)
+			bw.PeerId = wk.Peer.String()
+		}
+		data.Workers[i] = bw
+	}
+	httputil.WriteJson(w, &structs.BackfillStatusResponse{Data: data})
+}
+
+// PauseBackfill stops backfill from requesting new batches from peers. Batches being downloaded are completed.
+func (s *Server) PauseBackfill(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.PauseBackfill")
+	defer span.End()
+
+	s.BackfillController.Pause()
+	w.WriteHeader(http.StatusOK)
+}
+
+// ResumeBackfill lets a paused backfill request batches from peers again.
+func (s *Server) ResumeBackfill(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.ResumeBackfill")
+	defer span.End()
+
+	s.BackfillController.Resume()
+	w.WriteHeader(http.StatusOK)
+}
+
+// UpdateBackfill changes the target slot of backfill and the rate at which it requests slots from peers.
+// Fields which are not specified are left unchanged.
+func (s *Server) UpdateBackfill(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.UpdateBackfill")
+	defer span.End()
+
+	var req structs.UpdateBackfillRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	switch {
+	case errors.Is(err, io.EOF):
+		httputil.HandleError(w, "No data submitted", http.StatusBadRequest)
+		return
+	case err != nil:
+		httputil.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.TargetSlot == "" && req.RateLimit == "" {
+		httputil.HandleError(w, "One of target_slot and rate_limit must be specified", http.StatusBadRequest)
+		return
+	}
+
+	var rateLimit uint64
+	if req.RateLimit != "" {
+		var ok bool
+		rateLimit, ok = shared.ValidateUint(w, "RateLimit", req.RateLimit)
+		if !ok {
+			return
+		}
+	}
+	if req.TargetSlot != "" {
+		slot, ok := shared.ValidateUint(w, "TargetSlot", req.TargetSlot)
+		if !ok {
+			return
+		}
+		if err := s.BackfillController.SetMinimumSlot(primitives.Slot(slot)); err != nil {
+			httputil.HandleError(w, "Could not set target slot: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	if req.RateLimit != "" {
+		s.BackfillController.SetRateLimit(rateLimit)
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// GetExecutionStatus retrieves the sync status and peer count reported by the execution client, along with
+// whether the node is optimistic, to tell an execution client which is syncing or offline from a stuck beacon node.
+func (s *Server) GetExecutionStatus(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "node.GetExecutionStatus")
+	defer span.End()
+
+	optimistic, err := s.OptimisticModeFetcher.IsOptimistic(ctx)
+	if err != nil {
+		httputil.HandleError(w, "Could not check optimistic status: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	status := s.ExecutionChainInfoFetcher.ExecutionClientStatus()
+	data := &structs.ExecutionStatus{
+		Connected:           s.ExecutionChainInfoFetcher.ExecutionClientConnected(),
+		State:               string(status.State),
+		CurrentBlock:        strconv.FormatUint(status.CurrentBlock, 10),
+		HighestBlock:        strconv.FormatUint(status.HighestBlock, 10),
+		ExecutionOptimistic: optimistic,
+	}
+	if status.PeerCountKnown {
+		data.PeerCount = strconv.FormatUint(status.PeerCount, 10)
+	}
+	if !status.Updated.IsZero() {
+		data.Updated = status.Updated.UTC().Format(time.RFC3339)
+	}
+	if status.Err != nil {
+		data.Error = status.Err.Error()
+	}
+	httputil.WriteJson(w, &structs.ExecutionStatusResponse{Data: data})
+}
+
 // httpPeerInfo does the same thing as peerInfo function in node.go but returns the
 // http peer response.
 func httpPeerInfo(peerStatus *peers.Status, id peer.ID) (*structs.Peer, error) {
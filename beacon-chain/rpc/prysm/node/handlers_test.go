@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/p2p/enr"
@@ -14,10 +15,17 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 	libp2ptest "github.com/libp2p/go-libp2p/p2p/host/peerstore/test"
 	ma "github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	mockChain "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	executiontypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/execution/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
 	mockp2p "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/testutil"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/backfill"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/network/httputil"
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
@@ -249,3 +257,303 @@ func TestRemoveTrustedPeer_EmptyParameter(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, writer.Code)
 	assert.Equal(t, "Could not decode peer id: failed to parse peer ID: invalid cid: cid too short", e.Message)
 }
+
+func TestPeerBans(t *testing.T) {
+	peerFetcher := &mockp2p.MockPeersProvider{}
+	peerFetcher.ClearPeers()
+	s := Server{PeersFetcher: peerFetcher, PeerManager: &mockp2p.MockPeerManager{}}
+	id := "16Uiu2HAm1n583t4huDMMqEUUBuQs6bLts21mxCfX3tiqu9JfHvRJ"
+	pid, err := peer.Decode(id)
+	require.NoError(t, err)
+
+	addBan := func(t *testing.T, req *structs.AddPeerBanRequest) *httptest.ResponseRecorder {
+		reqJson, err := json.Marshal(req)
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com", bytes.NewReader(reqJson))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.AddPeerBan(writer, request)
+		return writer
+	}
+
+	t.Run("add", func(t *testing.T) {
+		writer := addBan(t, &structs.AddPeerBanRequest{PeerId: id, Reason: "spam", Duration: "3600"})
+		assert.Equal(t, http.StatusOK, writer.Code)
+		writer = addBan(t, &structs.AddPeerBanRequest{Agent: "^erigon", Reason: "bad release"})
+		assert.Equal(t, http.StatusOK, writer.Code)
+		require.ErrorIs(t, peerFetcher.Peers().IsBanned(pid), peers.ErrPeerBanned)
+	})
+	t.Run("list", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.ListPeerBans(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.PeerBansResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Bans))
+		assert.Equal(t, id, resp.Bans[0].PeerId)
+		assert.Equal(t, "spam", resp.Bans[0].Reason)
+		assert.Equal(t, "api", resp.Bans[0].Source)
+		assert.NotEqual(t, "", resp.Bans[0].ExpiresAt)
+		assert.Equal(t, "^erigon", resp.Bans[1].Agent)
+		assert.Equal(t, "", resp.Bans[1].ExpiresAt)
+	})
+	t.Run("remove", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodDelete, "http://example.com?peer_id="+id, nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.RemovePeerBan(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		require.NoError(t, peerFetcher.Peers().IsBanned(pid))
+
+		request = httptest.NewRequest(http.MethodDelete, "http://example.com?agent=%5Eerigon", nil)
+		writer = httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.RemovePeerBan(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, 0, len(peerFetcher.Peers().Bans()))
+	})
+	t.Run("no target", func(t *testing.T) {
+		writer := addBan(t, &structs.AddPeerBanRequest{Reason: "spam"})
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "One of peer_id and agent must be specified", e.Message)
+	})
+	t.Run("both targets", func(t *testing.T) {
+		writer := addBan(t, &structs.AddPeerBanRequest{PeerId: id, Agent: "^erigon"})
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("invalid agent pattern", func(t *testing.T) {
+		writer := addBan(t, &structs.AddPeerBanRequest{Agent: "("})
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "could not compile agent pattern", e.Message)
+	})
+	t.Run("invalid duration", func(t *testing.T) {
+		writer := addBan(t, &structs.AddPeerBanRequest{PeerId: id, Duration: "forever"})
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
+
+type mockGossipStats struct {
+	stats *sync.GossipStats
+}
+
+func (m *mockGossipStats) GossipStats() *sync.GossipStats {
+	return m.stats
+}
+
+func TestGetGossipStats(t *testing.T) {
+	pid, err := peer.Decode("16Uiu2HAm1n583t4huDMMqEUUBuQs6bLts21mxCfX3tiqu9JfHvRJ")
+	require.NoError(t, err)
+	now := time.Now()
+	failure := sync.GossipFailureStats{
+		Topic:  "/eth2/01020304/beacon_attestation_1/ssz_snappy",
+		Result: "reject",
+		Reason: "invalid_signature",
+		Count:  3,
+	}
+	withExamples := failure
+	withExamples.Examples = []sync.GossipFailureExample{{PeerID: pid, Agent: "Prysm/v5.0.0", Error: "signature did not verify", Time: now}}
+	s := Server{GossipStatsProvider: &mockGossipStats{stats: &sync.GossipStats{
+		Since:    now,
+		Failures: []sync.GossipFailureStats{withExamples},
+		Peers:    []sync.GossipPeerStats{{PeerID: pid, Agent: "Prysm/v5.0.0", Rejected: 3, Failures: []sync.GossipFailureStats{failure}}},
+	}}}
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.GetGossipStats(writer, request)
+	assert.Equal(t, http.StatusOK, writer.Code)
+	resp := &structs.GossipStatsResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.Equal(t, 1, len(resp.Data.Failures))
+	assert.Equal(t, failure.Topic, resp.Data.Failures[0].Topic)
+	assert.Equal(t, "reject", resp.Data.Failures[0].Result)
+	assert.Equal(t, "invalid_signature", resp.Data.Failures[0].Reason)
+	assert.Equal(t, "3", resp.Data.Failures[0].Count)
+	require.Equal(t, 1, len(resp.Data.Failures[0].Examples))
+	assert.Equal(t, pid.String(), resp.Data.Failures[0].Examples[0].PeerId)
+	assert.Equal(t, "signature did not verify", resp.Data.Failures[0].Examples[0].Error)
+	require.Equal(t, 1, len(resp.Data.Peers))
+	assert.Equal(t, pid.String(), resp.Data.Peers[0].PeerId)
+	assert.Equal(t, "3", resp.Data.Peers[0].Rejected)
+	assert.Equal(t, "0", resp.Data.Peers[0].Ignored)
+	require.Equal(t, 1, len(resp.Data.Peers[0].Failures))
+	assert.Equal(t, 0, len(resp.Data.Peers[0].Failures[0].Examples))
+}
+
+type mockBackfillController struct {
+	progress  *backfill.Progress
+	paused    bool
+	minimum   primitives.Slot
+	rateLimit uint64
+	err       error
+}
+
+func (m *mockBackfillController) Progress() *backfill.Progress {
+	return m.progress
+}
+
+func (m *mockBackfillController) Pause() {
+	m.paused = true
+}
+
+func (m *mockBackfillController) Resume() {
+	m.paused = false
+}
+
+func (m *mockBackfillController) SetMinimumSlot(slot primitives.Slot) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.minimum = slot
+	return nil
+}
+
+func (m *mockBackfillController) SetRateLimit(slotsPerSecond uint64) {
+	m.rateLimit = slotsPerSecond
+}
+
+func TestGetBackfillStatus(t *testing.T) {
+	pid, err := peer.Decode("16Uiu2HAm1n583t4huDMMqEUUBuQs6bLts21mxCfX3tiqu9JfHvRJ")
+	require.NoError(t, err)
+	now := time.Now()
+	s := Server{BackfillController: &mockBackfillController{progress: &backfill.Progress{
+		Enabled:        true,
+		Running:        true,
+		LowSlot:        1000,
+		TargetSlot:     100,
+		RateLimit:      64,
+		SlotsPerSecond: 12.5,
+		ETA:            72 * time.Second,
+		Workers: []backfill.WorkerStatus{
+			{ID: 0, State: backfill.WorkerDownloadingBlocks, Begin: 936, End: 1000, Peer: pid, Since: now},
+			{ID: 1, State: backfill.WorkerIdle, Since: now},
+		},
+	}}}
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.GetBackfillStatus(writer, request)
+	assert.Equal(t, http.StatusOK, writer.Code)
+	resp := &structs.BackfillStatusResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.Equal(t, true, resp.Data.Running)
+	assert.Equal(t, "1000", resp.Data.LowSlot)
+	assert.Equal(t, "100", resp.Data.TargetSlot)
+	assert.Equal(t, "64", resp.Data.RateLimit)
+	assert.Equal(t, "12.50", resp.Data.SlotsPerSecond)
+	assert.Equal(t, "72", resp.Data.EtaSeconds)
+	require.Equal(t, 2, len(resp.Data.Workers))
+	assert.Equal(t, "936", resp.Data.Workers[0].StartSlot)
+	assert.Equal(t, pid.String(), resp.Data.Workers[0].PeerId)
+	assert.Equal(t, "idle", resp.Data.Workers[1].State)
+	assert.Equal(t, "", resp.Data.Workers[1].StartSlot)
+}
+
+func TestPauseResumeBackfill(t *testing.T) {
+	ctrl := &mockBackfillController{}
+	s := Server{BackfillController: ctrl}
+	request := httptest.NewRequest(http.MethodPost, "http://example.com", nil)
+	writer := httptest.NewRecorder()
+	s.PauseBackfill(writer, request)
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, true, ctrl.paused)
+	writer = httptest.NewRecorder()
+	s.ResumeBackfill(writer, request)
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, false, ctrl.paused)
+}
+
+func TestUpdateBackfill(t *testing.T) {
+	update := func(s *Server, req interface{}) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		require.NoError(t, json.NewEncoder(&body).Encode(req))
+		request := httptest.NewRequest(http.MethodPost, "http://example.com", &body)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.UpdateBackfill(writer, request)
+		return writer
+	}
+
+	t.Run("target slot and rate limit", func(t *testing.T) {
+		ctrl := &mockBackfillController{rateLimit: 10}
+		writer := update(&Server{BackfillController: ctrl}, &structs.UpdateBackfillRequest{TargetSlot: "0", RateLimit: "128"})
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, primitives.Slot(0), ctrl.minimum)
+		assert.Equal(t, uint64(128), ctrl.rateLimit)
+	})
+	t.Run("rate limit only", func(t *testing.T) {
+		ctrl := &mockBackfillController{minimum: 50, rateLimit: 10}
+		writer := update(&Server{BackfillController: ctrl}, &structs.UpdateBackfillRequest{RateLimit: "0"})
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, primitives.Slot(50), ctrl.minimum)
+		assert.Equal(t, uint64(0), ctrl.rateLimit)
+	})
+	t.Run("nothing to update", func(t *testing.T) {
+		writer := update(&Server{BackfillController: &mockBackfillController{}}, &structs.UpdateBackfillRequest{})
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("invalid slot", func(t *testing.T) {
+		writer := update(&Server{BackfillController: &mockBackfillController{}}, &structs.UpdateBackfillRequest{TargetSlot: "genesis"})
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("target slot not saved", func(t *testing.T) {
+		ctrl := &mockBackfillController{err: errors.New("could not save backfill minimum slot"), rateLimit: 10}
+		writer := update(&Server{BackfillController: ctrl}, &structs.UpdateBackfillRequest{TargetSlot: "1", RateLimit: "20"})
+		assert.Equal(t, http.StatusInternalServerError, writer.Code)
+		assert.StringContains(t, "could not save backfill minimum slot", writer.Body.String())
+		assert.Equal(t, uint64(10), ctrl.rateLimit)
+	})
+}
+
+func TestGetExecutionStatus(t *testing.T) {
+	updated := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := Server{
+		OptimisticModeFetcher: &mockChain.ChainService{Optimistic: true},
+		ExecutionChainInfoFetcher: &testutil.MockExecutionChainInfoFetcher{ClientStatus: executiontypes.ExecutionClientStatus{
+			State:          executiontypes.ExecutionClientSyncing,
+			CurrentBlock:   100,
+			HighestBlock:   200,
+			PeerCount:      3,
+			PeerCountKnown: true,
+			Updated:        updated,
+		}},
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/node/execution_status", nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.GetExecutionStatus(writer, request)
+	assert.Equal(t, http.StatusOK, writer.Code)
+	resp := &structs.ExecutionStatusResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.Equal(t, true, resp.Data.Connected)
+	assert.Equal(t, "syncing", resp.Data.State)
+	assert.Equal(t, "100", resp.Data.CurrentBlock)
+	assert.Equal(t, "200", resp.Data.HighestBlock)
+	assert.Equal(t, "3", resp.Data.PeerCount)
+	assert.Equal(t, true, resp.Data.ExecutionOptimistic)
+	assert.Equal(t, "2024-01-01T00:00:00Z", resp.Data.Updated)
+	assert.Equal(t, "", resp.Data.Error)
+
+	s.ExecutionChainInfoFetcher = &testutil.MockExecutionChainInfoFetcher{ClientStatus: executiontypes.ExecutionClientStatus{
+		State: executiontypes.ExecutionClientOffline,
+		Err:   errors.New("connection refused"),
+	}}
+	writer = httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.GetExecutionStatus(writer, request)
+	assert.Equal(t, http.StatusOK, writer.Code)
+	resp = &structs.ExecutionStatusResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.Equal(t, "offline", resp.Data.State)
+	assert.Equal(t, "", resp.Data.PeerCount)
+	assert.Equal(t, "connection refused", resp.Data.Error)
+}
@@ -239,7 +239,13 @@ func (vs *Server) BuildBlockParallel(ctx context.Context, sBlk interfaces.Signed
 
 		// There's no reason to try to get a builder bid if local override is true.
 		var builderBid builderapi.Bid
-		if !(local.OverrideBuilder || skipMevBoost) {
+		var reason string
+		switch {
+		case local.OverrideBuilder:
+			reason = "local payload override requested by execution client"
+		case skipMevBoost:
+			reason = "builder skipped by validator"
+		default:
 			latestHeader, err := head.LatestExecutionPayloadHeader()
 			if err != nil {
 				return nil, status.Errorf(codes.Internal, "Could not get latest execution payload header: %v", err)
@@ -249,13 +255,19 @@ func (vs *Server) BuildBlockParallel(ctx context.Context, sBlk interfaces.Signed
 			if err != nil {
 				builderGetPayloadMissCount.Inc()
 				log.WithError(err).Error("Could not get builder payload")
+				reason = "could not get builder bid: " + err.Error()
 			}
 		}
 
-		winningBid, bundle, err = setExecutionData(ctx, sBlk, local, builderBid, builderBoostFactor)
+		var selectionReason string
+		winningBid, bundle, selectionReason, err = setExecutionData(ctx, sBlk, local, builderBid, builderBoostFactor)
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "Could not set execution data: %v", err)
 		}
+		if reason == "" {
+			reason = selectionReason
+		}
+		vs.logBidSelection(ctx, sBlk, local, reason)
 	}
 
 	wg.Wait()
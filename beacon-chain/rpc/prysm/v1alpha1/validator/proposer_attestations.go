@@ -46,6 +46,17 @@ func (vs *Server) packAttestations(ctx context.Context, latestState state.Beacon
 		atts = append(atts, uAtts...)
 	}
 
+	atts, err := SelectAttestations(atts, blkSlot)
+	if err != nil {
+		return nil, err
+	}
+	return vs.filterAttestationBySignature(ctx, atts, latestState)
+}
+
+// SelectAttestations selects the attestations packed by the proposer of the block at blkSlot: the attestations of
+// the fork of the block are aggregated, sorted by profitability and limited to the maximum number of attestations
+// per block. The attestations are expected to be valid for the block, and their signatures are not verified.
+func SelectAttestations(atts []ethpb.Att, blkSlot primitives.Slot) ([]ethpb.Att, error) {
 	// Checking the state's version here will give the wrong result if the last slot of Deneb is missed.
 	// The head state will still be in Deneb while we are trying to build an Electra block.
 	postElectra := slots.ToEpoch(blkSlot) >= params.BeaconConfig().ElectraForkEpoch
@@ -122,8 +133,7 @@ func (vs *Server) packAttestations(ctx context.Context, latestState state.Beacon
 		}
 	}
 
-	atts = sorted.limitToMaxAttestations()
-	return vs.filterAttestationBySignature(ctx, atts, latestState)
+	return sorted.limitToMaxAttestations(), nil
 }
 
 func onChainAggregates(attsById map[attestation.Id][]ethpb.Att) (proposerAtts, error) {
@@ -12,6 +12,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prysmaticlabs/prysm/v5/api/client/builder"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
@@ -54,28 +55,29 @@ const blockBuilderTimeout = 1 * time.Second
 const gasLimitAdjustmentFactor = 1024
 
 // Sets the execution data for the block. Execution data can come from local EL client or remote builder depends on validator registration and circuit breaker conditions.
-func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, local *blocks.GetPayloadResponse, bid builder.Bid, builderBoostFactor primitives.Gwei) (primitives.Wei, *enginev1.BlobsBundle, error) {
+// The reason of the choice between the local and the builder payloads is returned for the builder bid log.
+func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, local *blocks.GetPayloadResponse, bid builder.Bid, builderBoostFactor primitives.Gwei) (primitives.Wei, *enginev1.BlobsBundle, string, error) {
 	_, span := trace.StartSpan(ctx, "ProposerServer.setExecutionData")
 	defer span.End()
 
 	slot := blk.Block().Slot()
 	if slots.ToEpoch(slot) < params.BeaconConfig().BellatrixForkEpoch {
-		return primitives.ZeroWei(), nil, nil
+		return primitives.ZeroWei(), nil, "", nil
 	}
 
 	if local == nil {
-		return primitives.ZeroWei(), nil, errors.New("local payload is nil")
+		return primitives.ZeroWei(), nil, "", errors.New("local payload is nil")
 	}
 
 	// Use local payload if builder payload is nil.
 	if bid == nil {
-		return local.Bid, local.BlobsBundle, setLocalExecution(blk, local)
+		return local.Bid, local.BlobsBundle, "no builder bid", setLocalExecution(blk, local)
 	}
 
 	builderPayload, err := bid.Header()
 	if err != nil {
 		log.WithError(err).Warn("Proposer: failed to retrieve header from BuilderBid")
-		return local.Bid, local.BlobsBundle, setLocalExecution(blk, local)
+		return local.Bid, local.BlobsBundle, "could not get header from builder bid", setLocalExecution(blk, local)
 	}
 
 	switch {
@@ -84,7 +86,7 @@ func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, loc
 		if err != nil {
 			tracing.AnnotateError(span, err)
 			log.WithError(err).Warn("Proposer: failed to match withdrawals root")
-			return local.Bid, local.BlobsBundle, setLocalExecution(blk, local)
+			return local.Bid, local.BlobsBundle, "could not match withdrawals root", setLocalExecution(blk, local)
 		}
 
 		// Compare payload values between local and builder. Default to the local value if it is higher.
@@ -97,7 +99,7 @@ func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, loc
 				"minBuilderBid":    minBid,
 				"builderGweiValue": builderValueGwei,
 			}).Warn("Proposer: using local execution payload because min bid not attained")
-			return local.Bid, local.BlobsBundle, setLocalExecution(blk, local)
+			return local.Bid, local.BlobsBundle, "builder bid below minimum bid", setLocalExecution(blk, local)
 		}
 
 		// Use local block if min difference is not attained
@@ -108,7 +110,7 @@ func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, loc
 				"minBidDiff":       minDiff,
 				"builderGweiValue": builderValueGwei,
 			}).Warn("Proposer: using local execution payload because min difference with local value was not attained")
-			return local.Bid, local.BlobsBundle, setLocalExecution(blk, local)
+			return local.Bid, local.BlobsBundle, "builder bid below minimum difference with local value", setLocalExecution(blk, local)
 		}
 
 		// Use builder payload if the following in true:
@@ -133,7 +135,7 @@ func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, loc
 				bidDeneb, ok := bid.(builder.BidDeneb)
 				if !ok {
 					log.Warnf("bid type %T does not implement builder.BidDeneb", bid)
-					return local.Bid, local.BlobsBundle, setLocalExecution(blk, local)
+					return local.Bid, local.BlobsBundle, "builder bid does not implement deneb bid", setLocalExecution(blk, local)
 				} else {
 					builderKzgCommitments = bidDeneb.BlobKzgCommitments()
 				}
@@ -144,16 +146,16 @@ func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, loc
 				bidElectra, ok := bid.(builder.BidElectra)
 				if !ok {
 					log.Warnf("bid type %T does not implement builder.BidElectra", bid)
-					return local.Bid, local.BlobsBundle, setLocalExecution(blk, local)
+					return local.Bid, local.BlobsBundle, "builder bid does not implement electra bid", setLocalExecution(blk, local)
 				} else {
 					executionRequests = bidElectra.ExecutionRequests()
 				}
 			}
 			if err := setBuilderExecution(blk, builderPayload, builderKzgCommitments, executionRequests); err != nil {
 				log.WithError(err).Warn("Proposer: failed to set builder payload")
-				return local.Bid, local.BlobsBundle, setLocalExecution(blk, local)
+				return local.Bid, local.BlobsBundle, "could not set builder payload", setLocalExecution(blk, local)
 			} else {
-				return bid.Value(), nil, nil
+				return bid.Value(), nil, "builder bid higher than local value", nil
 			}
 		}
 		if !higherValueBuilder {
@@ -164,6 +166,10 @@ func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, loc
 				"builderBoostFactor":   builderBoostFactor,
 			}).Warn("Proposer: using local execution payload because higher value")
 		}
+		reason := "local value higher than builder bid"
+		if higherValueBuilder {
+			reason = "builder withdrawals root does not match local"
+		}
 		span.SetAttributes(
 			trace.BoolAttribute("higherValueBuilder", higherValueBuilder),
 			trace.Int64Attribute("localGweiValue", int64(localValueGwei)),         // lint:ignore uintcast -- This is OK for tracing.
@@ -171,17 +177,38 @@ func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, loc
 			trace.Int64Attribute("builderGweiValue", int64(builderValueGwei)),     // lint:ignore uintcast -- This is OK for tracing.
 			trace.Int64Attribute("builderBoostFactor", int64(builderBoostFactor)), // lint:ignore uintcast -- This is OK for tracing.
 		)
-		return local.Bid, local.BlobsBundle, setLocalExecution(blk, local)
+		return local.Bid, local.BlobsBundle, reason, setLocalExecution(blk, local)
 	default: // Bellatrix case.
 		if err := setBuilderExecution(blk, builderPayload, nil, nil); err != nil {
 			log.WithError(err).Warn("Proposer: failed to set builder payload")
-			return local.Bid, local.BlobsBundle, setLocalExecution(blk, local)
+			return local.Bid, local.BlobsBundle, "could not set builder payload", setLocalExecution(blk, local)
 		} else {
-			return bid.Value(), nil, nil
+			return bid.Value(), nil, "builder payload always used before capella", nil
 		}
 	}
 }
 
+// logBidSelection records the local payload of the block and the payload selected for it in the builder bid log,
+// when a builder is configured.
+func (vs *Server) logBidSelection(ctx context.Context, blk interfaces.ReadOnlySignedBeaconBlock, local *blocks.GetPayloadResponse, reason string) {
+	if vs.BuilderBids == nil || vs.BlockBuilder == nil || !vs.BlockBuilder.Configured() {
+		return
+	}
+	selected := cache.BuilderBidSelectionLocal
+	if blk.IsBlinded() {
+		selected = cache.BuilderBidSelectionBuilder
+	}
+	var localBlockHash []byte
+	if local.ExecutionData != nil && !local.ExecutionData.IsNil() {
+		localBlockHash = local.ExecutionData.BlockHash()
+	}
+	localValue := primitives.WeiToBigInt(local.Bid).String()
+	b := blk.Block()
+	if err := vs.BuilderBids.SetSelection(ctx, b.Slot(), b.ProposerIndex(), localValue, localBlockHash, selected, reason); err != nil {
+		log.WithError(err).Error("Could not record payload selection in the builder bid log")
+	}
+}
+
 // This function retrieves the payload header and kzg commitments given the slot number and the validator index.
 // It's a no-op if the latest head block is not versioned bellatrix.
 func (vs *Server) getPayloadHeaderFromBuilder(
@@ -210,16 +237,6 @@ func (vs *Server) getPayloadHeaderFromBuilder(
 		return nil, err
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, blockBuilderTimeout)
-	defer cancel()
-
-	signedBid, err := vs.BlockBuilder.GetHeader(ctx, slot, bytesutil.ToBytes32(h.BlockHash()), pk)
-	if err != nil {
-		return nil, err
-	}
-	if signedBid == nil || signedBid.IsNil() {
-		return nil, errors.New("builder returned nil bid")
-	}
 	fork, err := forks.Fork(slots.ToEpoch(slot))
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to get fork information")
@@ -228,59 +245,38 @@ func (vs *Server) getPayloadHeaderFromBuilder(
 	if !ok {
 		return nil, errors.New("unable to find current fork in schedule")
 	}
-	if !strings.EqualFold(version.String(signedBid.Version()), forkName) {
-		return nil, fmt.Errorf("builder bid response version: %d is different from head block version: %d for epoch %d", signedBid.Version(), b.Version(), slots.ToEpoch(slot))
-	}
-
-	bid, err := signedBid.Message()
-	if err != nil {
-		return nil, errors.Wrap(err, "could not get bid")
-	}
-	if bid == nil || bid.IsNil() {
-		return nil, errors.New("builder returned nil bid")
-	}
-
-	v := bid.Value()
-	if big.NewInt(0).Cmp(v) == 0 {
-		return nil, errors.New("builder returned header with 0 bid amount")
-	}
-
-	header, err := bid.Header()
-	if err != nil {
-		return nil, errors.Wrap(err, "could not get bid header")
-	}
-	txRoot, err := header.TransactionsRoot()
+	t, err := slots.ToTime(uint64(vs.TimeFetcher.GenesisTime().Unix()), slot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not get transaction root")
-	}
-	if bytesutil.ToBytes32(txRoot) == emptyTransactionsRoot {
-		return nil, errors.New("builder returned header with an empty tx root")
-	}
-
-	if !bytes.Equal(header.ParentHash(), h.BlockHash()) {
-		return nil, fmt.Errorf("incorrect parent hash %#x != %#x", header.ParentHash(), h.BlockHash())
+		return nil, err
 	}
-
+	var gasLimit *uint64
 	reg, err := vs.BlockBuilder.RegistrationByValidatorID(ctx, idx)
 	if err != nil {
 		log.WithError(err).Warn("Proposer: failed to get registration by validator ID, could not check gas limit")
 	} else {
-		gasLimit := expectedGasLimit(parentGasLimit, reg.GasLimit)
-		if gasLimit != header.GasLimit() {
-			return nil, fmt.Errorf("incorrect header gas limit %d != %d", gasLimit, header.GasLimit())
-		}
+		expected := expectedGasLimit(parentGasLimit, reg.GasLimit)
+		gasLimit = &expected
 	}
 
-	t, err := slots.ToTime(uint64(vs.TimeFetcher.GenesisTime().Unix()), slot)
+	ctx, cancel := context.WithTimeout(ctx, blockBuilderTimeout)
+	defer cancel()
+
+	// The bid of every relay is validated, for the highest valid bid to be returned.
+	validate := func(signedBid builder.SignedBid) error {
+		return validateBid(signedBid, forkName, b.Version(), h.BlockHash(), gasLimit, uint64(t.Unix()))
+	}
+	signedBid, err := vs.BlockBuilder.GetHeader(ctx, slot, bytesutil.ToBytes32(h.BlockHash()), pk, validate)
 	if err != nil {
 		return nil, err
 	}
-	if header.Timestamp() != uint64(t.Unix()) {
-		return nil, fmt.Errorf("incorrect timestamp %d != %d", header.Timestamp(), uint64(t.Unix()))
+	bid, err := signedBid.Message()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get bid")
 	}
-
-	if err := validateBuilderSignature(signedBid); err != nil {
-		return nil, errors.Wrap(err, "could not validate builder signature")
+	v := bid.Value()
+	header, err := bid.Header()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get bid header")
 	}
 
 	var kzgCommitments [][]byte
@@ -326,6 +322,59 @@ func (vs *Server) getPayloadHeaderFromBuilder(
 	return bid, nil
 }
 
+// validateBid returns an error if the bid can't be used for the block of the slot, whose fork is `forkName`, built on
+// top of the head block of version `headVersion` with execution block hash `parentHash`. The gas limit is not checked
+// if nil.
+func validateBid(signedBid builder.SignedBid, forkName string, headVersion int, parentHash []byte, gasLimit *uint64, timestamp uint64) error {
+	if signedBid == nil || signedBid.IsNil() {
+		return errors.New("builder returned nil bid")
+	}
+	if !strings.EqualFold(version.String(signedBid.Version()), forkName) {
+		return fmt.Errorf("builder bid response version: %d is different from head block version: %d", signedBid.Version(), headVersion)
+	}
+
+	bid, err := signedBid.Message()
+	if err != nil {
+		return errors.Wrap(err, "could not get bid")
+	}
+	if bid == nil || bid.IsNil() {
+		return errors.New("builder returned nil bid")
+	}
+
+	if big.NewInt(0).Cmp(bid.Value()) == 0 {
+		return errors.New("builder returned header with 0 bid amount")
+	}
+
+	header, err := bid.Header()
+	if err != nil {
+		return errors.Wrap(err, "could not get bid header")
+	}
+	txRoot, err := header.TransactionsRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not get transaction root")
+	}
+	if bytesutil.ToBytes32(txRoot) == emptyTransactionsRoot {
+		return errors.New("builder returned header with an empty tx root")
+	}
+
+	if !bytes.Equal(header.ParentHash(), parentHash) {
+		return fmt.Errorf("incorrect parent hash %#x != %#x", header.ParentHash(), parentHash)
+	}
+
+	if gasLimit != nil && *gasLimit != header.GasLimit() {
+		return fmt.Errorf("incorrect header gas limit %d != %d", *gasLimit, header.GasLimit())
+	}
+
+	if header.Timestamp() != timestamp {
+		return fmt.Errorf("incorrect timestamp %d != %d", header.Timestamp(), timestamp)
+	}
+
+	if err := validateBuilderSignature(signedBid); err != nil {
+		return errors.Wrap(err, "could not validate builder signature")
+	}
+	return nil
+}
+
 // Validates builder signature and returns an error if the signature is invalid.
 func validateBuilderSignature(signedBid builder.SignedBid) error {
 	d, err := signing.ComputeDomain(params.BeaconConfig().DomainApplicationBuilder,
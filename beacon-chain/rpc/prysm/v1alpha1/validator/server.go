@@ -76,6 +76,7 @@ type Server struct {
 	BeaconDB                db.HeadAccessDatabase
 	ExecutionEngineCaller   execution.EngineCaller
 	BlockBuilder            builder.BlockBuilder
+	BuilderBids             *cache.BuilderBidLog
 	BLSChangesPool          blstoexec.PoolManager
 	ClockWaiter             startup.ClockWaiter
 	CoreService             *core.Service
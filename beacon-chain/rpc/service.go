@@ -40,6 +40,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
 	chainSync "github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/backfill"
 	"github.com/prysmaticlabs/prysm/v5/config/features"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/io/logs"
@@ -102,6 +103,8 @@ type Config struct {
 	SyncCommitteeObjectPool   synccommittee.Pool
 	BLSChangesPool            blstoexec.PoolManager
 	SyncService               chainSync.Checker
+	GossipStatsProvider       chainSync.GossipStatsProvider
+	BackfillController        backfill.Controller
 	Broadcaster               p2p.Broadcaster
 	PeersFetcher              p2p.PeersProvider
 	PeerManager               p2p.PeerManager
@@ -116,11 +119,15 @@ type Config struct {
 	ExecutionEngineCaller     execution.EngineCaller
 	OptimisticModeFetcher     blockchain.OptimisticModeFetcher
 	BlockBuilder              builder.BlockBuilder
+	BuilderBids               *cache.BuilderBidLog
 	Router                    *http.ServeMux
 	ClockWaiter               startup.ClockWaiter
 	BlobStorage               *filesystem.BlobStorage
 	TrackedValidatorsCache    *cache.TrackedValidatorsCache
 	PayloadIDCache            *cache.PayloadIDCache
+	SlotTimingCache           *cache.SlotTimingCache
+	InvalidBlocks             *cache.InvalidBlockRegistry
+	ReorgCache                *cache.ReorgCache
 }
 
 // NewService instantiates a new RPC service instance that will
@@ -244,6 +251,7 @@ func NewService(ctx context.Context, cfg *Config) *Service {
 		ExecutionEngineCaller:   s.cfg.ExecutionEngineCaller,
 		BeaconDB:                s.cfg.BeaconDB,
 		BlockBuilder:            s.cfg.BlockBuilder,
+		BuilderBids:             s.cfg.BuilderBids,
 		BLSChangesPool:          s.cfg.BLSChangesPool,
 		ClockWaiter:             s.cfg.ClockWaiter,
 		CoreService:             coreService,
@@ -0,0 +1,282 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/verify"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz/detect"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrAPIFallbackNotFound is returned when none of the fallback beacon nodes has the requested data.
+	ErrAPIFallbackNotFound = errors.New("data not found on any fallback beacon node")
+	errAPIFallbackInvalid  = errors.New("invalid response from fallback beacon node")
+
+	apiFallbackBlocks = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "sync_api_fallback_blocks_total",
+		Help: "Number of blocks downloaded from the fallback beacon node apis because peers failed to serve them.",
+	})
+	apiFallbackBlobs = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "sync_api_fallback_blob_sidecars_total",
+		Help: "Number of blob sidecars downloaded from the fallback beacon node apis because peers failed to serve them.",
+	})
+)
+
+// APIFallback downloads blocks and blobs from trusted beacon node apis, once fetching them from peers has been failing
+// for longer than the stall period. The data goes through the same verification as data received from peers.
+// A nil *APIFallback is valid and never active.
+type APIFallback struct {
+	clients       []*beacon.Client
+	stallPeriod   time.Duration
+	lock          sync.Mutex
+	failingSince  time.Time
+	warned        bool
+	finalized     primitives.Epoch
+	finalizedTime time.Time
+}
+
+// NewAPIFallback creates an APIFallback using the beacon node apis at the given urls, which are tried in order.
+func NewAPIFallback(urls []string, stallPeriod time.Duration, opts ...client.ClientOpt) (*APIFallback, error) {
+	if len(urls) == 0 {
+		return nil, errors.New("no fallback beacon node api url provided")
+	}
+	f := &APIFallback{stallPeriod: stallPeriod}
+	for _, u := range urls {
+		c, err := beacon.NewClient(u, opts...)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid fallback beacon node api url %s", u)
+		}
+		f.clients = append(f.clients, c)
+	}
+	return f, nil
+}
+
+// PeerSuccess records that peers served a request, which deactivates the fallback.
+func (f *APIFallback) PeerSuccess() {
+	if f == nil {
+		return
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.warned {
+		log.Info("Peers are serving blocks again, no longer using the fallback beacon node api")
+	}
+	f.failingSince = time.Time{}
+	f.warned = false
+}
+
+// PeerFailure records that peers failed to serve a request, or that no suitable peers are available.
+func (f *APIFallback) PeerFailure() {
+	if f == nil {
+		return
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.failingSince.IsZero() {
+		f.failingSince = time.Now()
+	}
+}
+
+// Active is true when fetching from peers has been failing for at least the stall period.
+func (f *APIFallback) Active() bool {
+	if f == nil {
+		return false
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.failingSince.IsZero() || time.Since(f.failingSince) < f.stallPeriod {
+		return false
+	}
+	if !f.warned {
+		log.WithField("failingSince", f.failingSince).Warn("Peers failed to serve blocks, using the fallback beacon node api")
+		f.warned = true
+	}
+	return true
+}
+
+// FinalizedEpoch returns the highest finalized epoch of the fallback beacon nodes. The value is cached for a slot.
+func (f *APIFallback) FinalizedEpoch(ctx context.Context) (primitives.Epoch, error) {
+	f.lock.Lock()
+	if time.Since(f.finalizedTime) < time.Duration(params.BeaconConfig().SecondsPerSlot)*time.Second {
+		defer f.lock.Unlock()
+		return f.finalized, nil
+	}
+	f.lock.Unlock()
+
+	var highest primitives.Epoch
+	var found, invalid bool
+	for _, c := range f.clients {
+		fc, err := c.GetFinalityCheckpoints(ctx, beacon.IdHead)
+		if err != nil {
+			log.WithError(err).WithField("url", c.NodeURL()).Debug("Could not get finality checkpoints from fallback beacon node")
+			continue
+		}
+		if fc.Finalized == nil {
+			log.WithField("url", c.NodeURL()).Debug("Missing finalized checkpoint from fallback beacon node")
+			invalid = true
+			continue
+		}
+		epoch, err := strconv.ParseUint(fc.Finalized.Epoch, 10, 64)
+		if err != nil {
+			log.WithError(err).WithField("url", c.NodeURL()).Debug("Invalid finalized epoch from fallback beacon node")
+			invalid = true
+			continue
+		}
+		found = true
+		if primitives.Epoch(epoch) > highest {
+			highest = primitives.Epoch(epoch)
+		}
+	}
+	if !found {
+		if invalid {
+			return 0, errors.Wrap(errAPIFallbackInvalid, "no valid finalized checkpoint")
+		}
+		return 0, errors.Wrap(ErrAPIFallbackNotFound, "could not get finality checkpoints")
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.finalized, f.finalizedTime = highest, time.Now()
+	return highest, nil
+}
+
+// BlockByRoot downloads the block with the given root.
+func (f *APIFallback) BlockByRoot(ctx context.Context, root [32]byte) (blocks.ROBlock, error) {
+	b, err := f.block(ctx, beacon.IdFromRoot(root))
+	if err != nil {
+		return blocks.ROBlock{}, err
+	}
+	if b.Root() != root {
+		return blocks.ROBlock{}, errors.Wrapf(errAPIFallbackInvalid, "requested block root %#x, received %#x", root, b.Root())
+	}
+	return b, nil
+}
+
+// BlocksByRange downloads the canonical blocks of the fallback beacon nodes in [start, start+count).
+// Empty slots are skipped.
+func (f *APIFallback) BlocksByRange(ctx context.Context, start primitives.Slot, count uint64) ([]blocks.ROBlock, error) {
+	blks := make([]blocks.ROBlock, 0, count)
+	for slot := start; slot < start.Add(count); slot++ {
+		b, err := f.block(ctx, beacon.IdFromSlot(slot))
+		if errors.Is(err, ErrAPIFallbackNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if b.Block().Slot() != slot {
+			return nil, errors.Wrapf(errAPIFallbackInvalid, "requested block at slot %d, received slot %d", slot, b.Block().Slot())
+		}
+		blks = append(blks, b)
+	}
+	return blks, nil
+}
+
+func (f *APIFallback) block(ctx context.Context, id beacon.StateOrBlockId) (blocks.ROBlock, error) {
+	var lastErr error
+	for _, c := range f.clients {
+		enc, err := c.GetBlock(ctx, id)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		u, err := detect.FromBlock(enc)
+		if err != nil {
+			return blocks.ROBlock{}, errors.Wrap(errAPIFallbackInvalid, err.Error())
+		}
+		sb, err := u.UnmarshalBeaconBlock(enc)
+		if err != nil {
+			return blocks.ROBlock{}, errors.Wrap(errAPIFallbackInvalid, err.Error())
+		}
+		b, err := blocks.NewROBlock(sb)
+		if err != nil {
+			return blocks.ROBlock{}, err
+		}
+		apiFallbackBlocks.Inc()
+		return b, nil
+	}
+	if errors.Is(lastErr, client.ErrNotFound) {
+		return blocks.ROBlock{}, errors.Wrapf(ErrAPIFallbackNotFound, "block %s", id)
+	}
+	return blocks.ROBlock{}, errors.Wrapf(lastErr, "could not download block %s from fallback beacon nodes", id)
+}
+
+// BlobSidecars downloads the blob sidecars of the given block, checking that they match the block,
+// and returns them sorted by index.
+func (f *APIFallback) BlobSidecars(ctx context.Context, b blocks.ROBlock) ([]blocks.ROBlob, error) {
+	if b.Version() < version.Deneb {
+		return nil, nil
+	}
+	root := b.Root()
+	var lastErr error
+	for _, c := range f.clients {
+		enc, err := c.GetBlobSidecars(ctx, beacon.IdFromRoot(root))
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		blobs, err := unmarshalFallbackBlobs(enc, b)
+		if err != nil {
+			lastErr = err
+			log.WithError(err).WithFields(logrus.Fields{
+				"url":  c.NodeURL(),
+				"root": fmt.Sprintf("%#x", root),
+			}).Debug("Invalid blob sidecars from fallback beacon node")
+			continue
+		}
+		apiFallbackBlobs.Add(float64(len(blobs)))
+		return blobs, nil
+	}
+	if errors.Is(lastErr, client.ErrNotFound) {
+		return nil, errors.Wrapf(ErrAPIFallbackNotFound, "blob sidecars for block %#x", root)
+	}
+	return nil, errors.Wrapf(lastErr, "could not download blob sidecars for block %#x from fallback beacon nodes", root)
+}
+
+func unmarshalFallbackBlobs(enc []byte, b blocks.ROBlock) ([]blocks.ROBlob, error) {
+	commits, err := b.Block().Body().BlobKzgCommitments()
+	if err != nil {
+		return nil, err
+	}
+	if len(enc)%fieldparams.BlobSidecarSize != 0 {
+		return nil, errors.Wrapf(errAPIFallbackInvalid, "blob sidecars response size %d is not a multiple of %d", len(enc), fieldparams.BlobSidecarSize)
+	}
+	blobs := make([]blocks.ROBlob, 0, len(enc)/fieldparams.BlobSidecarSize)
+	for i := 0; i < len(enc); i += fieldparams.BlobSidecarSize {
+		sc := &ethpb.BlobSidecar{}
+		if err := sc.UnmarshalSSZ(enc[i : i+fieldparams.BlobSidecarSize]); err != nil {
+			return nil, errors.Wrap(errAPIFallbackInvalid, err.Error())
+		}
+		rb, err := blocks.NewROBlob(sc)
+		if err != nil {
+			return nil, err
+		}
+		if rb.Index >= uint64(len(commits)) {
+			return nil, errors.Wrapf(errAPIFallbackInvalid, "blob index %d exceeds the %d commitments of block %#x", rb.Index, len(commits), b.Root())
+		}
+		if err := verify.BlobAlignsWithBlock(rb, b); err != nil {
+			return nil, err
+		}
+		blobs = append(blobs, rb)
+	}
+	sort.Slice(blobs, func(i, j int) bool {
+		return blobs[i].Index < blobs[j].Index
+	})
+	return blobs, nil
+}
@@ -0,0 +1,193 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	gcache "github.com/patrickmn/go-cache"
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
+	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	dbtest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	p2ptest "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/rand"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+type apiFallbackRT func(*http.Request) (*http.Response, error)
+
+func (rt apiFallbackRT) RoundTrip(req *http.Request) (*http.Response, error) {
+	return rt(req)
+}
+
+// testAPIFallback returns an APIFallback serving the given ssz responses by url path, and 404 for other paths.
+func testAPIFallback(t *testing.T, served map[string][]byte, stallPeriod time.Duration) *APIFallback {
+	rt := apiFallbackRT(func(req *http.Request) (*http.Response, error) {
+		b, ok := served[req.URL.Path]
+		if !ok {
+			return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(bytes.NewBuffer(nil)), Request: req}, nil
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBuffer(b)), Request: req}, nil
+	})
+	c, err := beacon.NewClient("http://localhost:3500", client.WithRoundTripper(rt))
+	require.NoError(t, err)
+	return &APIFallback{clients: []*beacon.Client{c}, stallPeriod: stallPeriod}
+}
+
+func TestAPIFallback_Active(t *testing.T) {
+	var nilFallback *APIFallback
+	nilFallback.PeerFailure()
+	require.Equal(t, false, nilFallback.Active())
+
+	f := testAPIFallback(t, nil, time.Hour)
+	require.Equal(t, false, f.Active())
+	f.PeerFailure()
+	require.Equal(t, false, f.Active())
+	// The stall period counts from the first failure.
+	f.failingSince = time.Now().Add(-2 * time.Hour)
+	f.PeerFailure()
+	require.Equal(t, true, f.Active())
+	f.PeerSuccess()
+	require.Equal(t, false, f.Active())
+}
+
+func TestAPIFallback_FinalizedEpoch(t *testing.T) {
+	finality := func(epoch string) map[string][]byte {
+		return map[string][]byte{
+			"/eth/v1/beacon/states/head/finality_checkpoints": []byte(fmt.Sprintf(`{"data":{"finalized":{"epoch":"%s","root":"0x00"}}}`, epoch)),
+		}
+	}
+	ctx := context.Background()
+
+	// A node with an invalid response does not prevent the others from being queried.
+	f := testAPIFallback(t, finality("foo"), 0)
+	f.clients = append(f.clients, testAPIFallback(t, finality("5"), 0).clients...)
+	epoch, err := f.FinalizedEpoch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, primitives.Epoch(5), epoch)
+
+	f = testAPIFallback(t, finality("foo"), 0)
+	f.clients = append(f.clients, testAPIFallback(t, nil, 0).clients...)
+	_, err = f.FinalizedEpoch(ctx)
+	require.ErrorIs(t, err, errAPIFallbackInvalid)
+
+	f = testAPIFallback(t, nil, 0)
+	_, err = f.FinalizedEpoch(ctx)
+	require.ErrorIs(t, err, ErrAPIFallbackNotFound)
+}
+
+func TestAPIFallback_Blocks(t *testing.T) {
+	served := make(map[string][]byte)
+	var roots [][32]byte
+	for _, slot := range []primitives.Slot{1, 2, 4} {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = slot
+		enc, err := b.MarshalSSZ()
+		require.NoError(t, err)
+		root, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		roots = append(roots, root)
+		served[fmt.Sprintf("/eth/v2/beacon/blocks/%d", slot)] = enc
+		served[fmt.Sprintf("/eth/v2/beacon/blocks/%#x", root)] = enc
+	}
+	f := testAPIFallback(t, served, 0)
+	ctx := context.Background()
+
+	blks, err := f.BlocksByRange(ctx, 1, 5)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(blks))
+	require.Equal(t, primitives.Slot(4), blks[2].Block().Slot())
+
+	b, err := f.BlockByRoot(ctx, roots[1])
+	require.NoError(t, err)
+	require.Equal(t, roots[1], b.Root())
+	_, err = f.BlockByRoot(ctx, [32]byte{'a'})
+	require.ErrorIs(t, err, ErrAPIFallbackNotFound)
+
+	// A block which doesn't match the requested root is rejected.
+	served[fmt.Sprintf("/eth/v2/beacon/blocks/%#x", [32]byte{'b'})] = served["/eth/v2/beacon/blocks/1"]
+	_, err = f.BlockByRoot(ctx, [32]byte{'b'})
+	require.ErrorIs(t, err, errAPIFallbackInvalid)
+}
+
+func TestAPIFallback_BlobSidecars(t *testing.T) {
+	blk, blobs := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 10, 3)
+	other, _ := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 11, 3)
+	served := make(map[string][]byte)
+	for _, sc := range []struct {
+		root  [32]byte
+		blobs []*ethpb.BlobSidecar
+	}{
+		{root: blk.Root(), blobs: []*ethpb.BlobSidecar{blobs[2].BlobSidecar, blobs[0].BlobSidecar, blobs[1].BlobSidecar}},
+		// The blobs of another block are served for other.
+		{root: other.Root(), blobs: []*ethpb.BlobSidecar{blobs[0].BlobSidecar}},
+	} {
+		var enc []byte
+		for _, b := range sc.blobs {
+			sb, err := b.MarshalSSZ()
+			require.NoError(t, err)
+			enc = append(enc, sb...)
+		}
+		served[fmt.Sprintf("/eth/v1/beacon/blob_sidecars/%#x", sc.root)] = enc
+	}
+	f := testAPIFallback(t, served, 0)
+
+	got, err := f.BlobSidecars(context.Background(), blk)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(got))
+	for i := range got {
+		require.Equal(t, uint64(i), got[i].Index)
+	}
+	_, err = f.BlobSidecars(context.Background(), other)
+	require.NotNil(t, err)
+}
+
+func TestService_BatchRootRequestAPIFallback(t *testing.T) {
+	db := dbtest.SetupDB(t)
+	chain := &mock.ChainService{
+		FinalizedCheckPoint: &ethpb.Checkpoint{
+			Epoch: 1,
+			Root:  make([]byte, 32),
+		},
+		ValidatorsRoot: [32]byte{},
+		Genesis:        time.Now(),
+	}
+	b := util.NewBeaconBlock()
+	b.Block.Slot = 40
+	root, err := b.Block.HashTreeRoot()
+	require.NoError(t, err)
+	enc, err := b.MarshalSSZ()
+	require.NoError(t, err)
+	fallback := testAPIFallback(t, map[string][]byte{fmt.Sprintf("/eth/v2/beacon/blocks/%#x", root): enc}, time.Hour)
+
+	r := &Service{
+		cfg: &config{
+			p2p:         p2ptest.NewTestP2P(t),
+			beaconDB:    db,
+			chain:       chain,
+			clock:       startup.NewClock(chain.Genesis, chain.ValidatorsRoot),
+			apiFallback: fallback,
+		},
+		slotToPendingBlocks: gcache.New(time.Second, 2*time.Second),
+		seenPendingBlocks:   make(map[[32]byte]bool),
+	}
+	r.initCaches()
+
+	// Without peers, the fallback is only used once peers have been failing for the stall period.
+	require.NoError(t, r.sendBatchRootRequest(context.Background(), [][32]byte{root}, rand.NewGenerator()))
+	require.Equal(t, false, r.seenPendingBlocks[root])
+
+	fallback.failingSince = time.Now().Add(-2 * time.Hour)
+	require.NoError(t, r.sendBatchRootRequest(context.Background(), [][32]byte{root}, rand.NewGenerator()))
+	require.Equal(t, true, r.seenPendingBlocks[root])
+	require.Equal(t, 1, len(r.pendingBlocksInCache(40)))
+}
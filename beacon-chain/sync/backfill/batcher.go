@@ -125,6 +125,22 @@ func (c *batchSequencer) moveMinimum(min primitives.Slot) error {
 	return nil
 }
 
+// lowerMinimum extends the batch sequence below the current minimum slot. Batches which signaled the end
+// of the sequence are replaced with the batches covering the additional range.
+func (c *batchSequencer) lowerMinimum(min primitives.Slot) {
+	c.batcher.min = min
+	for i := range c.seq {
+		if c.seq[i].state != batchEndSequence {
+			continue
+		}
+		if i == 0 {
+			c.seq[i] = c.batcher.before(c.seq[i].begin)
+		} else {
+			c.seq[i] = c.batcher.beforeBatch(c.seq[i-1])
+		}
+	}
+}
+
 // countWithState provides a view into how many batches are in a particular state
 // to be used for logging or metrics purposes.
 func (c *batchSequencer) countWithState(s batchState) int {
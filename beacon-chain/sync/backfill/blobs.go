@@ -5,6 +5,7 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/das"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
@@ -13,6 +14,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 )
 
 var (
@@ -33,6 +35,14 @@ type blobSyncConfig struct {
 	retentionStart primitives.Slot
 	nbv            verification.NewBlobVerifier
 	store          *filesystem.BlobStorage
+	// archive requires the blobs of all blocks to be available, see das.WithArchive.
+	archive bool
+}
+
+// blobFetchConfig holds the settings workers use to decide which blobs to download, and where from.
+type blobFetchConfig struct {
+	archive  bool
+	fallback *beacon.Client
 }
 
 func newBlobSync(current primitives.Slot, vbs verifiedROBlocks, cfg *blobSyncConfig) (*blobSync, error) {
@@ -41,8 +51,12 @@ func newBlobSync(current primitives.Slot, vbs verifiedROBlocks, cfg *blobSyncCon
 		return nil, err
 	}
 	bbv := newBlobBatchVerifier(cfg.nbv)
-	as := das.NewLazilyPersistentStore(cfg.store, bbv)
-	return &blobSync{current: current, expected: expected, bbv: bbv, store: as}, nil
+	var opts []das.LazilyPersistentStoreOption
+	if cfg.archive {
+		opts = append(opts, das.WithArchive())
+	}
+	as := das.NewLazilyPersistentStore(cfg.store, bbv, opts...)
+	return &blobSync{current: current, expected: expected, bbv: bbv, store: as, cfg: cfg}, nil
 }
 
 type blobVerifierMap map[[32]byte][]verification.BlobVerifier
@@ -53,6 +67,7 @@ type blobSync struct {
 	next     int
 	bbv      *blobBatchVerifier
 	current  primitives.Slot
+	cfg      *blobSyncConfig
 }
 
 func (bs *blobSync) blobsNeeded() int {
@@ -97,6 +112,38 @@ func (bs *blobSync) validateNext(rb blocks.ROBlob) error {
 	return nil
 }
 
+// fetchFromAPI downloads the blobs which are still needed from a beacon node API, one block at a time.
+// The blobs go through the same validation as blobs received from peers.
+func (bs *blobSync) fetchFromAPI(ctx context.Context, c *beacon.Client) error {
+	for bs.blobsNeeded() > 0 {
+		root := bs.expected[bs.next].blockRoot
+		sb, err := c.GetBlobSidecars(ctx, beacon.IdFromRoot(root))
+		if err != nil {
+			return err
+		}
+		if len(sb)%fieldparams.BlobSidecarSize != 0 {
+			return errors.Wrapf(errUnexpectedResponseContent, "invalid blob sidecars response size %d for root=%#x", len(sb), root)
+		}
+		for i := 0; i < len(sb); i += fieldparams.BlobSidecarSize {
+			sc := &ethpb.BlobSidecar{}
+			if err := sc.UnmarshalSSZ(sb[i : i+fieldparams.BlobSidecarSize]); err != nil {
+				return errors.Wrapf(err, "could not unmarshal blob sidecar for root=%#x", root)
+			}
+			rb, err := blocks.NewROBlob(sc)
+			if err != nil {
+				return err
+			}
+			if err := bs.validateNext(rb); err != nil {
+				return err
+			}
+		}
+		if bs.blobsNeeded() > 0 && bs.expected[bs.next].blockRoot == root {
+			return errors.Wrapf(errUnexpectedResponseContent, "blob sidecars response is missing index %d for root=%#x", bs.expected[bs.next].index, root)
+		}
+	}
+	return nil
+}
+
 func newBlobBatchVerifier(nbv verification.NewBlobVerifier) *blobBatchVerifier {
 	return &blobBatchVerifier{newBlobVerifier: nbv, verifiers: make(blobVerifierMap)}
 }
@@ -1,10 +1,19 @@
 package backfill
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
 	"testing"
 
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
@@ -126,3 +135,99 @@ func testNewBlobVerifier(opts ...func(*verification.MockBlobVerifier)) verificat
 		return v
 	}
 }
+
+func TestNewBlobSync_archive(t *testing.T) {
+	current := primitives.Slot(128)
+	blks, _ := testBlobGen(t, 63, 4)
+	cfg := &blobSyncConfig{
+		retentionStart: 0,
+		nbv:            testNewBlobVerifier(),
+		store:          filesystem.NewEphemeralBlobStorage(t),
+		archive:        true,
+	}
+	bsync, err := newBlobSync(current, blks, cfg)
+	require.NoError(t, err)
+	require.Equal(t, 12, bsync.blobsNeeded())
+	require.Equal(t, cfg, bsync.cfg)
+}
+
+type testRT struct {
+	rt func(*http.Request) (*http.Response, error)
+}
+
+func (rt *testRT) RoundTrip(req *http.Request) (*http.Response, error) {
+	if rt.rt != nil {
+		return rt.rt(req)
+	}
+	return nil, errors.New("RoundTripper not implemented")
+}
+
+var _ http.RoundTripper = &testRT{}
+
+func TestFetchFromAPI(t *testing.T) {
+	current := primitives.Slot(128)
+	blks, blobs := testBlobGen(t, 63, 3)
+	served := make(map[string][]byte)
+	for i := range blks {
+		var b []byte
+		for _, sc := range blobs[i] {
+			sb, err := sc.BlobSidecar.MarshalSSZ()
+			require.NoError(t, err)
+			b = append(b, sb...)
+		}
+		served[fmt.Sprintf("/eth/v1/beacon/blob_sidecars/%#x", blks[i].Root())] = b
+	}
+	newClient := func(t *testing.T) *beacon.Client {
+		trans := &testRT{rt: func(req *http.Request) (*http.Response, error) {
+			b, ok := served[req.URL.Path]
+			if !ok {
+				return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(bytes.NewBuffer(nil)), Request: req}, nil
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBuffer(b)), Request: req}, nil
+		}}
+		c, err := beacon.NewClient("http://localhost:3500", client.WithRoundTripper(trans))
+		require.NoError(t, err)
+		return c
+	}
+	newSync := func(t *testing.T) *blobSync {
+		cfg := &blobSyncConfig{
+			nbv:     testNewBlobVerifier(),
+			store:   filesystem.NewEphemeralBlobStorage(t),
+			archive: true,
+		}
+		bsync, err := newBlobSync(current, blks, cfg)
+		require.NoError(t, err)
+		return bsync
+	}
+
+	t.Run("all served", func(t *testing.T) {
+		bsync := newSync(t)
+		require.NoError(t, bsync.fetchFromAPI(context.Background(), newClient(t)))
+		require.Equal(t, 0, bsync.blobsNeeded())
+	})
+	t.Run("missing index", func(t *testing.T) {
+		root := fmt.Sprintf("/eth/v1/beacon/blob_sidecars/%#x", blks[1].Root())
+		full := served[root]
+		served[root] = full[:2*fieldparams.BlobSidecarSize]
+		defer func() { served[root] = full }()
+		bsync := newSync(t)
+		err := bsync.fetchFromAPI(context.Background(), newClient(t))
+		require.ErrorIs(t, err, errUnexpectedResponseContent)
+		require.Equal(t, 4, bsync.blobsNeeded())
+	})
+	t.Run("invalid size", func(t *testing.T) {
+		root := fmt.Sprintf("/eth/v1/beacon/blob_sidecars/%#x", blks[0].Root())
+		full := served[root]
+		served[root] = full[:len(full)-1]
+		defer func() { served[root] = full }()
+		err := newSync(t).fetchFromAPI(context.Background(), newClient(t))
+		require.ErrorIs(t, err, errUnexpectedResponseContent)
+	})
+	t.Run("not found", func(t *testing.T) {
+		root := fmt.Sprintf("/eth/v1/beacon/blob_sidecars/%#x", blks[2].Root())
+		full := served[root]
+		delete(served, root)
+		defer func() { served[root] = full }()
+		require.NotNil(t, newSync(t).fetchFromAPI(context.Background(), newClient(t)))
+	})
+}
@@ -0,0 +1,258 @@
+package backfill
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+// Worker states reported in the backfill progress.
+const (
+	WorkerIdle              = "idle"
+	WorkerDownloadingBlocks = "downloading_blocks"
+	WorkerDownloadingBlobs  = "downloading_blobs"
+)
+
+// Controller allows the backfill service to be inspected and adjusted while it is running.
+type Controller interface {
+	Progress() *Progress
+	Pause()
+	Resume()
+	SetMinimumSlot(slot primitives.Slot) error
+	SetRateLimit(slotsPerSecond uint64)
+}
+
+var _ Controller = (*Service)(nil)
+
+// WorkerStatus describes what a backfill worker is currently doing.
+type WorkerStatus struct {
+	ID    int
+	State string
+	// Begin and End are the bounds of the batch being downloaded, End being exclusive.
+	Begin primitives.Slot
+	End   primitives.Slot
+	Peer  peer.ID
+	Since time.Time
+}
+
+// Progress describes the state of the backfill service.
+type Progress struct {
+	Enabled  bool
+	Running  bool
+	Paused   bool
+	Complete bool
+	// LowSlot is the lowest slot which has been backfilled.
+	LowSlot primitives.Slot
+	// TargetSlot is the slot at which backfill stops.
+	TargetSlot primitives.Slot
+	// RateLimit is the maximum number of slots requested from peers per second, 0 if unlimited.
+	RateLimit uint64
+	// SlotsPerSecond is the average number of slots backfilled per second since batches started to be imported.
+	SlotsPerSecond float64
+	// ETA is the estimated time until backfill is complete, 0 if unknown.
+	ETA     time.Duration
+	Workers []WorkerStatus
+}
+
+// controller holds the settings of the backfill service which can be changed at runtime,
+// along with the progress information reported through the Controller interface.
+type controller struct {
+	sync.Mutex
+	paused     bool
+	hasMinimum bool
+	minimum    primitives.Slot
+	// minimumSet signals the backfill service, which may be waiting after completion, that the minimum slot changed.
+	minimumSet  chan struct{}
+	rateLimit   uint64
+	nextRequest time.Time
+	running     bool
+	complete    bool
+	started     time.Time
+	startLow    primitives.Slot
+	low         primitives.Slot
+	target      primitives.Slot
+	workers     map[workerId]*WorkerStatus
+}
+
+func newController() *controller {
+	return &controller{workers: make(map[workerId]*WorkerStatus), minimumSet: make(chan struct{}, 1)}
+}
+
+// allowRequest determines if a batch can be handed to a worker, given the pause state and rate limit.
+// When the batch is allowed, its slots are counted against the rate limit.
+func (c *controller) allowRequest(b batch, now time.Time) bool {
+	c.Lock()
+	defer c.Unlock()
+	if c.paused {
+		return false
+	}
+	if c.rateLimit == 0 {
+		return true
+	}
+	if now.Before(c.nextRequest) {
+		return false
+	}
+	// Unused capacity does not accumulate, so that resuming after an idle period doesn't cause a burst of requests.
+	cost := time.Duration(float64(b.end-b.begin) / float64(c.rateLimit) * float64(time.Second))
+	c.nextRequest = now.Add(cost)
+	return true
+}
+
+func (c *controller) workerBusy(id workerId, b batch, state string) {
+	c.Lock()
+	defer c.Unlock()
+	c.workers[id] = &WorkerStatus{ID: int(id), State: state, Begin: b.begin, End: b.end, Peer: b.busy, Since: time.Now()}
+}
+
+func (c *controller) workerIdle(id workerId) {
+	c.Lock()
+	defer c.Unlock()
+	c.workers[id] = &WorkerStatus{ID: int(id), State: WorkerIdle, Since: time.Now()}
+}
+
+func (c *controller) setPaused(paused bool) {
+	c.Lock()
+	defer c.Unlock()
+	c.paused = paused
+}
+
+func (c *controller) setRateLimit(slotsPerSecond uint64) {
+	c.Lock()
+	defer c.Unlock()
+	c.rateLimit = slotsPerSecond
+	c.nextRequest = time.Time{}
+}
+
+func (c *controller) setMinimum(slot primitives.Slot) {
+	c.Lock()
+	defer c.Unlock()
+	c.hasMinimum, c.minimum = true, slot
+	select {
+	case c.minimumSet <- struct{}{}:
+	default:
+	}
+}
+
+// restoreMinimum sets the minimum slot saved in the db before the backfill service starts.
+func (c *controller) restoreMinimum(slot primitives.Slot) {
+	c.Lock()
+	defer c.Unlock()
+	c.hasMinimum, c.minimum = true, slot
+}
+
+// userMinimum returns the minimum slot set through the Controller interface, if any.
+func (c *controller) userMinimum() (primitives.Slot, bool) {
+	c.Lock()
+	defer c.Unlock()
+	return c.minimum, c.hasMinimum
+}
+
+func (c *controller) start(low, target primitives.Slot, now time.Time) {
+	c.Lock()
+	defer c.Unlock()
+	c.running = true
+	c.complete = false
+	c.started = now
+	c.startLow, c.low, c.target = low, low, target
+}
+
+func (c *controller) update(low, target primitives.Slot) {
+	c.Lock()
+	defer c.Unlock()
+	c.low, c.target = low, target
+}
+
+func (c *controller) markComplete() {
+	c.Lock()
+	defer c.Unlock()
+	c.running = false
+	c.complete = true
+}
+
+func (c *controller) progress(now time.Time) *Progress {
+	c.Lock()
+	defer c.Unlock()
+	p := &Progress{
+		Running:    c.running,
+		Paused:     c.paused,
+		Complete:   c.complete,
+		LowSlot:    c.low,
+		TargetSlot: c.target,
+		RateLimit:  c.rateLimit,
+		Workers:    make([]WorkerStatus, 0, len(c.workers)),
+	}
+	if c.running && c.low < c.startLow {
+		elapsed := now.Sub(c.started).Seconds()
+		if elapsed > 0 {
+			p.SlotsPerSecond = float64(c.startLow-c.low) / elapsed
+		}
+		if p.SlotsPerSecond > 0 && c.low > c.target {
+			p.ETA = time.Duration(float64(c.low-c.target) / p.SlotsPerSecond * float64(time.Second))
+		}
+	}
+	for _, w := range c.workers {
+		p.Workers = append(p.Workers, *w)
+	}
+	sort.Slice(p.Workers, func(i, j int) bool {
+		return p.Workers[i].ID < p.Workers[j].ID
+	})
+	return p
+}
+
+// Progress reports the progress of backfill and the state of its workers.
+func (s *Service) Progress() *Progress {
+	p := s.ctrl.progress(time.Now())
+	p.Enabled = s.enabled
+	return p
+}
+
+// Pause stops backfill from sending new requests to peers. Batches which are being downloaded are completed.
+func (s *Service) Pause() {
+	s.ctrl.setPaused(true)
+	log.Info("Backfill paused")
+}
+
+// Resume lets backfill send requests to peers again after Pause.
+func (s *Service) Resume() {
+	s.ctrl.setPaused(false)
+	log.Info("Backfill resumed")
+}
+
+// SetMinimumSlot changes the slot at which backfill stops. Like WithMinimumSlot, a slot greater than
+// current - MIN_EPOCHS_FOR_BLOCK_REQUESTS is ignored. The slot can be lowered, e.g. to backfill to genesis,
+// in which case backfill resumes if it had completed. The slot is saved in the db and takes precedence over
+// WithMinimumSlot after a restart.
+func (s *Service) SetMinimumSlot(slot primitives.Slot) error {
+	// Slot 0 is the genesis block, which can't be verified like the other blocks, see minimumBackfillSlot.
+	if slot == 0 {
+		slot = 1
+	}
+	if err := s.store.saveMinimumSlot(s.ctx, slot); err != nil {
+		return errors.Wrap(err, "could not save backfill minimum slot")
+	}
+	s.ctrl.setMinimum(slot)
+	log.WithField("minimumSlot", slot).Info("Backfill minimum slot updated")
+	return nil
+}
+
+// SetRateLimit caps the number of slots backfill requests from peers per second. 0 removes the limit.
+func (s *Service) SetRateLimit(slotsPerSecond uint64) {
+	s.ctrl.setRateLimit(slotsPerSecond)
+	log.WithField("slotsPerSecond", slotsPerSecond).Info("Backfill rate limit updated")
+}
+
+// minimumSlot returns the slot at which backfill stops, taking into account the minimum slot set at runtime.
+func (s *Service) minimumSlot(current primitives.Slot) primitives.Slot {
+	slot, ok := s.ctrl.userMinimum()
+	if !ok {
+		return s.ms(current)
+	}
+	if specMin := minimumBackfillSlot(current); slot > specMin {
+		return specMin
+	}
+	return slot
+}
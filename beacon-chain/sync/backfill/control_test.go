@@ -0,0 +1,147 @@
+package backfill
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/proto/dbval"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestControllerAllowRequest(t *testing.T) {
+	c := newController()
+	now := time.Now()
+	b := batch{begin: 100, end: 164}
+	require.Equal(t, true, c.allowRequest(b, now))
+	require.Equal(t, true, c.allowRequest(b, now))
+
+	c.setPaused(true)
+	require.Equal(t, false, c.allowRequest(b, now))
+	c.setPaused(false)
+
+	// 64 slots at 32 slots per second take 2 seconds of the allowance.
+	c.setRateLimit(32)
+	require.Equal(t, true, c.allowRequest(b, now))
+	require.Equal(t, false, c.allowRequest(b, now.Add(time.Second)))
+	require.Equal(t, true, c.allowRequest(b, now.Add(2*time.Second)))
+	// Unused allowance does not accumulate.
+	require.Equal(t, true, c.allowRequest(b, now.Add(time.Hour)))
+	require.Equal(t, false, c.allowRequest(b, now.Add(time.Hour+time.Second)))
+
+	c.setRateLimit(0)
+	require.Equal(t, true, c.allowRequest(b, now))
+}
+
+func TestControllerProgress(t *testing.T) {
+	c := newController()
+	now := time.Now()
+	c.start(1000, 200, now)
+	c.workerIdle(1)
+	c.workerBusy(0, batch{begin: 900, end: 964, busy: "peer"}, WorkerDownloadingBlocks)
+	c.update(800, 200)
+
+	p := c.progress(now.Add(10 * time.Second))
+	require.Equal(t, true, p.Running)
+	require.Equal(t, primitives.Slot(800), p.LowSlot)
+	require.Equal(t, primitives.Slot(200), p.TargetSlot)
+	require.Equal(t, float64(20), p.SlotsPerSecond)
+	require.Equal(t, 30*time.Second, p.ETA)
+	require.Equal(t, 2, len(p.Workers))
+	require.Equal(t, WorkerDownloadingBlocks, p.Workers[0].State)
+	require.Equal(t, primitives.Slot(900), p.Workers[0].Begin)
+	require.Equal(t, WorkerIdle, p.Workers[1].State)
+
+	c.markComplete()
+	p = c.progress(now)
+	require.Equal(t, false, p.Running)
+	require.Equal(t, true, p.Complete)
+
+	// Backfill is running again after the minimum slot is lowered.
+	c.setMinimum(1)
+	c.start(200, 1, now)
+	p = c.progress(now)
+	require.Equal(t, true, p.Running)
+	require.Equal(t, false, p.Complete)
+}
+
+func TestServiceMinimumSlot(t *testing.T) {
+	oe := helpers.MinEpochsForBlockRequests()
+	current := primitives.Slot((oe + 100).Mul(uint64(params.BeaconConfig().SlotsPerEpoch)))
+	specMin := minimumBackfillSlot(current)
+	db := &mockBackfillDB{}
+	s := &Service{ctx: context.Background(), ms: minimumBackfillSlot, ctrl: newController(), store: &Store{store: db}}
+	require.Equal(t, specMin, s.minimumSlot(current))
+
+	require.NoError(t, s.SetMinimumSlot(0))
+	require.Equal(t, primitives.Slot(1), s.minimumSlot(current))
+	require.Equal(t, primitives.Slot(1), *db.minimum)
+	require.NoError(t, s.SetMinimumSlot(specMin+1))
+	require.Equal(t, specMin, s.minimumSlot(current))
+}
+
+func TestServiceWaitForLowerMinimum(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	db := &mockBackfillDB{}
+	s := &Service{
+		ctx:   ctx,
+		ms:    minimumBackfillSlot,
+		ctrl:  newController(),
+		store: &Store{store: db, bs: &dbval.BackfillStatus{LowSlot: 1000}},
+		clock: startup.NewClock(time.Now().AddDate(-1, 0, 0), [32]byte{}),
+	}
+	resumed := make(chan bool)
+	go func() {
+		resumed <- s.waitForLowerMinimum(ctx)
+	}()
+
+	// A minimum slot which is not below the lowest backfilled slot does not resume backfill.
+	require.NoError(t, s.SetMinimumSlot(1000))
+	select {
+	case <-resumed:
+		t.Fatal("backfill resumed above the lowest backfilled slot")
+	case <-time.After(100 * time.Millisecond):
+	}
+	require.NoError(t, s.SetMinimumSlot(500))
+	require.Equal(t, true, <-resumed)
+	require.Equal(t, primitives.Slot(500), *db.minimum)
+
+	// The saved minimum slot is used by the next backfill service.
+	min, ok, err := s.store.minimumSlot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, true, ok)
+	require.Equal(t, primitives.Slot(500), min)
+
+	go func() {
+		resumed <- s.waitForLowerMinimum(ctx)
+	}()
+	cancel()
+	require.Equal(t, false, <-resumed)
+}
+
+func TestBatchSequencerLowerMinimum(t *testing.T) {
+	seq := newBatchSequencer(2, 100, 164, 32)
+	got, err := seq.sequence()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(got))
+	seq.update(got[0].withState(batchImportComplete))
+	seq.update(got[1].withState(batchImportComplete))
+	got, err = seq.sequence()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(got))
+	require.Equal(t, batchEndSequence, got[0].state)
+
+	seq.lowerMinimum(50)
+	got, err = seq.sequence()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(got))
+	require.Equal(t, primitives.Slot(68), got[0].begin)
+	require.Equal(t, primitives.Slot(100), got[0].end)
+	require.Equal(t, primitives.Slot(50), got[1].begin)
+	require.Equal(t, primitives.Slot(68), got[1].end)
+}
@@ -20,6 +20,7 @@ type batchWorkerPool interface {
 	spawn(ctx context.Context, n int, clock *startup.Clock, a PeerAssigner, v *verifier, cm sync.ContextByteVersions, blobVerifier verification.NewBlobVerifier, bfs *filesystem.BlobStorage)
 	todo(b batch)
 	complete() (batch, error)
+	reopen()
 }
 
 type worker interface {
@@ -28,9 +29,9 @@ type worker interface {
 
 type newWorker func(id workerId, in, out chan batch, c *startup.Clock, v *verifier, cm sync.ContextByteVersions, nbv verification.NewBlobVerifier, bfs *filesystem.BlobStorage) worker
 
-func defaultNewWorker(p p2p.P2P) newWorker {
+func defaultNewWorker(p p2p.P2P, ctrl *controller, bfc *blobFetchConfig) newWorker {
 	return func(id workerId, in, out chan batch, c *startup.Clock, v *verifier, cm sync.ContextByteVersions, nbv verification.NewBlobVerifier, bfs *filesystem.BlobStorage) worker {
-		return newP2pWorker(id, p, in, out, c, v, cm, nbv, bfs)
+		return newP2pWorker(id, p, in, out, c, v, cm, nbv, bfs, ctrl, bfc)
 	}
 }
 
@@ -43,14 +44,15 @@ type p2pBatchWorkerPool struct {
 	fromRouter  chan batch
 	shutdownErr chan error
 	endSeq      []batch
+	ctrl        *controller
 	ctx         context.Context
 	cancel      func()
 }
 
 var _ batchWorkerPool = &p2pBatchWorkerPool{}
 
-func newP2PBatchWorkerPool(p p2p.P2P, maxBatches int) *p2pBatchWorkerPool {
-	nw := defaultNewWorker(p)
+func newP2PBatchWorkerPool(p p2p.P2P, maxBatches int, ctrl *controller, bfc *blobFetchConfig) *p2pBatchWorkerPool {
+	nw := defaultNewWorker(p, ctrl, bfc)
 	return &p2pBatchWorkerPool{
 		newWorker:   nw,
 		toRouter:    make(chan batch, maxBatches),
@@ -59,6 +61,7 @@ func newP2PBatchWorkerPool(p p2p.P2P, maxBatches int) *p2pBatchWorkerPool {
 		fromWorkers: make(chan batch),
 		maxBatches:  maxBatches,
 		shutdownErr: make(chan error),
+		ctrl:        ctrl,
 	}
 }
 
@@ -83,6 +86,11 @@ func (p *p2pBatchWorkerPool) todo(b batch) {
 	p.toRouter <- b
 }
 
+// reopen discards the batchEndSequence batches received so far, after the minimum slot has been lowered.
+func (p *p2pBatchWorkerPool) reopen() {
+	p.endSeq = nil
+}
+
 func (p *p2pBatchWorkerPool) complete() (batch, error) {
 	if len(p.endSeq) == p.maxBatches {
 		return p.endSeq[0], errEndSequence
@@ -113,7 +121,7 @@ func (p *p2pBatchWorkerPool) batchRouter(pa PeerAssigner) {
 		case <-rt.C:
 			// Worker assignments can fail if assignBatch can't find a suitable peer.
 			// This ticker exists to periodically break out of the channel select
-			// to retry failed assignments.
+			// to retry failed assignments, and assignments held back while paused or rate limited.
 		case b := <-p.fromWorkers:
 			pid := b.busy
 			busy[pid] = false
@@ -143,6 +151,10 @@ func (p *p2pBatchWorkerPool) batchRouter(pa PeerAssigner) {
 			return
 		}
 		for _, pid := range assigned {
+			if !p.ctrl.allowRequest(todo[0], time.Now()) {
+				// Leave the remaining batches in the queue until backfill is resumed or the rate limit allows more requests.
+				break
+			}
 			if err := todo[0].waitUntilReady(p.ctx); err != nil {
 				log.WithError(p.ctx.Err()).Info("p2pBatchWorkerPool context canceled, shutting down")
 				p.shutdown(p.ctx.Err())
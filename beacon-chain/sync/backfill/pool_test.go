@@ -42,7 +42,7 @@ func TestPoolDetectAllEnded(t *testing.T) {
 	p2p := p2ptest.NewTestP2P(t)
 	ctx := context.Background()
 	ma := &mockAssigner{}
-	pool := newP2PBatchWorkerPool(p2p, nw)
+	pool := newP2PBatchWorkerPool(p2p, nw, newController(), &blobFetchConfig{})
 	st, err := util.NewBeaconState()
 	require.NoError(t, err)
 	keys, err := st.PublicKeys()
@@ -79,6 +79,9 @@ func (m *mockPool) todo(b batch) {
 	m.todoChan <- b
 }
 
+func (m *mockPool) reopen() {
+}
+
 func (m *mockPool) complete() (batch, error) {
 	select {
 	case b := <-m.finishedChan:
@@ -2,9 +2,11 @@ package backfill
 
 import (
 	"context"
+	"time"
 
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
@@ -40,6 +42,8 @@ type Service struct {
 	blobStore       *filesystem.BlobStorage
 	initSyncWaiter  func() error
 	complete        chan struct{}
+	ctrl            *controller
+	blobFetch       *blobFetchConfig
 }
 
 var _ runtime.Service = (*Service)(nil)
@@ -94,6 +98,29 @@ func WithBatchSize(n uint64) ServiceOption {
 	}
 }
 
+// WithBlobArchive makes backfill download the blobs of all blocks since deneb, rather than only those within
+// MIN_EPOCHS_FOR_BLOB_SIDECARS_REQUESTS. Peers are not required to serve older blobs, so this should be combined with
+// WithBlobFallback, and the blob storage should be created with filesystem.WithArchive so that the blobs are kept.
+// The archived range of the blob storage ends at the origin of the node.
+func WithBlobArchive() ServiceOption {
+	return func(s *Service) error {
+		s.blobFetch.archive = true
+		return nil
+	}
+}
+
+// WithBlobFallback sets the url of a beacon node api which is used to download blobs that peers fail to serve.
+func WithBlobFallback(url string) ServiceOption {
+	return func(s *Service) error {
+		c, err := beacon.NewClient(url)
+		if err != nil {
+			return errors.Wrapf(err, "invalid blob fallback beacon node url %s", url)
+		}
+		s.blobFetch.fallback = c
+		return nil
+	}
+}
+
 // WithInitSyncWaiter sets a function on the service which will block until init-sync
 // completes for the first time, or returns an error if context is canceled.
 func WithInitSyncWaiter(w func() error) ServiceOption {
@@ -150,13 +177,23 @@ func NewService(ctx context.Context, su *Store, bStore *filesystem.BlobStorage,
 		pa:            pa,
 		batchImporter: defaultBatchImporter,
 		complete:      make(chan struct{}),
+		ctrl:          newController(),
+		blobFetch:     &blobFetchConfig{},
 	}
 	for _, o := range opts {
 		if err := o(s); err != nil {
 			return nil, err
 		}
 	}
-	s.pool = newP2PBatchWorkerPool(p, s.nWorkers)
+	s.pool = newP2PBatchWorkerPool(p, s.nWorkers, s.ctrl, s.blobFetch)
+	if s.blobFetch.archive && bStore != nil {
+		// Only the blobs backfilled before the origin are archived, later blobs are pruned as usual.
+		var archiveEnd primitives.Epoch
+		if !su.isGenesisSync() {
+			archiveEnd = slots.ToEpoch(primitives.Slot(su.status().OriginSlot))
+		}
+		bStore.SetArchiveEnd(archiveEnd)
+	}
 
 	return s, nil
 }
@@ -179,18 +216,20 @@ func (s *Service) initVerifier(ctx context.Context) (*verifier, sync.ContextByte
 	return v, ctxMap, err
 }
 
-func (s *Service) updateComplete() bool {
+// updateComplete waits for the next batch completed by the worker pool. It returns errEndSequence once
+// every batch down to the minimum slot is complete.
+func (s *Service) updateComplete() error {
 	b, err := s.pool.complete()
 	if err != nil {
 		if errors.Is(err, errEndSequence) {
 			log.WithField("backfillSlot", b.begin).Info("Backfill is complete")
-			return true
+			return err
 		}
 		log.WithError(err).Error("Backfill service received unhandled error from worker pool")
-		return true
+		return err
 	}
 	s.batchSeq.update(b)
-	return false
+	return nil
 }
 
 func (s *Service) importBatches(ctx context.Context) {
@@ -220,6 +259,7 @@ func (s *Service) importBatches(ctx context.Context) {
 		imported += 1
 		// Calling update with state=batchImportComplete will advance the batch list.
 	}
+	s.ctrl.update(primitives.Slot(s.store.status().LowSlot), s.batchSeq.batcher.min)
 
 	nt := s.batchSeq.numTodo()
 	log.WithField("imported", imported).WithField("importable", len(importable)).
@@ -279,14 +319,25 @@ func (s *Service) Start() {
 		s.markComplete()
 		return
 	}
+	minimum, ok, err := s.store.minimumSlot(ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not read backfill minimum slot")
+		return
+	}
+	if ok {
+		log.WithField("minimumSlot", minimum).Info("Using backfill minimum slot saved in the db")
+		s.ctrl.restoreMinimum(minimum)
+	}
 	status := s.store.status()
-	// Exit early if there aren't going to be any batches to backfill.
-	if primitives.Slot(status.LowSlot) <= s.ms(s.clock.CurrentSlot()) {
-		log.WithField("minimumRequiredSlot", s.ms(s.clock.CurrentSlot())).
+	// Wait for the minimum slot to be lowered if there aren't going to be any batches to backfill.
+	if primitives.Slot(status.LowSlot) <= s.minimumSlot(s.clock.CurrentSlot()) {
+		log.WithField("minimumRequiredSlot", s.minimumSlot(s.clock.CurrentSlot())).
 			WithField("backfillLowestSlot", status.LowSlot).
-			Info("Exiting backfill service; minimum block retention slot > lowest backfilled block")
+			Info("Backfill service idle; minimum block retention slot > lowest backfilled block")
 		s.markComplete()
-		return
+		if !s.waitForLowerMinimum(ctx) {
+			return
+		}
 	}
 	s.verifier, s.ctxMap, err = s.initVerifier(ctx)
 	if err != nil {
@@ -302,29 +353,64 @@ func (s *Service) Start() {
 		}
 	}
 	s.pool.spawn(ctx, s.nWorkers, clock, s.pa, s.verifier, s.ctxMap, s.newBlobVerifier, s.blobStore)
-	s.batchSeq = newBatchSequencer(s.nWorkers, s.ms(s.clock.CurrentSlot()), primitives.Slot(status.LowSlot), primitives.Slot(s.batchSize))
+	s.batchSeq = newBatchSequencer(s.nWorkers, s.minimumSlot(s.clock.CurrentSlot()), primitives.Slot(status.LowSlot), primitives.Slot(s.batchSize))
 	if err = s.initBatches(); err != nil {
 		log.WithError(err).Error("Non-recoverable error in backfill service")
 		return
 	}
+	s.ctrl.start(primitives.Slot(status.LowSlot), s.batchSeq.batcher.min, time.Now())
 
 	for {
 		if ctx.Err() != nil {
 			return
 		}
-		if s.updateComplete() {
+		if err := s.updateComplete(); err != nil {
 			s.markComplete()
-			return
+			if !errors.Is(err, errEndSequence) || !s.waitForLowerMinimum(ctx) {
+				return
+			}
+			// Backfill further, down to the minimum slot lowered through SetMinimumSlot.
+			s.adjustMinimum(s.minimumSlot(s.clock.CurrentSlot()))
+			s.scheduleTodos()
+			s.ctrl.start(primitives.Slot(s.store.status().LowSlot), s.batchSeq.batcher.min, time.Now())
+			continue
 		}
 		s.importBatches(ctx)
 		batchesWaiting.Set(float64(s.batchSeq.countWithState(batchImportable)))
-		if err := s.batchSeq.moveMinimum(s.ms(s.clock.CurrentSlot())); err != nil {
-			log.WithError(err).Error("Non-recoverable error while adjusting backfill minimum slot")
-		}
+		s.adjustMinimum(s.minimumSlot(s.clock.CurrentSlot()))
 		s.scheduleTodos()
 	}
 }
 
+// adjustMinimum moves the slot at which backfill stops. Lowering the minimum, which can be requested through
+// SetMinimumSlot, reopens the end of the batch sequence so that the additional batches are scheduled.
+func (s *Service) adjustMinimum(min primitives.Slot) {
+	if min < s.batchSeq.batcher.min {
+		log.WithField("previous", s.batchSeq.batcher.min).WithField("minimumSlot", min).Info("Extending backfill to lower minimum slot")
+		s.batchSeq.lowerMinimum(min)
+		s.pool.reopen()
+	} else if err := s.batchSeq.moveMinimum(min); err != nil {
+		log.WithError(err).Error("Non-recoverable error while adjusting backfill minimum slot")
+	}
+	s.ctrl.update(primitives.Slot(s.store.status().LowSlot), s.batchSeq.batcher.min)
+}
+
+// waitForLowerMinimum waits until the minimum slot is lowered below the lowest backfilled slot through
+// SetMinimumSlot. It returns false if the context is canceled first.
+func (s *Service) waitForLowerMinimum(ctx context.Context) bool {
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-s.ctrl.minimumSet:
+			if primitives.Slot(s.store.status().LowSlot) > s.minimumSlot(s.clock.CurrentSlot()) {
+				log.WithField("minimumSlot", s.minimumSlot(s.clock.CurrentSlot())).Info("Resuming backfill")
+				return true
+			}
+		}
+	}
+}
+
 func (s *Service) initBatches() error {
 	batches, err := s.batchSeq.sequence()
 	if err != nil {
@@ -371,7 +457,13 @@ func newBlobVerifierFromInitializer(ini *verification.Initializer) verification.
 }
 
 func (s *Service) markComplete() {
-	close(s.complete)
+	s.ctrl.markComplete()
+	// Backfill can complete more than once, when the minimum slot is lowered after completion.
+	select {
+	case <-s.complete:
+	default:
+		close(s.complete)
+	}
 	log.Info("Backfill service marked as complete")
 }
 
@@ -132,3 +132,19 @@ func TestBackfillMinSlotDefault(t *testing.T) {
 		require.Equal(t, specMin, s.ms(current))
 	})
 }
+
+func TestNewServiceBlobArchiveEnd(t *testing.T) {
+	ctx := context.Background()
+	su, err := NewUpdater(ctx, &mockBackfillDB{})
+	require.NoError(t, err)
+	originEpoch := primitives.Epoch(10)
+	su.bs = &dbval.BackfillStatus{OriginSlot: uint64(params.BeaconConfig().SlotsPerEpoch) * uint64(originEpoch)}
+	bfs := filesystem.NewEphemeralBlobStorage(t, filesystem.WithArchive())
+	_, err = NewService(ctx, su, bfs, startup.NewClockSynchronizer(), p2ptest.NewTestP2P(t), &mockAssigner{}, WithBlobArchive())
+	require.NoError(t, err)
+
+	current := originEpoch + params.BeaconConfig().MinEpochsForBlobsSidecarsRequest*2
+	// The blobs backfilled before the origin are archived, later blobs follow the retention period.
+	require.Equal(t, true, bfs.WithinRetentionPeriod(originEpoch-1, current))
+	require.Equal(t, false, bfs.WithinRetentionPeriod(originEpoch, current))
+}
@@ -162,6 +162,22 @@ func (s *Store) isGenesisSync() bool {
 	return s.genesisSync
 }
 
+// minimumSlot returns the slot at which backfill stops which was set at runtime, if any.
+func (s *Store) minimumSlot(ctx context.Context) (primitives.Slot, bool, error) {
+	slot, err := s.store.BackfillMinimumSlot(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	return slot, true, nil
+}
+
+func (s *Store) saveMinimumSlot(ctx context.Context, slot primitives.Slot) error {
+	return s.store.SaveBackfillMinimumSlot(ctx, slot)
+}
+
 // originState looks up the state for the checkpoint sync origin. This is a hack, because StatusUpdater is the only
 // thing that needs db access and it has the origin root handy, so it's convenient to look it up here. The state is
 // needed by the verifier.
@@ -173,6 +189,8 @@ func (s *Store) originState(ctx context.Context) (state.BeaconState, error) {
 type BeaconDB interface {
 	SaveBackfillStatus(context.Context, *dbval.BackfillStatus) error
 	BackfillStatus(context.Context) (*dbval.BackfillStatus, error)
+	SaveBackfillMinimumSlot(context.Context, primitives.Slot) error
+	BackfillMinimumSlot(context.Context) (primitives.Slot, error)
 	BackfillFinalizedIndex(ctx context.Context, blocks []blocks.ROBlock, finalizedChildRoot [32]byte) error
 	OriginCheckpointBlockRoot(context.Context) ([32]byte, error)
 	Block(context.Context, [32]byte) (interfaces.ReadOnlySignedBeaconBlock, error)
@@ -27,6 +27,7 @@ type mockBackfillDB struct {
 	saveBackfillStatus        func(ctx context.Context, status *dbval.BackfillStatus) error
 	backfillStatus            func(context.Context) (*dbval.BackfillStatus, error)
 	status                    *dbval.BackfillStatus
+	minimum                   *primitives.Slot
 	err                       error
 	states                    map[[32]byte]state.BeaconState
 	blocks                    map[[32]byte]blocks.ROBlock
@@ -57,6 +58,18 @@ func (d *mockBackfillDB) BackfillStatus(ctx context.Context) (*dbval.BackfillSta
 	return d.status, nil
 }
 
+func (d *mockBackfillDB) SaveBackfillMinimumSlot(_ context.Context, slot primitives.Slot) error {
+	d.minimum = &slot
+	return nil
+}
+
+func (d *mockBackfillDB) BackfillMinimumSlot(context.Context) (primitives.Slot, error) {
+	if d.minimum == nil {
+		return 0, db.ErrNotFound
+	}
+	return *d.minimum, nil
+}
+
 func (d *mockBackfillDB) OriginCheckpointBlockRoot(ctx context.Context) ([32]byte, error) {
 	if d.originCheckpointBlockRoot != nil {
 		return d.originCheckpointBlockRoot(ctx)
@@ -10,6 +10,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 )
 
 type workerId int
@@ -24,18 +25,26 @@ type p2pWorker struct {
 	cm   sync.ContextByteVersions
 	nbv  verification.NewBlobVerifier
 	bfs  *filesystem.BlobStorage
+	ctrl *controller
+	bfc  *blobFetchConfig
 }
 
 func (w *p2pWorker) run(ctx context.Context) {
+	w.ctrl.workerIdle(w.id)
 	for {
 		select {
 		case b := <-w.todo:
 			log.WithFields(b.logFields()).WithField("backfillWorker", w.id).Debug("Backfill worker received batch")
+			var res batch
 			if b.state == batchBlobSync {
-				w.done <- w.handleBlobs(ctx, b)
+				w.ctrl.workerBusy(w.id, b, WorkerDownloadingBlobs)
+				res = w.handleBlobs(ctx, b)
 			} else {
-				w.done <- w.handleBlocks(ctx, b)
+				w.ctrl.workerBusy(w.id, b, WorkerDownloadingBlocks)
+				res = w.handleBlocks(ctx, b)
 			}
+			w.ctrl.workerIdle(w.id)
+			w.done <- res
 		case <-ctx.Done():
 			log.WithField("backfillWorker", w.id).Info("Backfill worker exiting after context canceled")
 			return
@@ -45,9 +54,14 @@ func (w *p2pWorker) run(ctx context.Context) {
 
 func (w *p2pWorker) handleBlocks(ctx context.Context, b batch) batch {
 	cs := w.c.CurrentSlot()
-	blobRetentionStart, err := sync.BlobRPCMinValidSlot(cs)
-	if err != nil {
-		return b.withRetryableError(errors.Wrap(err, "configuration issue, could not compute minimum blob retention slot"))
+	// Archive nodes download the blobs of all blocks since deneb.
+	var blobRetentionStart primitives.Slot
+	if !w.bfc.archive {
+		var err error
+		blobRetentionStart, err = sync.BlobRPCMinValidSlot(cs)
+		if err != nil {
+			return b.withRetryableError(errors.Wrap(err, "configuration issue, could not compute minimum blob retention slot"))
+		}
 	}
 	b.blockPid = b.busy
 	start := time.Now()
@@ -73,7 +87,7 @@ func (w *p2pWorker) handleBlocks(ctx context.Context, b batch) batch {
 	}
 	backfillBlocksApproximateBytes.Add(float64(bdl))
 	log.WithFields(b.logFields()).WithField("dlbytes", bdl).Debug("Backfill batch block bytes downloaded")
-	bs, err := newBlobSync(cs, vb, &blobSyncConfig{retentionStart: blobRetentionStart, nbv: w.nbv, store: w.bfs})
+	bs, err := newBlobSync(cs, vb, &blobSyncConfig{retentionStart: blobRetentionStart, nbv: w.nbv, store: w.bfs, archive: w.bfc.archive})
 	if err != nil {
 		return b.withRetryableError(err)
 	}
@@ -86,6 +100,10 @@ func (w *p2pWorker) handleBlobs(ctx context.Context, b batch) batch {
 	// we don't need to use the response for anything other than metrics, because blobResponseValidation
 	// adds each of them to a batch AvailabilityStore once it is checked.
 	blobs, err := sync.SendBlobsByRangeRequest(ctx, w.c, w.p2p, b.blobPid, w.cm, b.blobRequest(), b.blobResponseValidator(), blobValidationMetrics)
+	if (err != nil || b.blobsNeeded() > 0) && w.bfc.fallback != nil {
+		log.WithError(err).WithFields(b.logFields()).Debug("Peer did not serve all blobs, downloading them from the fallback beacon node")
+		return w.handleBlobsFromAPI(ctx, b)
+	}
 	if err != nil {
 		b.bs = nil
 		return b.withRetryableError(err)
@@ -101,7 +119,26 @@ func (w *p2pWorker) handleBlobs(ctx context.Context, b batch) batch {
 	return b.postBlobSync()
 }
 
-func newP2pWorker(id workerId, p p2p.P2P, todo, done chan batch, c *startup.Clock, v *verifier, cm sync.ContextByteVersions, nbv verification.NewBlobVerifier, bfs *filesystem.BlobStorage) *p2pWorker {
+// handleBlobsFromAPI downloads the blobs of a batch from the fallback beacon node API. Blobs received from
+// the peer are discarded, because the peer response may have been invalid.
+func (w *p2pWorker) handleBlobsFromAPI(ctx context.Context, b batch) batch {
+	bs, err := newBlobSync(b.bs.current, b.results, b.bs.cfg)
+	if err != nil {
+		b.bs = nil
+		return b.withRetryableError(err)
+	}
+	start := time.Now()
+	if err := bs.fetchFromAPI(ctx, w.bfc.fallback); err != nil {
+		log.WithError(err).WithFields(b.logFields()).Debug("Could not download blobs from the fallback beacon node")
+		b.bs = nil
+		return b.withRetryableError(err)
+	}
+	backfillBatchTimeDownloadingBlobs.Observe(float64(time.Since(start).Milliseconds()))
+	b.bs = bs
+	return b.postBlobSync()
+}
+
+func newP2pWorker(id workerId, p p2p.P2P, todo, done chan batch, c *startup.Clock, v *verifier, cm sync.ContextByteVersions, nbv verification.NewBlobVerifier, bfs *filesystem.BlobStorage, ctrl *controller, bfc *blobFetchConfig) *p2pWorker {
 	return &p2pWorker{
 		id:   id,
 		todo: todo,
@@ -112,5 +149,7 @@ func newP2pWorker(id workerId, p p2p.P2P, todo, done chan batch, c *startup.Cloc
 		cm:   cm,
 		nbv:  nbv,
 		bfs:  bfs,
+		ctrl: ctrl,
+		bfc:  bfc,
 	}
 }
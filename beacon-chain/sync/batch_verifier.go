@@ -67,12 +67,12 @@ func (s *Service) validateWithBatchVerifier(ctx context.Context, message string,
 		if err != nil {
 			verErr := errors.Wrapf(err, "Could not verify %s", message)
 			tracing.AnnotateError(span, verErr)
-			return pubsub.ValidationReject, verErr
+			return pubsub.ValidationReject, withReason(reasonInvalidSignature, verErr)
 		}
 		if !verified {
 			verErr := errors.Errorf("Verification of %s failed", message)
 			tracing.AnnotateError(span, verErr)
-			return pubsub.ValidationReject, verErr
+			return pubsub.ValidationReject, withReason(reasonInvalidSignature, verErr)
 		}
 	}
 	return pubsub.ValidationAccept, nil
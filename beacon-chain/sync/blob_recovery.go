@@ -0,0 +1,158 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// The execution client may receive a blob transaction after the block including it, so blobs it misses
+	// are requested again a few times, while they may still arrive sooner than from the network.
+	blobRecoveryAttempts      = 3
+	blobRecoveryRetryInterval = 250 * time.Millisecond
+)
+
+// Outcomes of the recovery of the blobs missing when a block is received.
+const (
+	blobRecoveryComplete = "complete"
+	blobRecoveryPartial  = "partial"
+	blobRecoveryNone     = "none"
+)
+
+// reconstructAndBroadcastBlobs recovers the blobs of a block received before its blob sidecars from the
+// mempool of the execution client, with engine_getBlobsV1 before Fulu and engine_getBlobsV2 from Fulu.
+// The recovered sidecars are verified, broadcast over P2P and saved into the blob storage, without waiting
+// for them to arrive from the network.
+func (s *Service) reconstructAndBroadcastBlobs(ctx context.Context, block interfaces.ReadOnlySignedBeaconBlock) {
+	if block.Version() < version.Deneb || s.cfg.blobStorage == nil {
+		return
+	}
+	blockRoot, err := block.Block().HashTreeRoot()
+	if err != nil {
+		log.WithError(err).Error("Failed to calculate block root")
+		return
+	}
+	cmts, err := block.Block().Body().BlobKzgCommitments()
+	if err != nil {
+		log.WithError(err).Error("Failed to read commitments from block")
+		return
+	}
+	if len(cmts) == 0 {
+		return
+	}
+	// The same block may be received more than once, from gossip and from peers.
+	if _, running := s.blobRecoveries.LoadOrStore(blockRoot, struct{}{}); running {
+		return
+	}
+	defer s.blobRecoveries.Delete(blockRoot)
+
+	startTime, err := slots.ToTime(uint64(s.cfg.chain.GenesisTime().Unix()), block.Block().Slot())
+	if err != nil {
+		log.WithError(err).Error("Failed to convert slot to time")
+	}
+
+	summary := s.cfg.blobStorage.Summary(blockRoot)
+	missing := 0
+	for i := range cmts {
+		if summary.HasIndex(uint64(i)) {
+			blobExistedInDBTotal.Inc()
+		} else {
+			missing++
+		}
+	}
+	if missing == 0 {
+		return
+	}
+
+	// Recovered blobs are tracked apart from the blob storage, as they may not have been saved yet.
+	recovered := make(map[uint64]bool)
+	hasIndex := func(idx uint64) bool {
+		return recovered[idx] || s.cfg.blobStorage.Summary(blockRoot).HasIndex(idx)
+	}
+	for attempt := 0; attempt < blobRecoveryAttempts && !hasAllBlobs(hasIndex, len(cmts)); attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(blobRecoveryRetryInterval):
+			}
+		}
+		blobSidecars, err := s.cfg.executionReconstructor.ReconstructBlobSidecars(ctx, block, blockRoot, hasIndex)
+		if err != nil {
+			log.WithError(err).Error("Failed to reconstruct blob sidecars")
+			break
+		}
+		for _, idx := range s.broadcastAndReceiveRecoveredBlobs(ctx, blobSidecars, hasIndex, startTime) {
+			recovered[idx] = true
+		}
+	}
+
+	outcome := blobRecoveryNone
+	if len(recovered) > 0 {
+		outcome = blobRecoveryPartial
+		if hasAllBlobs(hasIndex, len(cmts)) {
+			outcome = blobRecoveryComplete
+			blobRecoveryCompletedSinceSlotStart.Observe(float64(s.cfg.clock.Now().Sub(startTime).Milliseconds()))
+		}
+	}
+	blobRecoveryBlocksTotal.WithLabelValues(outcome).Inc()
+	log.WithFields(logrus.Fields{
+		"slot":      block.Block().Slot(),
+		"blockRoot": fmt.Sprintf("%#x", blockRoot),
+		"missing":   missing,
+		"recovered": len(recovered),
+		"outcome":   outcome,
+	}).Debug("Recovered blobs from EL")
+}
+
+func hasAllBlobs(hasIndex func(uint64) bool, count int) bool {
+	for i := 0; i < count; i++ {
+		if !hasIndex(uint64(i)) {
+			return false
+		}
+	}
+	return true
+}
+
+// broadcastAndReceiveRecoveredBlobs broadcasts the sidecars recovered from the EL which have not arrived
+// in the meantime, then saves them. It returns the indices of the sidecars received.
+func (s *Service) broadcastAndReceiveRecoveredBlobs(ctx context.Context, blobSidecars []blocks.VerifiedROBlob, hasIndex func(uint64) bool, startTime time.Time) []uint64 {
+	var pending []blocks.VerifiedROBlob
+	seen := make(map[uint64]bool)
+	for _, sidecar := range blobSidecars {
+		// Don't broadcast the blob if it has appeared on disk.
+		if !hasIndex(sidecar.Index) && !seen[sidecar.Index] {
+			seen[sidecar.Index] = true
+			pending = append(pending, sidecar)
+		}
+	}
+
+	// Broadcast blob sidecars first than save them to the db
+	for _, sidecar := range pending {
+		if err := s.cfg.p2p.BroadcastBlob(ctx, sidecar.Index, sidecar.BlobSidecar); err != nil {
+			log.WithFields(blobFields(sidecar.ROBlob)).WithError(err).Error("Failed to broadcast blob sidecar")
+		}
+	}
+
+	received := make([]uint64, 0, len(pending))
+	for _, sidecar := range pending {
+		if err := s.subscribeBlob(ctx, sidecar); err != nil {
+			log.WithFields(blobFields(sidecar.ROBlob)).WithError(err).Error("Failed to receive blob")
+			continue
+		}
+
+		received = append(received, sidecar.Index)
+		blobRecoveredFromELTotal.Inc()
+		fields := blobFields(sidecar.ROBlob)
+		fields["sinceSlotStartTime"] = s.cfg.clock.Now().Sub(startTime)
+		log.WithFields(fields).Debug("Processed blob sidecar from EL")
+	}
+	return received
+}
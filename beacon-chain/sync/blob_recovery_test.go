@@ -0,0 +1,126 @@
+package sync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	chainMock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	mockExecution "github.com/prysmaticlabs/prysm/v5/beacon-chain/execution/testing"
+	mockp2p "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
+	lruwrpr "github.com/prysmaticlabs/prysm/v5/cache/lru"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestReconstructAndBroadcastBlobs(t *testing.T) {
+	block, robs := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 1, 6)
+	verified := make([]blocks.VerifiedROBlob, len(robs))
+	for i := range robs {
+		verified[i] = blocks.NewVerifiedROBlob(robs[i])
+	}
+
+	tests := []struct {
+		name              string
+		blobSidecars      []blocks.VerifiedROBlob
+		stored            []blocks.VerifiedROBlob
+		running           bool
+		expectedBlobCount int
+	}{
+		{
+			name:              "Constructed 0 blobs",
+			blobSidecars:      nil,
+			expectedBlobCount: 0,
+		},
+		{
+			name:              "Constructed 6 blobs",
+			blobSidecars:      verified,
+			expectedBlobCount: 6,
+		},
+		{
+			name:              "Constructed 3 blobs",
+			blobSidecars:      verified[:3],
+			expectedBlobCount: 3,
+		},
+		{
+			name:              "Duplicate blobs received once",
+			blobSidecars:      []blocks.VerifiedROBlob{verified[0], verified[0], verified[1]},
+			expectedBlobCount: 2,
+		},
+		{
+			name:              "Stored blobs not received",
+			blobSidecars:      verified,
+			stored:            verified[2:5],
+			expectedBlobCount: 3,
+		},
+		{
+			name:              "All blobs stored",
+			blobSidecars:      verified,
+			stored:            verified,
+			expectedBlobCount: 0,
+		},
+		{
+			name:              "Recovery already running",
+			blobSidecars:      verified,
+			running:           true,
+			expectedBlobCount: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chainService := &chainMock.ChainService{
+				Genesis: time.Now(),
+			}
+			blobStorage := filesystem.NewEphemeralBlobStorage(t)
+			for _, sidecar := range tt.stored {
+				require.NoError(t, blobStorage.Save(sidecar))
+			}
+			s := Service{
+				cfg: &config{
+					p2p:         mockp2p.NewTestP2P(t),
+					chain:       chainService,
+					clock:       startup.NewClock(time.Now(), [32]byte{}),
+					blobStorage: blobStorage,
+					executionReconstructor: &mockExecution.EngineClient{
+						BlobSidecars: tt.blobSidecars,
+					},
+					operationNotifier: &chainMock.MockOperationNotifier{},
+				},
+				seenBlobCache: lruwrpr.New(1),
+			}
+			if tt.running {
+				s.blobRecoveries.Store(block.Root(), struct{}{})
+			}
+			s.reconstructAndBroadcastBlobs(context.Background(), block)
+			require.Equal(t, tt.expectedBlobCount, len(chainService.Blobs))
+		})
+	}
+}
+
+func TestReconstructAndBroadcastBlobs_ContextCanceled(t *testing.T) {
+	block, _ := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 1, 2)
+	chainService := &chainMock.ChainService{
+		Genesis: time.Now(),
+	}
+	s := Service{
+		cfg: &config{
+			p2p:                    mockp2p.NewTestP2P(t),
+			chain:                  chainService,
+			clock:                  startup.NewClock(time.Now(), [32]byte{}),
+			blobStorage:            filesystem.NewEphemeralBlobStorage(t),
+			executionReconstructor: &mockExecution.EngineClient{},
+		},
+		seenBlobCache: lruwrpr.New(1),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+	s.reconstructAndBroadcastBlobs(ctx, block)
+	require.Equal(t, true, time.Since(start) < blobRecoveryRetryInterval)
+	_, running := s.blobRecoveries.Load(block.Root())
+	require.Equal(t, false, running)
+}
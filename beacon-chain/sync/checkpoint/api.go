@@ -1,42 +1,106 @@
 package checkpoint
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"path"
+	"sort"
+	"strconv"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/api/client"
 	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/kzg"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v5/encoding/ssz/detect"
 	"github.com/prysmaticlabs/prysm/v5/io/file"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
 	"github.com/sirupsen/logrus"
 )
 
-var errCheckpointBlockMismatch = errors.New("mismatch between checkpoint sync state and block")
+var (
+	errCheckpointBlockMismatch = errors.New("mismatch between checkpoint sync state and block")
+	errCheckpointRootMismatch  = errors.New("checkpoint sync data does not match the finalized checkpoint")
+	errCheckpointQuorum        = errors.New("checkpoint sync providers do not agree on the finalized checkpoint")
+	errBlobSidecarMismatch     = errors.New("checkpoint sync blob sidecars do not match the block")
+)
 
 // APIInitializer manages initializing the beacon node using checkpoint sync, retrieving the checkpoint state and root
-// from the remote beacon node api.
+// from one or more remote beacon node apis. A quorum of the providers must agree on the finalized checkpoint. Providers
+// are compared at the latest epoch finalized by a quorum of them, so that providers lagging behind by a few epochs
+// still take part in the quorum.
 type APIInitializer struct {
-	c *beacon.Client
+	clients     []*beacon.Client
+	quorum      int
+	fetchBlobs  bool
+	blobStorage *filesystem.BlobStorage
 }
 
-// NewAPIInitializer creates an APIInitializer, handling the set up of a beacon node api client
-// using the provided host string.
-func NewAPIInitializer(beaconNodeHost string) (*APIInitializer, error) {
-	c, err := beacon.NewClient(beaconNodeHost, client.WithMaxBodySize(client.MaxBodySizeState))
-	if err != nil {
-		return nil, errors.Wrapf(err, "unable to parse beacon node url or hostname - %s", beaconNodeHost)
+// APIInitializerOption is a functional option for the APIInitializer.
+type APIInitializerOption func(*APIInitializer)
+
+// WithQuorum sets the number of providers which must agree on the finalized checkpoint.
+// All providers must agree by default.
+func WithQuorum(quorum int) APIInitializerOption {
+	return func(dl *APIInitializer) {
+		dl.quorum = quorum
+	}
+}
+
+// WithBlobSidecars makes the initializer also download the blob sidecars of the checkpoint block,
+// so that the node is able to serve them immediately.
+func WithBlobSidecars() APIInitializerOption {
+	return func(dl *APIInitializer) {
+		dl.fetchBlobs = true
+	}
+}
+
+// NewAPIInitializer creates an APIInitializer, handling the set up of the beacon node api clients
+// using the provided host strings.
+func NewAPIInitializer(beaconNodeHosts []string, opts ...APIInitializerOption) (*APIInitializer, error) {
+	if len(beaconNodeHosts) == 0 {
+		return nil, errors.New("no checkpoint sync provider given")
+	}
+	dl := &APIInitializer{clients: make([]*beacon.Client, len(beaconNodeHosts))}
+	for i, host := range beaconNodeHosts {
+		c, err := beacon.NewClient(host, client.WithMaxBodySize(client.MaxBodySizeState))
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to parse beacon node url or hostname - %s", host)
+		}
+		dl.clients[i] = c
+	}
+	for _, o := range opts {
+		o(dl)
 	}
-	return &APIInitializer{c: c}, nil
+	if dl.quorum <= 0 {
+		dl.quorum = len(dl.clients)
+	}
+	if dl.quorum > len(dl.clients) {
+		return nil, errors.Errorf("checkpoint sync quorum %d is larger than the number of providers %d", dl.quorum, len(dl.clients))
+	}
+	return dl, nil
+}
+
+// BlobStorageSetter is implemented by initializers which save blob sidecars along with the origin state and block.
+type BlobStorageSetter interface {
+	SetBlobStorage(bs *filesystem.BlobStorage)
+}
+
+// SetBlobStorage sets the storage in which the blob sidecars of the checkpoint block are saved.
+func (dl *APIInitializer) SetBlobStorage(bs *filesystem.BlobStorage) {
+	dl.blobStorage = bs
 }
 
 // Initialize downloads origin state and block for checkpoint sync and initializes database records to
@@ -50,11 +114,200 @@ func (dl *APIInitializer) Initialize(ctx context.Context, d db.Database) error {
 	if err != nil && !errors.Is(err, db.ErrNotFound) {
 		return errors.Wrap(err, "error while checking database for origin root")
 	}
-	od, err := DownloadFinalizedData(ctx, dl.c)
+	cp, providers, err := dl.finalizedCheckpoint(ctx)
 	if err != nil {
+		return errors.Wrap(err, "Error retrieving finalized checkpoint")
+	}
+	var od *OriginData
+	var provider *beacon.Client
+	for _, c := range providers {
+		od, err = DownloadCheckpointData(ctx, c, cp)
+		if err == nil {
+			provider = c
+			break
+		}
+		log.WithError(err).WithField("provider", c.BaseURL().Redacted()).Warn("Could not download checkpoint state and block")
+	}
+	if od == nil {
 		return errors.Wrap(err, "Error retrieving checkpoint origin state and block")
 	}
-	return d.SaveOrigin(ctx, od.StateBytes(), od.BlockBytes())
+	if err := d.SaveOrigin(ctx, od.StateBytes(), od.BlockBytes()); err != nil {
+		return err
+	}
+	if dl.fetchBlobs {
+		if err := dl.saveBlobSidecars(ctx, provider, od); err != nil {
+			return errors.Wrap(err, "Error retrieving checkpoint block blob sidecars")
+		}
+	}
+	return nil
+}
+
+// Checkpoint identifies the finalized checkpoint agreed upon by the checkpoint sync providers.
+type Checkpoint struct {
+	Epoch primitives.Epoch
+	Root  [32]byte
+}
+
+// finalizedCheckpoint queries the finalized checkpoint of each provider, and compares the providers at the latest
+// epoch finalized by a quorum of them. It returns the checkpoint at that epoch reported by the most providers, along
+// with these providers, if they reach the quorum.
+func (dl *APIInitializer) finalizedCheckpoint(ctx context.Context) (Checkpoint, []*beacon.Client, error) {
+	finalized := make(map[*beacon.Client]Checkpoint, len(dl.clients))
+	epochs := make([]primitives.Epoch, 0, len(dl.clients))
+	for _, c := range dl.clients {
+		cp, err := finalizedCheckpoint(ctx, c)
+		if err != nil {
+			log.WithError(err).WithField("provider", c.BaseURL().Redacted()).Warn("Could not retrieve finalized checkpoint")
+			continue
+		}
+		log.WithFields(logrus.Fields{
+			"provider": c.BaseURL().Redacted(),
+			"epoch":    cp.Epoch,
+			"root":     fmt.Sprintf("%#x", cp.Root),
+		}).Info("Retrieved finalized checkpoint from provider")
+		finalized[c] = cp
+		epochs = append(epochs, cp.Epoch)
+	}
+	if len(epochs) < dl.quorum {
+		return Checkpoint{}, nil, errors.Wrapf(errCheckpointQuorum, "%d of %d providers returned a finalized checkpoint, %d required",
+			len(epochs), len(dl.clients), dl.quorum)
+	}
+	sort.Slice(epochs, func(i, j int) bool { return epochs[i] > epochs[j] })
+	epoch := epochs[dl.quorum-1]
+
+	votes := make(map[Checkpoint][]*beacon.Client)
+	for _, c := range dl.clients {
+		cp, ok := finalized[c]
+		if !ok || cp.Epoch < epoch {
+			continue
+		}
+		if cp.Epoch > epoch {
+			root, err := checkpointRoot(ctx, c, epoch)
+			if err != nil {
+				log.WithError(err).WithField("provider", c.BaseURL().Redacted()).WithField("epoch", epoch).
+					Warn("Could not retrieve checkpoint root")
+				continue
+			}
+			cp = Checkpoint{Epoch: epoch, Root: root}
+		}
+		votes[cp] = append(votes[cp], c)
+	}
+	var best Checkpoint
+	for cp, providers := range votes {
+		n := len(votes[best])
+		if len(providers) > n || (len(providers) == n && cp.Epoch > best.Epoch) {
+			best = cp
+		}
+	}
+	if len(votes) > 1 {
+		for cp, providers := range votes {
+			log.WithFields(logrus.Fields{
+				"epoch":     cp.Epoch,
+				"root":      fmt.Sprintf("%#x", cp.Root),
+				"providers": len(providers),
+			}).Warn("Checkpoint sync providers disagree on the finalized checkpoint")
+		}
+	}
+	if len(votes[best]) < dl.quorum {
+		return Checkpoint{}, nil, errors.Wrapf(errCheckpointQuorum, "%d of %d providers agree on %#x:%d, %d required",
+			len(votes[best]), len(dl.clients), best.Root, best.Epoch, dl.quorum)
+	}
+	if best.Root == params.BeaconConfig().ZeroHash {
+		return Checkpoint{}, nil, errors.New("providers have not finalized a checkpoint yet")
+	}
+	return best, votes[best], nil
+}
+
+// checkpointRoot returns the root of the checkpoint of a finalized epoch, which is the root of the latest block
+// at or before the start slot of the epoch.
+func checkpointRoot(ctx context.Context, c *beacon.Client, epoch primitives.Epoch) ([32]byte, error) {
+	slot, err := slots.EpochStart(epoch)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	for {
+		root, err := c.GetBlockRoot(ctx, beacon.IdFromSlot(slot))
+		if err == nil {
+			return root, nil
+		}
+		// There is no block at skipped slots.
+		if !errors.Is(err, client.ErrNotFound) || slot == 0 {
+			return [32]byte{}, err
+		}
+		slot--
+	}
+}
+
+func finalizedCheckpoint(ctx context.Context, c *beacon.Client) (Checkpoint, error) {
+	fc, err := c.GetFinalityCheckpoints(ctx, beacon.IdHead)
+	if err != nil {
+		return Checkpoint{}, err
+	}
+	epoch, err := strconv.ParseUint(fc.Finalized.Epoch, 10, 64)
+	if err != nil {
+		return Checkpoint{}, errors.Wrapf(err, "invalid finalized epoch %s", fc.Finalized.Epoch)
+	}
+	root, err := hexutil.Decode(fc.Finalized.Root)
+	if err != nil || len(root) != 32 {
+		return Checkpoint{}, errors.Errorf("invalid finalized root %s", fc.Finalized.Root)
+	}
+	return Checkpoint{Epoch: primitives.Epoch(epoch), Root: bytesutil.ToBytes32(root)}, nil
+}
+
+// saveBlobSidecars downloads the blob sidecars of the checkpoint block from the given provider,
+// verifies them against the block and saves them to the blob storage.
+func (dl *APIInitializer) saveBlobSidecars(ctx context.Context, c *beacon.Client, od *OriginData) error {
+	if od.b.Version() < version.Deneb {
+		return nil
+	}
+	commitments, err := od.b.Block().Body().BlobKzgCommitments()
+	if err != nil {
+		return err
+	}
+	if len(commitments) == 0 {
+		return nil
+	}
+	if dl.blobStorage == nil {
+		return errors.New("blob storage is not available")
+	}
+	sb, err := c.GetBlobSidecars(ctx, beacon.IdFromRoot(od.br))
+	if err != nil {
+		return err
+	}
+	if len(sb) != len(commitments)*fieldparams.BlobSidecarSize {
+		return errors.Wrapf(errBlobSidecarMismatch, "received %d bytes for %d blob sidecars", len(sb), len(commitments))
+	}
+	if err := kzg.Start(); err != nil {
+		return errors.Wrap(err, "could not initialize go-kzg context")
+	}
+	sidecars := make([]blocks.ROBlob, len(commitments))
+	for i := range commitments {
+		sc := &ethpb.BlobSidecar{}
+		if err := sc.UnmarshalSSZ(sb[i*fieldparams.BlobSidecarSize : (i+1)*fieldparams.BlobSidecarSize]); err != nil {
+			return errors.Wrap(err, "could not unmarshal blob sidecar")
+		}
+		rob, err := blocks.NewROBlob(sc)
+		if err != nil {
+			return err
+		}
+		if rob.BlockRoot() != od.br || rob.Index != uint64(i) || !bytes.Equal(rob.KzgCommitment, commitments[i]) {
+			return errors.Wrapf(errBlobSidecarMismatch, "unexpected blob sidecar with index %d and block root %#x", rob.Index, rob.BlockRoot())
+		}
+		if err := blocks.VerifyKZGInclusionProof(rob); err != nil {
+			return errors.Wrapf(err, "invalid inclusion proof for blob sidecar %d", i)
+		}
+		sidecars[i] = rob
+	}
+	if err := kzg.Verify(sidecars...); err != nil {
+		return errors.Wrap(err, "invalid kzg proof for blob sidecars")
+	}
+	for _, rob := range sidecars {
+		if err := dl.blobStorage.Save(blocks.NewVerifiedROBlob(rob)); err != nil {
+			return errors.Wrapf(err, "could not save blob sidecar %d", rob.Index)
+		}
+	}
+	log.WithField("blobSidecars", len(sidecars)).Info("Downloaded checkpoint block blob sidecars")
+	return nil
 }
 
 // OriginData represents the BeaconState and ReadOnlySignedBeaconBlock necessary to start an empty Beacon Node
@@ -97,6 +350,76 @@ func fname(prefix string, vu *detect.VersionedUnmarshaler, slot primitives.Slot,
 	return fmt.Sprintf("%s_%s_%s_%d-%#x.ssz", prefix, vu.Config.ConfigName, version.String(vu.Fork), slot, root)
 }
 
+// DownloadCheckpointData downloads the state and block of the given finalized checkpoint. The checkpoint state is the
+// state at the start of the checkpoint epoch. Its latest block header and the downloaded block must match the checkpoint root.
+func DownloadCheckpointData(ctx context.Context, client *beacon.Client, cp Checkpoint) (*OriginData, error) {
+	slot, err := slots.EpochStart(cp.Epoch)
+	if err != nil {
+		return nil, err
+	}
+	sb, err := client.GetState(ctx, beacon.IdFromSlot(slot))
+	if err != nil {
+		return nil, err
+	}
+	vu, err := detect.FromState(sb)
+	if err != nil {
+		return nil, errors.Wrap(err, "error detecting chain config for checkpoint state")
+	}
+	s, err := vu.UnmarshalBeaconState(sb)
+	if err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling checkpoint state to correct version")
+	}
+	sr, err := s.HashTreeRoot(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compute htr for checkpoint state at slot=%d", s.Slot())
+	}
+
+	// The state root of the latest block header is only filled in by the slot following the block.
+	header := s.LatestBlockHeader()
+	if bytesutil.ToBytes32(header.StateRoot) == params.BeaconConfig().ZeroHash {
+		header.StateRoot = sr[:]
+	}
+	hr, err := header.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "error computing hash_tree_root of the latest block header of the checkpoint state")
+	}
+	if hr != cp.Root {
+		return nil, errors.Wrapf(errCheckpointRootMismatch, "state latest block header root = %#x, checkpoint root = %#x", hr, cp.Root)
+	}
+
+	bb, err := client.GetBlock(ctx, beacon.IdFromRoot(cp.Root))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting block by root = %#x", cp.Root)
+	}
+	b, err := vu.UnmarshalBeaconBlock(bb)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to unmarshal block to a supported type using the detected fork schedule")
+	}
+	br, err := b.Block().HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "error computing hash_tree_root of retrieved block")
+	}
+	if br != cp.Root {
+		return nil, errors.Wrapf(errCheckpointRootMismatch, "block root = %#x, checkpoint root = %#x", br, cp.Root)
+	}
+
+	log.
+		WithField("blockSlot", b.Block().Slot()).
+		WithField("stateSlot", s.Slot()).
+		WithField("stateRoot", hexutil.Encode(sr[:])).
+		WithField("blockRoot", hexutil.Encode(br[:])).
+		Info("Downloaded checkpoint sync state and block.")
+	return &OriginData{
+		st: s,
+		b:  b,
+		sb: sb,
+		bb: bb,
+		vu: vu,
+		br: br,
+		sr: sr,
+	}, nil
+}
+
 // DownloadFinalizedData downloads the most recently finalized state, and the block most recently applied to that state.
 // This pair can be used to initialize a new beacon node via checkpoint sync.
 func DownloadFinalizedData(ctx context.Context, client *beacon.Client) (*OriginData, error) {
@@ -3,13 +3,17 @@ package checkpoint
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/api/client"
 	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	blocktest "github.com/prysmaticlabs/prysm/v5/consensus-types/blocks/testing"
@@ -113,6 +117,155 @@ func TestDownloadFinalizedData(t *testing.T) {
 	require.Equal(t, expected.sr, od.sr)
 }
 
+// checkpointTestData returns the ssz-encoded state and block of a checkpoint at the start of an epoch,
+// along with the checkpoint epoch and root.
+func checkpointTestData(t *testing.T) (ms []byte, mb []byte, cp Checkpoint) {
+	ctx := context.Background()
+	cfg := params.MainnetConfig().Copy()
+	epoch := cfg.AltairForkEpoch - 1
+	slot, err := slots.EpochStart(epoch)
+	require.NoError(t, err)
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	fork, err := forks.ForkForEpochFromConfig(cfg, epoch)
+	require.NoError(t, err)
+	require.NoError(t, st.SetFork(fork))
+	require.NoError(t, st.SetSlot(slot))
+
+	b, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlock())
+	require.NoError(t, err)
+	b, err = blocktest.SetBlockSlot(b, slot)
+	require.NoError(t, err)
+	header, err := b.Header()
+	require.NoError(t, err)
+	require.NoError(t, st.SetLatestBlockHeader(header.Header))
+	sr, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	b, err = blocktest.SetBlockStateRoot(b, sr)
+	require.NoError(t, err)
+	br, err := b.Block().HashTreeRoot()
+	require.NoError(t, err)
+
+	mb, err = b.MarshalSSZ()
+	require.NoError(t, err)
+	ms, err = st.MarshalSSZ()
+	require.NoError(t, err)
+	return ms, mb, Checkpoint{Epoch: epoch, Root: br}
+}
+
+func finalityCheckpointsResponse(t *testing.T, cp Checkpoint) []byte {
+	resp := &structs.GetFinalityCheckpointsResponse{Data: &structs.FinalityCheckpoints{
+		Finalized: &structs.Checkpoint{Epoch: fmt.Sprintf("%d", cp.Epoch), Root: hexutil.Encode(cp.Root[:])},
+	}}
+	b, err := json.Marshal(resp)
+	require.NoError(t, err)
+	return b
+}
+
+func checkpointTestClient(t *testing.T, ms, mb []byte, finalized Checkpoint, served Checkpoint) *beacon.Client {
+	slot, err := slots.EpochStart(served.Epoch)
+	require.NoError(t, err)
+	trans := &testRT{rt: func(req *http.Request) (*http.Response, error) {
+		res := &http.Response{Request: req, StatusCode: http.StatusOK}
+		switch req.URL.Path {
+		case "/eth/v1/beacon/states/head/finality_checkpoints":
+			res.Body = io.NopCloser(bytes.NewBuffer(finalityCheckpointsResponse(t, finalized)))
+		case beacon.RenderGetStatePath(beacon.IdFromSlot(slot)):
+			res.Body = io.NopCloser(bytes.NewBuffer(ms))
+		case beacon.RenderGetBlockPath(beacon.IdFromRoot(served.Root)):
+			res.Body = io.NopCloser(bytes.NewBuffer(mb))
+		case fmt.Sprintf("/eth/v1/beacon/blocks/%d/root", slot):
+			res.Body = io.NopCloser(bytes.NewBufferString(fmt.Sprintf(`{"data":{"root":"%#x"}}`, served.Root)))
+		default:
+			res.StatusCode = http.StatusInternalServerError
+			res.Body = io.NopCloser(bytes.NewBufferString(""))
+		}
+		return res, nil
+	}}
+	c, err := beacon.NewClient("http://localhost:3500", client.WithRoundTripper(trans))
+	require.NoError(t, err)
+	return c
+}
+
+func TestDownloadCheckpointData(t *testing.T) {
+	ctx := context.Background()
+	ms, mb, cp := checkpointTestData(t)
+	c := checkpointTestClient(t, ms, mb, cp, cp)
+	od, err := DownloadCheckpointData(ctx, c, cp)
+	require.NoError(t, err)
+	require.Equal(t, true, bytes.Equal(ms, od.sb))
+	require.Equal(t, true, bytes.Equal(mb, od.bb))
+	require.Equal(t, cp.Root, od.br)
+
+	// The state does not point at a different checkpoint root.
+	wrong := Checkpoint{Epoch: cp.Epoch, Root: [32]byte{'a'}}
+	c = checkpointTestClient(t, ms, mb, wrong, wrong)
+	_, err = DownloadCheckpointData(ctx, c, wrong)
+	require.ErrorIs(t, err, errCheckpointRootMismatch)
+}
+
+func TestAPIInitializer_FinalizedCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	ms, mb, cp := checkpointTestData(t)
+	other := Checkpoint{Epoch: cp.Epoch - 1, Root: [32]byte{'a'}}
+	ahead := Checkpoint{Epoch: cp.Epoch + 1, Root: [32]byte{'b'}}
+	clients := []*beacon.Client{
+		checkpointTestClient(t, ms, mb, cp, cp),
+		checkpointTestClient(t, ms, mb, other, cp),
+		checkpointTestClient(t, ms, mb, cp, cp),
+		// A provider which finalized a later epoch is compared at the epoch finalized by the quorum.
+		checkpointTestClient(t, ms, mb, ahead, cp),
+	}
+
+	dl := &APIInitializer{clients: clients, quorum: 3}
+	got, providers, err := dl.finalizedCheckpoint(ctx)
+	require.NoError(t, err)
+	require.Equal(t, cp, got)
+	require.Equal(t, 3, len(providers))
+	require.Equal(t, clients[0], providers[0])
+	require.Equal(t, clients[2], providers[1])
+	require.Equal(t, clients[3], providers[2])
+
+	dl.quorum = 4
+	_, _, err = dl.finalizedCheckpoint(ctx)
+	require.ErrorIs(t, err, errCheckpointQuorum)
+}
+
+func TestCheckpointRoot_SkippedSlots(t *testing.T) {
+	root := [32]byte{'c'}
+	start, err := slots.EpochStart(2)
+	require.NoError(t, err)
+	trans := &testRT{rt: func(req *http.Request) (*http.Response, error) {
+		res := &http.Response{Request: req, StatusCode: http.StatusNotFound, Body: io.NopCloser(bytes.NewBufferString(""))}
+		// The checkpoint block is two slots before the start of the epoch.
+		if req.URL.Path == fmt.Sprintf("/eth/v1/beacon/blocks/%d/root", start-2) {
+			res.StatusCode = http.StatusOK
+			res.Body = io.NopCloser(bytes.NewBufferString(fmt.Sprintf(`{"data":{"root":"%#x"}}`, root)))
+		}
+		return res, nil
+	}}
+	c, err := beacon.NewClient("http://localhost:3500", client.WithRoundTripper(trans))
+	require.NoError(t, err)
+	got, err := checkpointRoot(context.Background(), c, 2)
+	require.NoError(t, err)
+	require.Equal(t, root, got)
+}
+
+func TestNewAPIInitializer_Quorum(t *testing.T) {
+	hosts := []string{"http://localhost:3500", "http://localhost:3501"}
+	dl, err := NewAPIInitializer(hosts)
+	require.NoError(t, err)
+	require.Equal(t, 2, dl.quorum)
+	dl, err = NewAPIInitializer(hosts, WithQuorum(1), WithBlobSidecars())
+	require.NoError(t, err)
+	require.Equal(t, 1, dl.quorum)
+	require.Equal(t, true, dl.fetchBlobs)
+	_, err = NewAPIInitializer(hosts, WithQuorum(3))
+	require.ErrorContains(t, "larger than the number of providers", err)
+	_, err = NewAPIInitializer(nil)
+	require.ErrorContains(t, "no checkpoint sync provider", err)
+}
+
 type testRT struct {
 	rt func(*http.Request) (*http.Response, error)
 }
@@ -0,0 +1,307 @@
+package sync
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	lruwrpr "github.com/prysmaticlabs/prysm/v5/cache/lru"
+	prysmTime "github.com/prysmaticlabs/prysm/v5/time"
+)
+
+const (
+	// Number of peers for which gossip validation failures are tracked.
+	gossipStatsPeerLimit = 1000
+	// Number of recent failures kept as examples for each topic and reason.
+	gossipStatsExampleLimit = 5
+	// Maximum length of the error message kept in an example.
+	gossipStatsErrorLength = 256
+)
+
+// Reason codes attached to gossip messages which are rejected or ignored during validation.
+// These are exposed in metrics and through the API, so they must remain stable.
+const (
+	reasonUnspecified             = "unspecified"
+	reasonOther                   = "other"
+	reasonTimeout                 = "timeout"
+	reasonChainNotStarted         = "chain_not_started"
+	reasonSyncing                 = "syncing"
+	reasonInvalidTopic            = "invalid_topic"
+	reasonForkDigestMismatch      = "fork_digest_mismatch"
+	reasonInternalError           = "internal_error"
+	reasonDecodeFailed            = "decode_failed"
+	reasonWrongMessageType        = "wrong_message_type"
+	reasonMalformed               = "malformed"
+	reasonAlreadySeen             = "already_seen"
+	reasonGenesisSlot             = "genesis_slot"
+	reasonSlotOutOfRange          = "slot_out_of_range"
+	reasonFutureSlot              = "future_slot"
+	reasonBeforeFinalized         = "before_finalized"
+	reasonInvalidTargetEpoch      = "invalid_target_epoch"
+	reasonBadBlock                = "references_bad_block"
+	reasonUnknownBlock            = "unknown_block"
+	reasonNotDescendantOfFinal    = "not_descendant_of_finalized"
+	reasonInconsistentVote        = "inconsistent_lmd_ffg_vote"
+	reasonStateUnavailable        = "state_unavailable"
+	reasonWrongSubnet             = "wrong_subnet"
+	reasonInvalidCommitteeIndex   = "invalid_committee_index"
+	reasonInvalidAggregationBits  = "invalid_aggregation_bits"
+	reasonNotInCommittee          = "not_in_committee"
+	reasonNotAggregator           = "not_aggregator"
+	reasonInvalidSignature        = "invalid_signature"
+	reasonInvalidProposer         = "invalid_proposer"
+	reasonInvalidParent           = "invalid_parent"
+	reasonInvalidExecutionPayload = "invalid_execution_payload"
+	reasonInvalidBlobIndex        = "invalid_blob_index"
+	reasonInvalidInclusionProof   = "invalid_inclusion_proof"
+	reasonInvalidKzgProof         = "invalid_kzg_proof"
+	reasonInvalidOperation        = "invalid_operation"
+)
+
+// gossipValidationError tags a gossip validation failure with a stable reason code,
+// so that failures can be aggregated by reason.
+type gossipValidationError struct {
+	reason string
+	err    error
+}
+
+// Error returns the underlying error message, or the reason code if there is no underlying error.
+func (e *gossipValidationError) Error() string {
+	if e.err == nil {
+		return e.reason
+	}
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *gossipValidationError) Unwrap() error {
+	return e.err
+}
+
+// withReason tags a gossip validation error with a reason code. The error may be nil for
+// messages which are ignored for benign reasons, such as having been seen before.
+func withReason(reason string, err error) error {
+	return &gossipValidationError{reason: reason, err: err}
+}
+
+// gossipFailureReason returns the reason code a validation error was tagged with.
+func gossipFailureReason(err error) string {
+	var gErr *gossipValidationError
+	if errors.As(err, &gErr) {
+		return gErr.reason
+	}
+	switch {
+	case err == nil:
+		return reasonUnspecified
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		return reasonTimeout
+	default:
+		return reasonOther
+	}
+}
+
+// gossipFailureCause strips the reason code from a validation error. It returns nil
+// for errors which only carry a reason code.
+func gossipFailureCause(err error) error {
+	var gErr *gossipValidationError
+	if errors.As(err, &gErr) && gErr == err {
+		return gErr.err
+	}
+	return err
+}
+
+// GossipFailureExample is a recent gossip message which failed validation.
+type GossipFailureExample struct {
+	PeerID peer.ID
+	Agent  string
+	Error  string
+	Time   time.Time
+}
+
+// GossipFailureStats counts the gossip messages on a topic which failed validation for a given reason.
+type GossipFailureStats struct {
+	Topic  string
+	Result string
+	Reason string
+	Count  uint64
+	// Examples holds the most recent failures, newest first.
+	Examples []GossipFailureExample
+}
+
+// GossipPeerStats counts the gossip messages received from a peer which failed validation.
+type GossipPeerStats struct {
+	PeerID   peer.ID
+	Agent    string
+	Rejected uint64
+	Ignored  uint64
+	Failures []GossipFailureStats
+}
+
+// GossipStats is a snapshot of the gossip validation failures seen by the node.
+type GossipStats struct {
+	Since    time.Time
+	Failures []GossipFailureStats
+	Peers    []GossipPeerStats
+}
+
+// GossipStatsProvider exposes aggregated gossip validation failures.
+type GossipStatsProvider interface {
+	GossipStats() *GossipStats
+}
+
+type gossipFailureKey struct {
+	topic  string
+	result string
+	reason string
+}
+
+type peerGossipFailures struct {
+	agent  string
+	counts map[gossipFailureKey]uint64
+}
+
+// gossipStats aggregates gossip validation failures per topic, reason and peer. Only the most
+// recently active peers are kept, so that memory use is bounded regardless of the number of peers.
+type gossipStats struct {
+	sync.Mutex
+	since    time.Time
+	failures map[gossipFailureKey]*GossipFailureStats
+	peers    *lru.Cache
+}
+
+func newGossipStats() *gossipStats {
+	return &gossipStats{
+		since:    prysmTime.Now(),
+		failures: make(map[gossipFailureKey]*GossipFailureStats),
+		peers:    lruwrpr.New(gossipStatsPeerLimit),
+	}
+}
+
+func validationResultString(res pubsub.ValidationResult) string {
+	switch res {
+	case pubsub.ValidationReject:
+		return "reject"
+	case pubsub.ValidationIgnore:
+		return "ignore"
+	default:
+		return "accept"
+	}
+}
+
+// record counts a gossip message which failed validation.
+func (g *gossipStats) record(topic string, pid peer.ID, agent string, res pubsub.ValidationResult, reason string, cause error) {
+	if g == nil {
+		return
+	}
+	key := gossipFailureKey{topic: topic, result: validationResultString(res), reason: reason}
+	example := GossipFailureExample{PeerID: pid, Agent: agent, Time: prysmTime.Now()}
+	if cause != nil {
+		example.Error = cause.Error()
+		if len(example.Error) > gossipStatsErrorLength {
+			example.Error = example.Error[:gossipStatsErrorLength]
+		}
+	}
+
+	g.Lock()
+	defer g.Unlock()
+	stats, ok := g.failures[key]
+	if !ok {
+		stats = &GossipFailureStats{Topic: key.topic, Result: key.result, Reason: key.reason}
+		g.failures[key] = stats
+	}
+	stats.Count++
+	stats.Examples = append([]GossipFailureExample{example}, stats.Examples...)
+	if len(stats.Examples) > gossipStatsExampleLimit {
+		stats.Examples = stats.Examples[:gossipStatsExampleLimit]
+	}
+
+	if pid == "" {
+		return
+	}
+	var failures *peerGossipFailures
+	if v, ok := g.peers.Get(pid); ok {
+		failures = v.(*peerGossipFailures)
+	} else {
+		failures = &peerGossipFailures{counts: make(map[gossipFailureKey]uint64)}
+		g.peers.Add(pid, failures)
+	}
+	if agent != "" {
+		failures.agent = agent
+	}
+	failures.counts[key]++
+}
+
+// snapshot returns a copy of the aggregated failures. Failures are sorted by decreasing
+// count, and peers by decreasing number of rejected messages.
+func (g *gossipStats) snapshot() *GossipStats {
+	if g == nil {
+		return &GossipStats{}
+	}
+	g.Lock()
+	defer g.Unlock()
+	stats := &GossipStats{
+		Since:    g.since,
+		Failures: make([]GossipFailureStats, 0, len(g.failures)),
+		Peers:    make([]GossipPeerStats, 0, g.peers.Len()),
+	}
+	for _, f := range g.failures {
+		cp := *f
+		cp.Examples = append([]GossipFailureExample{}, f.Examples...)
+		stats.Failures = append(stats.Failures, cp)
+	}
+	sortGossipFailures(stats.Failures)
+	for _, k := range g.peers.Keys() {
+		v, ok := g.peers.Peek(k)
+		if !ok {
+			continue
+		}
+		pid, failures := k.(peer.ID), v.(*peerGossipFailures)
+		ps := GossipPeerStats{PeerID: pid, Agent: failures.agent}
+		for key, count := range failures.counts {
+			if key.result == "reject" {
+				ps.Rejected += count
+			} else {
+				ps.Ignored += count
+			}
+			ps.Failures = append(ps.Failures, GossipFailureStats{Topic: key.topic, Result: key.result, Reason: key.reason, Count: count})
+		}
+		sortGossipFailures(ps.Failures)
+		stats.Peers = append(stats.Peers, ps)
+	}
+	sort.Slice(stats.Peers, func(i, j int) bool {
+		if stats.Peers[i].Rejected != stats.Peers[j].Rejected {
+			return stats.Peers[i].Rejected > stats.Peers[j].Rejected
+		}
+		return stats.Peers[i].Ignored > stats.Peers[j].Ignored
+	})
+	return stats
+}
+
+func sortGossipFailures(failures []GossipFailureStats) {
+	sort.Slice(failures, func(i, j int) bool {
+		if failures[i].Count != failures[j].Count {
+			return failures[i].Count > failures[j].Count
+		}
+		if failures[i].Topic != failures[j].Topic {
+			return failures[i].Topic < failures[j].Topic
+		}
+		return failures[i].Reason < failures[j].Reason
+	})
+}
+
+// GossipStats returns the gossip validation failures seen since the node started.
+func (s *Service) GossipStats() *GossipStats {
+	return s.gossipStats.snapshot()
+}
+
+// recordGossipFailure updates the metrics and statistics for a gossip message which was rejected or ignored.
+func (s *Service) recordGossipFailure(topic string, pid peer.ID, agent string, res pubsub.ValidationResult, err error) {
+	reason := gossipFailureReason(err)
+	gossipValidationFailureCounter.WithLabelValues(topic, validationResultString(res), reason).Inc()
+	s.gossipStats.record(topic, pid, agent, res, reason, gossipFailureCause(err))
+}
@@ -0,0 +1,78 @@
+package sync
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	libp2ptest "github.com/libp2p/go-libp2p/p2p/host/peerstore/test"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestGossipFailureReason(t *testing.T) {
+	err := withReason(reasonInvalidSignature, errWrongMessage)
+	assert.Equal(t, reasonInvalidSignature, gossipFailureReason(err))
+	assert.Equal(t, errWrongMessage.Error(), err.Error())
+	require.ErrorIs(t, err, errWrongMessage)
+	assert.Equal(t, errWrongMessage, gossipFailureCause(err))
+
+	// Reasons are preserved when the error is wrapped further.
+	wrapped := errors.Wrap(err, "could not validate")
+	assert.Equal(t, reasonInvalidSignature, gossipFailureReason(wrapped))
+	assert.Equal(t, wrapped, gossipFailureCause(wrapped))
+
+	err = withReason(reasonAlreadySeen, nil)
+	assert.Equal(t, reasonAlreadySeen, gossipFailureReason(err))
+	assert.Equal(t, reasonAlreadySeen, err.Error())
+	assert.Equal(t, nil, gossipFailureCause(err))
+
+	assert.Equal(t, reasonUnspecified, gossipFailureReason(nil))
+	assert.Equal(t, reasonTimeout, gossipFailureReason(errors.Wrap(context.DeadlineExceeded, "timeout")))
+	assert.Equal(t, reasonOther, gossipFailureReason(errors.New("foo")))
+}
+
+func TestGossipStats(t *testing.T) {
+	ids := libp2ptest.GeneratePeerIDs(2)
+	bad, good := ids[0], ids[1]
+	const topic = "/eth2/01020304/blob_sidecar_0/ssz_snappy"
+	g := newGossipStats()
+	for i := 0; i < gossipStatsExampleLimit+2; i++ {
+		g.record(topic, bad, "bad/v1.0.0", pubsub.ValidationReject, reasonInvalidKzgProof, errors.New("invalid proof"))
+	}
+	g.record(topic, good, "good/v1.0.0", pubsub.ValidationIgnore, reasonAlreadySeen, nil)
+	g.record(topic, "", "", pubsub.ValidationIgnore, reasonChainNotStarted, nil)
+	g.record(topic, good, "", pubsub.ValidationReject, reasonInvalidKzgProof, errors.New(strings.Repeat("a", 2*gossipStatsErrorLength)))
+
+	stats := g.snapshot()
+	require.Equal(t, 3, len(stats.Failures))
+	assert.Equal(t, reasonInvalidKzgProof, stats.Failures[0].Reason)
+	assert.Equal(t, "reject", stats.Failures[0].Result)
+	assert.Equal(t, uint64(gossipStatsExampleLimit+3), stats.Failures[0].Count)
+	require.Equal(t, gossipStatsExampleLimit, len(stats.Failures[0].Examples))
+	// The most recent example comes first and has its error truncated.
+	assert.Equal(t, good, stats.Failures[0].Examples[0].PeerID)
+	assert.Equal(t, gossipStatsErrorLength, len(stats.Failures[0].Examples[0].Error))
+	assert.Equal(t, bad, stats.Failures[0].Examples[1].PeerID)
+	assert.Equal(t, "invalid proof", stats.Failures[0].Examples[1].Error)
+
+	// Messages without a peer are only counted per topic.
+	require.Equal(t, 2, len(stats.Peers))
+	assert.Equal(t, bad, stats.Peers[0].PeerID)
+	assert.Equal(t, "bad/v1.0.0", stats.Peers[0].Agent)
+	assert.Equal(t, uint64(gossipStatsExampleLimit+2), stats.Peers[0].Rejected)
+	assert.Equal(t, uint64(0), stats.Peers[0].Ignored)
+	assert.Equal(t, good, stats.Peers[1].PeerID)
+	// The agent of a peer is kept when it is not known for a later message.
+	assert.Equal(t, "good/v1.0.0", stats.Peers[1].Agent)
+	assert.Equal(t, uint64(1), stats.Peers[1].Rejected)
+	assert.Equal(t, uint64(1), stats.Peers[1].Ignored)
+	assert.Equal(t, 2, len(stats.Peers[1].Failures))
+
+	// A nil tracker is a no-op.
+	var empty *gossipStats
+	empty.record(topic, bad, "", pubsub.ValidationReject, reasonOther, nil)
+	assert.Equal(t, 0, len(empty.snapshot().Failures))
+}
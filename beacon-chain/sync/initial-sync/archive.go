@@ -0,0 +1,318 @@
+package initialsync
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v5/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz/detect"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	archiveSSZExt = ".ssz"
+	archiveEraExt = ".era"
+
+	// e2store records start with a header made of a 2 byte type, a 4 byte little endian length and 2 reserved bytes.
+	e2sHeaderSize = 8
+	// signedBlockSlotOffset is the offset of the slot in a ssz encoded signed block, which starts with the
+	// 4 byte offset of the message, followed by the 96 byte signature.
+	signedBlockSlotOffset = 4 + 96
+)
+
+var (
+	errInvalidArchive    = errors.New("invalid block archive file")
+	e2sTypeCompressedBlk = [2]byte{0x01, 0x00}
+	eraFileName          = regexp.MustCompile(`-(\d+)-[0-9a-fA-F]+\.era$`)
+)
+
+// WithBlockArchive sets a directory of finalized blocks which initial-sync imports before syncing from peers.
+// The directory can hold ssz encoded signed blocks, one per file with the .ssz extension, and era files.
+func WithBlockArchive(dir string) Option {
+	return func(s *Service) {
+		s.archiveDir = dir
+	}
+}
+
+// archiveEntry is a file of the block archive, along with the lowest slot of the blocks it contains.
+type archiveEntry struct {
+	path string
+	slot primitives.Slot
+	era  bool
+}
+
+// blockArchive reads the blocks of a block archive directory in slot order.
+type blockArchive struct {
+	entries []archiveEntry
+	era     *eraReader
+}
+
+func openBlockArchive(dir string) (*blockArchive, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read block archive directory %s", dir)
+	}
+	a := &blockArchive{}
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, f.Name())
+		switch strings.ToLower(filepath.Ext(f.Name())) {
+		case archiveSSZExt:
+			slot, err := sszBlockFileSlot(path)
+			if err != nil {
+				return nil, err
+			}
+			a.entries = append(a.entries, archiveEntry{path: path, slot: slot})
+		case archiveEraExt:
+			slot, err := eraFileSlot(f.Name())
+			if err != nil {
+				return nil, err
+			}
+			a.entries = append(a.entries, archiveEntry{path: path, slot: slot, era: true})
+		}
+	}
+	sort.SliceStable(a.entries, func(i, j int) bool {
+		return a.entries[i].slot < a.entries[j].slot
+	})
+	return a, nil
+}
+
+// sszBlockFileSlot reads the slot of a ssz encoded signed block, without reading the whole file.
+func sszBlockFileSlot(path string) (primitives.Slot, error) {
+	f, err := os.Open(path) // #nosec G304
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.WithError(err).WithField("path", path).Debug("Could not close block archive file")
+		}
+	}()
+	header := make([]byte, signedBlockSlotOffset+8)
+	if _, err := io.ReadFull(f, header); err != nil {
+		return 0, errors.Wrapf(errInvalidArchive, "could not read block header from %s: %v", path, err)
+	}
+	return primitives.Slot(binary.LittleEndian.Uint64(header[signedBlockSlotOffset:])), nil
+}
+
+// eraFileSlot determines the first slot of the blocks in an era file from its name, which follows the
+// <config-name>-<era-number>-<short-historical-root>.era convention. Era N holds the blocks of the
+// SLOTS_PER_HISTORICAL_ROOT slots preceding the state at slot N * SLOTS_PER_HISTORICAL_ROOT.
+func eraFileSlot(name string) (primitives.Slot, error) {
+	m := eraFileName.FindStringSubmatch(name)
+	if m == nil {
+		return 0, errors.Wrapf(errInvalidArchive, "era file name %s does not match <config-name>-<era-number>-<short-historical-root>.era", name)
+	}
+	era, err := strconv.ParseUint(m[1], 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(errInvalidArchive, "invalid era number in file name %s", name)
+	}
+	if era == 0 {
+		return 0, nil
+	}
+	return primitives.Slot((era - 1) * uint64(params.BeaconConfig().SlotsPerHistoricalRoot)), nil
+}
+
+// next returns the next block of the archive, or io.EOF once all files have been read.
+func (a *blockArchive) next() (blocks.ROBlock, error) {
+	for {
+		if a.era != nil {
+			b, err := a.era.next()
+			if !errors.Is(err, io.EOF) {
+				return b, err
+			}
+			if err := a.era.close(); err != nil {
+				return blocks.ROBlock{}, err
+			}
+			a.era = nil
+		}
+		if len(a.entries) == 0 {
+			return blocks.ROBlock{}, io.EOF
+		}
+		e := a.entries[0]
+		a.entries = a.entries[1:]
+		if !e.era {
+			return readSSZBlock(e.path)
+		}
+		er, err := openEraReader(e.path)
+		if err != nil {
+			return blocks.ROBlock{}, err
+		}
+		a.era = er
+	}
+}
+
+func (a *blockArchive) close() error {
+	if a.era == nil {
+		return nil
+	}
+	return a.era.close()
+}
+
+func readSSZBlock(path string) (blocks.ROBlock, error) {
+	enc, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return blocks.ROBlock{}, err
+	}
+	b, err := unmarshalArchiveBlock(enc)
+	if err != nil {
+		return blocks.ROBlock{}, errors.Wrapf(err, "could not read block from %s", path)
+	}
+	return b, nil
+}
+
+// eraReader reads the blocks of an era file, which is an e2store file holding snappy compressed blocks,
+// followed by a state and indices which are skipped.
+type eraReader struct {
+	path string
+	f    *os.File
+	r    *bufio.Reader
+}
+
+func openEraReader(path string) (*eraReader, error) {
+	f, err := os.Open(path) // #nosec G304
+	if err != nil {
+		return nil, err
+	}
+	return &eraReader{path: path, f: f, r: bufio.NewReader(f)}, nil
+}
+
+// next returns the next block of the era file, or io.EOF once all records have been read.
+func (e *eraReader) next() (blocks.ROBlock, error) {
+	header := make([]byte, e2sHeaderSize)
+	for {
+		if _, err := io.ReadFull(e.r, header); err != nil {
+			if errors.Is(err, io.EOF) {
+				return blocks.ROBlock{}, io.EOF
+			}
+			return blocks.ROBlock{}, errors.Wrapf(errInvalidArchive, "truncated e2store record header in %s", e.path)
+		}
+		size := int64(binary.LittleEndian.Uint32(header[2:6]))
+		if [2]byte{header[0], header[1]} != e2sTypeCompressedBlk {
+			if _, err := e.r.Discard(int(size)); err != nil {
+				return blocks.ROBlock{}, errors.Wrapf(errInvalidArchive, "truncated e2store record in %s", e.path)
+			}
+			continue
+		}
+		data := make([]byte, size)
+		if _, err := io.ReadFull(e.r, data); err != nil {
+			return blocks.ROBlock{}, errors.Wrapf(errInvalidArchive, "truncated e2store record in %s", e.path)
+		}
+		raw, err := io.ReadAll(snappy.NewReader(bytes.NewReader(data)))
+		if err != nil {
+			return blocks.ROBlock{}, errors.Wrapf(err, "could not decompress block in %s", e.path)
+		}
+		b, err := unmarshalArchiveBlock(raw)
+		if err != nil {
+			return blocks.ROBlock{}, errors.Wrapf(err, "could not read block from %s", e.path)
+		}
+		return b, nil
+	}
+}
+
+func (e *eraReader) close() error {
+	return e.f.Close()
+}
+
+func unmarshalArchiveBlock(enc []byte) (blocks.ROBlock, error) {
+	u, err := detect.FromBlock(enc)
+	if err != nil {
+		return blocks.ROBlock{}, err
+	}
+	sb, err := u.UnmarshalBeaconBlock(enc)
+	if err != nil {
+		return blocks.ROBlock{}, err
+	}
+	return blocks.NewROBlock(sb)
+}
+
+// syncFromArchive imports the blocks of the block archive which extend the chain, in batches which are verified
+// like batches downloaded from peers. Import stops at the end of the archive, at the first gap or invalid block,
+// and at the first block whose blobs must be available, as archives don't hold blobs. Sync then continues from peers.
+func (s *Service) syncFromArchive(ctx context.Context, genesis time.Time) error {
+	a, err := openBlockArchive(s.archiveDir)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := a.close(); err != nil {
+			log.WithError(err).Debug("Could not close block archive file")
+		}
+	}()
+	transition.SkipSlotCache.Disable()
+	defer transition.SkipSlotCache.Enable()
+
+	blobStart, err := sync.BlobRPCMinValidSlot(s.clock.CurrentSlot())
+	if err != nil {
+		return errors.Wrap(err, "could not compute minimum blob retention slot")
+	}
+	batchSize := flags.Get().BlockBatchLimit
+	if batchSize <= 0 {
+		batchSize = 64
+	}
+	startSlot := s.cfg.Chain.HeadSlot()
+	batch := make([]blocks.BlockWithROBlobs, 0, batchSize)
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		b, err := a.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if b.Block().Slot() <= s.cfg.Chain.HeadSlot() {
+			continue
+		}
+		if b.Block().Slot() >= blobStart && b.Version() >= version.Deneb {
+			commitments, err := b.Block().Body().BlobKzgCommitments()
+			if err != nil {
+				return err
+			}
+			if len(commitments) > 0 {
+				log.WithField("slot", b.Block().Slot()).Info("Reached blocks with blobs in the retention period, stopping block archive import")
+				break
+			}
+		}
+		batch = append(batch, blocks.BlockWithROBlobs{Block: b})
+		if len(batch) < batchSize {
+			continue
+		}
+		if err := s.processBatchedBlocks(ctx, genesis, batch, s.cfg.Chain.ReceiveBlockBatch); err != nil {
+			return err
+		}
+		batch = batch[:0]
+	}
+	if len(batch) > 0 {
+		if err := s.processBatchedBlocks(ctx, genesis, batch, s.cfg.Chain.ReceiveBlockBatch); err != nil {
+			return err
+		}
+	}
+	log.WithFields(logrus.Fields{
+		"startSlot":  startSlot,
+		"syncedSlot": s.cfg.Chain.HeadSlot(),
+	}).Info("Imported blocks from block archive")
+	return nil
+}
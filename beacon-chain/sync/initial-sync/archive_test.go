@@ -0,0 +1,155 @@
+package initialsync
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/snappy"
+	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	dbtest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	p2pt "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+// testArchiveChain returns a chain of n blocks following parent, starting at slot start.
+func testArchiveChain(t *testing.T, parent [32]byte, start primitives.Slot, n int) []*eth.SignedBeaconBlock {
+	blks := make([]*eth.SignedBeaconBlock, n)
+	for i := range blks {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = start + primitives.Slot(i)
+		b.Block.ParentRoot = bytesutil.SafeCopyBytes(parent[:])
+		root, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		parent = root
+		blks[i] = b
+	}
+	return blks
+}
+
+func writeSSZBlocks(t *testing.T, dir string, blks []*eth.SignedBeaconBlock) {
+	for _, b := range blks {
+		enc, err := b.MarshalSSZ()
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, fmt.Sprintf("block_%d.ssz", b.Block.Slot)), enc, 0600))
+	}
+}
+
+func writeE2sRecord(t *testing.T, w io.Writer, typ [2]byte, data []byte) {
+	header := make([]byte, e2sHeaderSize)
+	copy(header, typ[:])
+	binary.LittleEndian.PutUint32(header[2:6], uint32(len(data)))
+	_, err := w.Write(append(header, data...))
+	require.NoError(t, err)
+}
+
+func writeEraFile(t *testing.T, path string, blks []*eth.SignedBeaconBlock) {
+	buf := &bytes.Buffer{}
+	// version record
+	writeE2sRecord(t, buf, [2]byte{0x65, 0x32}, nil)
+	for _, b := range blks {
+		enc, err := b.MarshalSSZ()
+		require.NoError(t, err)
+		c := &bytes.Buffer{}
+		sw := snappy.NewBufferedWriter(c)
+		_, err = sw.Write(enc)
+		require.NoError(t, err)
+		require.NoError(t, sw.Close())
+		writeE2sRecord(t, buf, e2sTypeCompressedBlk, c.Bytes())
+	}
+	// the state and slot index records are skipped by the reader.
+	writeE2sRecord(t, buf, [2]byte{0x02, 0x00}, []byte{1, 2, 3})
+	writeE2sRecord(t, buf, [2]byte{0x69, 0x32}, []byte{4, 5, 6, 7})
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0600))
+}
+
+func TestBlockArchive_Order(t *testing.T) {
+	dir := t.TempDir()
+	blks := testArchiveChain(t, [32]byte{}, 1, 20)
+	writeEraFile(t, filepath.Join(dir, "mainnet-00001-01234567.era"), blks[:10])
+	writeSSZBlocks(t, dir, blks[10:])
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README"), []byte("ignored"), 0600))
+
+	a, err := openBlockArchive(dir)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, a.close())
+	}()
+	for i := range blks {
+		b, err := a.next()
+		require.NoError(t, err)
+		require.Equal(t, blks[i].Block.Slot, b.Block().Slot())
+	}
+	_, err = a.next()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestEraFileSlot(t *testing.T) {
+	sphr := params.BeaconConfig().SlotsPerHistoricalRoot
+	slot, err := eraFileSlot("mainnet-00000-4b363db9.era")
+	require.NoError(t, err)
+	require.Equal(t, primitives.Slot(0), slot)
+	slot, err = eraFileSlot("mainnet-00003-5ae1ef1d.era")
+	require.NoError(t, err)
+	require.Equal(t, primitives.Slot(2*sphr), slot)
+	_, err = eraFileSlot("blocks.era")
+	require.ErrorIs(t, err, errInvalidArchive)
+}
+
+func TestService_syncFromArchive(t *testing.T) {
+	genesisBlk := util.NewBeaconBlock()
+	genesisRoot, err := genesisBlk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	newService := func(t *testing.T, dir string) (*Service, *mock.ChainService) {
+		beaconDB := dbtest.SetupDB(t)
+		util.SaveBlock(t, context.Background(), beaconDB, genesisBlk)
+		st, err := util.NewBeaconState()
+		require.NoError(t, err)
+		chain := &mock.ChainService{
+			State:               st,
+			Root:                genesisRoot[:],
+			DB:                  beaconDB,
+			FinalizedCheckPoint: &eth.Checkpoint{Epoch: 0},
+		}
+		s := NewService(context.Background(), &Config{
+			P2P:   p2pt.NewTestP2P(t),
+			DB:    beaconDB,
+			Chain: chain,
+		}, WithBlockArchive(dir))
+		return s, chain
+	}
+	genesis := makeGenesisTime(200)
+	blks := testArchiveChain(t, genesisRoot, 1, 100)
+
+	t.Run("contiguous", func(t *testing.T) {
+		dir := t.TempDir()
+		writeEraFile(t, filepath.Join(dir, "mainnet-00001-01234567.era"), blks[:70])
+		writeSSZBlocks(t, dir, blks[70:])
+		s, chain := newService(t, dir)
+		require.NoError(t, s.syncFromArchive(context.Background(), genesis))
+		require.Equal(t, primitives.Slot(100), chain.HeadSlot())
+		require.Equal(t, 100, len(chain.BlocksReceived))
+	})
+	t.Run("gap", func(t *testing.T) {
+		dir := t.TempDir()
+		writeSSZBlocks(t, dir, blks[:64])
+		writeSSZBlocks(t, dir, blks[65:])
+		s, chain := newService(t, dir)
+		require.ErrorIs(t, s.syncFromArchive(context.Background(), genesis), errParentDoesNotExist)
+		require.Equal(t, primitives.Slot(64), chain.HeadSlot())
+	})
+	t.Run("missing directory", func(t *testing.T) {
+		s, _ := newService(t, filepath.Join(t.TempDir(), "missing"))
+		require.ErrorContains(t, "could not read block archive directory", s.syncFromArchive(context.Background(), genesis))
+	})
+}
@@ -77,6 +77,7 @@ type blocksFetcherConfig struct {
 	peerFilterCapacityWeight float64
 	mode                     syncMode
 	bs                       filesystem.BlobStorageSummarizer
+	apiFallback              *prysmsync.APIFallback
 }
 
 // blocksFetcher is a service to fetch chain data from peers.
@@ -93,6 +94,7 @@ type blocksFetcher struct {
 	p2p             p2p.P2P
 	db              db.ReadOnlyDatabase
 	bs              filesystem.BlobStorageSummarizer
+	apiFallback     *prysmsync.APIFallback
 	blocksPerPeriod uint64
 	rateLimiter     *leakybucket.Collector
 	peerLocks       map[peer.ID]*peerLock
@@ -152,6 +154,7 @@ func newBlocksFetcher(ctx context.Context, cfg *blocksFetcherConfig) *blocksFetc
 		p2p:             cfg.p2p,
 		db:              cfg.db,
 		bs:              cfg.bs,
+		apiFallback:     cfg.apiFallback,
 		blocksPerPeriod: uint64(blocksPerPeriod),
 		rateLimiter:     rateLimiter,
 		peerLocks:       make(map[peer.ID]*peerLock),
@@ -299,6 +302,11 @@ func (f *blocksFetcher) handleRequest(ctx context.Context, start primitives.Slot
 
 	_, targetEpoch, peers := f.calculateHeadAndTargetEpochs()
 	if len(peers) == 0 {
+		f.apiFallback.PeerFailure()
+		if f.apiFallback.Active() {
+			response.bwb, response.err = f.fetchFromAPIFallback(ctx, start, count)
+			return response
+		}
 		response.err = errNoPeersAvailable
 		return response
 	}
@@ -321,6 +329,16 @@ func (f *blocksFetcher) handleRequest(ctx context.Context, start primitives.Slot
 		}
 		response.bwb = bwb
 	}
+	if response.err != nil && ctx.Err() == nil {
+		f.apiFallback.PeerFailure()
+		if f.apiFallback.Active() {
+			log.WithError(response.err).Debug("Could not fetch blocks from peers, using the fallback beacon node api")
+			response.pid = ""
+			response.bwb, response.err = f.fetchFromAPIFallback(ctx, start, count)
+		}
+	} else if response.err == nil {
+		f.apiFallback.PeerSuccess()
+	}
 	return response
 }
 
@@ -0,0 +1,59 @@
+package initialsync
+
+import (
+	"context"
+
+	prysmsync "github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// fetchFromAPIFallback fetches a range of blocks, along with the blobs still within the retention period,
+// from the fallback beacon node api. Like data received from peers, the blocks and blobs are verified when
+// they are processed.
+func (f *blocksFetcher) fetchFromAPIFallback(ctx context.Context, start primitives.Slot, count uint64) ([]blocks.BlockWithROBlobs, error) {
+	ctx, span := trace.StartSpan(ctx, "initialsync.fetchFromAPIFallback")
+	defer span.End()
+
+	blks, err := f.apiFallback.BlocksByRange(ctx, start, count)
+	if err != nil {
+		return nil, err
+	}
+	bwb := make([]blocks.BlockWithROBlobs, len(blks))
+	for i := range blks {
+		bwb[i] = blocks.BlockWithROBlobs{Block: blks[i]}
+	}
+	if slots.ToEpoch(f.clock.CurrentSlot()) < params.BeaconConfig().DenebForkEpoch {
+		return bwb, nil
+	}
+	blobWindowStart, err := prysmsync.BlobRPCMinValidSlot(f.clock.CurrentSlot())
+	if err != nil {
+		return nil, err
+	}
+	for i := range bwb {
+		b := bwb[i].Block
+		if b.Version() < version.Deneb || b.Block().Slot() < blobWindowStart {
+			continue
+		}
+		commits, err := b.Block().Body().BlobKzgCommitments()
+		if err != nil {
+			return nil, err
+		}
+		if len(commits) == 0 || (f.bs != nil && f.bs.Summary(b.Root()).AllAvailable(len(commits))) {
+			continue
+		}
+		blobs, err := f.apiFallback.BlobSidecars(ctx, b)
+		if err != nil {
+			return nil, err
+		}
+		if len(blobs) != len(commits) {
+			return nil, missingCommitError(b.Root(), b.Block().Slot(), commits)
+		}
+		bwb[i].Blobs = blobs
+	}
+	return bwb, nil
+}
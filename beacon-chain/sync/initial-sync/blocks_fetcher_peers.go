@@ -83,6 +83,10 @@ func (f *blocksFetcher) waitForMinimumPeers(ctx context.Context) ([]peer.ID, err
 		if len(peers) >= required {
 			return peers, nil
 		}
+		f.apiFallback.PeerFailure()
+		if f.apiFallback.Active() {
+			return peers, nil
+		}
 		log.WithFields(logrus.Fields{
 			"suitable": len(peers),
 			"required": required}).Info("Waiting for enough suitable peers before syncing")
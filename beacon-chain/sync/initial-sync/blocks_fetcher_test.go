@@ -1,9 +1,12 @@
 package initialsync
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"math"
+	"net/http"
 	"sort"
 	"sync"
 	"testing"
@@ -12,6 +15,7 @@ import (
 	libp2pcore "github.com/libp2p/go-libp2p/core"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prysmaticlabs/prysm/v5/api/client"
 	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
 	dbtest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
@@ -481,6 +485,63 @@ func TestBlocksFetcher_handleRequest(t *testing.T) {
 	})
 }
 
+type fallbackRoundTripper func(*http.Request) (*http.Response, error)
+
+func (rt fallbackRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return rt(req)
+}
+
+func TestBlocksFetcher_handleRequestAPIFallback(t *testing.T) {
+	served := make(map[string][]byte)
+	for _, slot := range []primitives.Slot{1, 2, 3, 5} {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = slot
+		enc, err := b.MarshalSSZ()
+		require.NoError(t, err)
+		served[fmt.Sprintf("/eth/v2/beacon/blocks/%d", slot)] = enc
+	}
+	rt := fallbackRoundTripper(func(req *http.Request) (*http.Response, error) {
+		b, ok := served[req.URL.Path]
+		if !ok {
+			return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(bytes.NewBuffer(nil)), Request: req}, nil
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBuffer(b)), Request: req}, nil
+	})
+	fallback, err := beaconsync.NewAPIFallback([]string{"http://localhost:3500"}, 0, client.WithRoundTripper(rt))
+	require.NoError(t, err)
+
+	mc, p2p, _ := initializeTestServices(t, []primitives.Slot{}, []*peerData{})
+	mc.ValidatorsRoot = [32]byte{}
+	mc.Genesis = time.Now()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	t.Run("no fallback", func(t *testing.T) {
+		fetcher := newBlocksFetcher(ctx, &blocksFetcherConfig{
+			chain: mc,
+			p2p:   p2p,
+			clock: startup.NewClock(mc.Genesis, mc.ValidatorsRoot),
+		})
+		response := fetcher.handleRequest(ctx, 1, 6)
+		require.ErrorIs(t, response.err, errNoPeersAvailable)
+	})
+	t.Run("fallback without peers", func(t *testing.T) {
+		fetcher := newBlocksFetcher(ctx, &blocksFetcherConfig{
+			chain:       mc,
+			p2p:         p2p,
+			clock:       startup.NewClock(mc.Genesis, mc.ValidatorsRoot),
+			apiFallback: fallback,
+		})
+		response := fetcher.handleRequest(ctx, 1, 6)
+		require.NoError(t, response.err)
+		require.Equal(t, peer.ID(""), response.pid)
+		require.Equal(t, 4, len(response.bwb))
+		for i, slot := range []primitives.Slot{1, 2, 3, 5} {
+			require.Equal(t, slot, response.bwb[i].Block.Block().Slot())
+		}
+	})
+}
+
 func TestBlocksFetcher_requestBeaconBlocksByRange(t *testing.T) {
 	blockBatchLimit := flags.Get().BlockBatchLimit
 	chainConfig := struct {
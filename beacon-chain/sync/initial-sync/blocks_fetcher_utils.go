@@ -330,6 +330,11 @@ func (f *blocksFetcher) bestFinalizedSlot() primitives.Slot {
 	cp := f.chain.FinalizedCheckpt()
 	finalizedEpoch, _ := f.p2p.Peers().BestFinalized(
 		params.BeaconConfig().MaxPeersToSync, cp.Epoch)
+	if f.apiFallback.Active() {
+		if fe, err := f.apiFallback.FinalizedEpoch(f.ctx); err == nil && fe > finalizedEpoch {
+			finalizedEpoch = fe
+		}
+	}
 	return params.BeaconConfig().SlotsPerEpoch.Mul(uint64(finalizedEpoch))
 }
 
@@ -337,6 +342,12 @@ func (f *blocksFetcher) bestFinalizedSlot() primitives.Slot {
 func (f *blocksFetcher) bestNonFinalizedSlot() primitives.Slot {
 	headEpoch := slots.ToEpoch(f.chain.HeadSlot())
 	targetEpoch, _ := f.p2p.Peers().BestNonFinalized(flags.Get().MinimumSyncPeers*2, headEpoch)
+	// The fallback beacon nodes are trusted to follow the chain up to the current slot.
+	if f.apiFallback.Active() {
+		if ce := slots.ToEpoch(f.clock.CurrentSlot()); ce > targetEpoch {
+			targetEpoch = ce
+		}
+	}
 	return params.BeaconConfig().SlotsPerEpoch.Mul(uint64(targetEpoch))
 }
 
@@ -71,6 +71,7 @@ type blocksQueueConfig struct {
 	db                  db.ReadOnlyDatabase
 	mode                syncMode
 	bs                  filesystem.BlobStorageSummarizer
+	apiFallback         *beaconsync.APIFallback
 }
 
 // blocksQueue is a priority queue that serves as a intermediary between block fetchers (producers)
@@ -107,12 +108,13 @@ func newBlocksQueue(ctx context.Context, cfg *blocksQueueConfig) *blocksQueue {
 			log.Warn("rpc fetcher starting without blob availability cache, duplicate blobs may be requested.")
 		}
 		blocksFetcher = newBlocksFetcher(ctx, &blocksFetcherConfig{
-			ctxMap: cfg.ctxMap,
-			chain:  cfg.chain,
-			p2p:    cfg.p2p,
-			db:     cfg.db,
-			clock:  cfg.clock,
-			bs:     cfg.bs,
+			ctxMap:      cfg.ctxMap,
+			chain:       cfg.chain,
+			p2p:         cfg.p2p,
+			db:          cfg.db,
+			clock:       cfg.clock,
+			bs:          cfg.bs,
+			apiFallback: cfg.apiFallback,
 		})
 	}
 	highestExpectedSlot := cfg.highestExpectedSlot
@@ -78,6 +78,7 @@ func (s *Service) startBlocksQueue(ctx context.Context, highestSlot primitives.S
 		highestExpectedSlot: highestSlot,
 		mode:                mode,
 		bs:                  s.cfg.BlobStorage,
+		apiFallback:         s.apiFallback,
 	}
 	queue := newBlocksQueue(ctx, cfg)
 	if err := queue.start(); err != nil {
@@ -205,6 +206,14 @@ func (s *Service) highestFinalizedEpoch() primitives.Epoch {
 			highest = peerChainState.FinalizedEpoch
 		}
 	}
+	if s.apiFallback.Active() {
+		fe, err := s.apiFallback.FinalizedEpoch(s.ctx)
+		if err != nil {
+			log.WithError(err).Debug("Could not get finalized epoch from the fallback beacon node api")
+		} else if fe > highest {
+			highest = fe
+		}
+	}
 
 	return highest
 }
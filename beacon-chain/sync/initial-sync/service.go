@@ -68,6 +68,8 @@ type Service struct {
 	verifierWaiter  *verification.InitializerWaiter
 	newBlobVerifier verification.NewBlobVerifier
 	ctxMap          sync.ContextByteVersions
+	archiveDir      string
+	apiFallback     *sync.APIFallback
 }
 
 // Option is a functional option for the initial-sync Service.
@@ -89,6 +91,13 @@ func WithSyncChecker(checker *SyncChecker) Option {
 	}
 }
 
+// WithAPIFallback sets the trusted beacon node apis used to fetch blocks and blobs when peers fail to serve them.
+func WithAPIFallback(f *sync.APIFallback) Option {
+	return func(s *Service) {
+		s.apiFallback = f
+	}
+}
+
 // SyncChecker allows other services to check the current status of
 // initial-sync and use that internally in their service.
 type SyncChecker struct {
@@ -154,6 +163,14 @@ func (s *Service) Start() {
 		log.Debug("Exiting Initial Sync Service")
 		return
 	}
+	if s.archiveDir != "" && !gt.After(prysmTime.Now()) {
+		if err := s.syncFromArchive(s.ctx, gt); err != nil {
+			if errors.Is(s.ctx.Err(), context.Canceled) {
+				return
+			}
+			log.WithError(err).WithField("slot", s.cfg.Chain.HeadSlot()).Warn("Could not import all blocks from block archive, syncing the rest from peers")
+		}
+	}
 	// Exit entering round-robin sync if we require 0 peers to sync.
 	if flags.Get().MinimumSyncPeers == 0 {
 		s.markSynced()
@@ -265,6 +282,10 @@ func (s *Service) waitForMinimumPeers() ([]peer.ID, error) {
 		if len(peers) >= required {
 			return peers, nil
 		}
+		s.apiFallback.PeerFailure()
+		if s.apiFallback.Active() {
+			return peers, nil
+		}
 		log.WithFields(logrus.Fields{
 			"suitable": len(peers),
 			"required": required,
@@ -350,9 +371,31 @@ func (s *Service) fetchOriginBlobs(pids []peer.ID) error {
 		log.WithField("nBlobs", len(sidecars)).WithField("root", fmt.Sprintf("%#x", r)).Info("Successfully downloaded blobs for checkpoint sync block")
 		return nil
 	}
+	if s.apiFallback.Active() {
+		return s.fetchOriginBlobsFromAPI(rob)
+	}
 	return fmt.Errorf("no connected peer able to provide blobs for checkpoint sync block %#x", r)
 }
 
+// fetchOriginBlobsFromAPI downloads the blobs of the checkpoint sync block from the fallback beacon node api.
+func (s *Service) fetchOriginBlobsFromAPI(rob blocks.ROBlock) error {
+	sidecars, err := s.apiFallback.BlobSidecars(s.ctx, rob)
+	if err != nil {
+		return errors.Wrapf(err, "could not download blobs for checkpoint sync block %#x from the fallback beacon node api", rob.Root())
+	}
+	bv := verification.NewBlobBatchVerifier(s.newBlobVerifier, verification.InitsyncBlobSidecarRequirements)
+	avs := das.NewLazilyPersistentStore(s.cfg.BlobStorage, bv)
+	current := s.clock.CurrentSlot()
+	if err := avs.Persist(current, sidecars...); err != nil {
+		return err
+	}
+	if err := avs.IsDataAvailable(s.ctx, current, rob); err != nil {
+		return errors.Wrapf(err, "blobs from the fallback beacon node api for checkpoint sync block %#x were unusable", rob.Root())
+	}
+	log.WithField("nBlobs", len(sidecars)).WithField("root", fmt.Sprintf("%#x", rob.Root())).Info("Downloaded blobs for checkpoint sync block from the fallback beacon node api")
+	return nil
+}
+
 func shufflePeers(pids []peer.ID) {
 	rg := rand.NewGenerator()
 	rg.Shuffle(len(pids), func(i, j int) {
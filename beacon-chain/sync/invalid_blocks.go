@@ -0,0 +1,72 @@
+package sync
+
+import (
+	"context"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	prysmTime "github.com/prysmaticlabs/prysm/v5/time"
+)
+
+// recordInvalidGossipBlock adds a block rejected by gossip validation to the invalid block registry.
+// Only blocks marked as bad after their proposer signature was verified are recorded, so that peers
+// cannot fill the registry with blocks they did not sign.
+func (s *Service) recordInvalidGossipBlock(ctx context.Context, blk interfaces.ReadOnlySignedBeaconBlock, root [32]byte, pid peer.ID, err error) {
+	if s.invalidBlocks == nil || ctx.Err() != nil || !s.hasBadBlock(root) {
+		return
+	}
+	switch gossipFailureReason(err) {
+	case reasonInvalidProposer, reasonInvalidExecutionPayload:
+	default:
+		return
+	}
+	enc, encErr := blk.MarshalSSZ()
+	if encErr != nil {
+		log.WithError(encErr).Error("Could not encode invalid block")
+	}
+	b := blk.Block()
+	record := &cache.InvalidBlock{
+		Root:          root,
+		ParentRoot:    b.ParentRoot(),
+		Slot:          b.Slot(),
+		ProposerIndex: b.ProposerIndex(),
+		Peer:          pid.String(),
+		Stage:         cache.InvalidBlockStageGossip,
+		Error:         err.Error(),
+		Time:          prysmTime.Now(),
+		Version:       blk.Version(),
+		Block:         enc,
+	}
+	if err := s.invalidBlocks.Add(ctx, record); err != nil {
+		log.WithError(err).Error("Could not record invalid block")
+	}
+}
+
+// recordInvalidBlob adds a blob sidecar rejected by gossip validation to the invalid block registry.
+// The block of the sidecar is not considered invalid.
+func (s *Service) recordInvalidBlob(ctx context.Context, blob blocks.ROBlob, pid peer.ID, err error) {
+	if s.invalidBlocks == nil || ctx.Err() != nil {
+		return
+	}
+	enc, encErr := blob.BlobSidecar.MarshalSSZ()
+	if encErr != nil {
+		log.WithError(encErr).Error("Could not encode invalid blob sidecar")
+		return
+	}
+	record := &cache.InvalidBlock{
+		Root:          blob.BlockRoot(),
+		ParentRoot:    blob.ParentRoot(),
+		Slot:          blob.Slot(),
+		ProposerIndex: blob.ProposerIndex(),
+		Peer:          pid.String(),
+		Stage:         cache.InvalidBlockStageGossip,
+		Error:         err.Error(),
+		Time:          prysmTime.Now(),
+		Blobs:         [][]byte{enc},
+	}
+	if err := s.invalidBlocks.Add(ctx, record); err != nil {
+		log.WithError(err).Error("Could not record invalid blob sidecar")
+	}
+}
@@ -0,0 +1,83 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestService_hasBadBlock_InvalidBlockRegistry(t *testing.T) {
+	ctx := context.Background()
+	registry := cache.NewInvalidBlockRegistry(8)
+	s := &Service{invalidBlocks: registry}
+	s.initCaches()
+
+	invalid, unavailable := [32]byte{'a'}, [32]byte{'b'}
+	require.NoError(t, registry.Add(ctx, &cache.InvalidBlock{Root: invalid, Stage: cache.InvalidBlockStageStateTransition}))
+	require.NoError(t, registry.Add(ctx, &cache.InvalidBlock{Root: unavailable, Stage: cache.InvalidBlockStageDataAvailability}))
+	assert.Equal(t, true, s.hasBadBlock(invalid))
+	assert.Equal(t, false, s.hasBadBlock(unavailable))
+}
+
+func TestService_recordInvalidGossipBlock(t *testing.T) {
+	ctx := context.Background()
+	registry := cache.NewInvalidBlockRegistry(8)
+	s := &Service{invalidBlocks: registry}
+	s.initCaches()
+
+	b := util.NewBeaconBlock()
+	b.Block.Slot = 4
+	blk, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	root, err := b.Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	// Blocks which were not marked as bad are not recorded.
+	s.recordInvalidGossipBlock(ctx, blk, root, "peer", withReason(reasonInvalidProposer, errors.New("incorrect proposer index")))
+	assert.Equal(t, 0, len(registry.List()))
+	// Blocks rejected before their signature is verified are not recorded.
+	s.setBadBlock(ctx, root)
+	s.recordInvalidGossipBlock(ctx, blk, root, "peer", withReason(reasonNotDescendantOfFinal, errors.New("not descendant")))
+	assert.Equal(t, 0, len(registry.List()))
+
+	pid := peer.ID("peer")
+	s.recordInvalidGossipBlock(ctx, blk, root, pid, withReason(reasonInvalidProposer, errors.New("incorrect proposer index")))
+	record, ok := registry.Get(root)
+	require.Equal(t, true, ok)
+	assert.Equal(t, cache.InvalidBlockStageGossip, record.Stage)
+	assert.Equal(t, pid.String(), record.Peer)
+	assert.Equal(t, "incorrect proposer index", record.Error)
+	enc, err := blk.MarshalSSZ()
+	require.NoError(t, err)
+	assert.DeepEqual(t, enc, record.Block)
+	assert.Equal(t, true, registry.IsInvalid(root))
+}
+
+func TestService_recordInvalidBlob(t *testing.T) {
+	ctx := context.Background()
+	registry := cache.NewInvalidBlockRegistry(8)
+	s := &Service{invalidBlocks: registry}
+
+	_, scs := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 1, 1)
+	pid := peer.ID("peer")
+	s.recordInvalidBlob(ctx, scs[0], pid, errors.New("invalid kzg proof"))
+
+	record, ok := registry.Get(scs[0].BlockRoot())
+	require.Equal(t, true, ok)
+	assert.Equal(t, scs[0].Slot(), record.Slot)
+	assert.Equal(t, pid.String(), record.Peer)
+	assert.Equal(t, "invalid kzg proof", record.Error)
+	require.Equal(t, 1, len(record.Blobs))
+	enc, err := scs[0].BlobSidecar.MarshalSSZ()
+	require.NoError(t, err)
+	assert.DeepEqual(t, enc, record.Blobs[0])
+	// A rejected blob sidecar does not invalidate its block.
+	assert.Equal(t, false, registry.IsInvalid(scs[0].BlockRoot()))
+}
@@ -49,6 +49,13 @@ var (
 		},
 		[]string{"topic"},
 	)
+	gossipValidationFailureCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "p2p_message_validation_failure_total",
+			Help: "Count of messages that were rejected or ignored in validation, by reason.",
+		},
+		[]string{"topic", "result", "reason"},
+	)
 	messageFailedProcessingCounter = promauto.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "p2p_message_failed_processing_total",
@@ -96,6 +103,13 @@ var (
 			Buckets: []float64{100, 250, 500, 750, 1000, 1500, 2000, 4000, 8000, 12000, 16000, 20000, 24000},
 		},
 	)
+	blobSidecarArrivalHistogram = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "blob_sidecar_arrival_latency_milliseconds",
+			Help:    "Captures blob sidecars propagation time. Blob sidecars arrival in milliseconds distribution",
+			Buckets: []float64{100, 250, 500, 750, 1000, 1500, 2000, 4000, 8000, 12000, 16000, 20000, 24000},
+		},
+	)
 	arrivalBlockPropagationGauge = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "block_arrival_latency_milliseconds_gauge",
 		Help: "Captures blocks propagation time. Blocks arrival in milliseconds",
@@ -184,6 +198,23 @@ var (
 			Help: "Count the number of times blobs have been found in the database.",
 		},
 	)
+
+	blobRecoveryBlocksTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "blob_recovery_blocks_total",
+			Help: "Count the blocks received before their blob sidecars, by whether the execution layer provided all " +
+				"(complete), some (partial) or none of the missing blobs.",
+		},
+		[]string{"outcome"},
+	)
+
+	blobRecoveryCompletedSinceSlotStart = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "blob_recovery_completed_since_slot_start_milliseconds",
+			Help:    "Time since the start of the slot at which all the blobs of a block were available after recovering blobs from the execution layer.",
+			Buckets: []float64{250, 500, 1000, 1500, 2000, 3000, 4000, 6000, 8000, 12000},
+		},
+	)
 )
 
 func (s *Service) updateMetrics() {
@@ -0,0 +1,327 @@
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/async"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+)
+
+// interval at which the operation pools are saved to the database, in addition to shutdown.
+const operationPoolSnapshotInterval = 5 * time.Minute
+
+// operationPoolSnapshot holds the SSZ encoded pending operations of the operation pools.
+type operationPoolSnapshot struct {
+	Attestations             [][]byte `json:"attestations"`
+	AttestationsElectra      [][]byte `json:"attestations_electra"`
+	ProposerSlashings        [][]byte `json:"proposer_slashings"`
+	AttesterSlashings        [][]byte `json:"attester_slashings"`
+	AttesterSlashingsElectra [][]byte `json:"attester_slashings_electra"`
+	VoluntaryExits           [][]byte `json:"voluntary_exits"`
+	BLSToExecutionChanges    [][]byte `json:"bls_to_execution_changes"`
+}
+
+// operationPoolsPersisted is true when the service has the database and all the pools to snapshot.
+func (s *Service) operationPoolsPersisted() bool {
+	return s.cfg.beaconDB != nil && s.cfg.attPool != nil && s.cfg.slashingPool != nil &&
+		s.cfg.exitPool != nil && s.cfg.blsToExecPool != nil
+}
+
+// startOperationPoolPersistence restores the operation pools saved by a previous run, then saves them periodically.
+// The pools are not saved before they are restored, so that a snapshot is never overwritten by empty pools.
+func (s *Service) startOperationPoolPersistence() {
+	if !s.operationPoolsPersisted() {
+		return
+	}
+	if err := s.restoreOperationPools(s.ctx); err != nil {
+		log.WithError(err).Error("Could not restore operation pools")
+	}
+	s.operationPoolsRestored.Store(true)
+	async.RunEvery(s.ctx, operationPoolSnapshotInterval, func() {
+		if err := s.saveOperationPools(s.ctx); err != nil {
+			log.WithError(err).Error("Could not save operation pools")
+		}
+	})
+}
+
+// saveOperationPools saves the pending operations of the attestation, slashing, voluntary exit and
+// BLS to execution change pools to the database.
+func (s *Service) saveOperationPools(ctx context.Context) error {
+	if !s.operationPoolsRestored.Load() {
+		return nil
+	}
+	st, err := s.cfg.chain.HeadStateReadOnly(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get head state")
+	}
+	if st == nil || st.IsNil() {
+		return errors.New("head state is nil")
+	}
+
+	snapshot := &operationPoolSnapshot{}
+	unaggregated, err := s.cfg.attPool.UnaggregatedAttestations()
+	if err != nil {
+		return errors.Wrap(err, "could not get unaggregated attestations")
+	}
+	for _, att := range append(s.cfg.attPool.AggregatedAttestations(), unaggregated...) {
+		var enc []byte
+		switch a := att.(type) {
+		case *ethpb.Attestation:
+			enc, err = a.MarshalSSZ()
+			snapshot.Attestations = append(snapshot.Attestations, enc)
+		case *ethpb.AttestationElectra:
+			enc, err = a.MarshalSSZ()
+			snapshot.AttestationsElectra = append(snapshot.AttestationsElectra, enc)
+		default:
+			continue
+		}
+		if err != nil {
+			return errors.Wrap(err, "could not marshal attestation")
+		}
+	}
+	for _, ps := range s.cfg.slashingPool.PendingProposerSlashings(ctx, st, true) {
+		enc, err := ps.MarshalSSZ()
+		if err != nil {
+			return errors.Wrap(err, "could not marshal proposer slashing")
+		}
+		snapshot.ProposerSlashings = append(snapshot.ProposerSlashings, enc)
+	}
+	for _, as := range s.cfg.slashingPool.PendingAttesterSlashings(ctx, st, true) {
+		var enc []byte
+		switch sl := as.(type) {
+		case *ethpb.AttesterSlashing:
+			enc, err = sl.MarshalSSZ()
+			snapshot.AttesterSlashings = append(snapshot.AttesterSlashings, enc)
+		case *ethpb.AttesterSlashingElectra:
+			enc, err = sl.MarshalSSZ()
+			snapshot.AttesterSlashingsElectra = append(snapshot.AttesterSlashingsElectra, enc)
+		default:
+			continue
+		}
+		if err != nil {
+			return errors.Wrap(err, "could not marshal attester slashing")
+		}
+	}
+	exits, err := s.cfg.exitPool.PendingExits()
+	if err != nil {
+		return errors.Wrap(err, "could not get pending voluntary exits")
+	}
+	for _, exit := range exits {
+		enc, err := exit.MarshalSSZ()
+		if err != nil {
+			return errors.Wrap(err, "could not marshal voluntary exit")
+		}
+		snapshot.VoluntaryExits = append(snapshot.VoluntaryExits, enc)
+	}
+	changes, err := s.cfg.blsToExecPool.PendingBLSToExecChanges()
+	if err != nil {
+		return errors.Wrap(err, "could not get pending BLS to execution changes")
+	}
+	for _, change := range changes {
+		enc, err := change.MarshalSSZ()
+		if err != nil {
+			return errors.Wrap(err, "could not marshal BLS to execution change")
+		}
+		snapshot.BLSToExecutionChanges = append(snapshot.BLSToExecutionChanges, enc)
+	}
+
+	enc, err := json.Marshal(snapshot)
+	if err != nil {
+		return errors.Wrap(err, "could not encode operation pools")
+	}
+	if err := s.cfg.beaconDB.SaveOperationPoolSnapshot(ctx, enc); err != nil {
+		return errors.Wrap(err, "could not save operation pools")
+	}
+	log.WithFields(snapshot.fields()).Debug("Saved operation pools")
+	return nil
+}
+
+// restoreOperationPools inserts the operations saved by saveOperationPools back into the pools, dropping the ones
+// which are no longer valid against the head state. The restored slashings, voluntary exits and BLS to execution
+// changes are broadcast again, as they may have been received only once from gossip. Attestations are not
+// broadcast, as the aggregate proofs they were received with are not saved.
+func (s *Service) restoreOperationPools(ctx context.Context) error {
+	enc, err := s.cfg.beaconDB.OperationPoolSnapshot(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not read saved operation pools")
+	}
+	snapshot := &operationPoolSnapshot{}
+	if err := json.Unmarshal(enc, snapshot); err != nil {
+		return errors.Wrap(err, "could not decode saved operation pools")
+	}
+	st, err := s.cfg.chain.HeadStateReadOnly(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get head state")
+	}
+	if st == nil || st.IsNil() {
+		return errors.New("head state is nil")
+	}
+
+	restored := &operationPoolSnapshot{}
+	for _, b := range snapshot.Attestations {
+		att := &ethpb.Attestation{}
+		if err := att.UnmarshalSSZ(b); err != nil {
+			return errors.Wrap(err, "could not unmarshal attestation")
+		}
+		if s.restoreAttestation(ctx, st, att) {
+			restored.Attestations = append(restored.Attestations, b)
+		}
+	}
+	for _, b := range snapshot.AttestationsElectra {
+		att := &ethpb.AttestationElectra{}
+		if err := att.UnmarshalSSZ(b); err != nil {
+			return errors.Wrap(err, "could not unmarshal attestation")
+		}
+		if s.restoreAttestation(ctx, st, att) {
+			restored.AttestationsElectra = append(restored.AttestationsElectra, b)
+		}
+	}
+	for _, b := range snapshot.ProposerSlashings {
+		ps := &ethpb.ProposerSlashing{}
+		if err := ps.UnmarshalSSZ(b); err != nil {
+			return errors.Wrap(err, "could not unmarshal proposer slashing")
+		}
+		if err := s.cfg.slashingPool.InsertProposerSlashing(ctx, st, ps); err != nil {
+			log.WithError(err).Debug("Dropping saved proposer slashing")
+			continue
+		}
+		s.rebroadcastOperation(ctx, ps)
+		restored.ProposerSlashings = append(restored.ProposerSlashings, b)
+	}
+	for _, b := range snapshot.AttesterSlashings {
+		as := &ethpb.AttesterSlashing{}
+		if err := as.UnmarshalSSZ(b); err != nil {
+			return errors.Wrap(err, "could not unmarshal attester slashing")
+		}
+		if s.restoreAttesterSlashing(ctx, st, as) {
+			restored.AttesterSlashings = append(restored.AttesterSlashings, b)
+		}
+	}
+	for _, b := range snapshot.AttesterSlashingsElectra {
+		as := &ethpb.AttesterSlashingElectra{}
+		if err := as.UnmarshalSSZ(b); err != nil {
+			return errors.Wrap(err, "could not unmarshal attester slashing")
+		}
+		if s.restoreAttesterSlashing(ctx, st, as) {
+			restored.AttesterSlashingsElectra = append(restored.AttesterSlashingsElectra, b)
+		}
+	}
+	for _, b := range snapshot.VoluntaryExits {
+		exit := &ethpb.SignedVoluntaryExit{}
+		if err := exit.UnmarshalSSZ(b); err != nil {
+			return errors.Wrap(err, "could not unmarshal voluntary exit")
+		}
+		val, err := st.ValidatorAtIndexReadOnly(exit.Exit.ValidatorIndex)
+		if err != nil {
+			log.WithError(err).Debug("Dropping saved voluntary exit")
+			continue
+		}
+		if err := blocks.VerifyExitAndSignature(val, st, exit); err != nil {
+			log.WithError(err).Debug("Dropping saved voluntary exit")
+			continue
+		}
+		s.cfg.exitPool.InsertVoluntaryExit(exit)
+		s.rebroadcastOperation(ctx, exit)
+		restored.VoluntaryExits = append(restored.VoluntaryExits, b)
+	}
+	changes := make([]*ethpb.SignedBLSToExecutionChange, 0, len(snapshot.BLSToExecutionChanges))
+	for _, b := range snapshot.BLSToExecutionChanges {
+		change := &ethpb.SignedBLSToExecutionChange{}
+		if err := change.UnmarshalSSZ(b); err != nil {
+			return errors.Wrap(err, "could not unmarshal BLS to execution change")
+		}
+		if _, err := blocks.ValidateBLSToExecutionChange(st, change); err != nil {
+			log.WithError(err).Debug("Dropping saved BLS to execution change")
+			continue
+		}
+		if err := blocks.VerifyBLSChangeSignature(st, change); err != nil {
+			log.WithError(err).Debug("Dropping saved BLS to execution change")
+			continue
+		}
+		s.cfg.blsToExecPool.InsertBLSToExecChange(change)
+		changes = append(changes, change)
+		restored.BLSToExecutionChanges = append(restored.BLSToExecutionChanges, b)
+	}
+	// Before Capella, the pending changes are broadcast at the fork by broadcastBLSChanges.
+	if len(changes) > 0 && st.Version() >= version.Capella {
+		go s.rateBLSChanges(s.ctx, changes)
+	}
+
+	log.WithFields(restored.fields()).WithField("dropped", snapshot.count()-restored.count()).Info("Restored operation pools")
+	return nil
+}
+
+// restoreAttestation saves the attestation to the pool if it has not expired and is valid against the state.
+func (s *Service) restoreAttestation(ctx context.Context, st state.ReadOnlyBeaconState, att ethpb.Att) bool {
+	if err := helpers.ValidateAttestationTime(att.GetData().Slot, s.cfg.clock.GenesisTime(), earlyAttestationProcessingTolerance); err != nil {
+		log.WithError(err).Debug("Dropping saved attestation")
+		return false
+	}
+	if err := blocks.VerifyAttestationNoVerifySignature(ctx, st, att); err != nil {
+		log.WithError(err).Debug("Dropping saved attestation")
+		return false
+	}
+	set, err := blocks.AttestationSignatureBatch(ctx, st, []ethpb.Att{att})
+	if err != nil {
+		log.WithError(err).Debug("Dropping saved attestation")
+		return false
+	}
+	if verified, err := set.Verify(); err != nil || !verified {
+		log.WithError(err).Debug("Dropping saved attestation with invalid signature")
+		return false
+	}
+	if att.IsAggregated() {
+		err = s.cfg.attPool.SaveAggregatedAttestation(att)
+	} else {
+		err = s.cfg.attPool.SaveUnaggregatedAttestation(att)
+	}
+	if err != nil {
+		log.WithError(err).Debug("Could not save restored attestation")
+		return false
+	}
+	return true
+}
+
+// restoreAttesterSlashing inserts the slashing into the pool, which verifies it against the state, and broadcasts it.
+func (s *Service) restoreAttesterSlashing(ctx context.Context, st state.ReadOnlyBeaconState, slashing ethpb.AttSlashing) bool {
+	if err := s.cfg.slashingPool.InsertAttesterSlashing(ctx, st, slashing); err != nil {
+		log.WithError(err).Debug("Dropping saved attester slashing")
+		return false
+	}
+	s.rebroadcastOperation(ctx, slashing)
+	return true
+}
+
+func (s *Service) rebroadcastOperation(ctx context.Context, msg proto.Message) {
+	if err := s.cfg.p2p.Broadcast(ctx, msg); err != nil {
+		log.WithError(err).Debug("Could not broadcast restored operation")
+	}
+}
+
+func (p *operationPoolSnapshot) count() int {
+	return len(p.Attestations) + len(p.AttestationsElectra) + len(p.ProposerSlashings) + len(p.AttesterSlashings) +
+		len(p.AttesterSlashingsElectra) + len(p.VoluntaryExits) + len(p.BLSToExecutionChanges)
+}
+
+func (p *operationPoolSnapshot) fields() logrus.Fields {
+	return logrus.Fields{
+		"attestations":          len(p.Attestations) + len(p.AttestationsElectra),
+		"proposerSlashings":     len(p.ProposerSlashings),
+		"attesterSlashings":     len(p.AttesterSlashings) + len(p.AttesterSlashingsElectra),
+		"voluntaryExits":        len(p.VoluntaryExits),
+		"blsToExecutionChanges": len(p.BLSToExecutionChanges),
+	}
+}
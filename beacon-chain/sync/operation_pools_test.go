@@ -0,0 +1,117 @@
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	mockChain "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	dbTest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/attestations"
+	blstoexecmock "github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/blstoexec/mock"
+	slashingsmock "github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/slashings/mock"
+	exitsmock "github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/voluntaryexits/mock"
+	p2ptest "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestOperationPools_SaveAndRestore(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbTest.SetupDB(t)
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	clock := startup.NewClock(time.Now().Add(-time.Hour), [32]byte{})
+
+	proposerSlashing := &ethpb.ProposerSlashing{
+		Header_1: util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{}),
+		Header_2: util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{}),
+	}
+	attesterSlashing := &ethpb.AttesterSlashingElectra{
+		Attestation_1: util.HydrateIndexedAttestationElectra(&ethpb.IndexedAttestationElectra{}),
+		Attestation_2: util.HydrateIndexedAttestationElectra(&ethpb.IndexedAttestationElectra{}),
+	}
+	// The validator of the exit and of the BLS change does not exist in the head state.
+	exit := &ethpb.SignedVoluntaryExit{
+		Exit:      &ethpb.VoluntaryExit{ValidatorIndex: 1000},
+		Signature: make([]byte, 96),
+	}
+	change := &ethpb.SignedBLSToExecutionChange{
+		Message: &ethpb.BLSToExecutionChange{
+			ValidatorIndex:     1000,
+			FromBlsPubkey:      make([]byte, 48),
+			ToExecutionAddress: make([]byte, 20),
+		},
+		Signature: make([]byte, 96),
+	}
+	// The attestation has expired.
+	att := util.HydrateAttestation(&ethpb.Attestation{AggregationBits: bitfield.Bitlist{0b101}})
+
+	attPool := attestations.NewPool()
+	require.NoError(t, attPool.SaveUnaggregatedAttestation(att))
+	s := &Service{
+		ctx: ctx,
+		cfg: &config{
+			beaconDB:      beaconDB,
+			chain:         &mockChain.ChainService{State: st},
+			p2p:           p2ptest.NewTestP2P(t),
+			clock:         clock,
+			attPool:       attPool,
+			slashingPool:  &slashingsmock.PoolMock{PendingPropSlashings: []*ethpb.ProposerSlashing{proposerSlashing}, PendingAttSlashings: []ethpb.AttSlashing{attesterSlashing}},
+			exitPool:      &exitsmock.PoolMock{Exits: []*ethpb.SignedVoluntaryExit{exit}},
+			blsToExecPool: &blstoexecmock.PoolMock{Changes: []*ethpb.SignedBLSToExecutionChange{change}},
+		},
+	}
+
+	// The pools are not saved before they have been restored.
+	require.NoError(t, s.saveOperationPools(ctx))
+	_, err = beaconDB.OperationPoolSnapshot(ctx)
+	require.ErrorContains(t, "not found", err)
+
+	s.operationPoolsRestored.Store(true)
+	require.NoError(t, s.saveOperationPools(ctx))
+	enc, err := beaconDB.OperationPoolSnapshot(ctx)
+	require.NoError(t, err)
+	snapshot := &operationPoolSnapshot{}
+	require.NoError(t, json.Unmarshal(enc, snapshot))
+	assert.Equal(t, 1, len(snapshot.Attestations))
+	assert.Equal(t, 1, len(snapshot.ProposerSlashings))
+	assert.Equal(t, 0, len(snapshot.AttesterSlashings))
+	assert.Equal(t, 1, len(snapshot.AttesterSlashingsElectra))
+	assert.Equal(t, 1, len(snapshot.VoluntaryExits))
+	assert.Equal(t, 1, len(snapshot.BLSToExecutionChanges))
+
+	p2p := p2ptest.NewTestP2P(t)
+	slashingPool := &slashingsmock.PoolMock{}
+	exitPool := &exitsmock.PoolMock{}
+	blsToExecPool := &blstoexecmock.PoolMock{}
+	restarted := &Service{
+		ctx: ctx,
+		cfg: &config{
+			beaconDB:      beaconDB,
+			chain:         &mockChain.ChainService{State: st},
+			p2p:           p2p,
+			clock:         clock,
+			attPool:       attestations.NewPool(),
+			slashingPool:  slashingPool,
+			exitPool:      exitPool,
+			blsToExecPool: blsToExecPool,
+		},
+	}
+	require.NoError(t, restarted.restoreOperationPools(ctx))
+	// The slashings are inserted into the pool, which validates them, and broadcast again.
+	require.Equal(t, 1, len(slashingPool.PendingPropSlashings))
+	assert.DeepEqual(t, proposerSlashing, slashingPool.PendingPropSlashings[0])
+	require.Equal(t, 1, len(slashingPool.PendingAttSlashings))
+	assert.DeepEqual(t, attesterSlashing, slashingPool.PendingAttSlashings[0])
+	assert.Equal(t, true, p2p.BroadcastCalled.Load())
+	// The operations which are no longer valid are dropped.
+	assert.Equal(t, 0, len(exitPool.Exits))
+	assert.Equal(t, 0, len(blsToExecPool.Changes))
+	assert.Equal(t, 0, restarted.cfg.attPool.UnaggregatedAttestationCount())
+}
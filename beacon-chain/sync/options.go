@@ -188,3 +188,28 @@ func WithAvailableBlocker(avb coverage.AvailableBlocker) Option {
 		return nil
 	}
 }
+
+// WithSlotTimingCache allows the sync package to record the arrival of blocks and blob sidecars.
+func WithSlotTimingCache(c *cache.SlotTimingCache) Option {
+	return func(s *Service) error {
+		s.cfg.slotTimingCache = c
+		return nil
+	}
+}
+
+// WithInvalidBlockRegistry allows the sync package to record rejected blocks and blob sidecars, and to reject their descendants.
+func WithInvalidBlockRegistry(r *cache.InvalidBlockRegistry) Option {
+	return func(s *Service) error {
+		s.invalidBlocks = r
+		return nil
+	}
+}
+
+// WithAPIFallback sets the trusted beacon node apis used by the pending block queue to fetch blocks and blobs
+// when peers fail to serve them.
+func WithAPIFallback(f *APIFallback) Option {
+	return func(s *Service) error {
+		s.cfg.apiFallback = f
+		return nil
+	}
+}
@@ -303,6 +303,10 @@ func (s *Service) sendBatchRootRequest(ctx context.Context, roots [][32]byte, ra
 	}
 	bestPeers := s.getBestPeers()
 	if len(bestPeers) == 0 {
+		s.cfg.apiFallback.PeerFailure()
+		if s.cfg.apiFallback.Active() {
+			s.requestRootsFromAPIFallback(ctx, roots)
+		}
 		return nil
 	}
 	// Randomly choose a peer to query from our best peers. If that peer cannot return
@@ -328,16 +332,75 @@ func (s *Service) sendBatchRootRequest(ctx context.Context, roots [][32]byte, ra
 		}
 		s.pendingQueueLock.RUnlock()
 		if len(newRoots) == 0 {
-			break
+			s.cfg.apiFallback.PeerSuccess()
+			return nil
 		}
 		// Choosing a new peer with the leftover set of
 		// roots to request.
 		roots = newRoots
 		pid = bestPeers[randGen.Int()%len(bestPeers)]
 	}
+	s.cfg.apiFallback.PeerFailure()
+	if s.cfg.apiFallback.Active() {
+		s.requestRootsFromAPIFallback(ctx, roots)
+	}
 	return nil
 }
 
+// requestRootsFromAPIFallback downloads the blocks with the given roots, along with their blobs, from the fallback
+// beacon node api. Like blocks received from peers, the blocks are added to the pending queue to be processed,
+// and the blobs are verified before being saved.
+func (s *Service) requestRootsFromAPIFallback(ctx context.Context, roots [][32]byte) {
+	ctx, span := prysmTrace.StartSpan(ctx, "requestRootsFromAPIFallback")
+	defer span.End()
+
+	for _, r := range roots {
+		log := log.WithField("blockRoot", fmt.Sprintf("%#x", r))
+		blk, err := s.cfg.apiFallback.BlockByRoot(ctx, r)
+		if err != nil {
+			log.WithError(err).Debug("Could not download block from the fallback beacon node api")
+			continue
+		}
+		s.pendingQueueLock.Lock()
+		err = s.insertBlockToPendingQueue(blk.Block().Slot(), blk, r)
+		s.pendingQueueLock.Unlock()
+		if err != nil {
+			log.WithError(err).Debug("Could not insert block from the fallback beacon node api in the pending queue")
+			continue
+		}
+		request, err := s.pendingBlobsRequestForBlock(r, blk)
+		if err != nil {
+			log.WithError(err).Debug("Could not determine missing blobs of block from the fallback beacon node api")
+			continue
+		}
+		if len(request) == 0 {
+			continue
+		}
+		sidecars, err := s.cfg.apiFallback.BlobSidecars(ctx, blk)
+		if err != nil {
+			log.WithError(err).Debug("Could not download blob sidecars from the fallback beacon node api")
+			continue
+		}
+		wanted := make(map[uint64]bool, len(request))
+		for _, id := range request {
+			wanted[id.Index] = true
+		}
+		requested := make([]blocks.ROBlob, 0, len(request))
+		for _, sc := range sidecars {
+			if wanted[sc.Index] {
+				requested = append(requested, sc)
+			}
+		}
+		if len(requested) != len(request) {
+			log.WithField("received", len(requested)).WithField("expected", len(request)).Debug("Fallback beacon node api did not serve all missing blob sidecars")
+			continue
+		}
+		if err := s.verifyAndSaveBlobSidecars(ctx, requested, blk); err != nil {
+			log.WithError(err).Debug("Could not save blob sidecars from the fallback beacon node api")
+		}
+	}
+}
+
 func (s *Service) sortedPendingSlots() []primitives.Slot {
 	s.pendingQueueLock.RLock()
 	defer s.pendingQueueLock.RUnlock()
@@ -151,14 +151,19 @@ func (s *Service) sendAndSaveBlobSidecars(ctx context.Context, request types.Blo
 	if len(sidecars) != len(request) {
 		return fmt.Errorf("received %d blob sidecars, expected %d for RPC", len(sidecars), len(request))
 	}
+	return s.verifyAndSaveBlobSidecars(ctx, sidecars, RoBlock)
+}
+
+// verifyAndSaveBlobSidecars checks that the sidecars belong to the block, verifies them and saves them to blob storage.
+func (s *Service) verifyAndSaveBlobSidecars(ctx context.Context, sidecars []blocks.ROBlob, roBlock blocks.ROBlock) error {
 	bv := verification.NewBlobBatchVerifier(s.newBlobVerifier, verification.PendingQueueBlobSidecarRequirements)
 	for _, sidecar := range sidecars {
-		if err := verify.BlobAlignsWithBlock(sidecar, RoBlock); err != nil {
+		if err := verify.BlobAlignsWithBlock(sidecar, roBlock); err != nil {
 			return err
 		}
 		log.WithFields(blobFields(sidecar)).Debug("Received blob sidecar RPC")
 	}
-	vscs, err := bv.VerifiedROBlobs(ctx, RoBlock, sidecars)
+	vscs, err := bv.VerifiedROBlobs(ctx, roBlock, sidecars)
 	if err != nil {
 		return err
 	}
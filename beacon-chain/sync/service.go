@@ -7,6 +7,7 @@ package sync
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	lru "github.com/hashicorp/golang-lru"
@@ -102,6 +103,8 @@ type config struct {
 	clock                   *startup.Clock
 	stateNotifier           statefeed.Notifier
 	blobStorage             *filesystem.BlobStorage
+	slotTimingCache         *cache.SlotTimingCache
+	apiFallback             *APIFallback
 }
 
 // This defines the interface for interacting with block chain service
@@ -139,6 +142,7 @@ type Service struct {
 	seenBlockCache                   *lru.Cache
 	seenBlobLock                     sync.RWMutex
 	seenBlobCache                    *lru.Cache
+	blobRecoveries                   sync.Map
 	seenAggregatedAttestationLock    sync.RWMutex
 	seenAggregatedAttestationCache   *lru.Cache
 	seenUnAggregatedAttestationLock  sync.RWMutex
@@ -155,6 +159,7 @@ type Service struct {
 	seenSyncContributionCache        *lru.Cache
 	badBlockCache                    *lru.Cache
 	badBlockLock                     sync.RWMutex
+	invalidBlocks                    *cache.InvalidBlockRegistry
 	syncContributionBitsOverlapLock  sync.RWMutex
 	syncContributionBitsOverlapCache *lru.Cache
 	signatureChan                    chan *signatureVerifier
@@ -164,6 +169,8 @@ type Service struct {
 	newBlobVerifier                  verification.NewBlobVerifier
 	availableBlocker                 coverage.AvailableBlocker
 	ctxMap                           ContextByteVersions
+	gossipStats                      *gossipStats
+	operationPoolsRestored           atomic.Bool
 }
 
 // NewService initializes new regular sync service.
@@ -178,6 +185,7 @@ func NewService(ctx context.Context, opts ...Option) *Service {
 		seenPendingBlocks:    make(map[[32]byte]bool),
 		blkRootToPendingAtts: make(map[[32]byte][]ethpb.SignedAggregateAttAndProof),
 		signatureChan:        make(chan *signatureVerifier, verifierLimit),
+		gossipStats:          newGossipStats(),
 	}
 
 	for _, opt := range opts {
@@ -254,6 +262,9 @@ func (s *Service) Stop() error {
 			s.rateLimiter.free()
 		}
 	}()
+	if err := s.saveOperationPools(s.ctx); err != nil {
+		log.WithError(err).Error("Could not save operation pools")
+	}
 	// Removing RPC Stream handlers.
 	for _, p := range s.cfg.p2p.Host().Mux().Protocols() {
 		s.cfg.p2p.Host().RemoveStreamHandler(p)
@@ -351,6 +362,9 @@ func (s *Service) startTasksPostInitialSync() {
 		// Start the fork watcher.
 		go s.forkWatcher()
 
+		// Restore the operation pools saved on shutdown, and save them periodically from now on.
+		s.startOperationPoolPersistence()
+
 	case <-s.ctx.Done():
 		log.Debug("Context closed, exiting goroutine")
 	}
@@ -293,25 +293,30 @@ func (s *Service) wrapAndReportValidation(topic string, v wrappedVal) (string, p
 		messageReceivedCounter.WithLabelValues(topic).Inc()
 		if msg.Topic == nil {
 			messageFailedValidationCounter.WithLabelValues(topic).Inc()
+			s.recordGossipFailure(topic, pid, "", pubsub.ValidationReject, withReason(reasonInvalidTopic, nil))
 			return pubsub.ValidationReject
 		}
 		// Ignore any messages received before chainstart.
 		if s.chainStarted.IsNotSet() {
 			messageIgnoredValidationCounter.WithLabelValues(topic).Inc()
+			s.recordGossipFailure(topic, pid, "", pubsub.ValidationIgnore, withReason(reasonChainNotStarted, nil))
 			return pubsub.ValidationIgnore
 		}
 		retDigest, err := p2p.ExtractGossipDigest(topic)
 		if err != nil {
 			log.WithField("topic", topic).Errorf("Invalid topic format of pubsub topic: %v", err)
+			s.recordGossipFailure(topic, pid, "", pubsub.ValidationIgnore, withReason(reasonInvalidTopic, err))
 			return pubsub.ValidationIgnore
 		}
 		currDigest, err := s.currentForkDigest()
 		if err != nil {
 			log.WithField("topic", topic).Errorf("Unable to retrieve fork data: %v", err)
+			s.recordGossipFailure(topic, pid, "", pubsub.ValidationIgnore, withReason(reasonInternalError, err))
 			return pubsub.ValidationIgnore
 		}
 		if currDigest != retDigest {
 			log.WithField("topic", topic).Debugf("Received message from outdated fork digest %#x", retDigest)
+			s.recordGossipFailure(topic, pid, "", pubsub.ValidationIgnore, withReason(reasonForkDigestMismatch, nil))
 			return pubsub.ValidationIgnore
 		}
 		b, err := v(ctx, pid, msg)
@@ -319,14 +324,20 @@ func (s *Service) wrapAndReportValidation(topic string, v wrappedVal) (string, p
 		// trying to process those messages.
 		if b == pubsub.ValidationReject && ctx.Err() != nil {
 			b = pubsub.ValidationIgnore
+			err = withReason(reasonTimeout, err)
 		}
+		if b == pubsub.ValidationAccept {
+			return b
+		}
+		agent := agentString(pid, s.cfg.p2p.Host())
 		if b == pubsub.ValidationReject {
 			fields := logrus.Fields{
 				"topic":        topic,
 				"multiaddress": multiAddr(pid, s.cfg.p2p.Peers()),
 				"peerID":       pid.String(),
-				"agent":        agentString(pid, s.cfg.p2p.Host()),
+				"agent":        agent,
 				"gossipScore":  s.cfg.p2p.Peers().Scorers().GossipScorer().Score(pid),
+				"reason":       gossipFailureReason(err),
 			}
 			if features.Get().EnableFullSSZDataLogging {
 				fields["message"] = hexutil.Encode(msg.Data)
@@ -335,17 +346,19 @@ func (s *Service) wrapAndReportValidation(topic string, v wrappedVal) (string, p
 			messageFailedValidationCounter.WithLabelValues(topic).Inc()
 		}
 		if b == pubsub.ValidationIgnore {
-			if err != nil && !errorIsIgnored(err) {
-				log.WithError(err).WithFields(logrus.Fields{
+			if cause := gossipFailureCause(err); cause != nil && !errorIsIgnored(cause) {
+				log.WithError(cause).WithFields(logrus.Fields{
 					"topic":        topic,
 					"multiaddress": multiAddr(pid, s.cfg.p2p.Peers()),
 					"peerID":       pid.String(),
-					"agent":        agentString(pid, s.cfg.p2p.Host()),
+					"agent":        agent,
 					"gossipScore":  fmt.Sprintf("%.2f", s.cfg.p2p.Peers().Scorers().GossipScorer().Score(pid)),
+					"reason":       gossipFailureReason(err),
 				}).Debug("Gossip message was ignored")
 			}
 			messageIgnoredValidationCounter.WithLabelValues(topic).Inc()
 		}
+		s.recordGossipFailure(topic, pid, agent, b, err)
 		return b
 	}
 }
@@ -12,8 +12,6 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v5/io/file"
-	"github.com/prysmaticlabs/prysm/v5/runtime/version"
-	"github.com/prysmaticlabs/prysm/v5/time/slots"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -59,80 +57,6 @@ func (s *Service) beaconBlockSubscriber(ctx context.Context, msg proto.Message)
 	return err
 }
 
-// reconstructAndBroadcastBlobs processes and broadcasts blob sidecars for a given beacon block.
-// This function reconstructs the blob sidecars from the EL using the block's KZG commitments,
-// broadcasts the reconstructed blobs over P2P, and saves them into the blob storage.
-func (s *Service) reconstructAndBroadcastBlobs(ctx context.Context, block interfaces.ReadOnlySignedBeaconBlock) {
-	if block.Version() < version.Deneb {
-		return
-	}
-
-	startTime, err := slots.ToTime(uint64(s.cfg.chain.GenesisTime().Unix()), block.Block().Slot())
-	if err != nil {
-		log.WithError(err).Error("Failed to convert slot to time")
-	}
-
-	blockRoot, err := block.Block().HashTreeRoot()
-	if err != nil {
-		log.WithError(err).Error("Failed to calculate block root")
-		return
-	}
-
-	if s.cfg.blobStorage == nil {
-		return
-	}
-	summary := s.cfg.blobStorage.Summary(blockRoot)
-	cmts, err := block.Block().Body().BlobKzgCommitments()
-	if err != nil {
-		log.WithError(err).Error("Failed to read commitments from block")
-		return
-	}
-	for i := range cmts {
-		if summary.HasIndex(uint64(i)) {
-			blobExistedInDBTotal.Inc()
-		}
-	}
-
-	// Reconstruct blob sidecars from the EL
-	blobSidecars, err := s.cfg.executionReconstructor.ReconstructBlobSidecars(ctx, block, blockRoot, summary.HasIndex)
-	if err != nil {
-		log.WithError(err).Error("Failed to reconstruct blob sidecars")
-		return
-	}
-	if len(blobSidecars) == 0 {
-		return
-	}
-
-	// Refresh indices as new blobs may have been added to the db
-	summary = s.cfg.blobStorage.Summary(blockRoot)
-
-	// Broadcast blob sidecars first than save them to the db
-	for _, sidecar := range blobSidecars {
-		// Don't broadcast the blob if it has appeared on disk.
-		if summary.HasIndex(sidecar.Index) {
-			continue
-		}
-		if err := s.cfg.p2p.BroadcastBlob(ctx, sidecar.Index, sidecar.BlobSidecar); err != nil {
-			log.WithFields(blobFields(sidecar.ROBlob)).WithError(err).Error("Failed to broadcast blob sidecar")
-		}
-	}
-
-	for _, sidecar := range blobSidecars {
-		if summary.HasIndex(sidecar.Index) {
-			continue
-		}
-		if err := s.subscribeBlob(ctx, sidecar); err != nil {
-			log.WithFields(blobFields(sidecar.ROBlob)).WithError(err).Error("Failed to receive blob")
-			continue
-		}
-
-		blobRecoveredFromELTotal.Inc()
-		fields := blobFields(sidecar.ROBlob)
-		fields["sinceSlotStartTime"] = s.cfg.clock.Now().Sub(startTime)
-		log.WithFields(fields).Debug("Processed blob sidecar from EL")
-	}
-}
-
 // WriteInvalidBlockToDisk as a block ssz. Writes to temp directory.
 func saveInvalidBlockToTemp(block interfaces.ReadOnlySignedBeaconBlock) {
 	if !features.Get().SaveInvalidBlock {
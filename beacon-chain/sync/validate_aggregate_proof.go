@@ -40,30 +40,30 @@ func (s *Service) validateAggregateAndProof(ctx context.Context, pid peer.ID, ms
 	// To process the following it requires the recent blocks to be present in the database, so we'll skip
 	// validating or processing aggregated attestations until fully synced.
 	if s.cfg.initialSync.Syncing() {
-		return pubsub.ValidationIgnore, nil
+		return pubsub.ValidationIgnore, withReason(reasonSyncing, nil)
 	}
 
 	raw, err := s.decodePubsubMessage(msg)
 	if err != nil {
 		tracing.AnnotateError(span, err)
-		return pubsub.ValidationReject, err
+		return pubsub.ValidationReject, withReason(reasonDecodeFailed, err)
 	}
 	m, ok := raw.(ethpb.SignedAggregateAttAndProof)
 	if !ok {
-		return pubsub.ValidationReject, errors.Errorf("invalid message type: %T", raw)
+		return pubsub.ValidationReject, withReason(reasonWrongMessageType, errors.Errorf("invalid message type: %T", raw))
 	}
 	if m.AggregateAttestationAndProof() == nil {
-		return pubsub.ValidationReject, errNilMessage
+		return pubsub.ValidationReject, withReason(reasonMalformed, errNilMessage)
 	}
 
 	aggregate := m.AggregateAttestationAndProof().AggregateVal()
 	if err := helpers.ValidateNilAttestation(aggregate); err != nil {
-		return pubsub.ValidationReject, err
+		return pubsub.ValidationReject, withReason(reasonMalformed, err)
 	}
 	data := aggregate.GetData()
 	// Do not process slot 0 aggregates.
 	if data.Slot == 0 {
-		return pubsub.ValidationIgnore, nil
+		return pubsub.ValidationIgnore, withReason(reasonGenesisSlot, nil)
 	}
 
 	// Broadcast the aggregated attestation on a feed to notify other services in the beacon node
@@ -76,7 +76,7 @@ func (s *Service) validateAggregateAndProof(ctx context.Context, pid peer.ID, ms
 	})
 
 	if err := helpers.ValidateSlotTargetEpoch(data); err != nil {
-		return pubsub.ValidationReject, err
+		return pubsub.ValidationReject, withReason(reasonInvalidTargetEpoch, err)
 	}
 
 	// Attestation's slot is within ATTESTATION_PROPAGATION_SLOT_RANGE and early attestation
@@ -87,19 +87,19 @@ func (s *Service) validateAggregateAndProof(ctx context.Context, pid peer.ID, ms
 		earlyAttestationProcessingTolerance,
 	); err != nil {
 		tracing.AnnotateError(span, err)
-		return pubsub.ValidationIgnore, err
+		return pubsub.ValidationIgnore, withReason(reasonSlotOutOfRange, err)
 	}
 
 	// Verify this is the first aggregate received from the aggregator with index and slot.
 	if s.hasSeenAggregatorIndexEpoch(data.Target.Epoch, m.AggregateAttestationAndProof().GetAggregatorIndex()) {
-		return pubsub.ValidationIgnore, nil
+		return pubsub.ValidationIgnore, withReason(reasonAlreadySeen, nil)
 	}
 	// Check that the block being voted on isn't invalid.
 	if s.hasBadBlock(bytesutil.ToBytes32(data.BeaconBlockRoot)) ||
 		s.hasBadBlock(bytesutil.ToBytes32(data.Target.Root)) ||
 		s.hasBadBlock(bytesutil.ToBytes32(data.Source.Root)) {
 		attBadBlockCount.Inc()
-		return pubsub.ValidationReject, errors.New("bad block referenced in attestation data")
+		return pubsub.ValidationReject, withReason(reasonBadBlock, errors.New("bad block referenced in attestation data"))
 	}
 
 	if features.Get().EnableExperimentalAttestationPool {
@@ -108,27 +108,27 @@ func (s *Service) validateAggregateAndProof(ctx context.Context, pid peer.ID, ms
 		isRedundant, err := s.cfg.attestationCache.AggregateIsRedundant(aggregate)
 		if err != nil {
 			tracing.AnnotateError(span, err)
-			return pubsub.ValidationIgnore, err
+			return pubsub.ValidationIgnore, withReason(reasonInternalError, err)
 		}
 		if isRedundant {
-			return pubsub.ValidationIgnore, nil
+			return pubsub.ValidationIgnore, withReason(reasonAlreadySeen, nil)
 		}
 	} else {
 		// Verify aggregate attestation has not already been seen via aggregate gossip, within a block, or through the creation locally.
 		seen, err := s.cfg.attPool.HasAggregatedAttestation(aggregate)
 		if err != nil {
 			tracing.AnnotateError(span, err)
-			return pubsub.ValidationIgnore, err
+			return pubsub.ValidationIgnore, withReason(reasonInternalError, err)
 		}
 		if seen {
-			return pubsub.ValidationIgnore, nil
+			return pubsub.ValidationIgnore, withReason(reasonAlreadySeen, nil)
 		}
 	}
 
 	// Verify the block being voted on is in the beacon chain.
 	// If not, store this attestation in the map of pending attestations.
 	if !s.validateBlockInAttestation(ctx, m) {
-		return pubsub.ValidationIgnore, nil
+		return pubsub.ValidationIgnore, withReason(reasonUnknownBlock, nil)
 	}
 
 	validationRes, err := s.validateAggregatedAtt(ctx, m)
@@ -161,19 +161,19 @@ func (s *Service) validateAggregatedAtt(ctx context.Context, signed ethpb.Signed
 	if err := s.cfg.chain.VerifyLmdFfgConsistency(ctx, aggregate); err != nil {
 		tracing.AnnotateError(span, err)
 		attBadLmdConsistencyCount.Inc()
-		return pubsub.ValidationReject, err
+		return pubsub.ValidationReject, withReason(reasonInconsistentVote, err)
 	}
 
 	// Verify current finalized checkpoint is an ancestor of the block defined by the attestation's beacon block root.
 	if !s.cfg.chain.InForkchoice(bytesutil.ToBytes32(data.BeaconBlockRoot)) {
 		tracing.AnnotateError(span, blockchain.ErrNotDescendantOfFinalized)
-		return pubsub.ValidationIgnore, blockchain.ErrNotDescendantOfFinalized
+		return pubsub.ValidationIgnore, withReason(reasonNotDescendantOfFinal, blockchain.ErrNotDescendantOfFinalized)
 	}
 
 	bs, err := s.cfg.chain.AttestationTargetState(ctx, data.Target)
 	if err != nil {
 		tracing.AnnotateError(span, err)
-		return pubsub.ValidationIgnore, err
+		return pubsub.ValidationIgnore, withReason(reasonStateUnavailable, err)
 	}
 
 	committeeIndex, _, result, err := s.validateCommitteeIndexAndCount(ctx, aggregate, bs)
@@ -186,13 +186,13 @@ func (s *Service) validateAggregatedAtt(ctx context.Context, signed ethpb.Signed
 	committee, err := helpers.BeaconCommitteeFromState(ctx, bs, aggregate.GetData().Slot, committeeIndex)
 	if err != nil {
 		tracing.AnnotateError(span, err)
-		return pubsub.ValidationIgnore, err
+		return pubsub.ValidationIgnore, withReason(reasonStateUnavailable, err)
 	}
 
 	// Verify number of aggregation bits matches the committee size.
 	if err = helpers.VerifyBitfieldLength(aggregate.GetAggregationBits(), uint64(len(committee))); err != nil {
 		tracing.AnnotateError(span, err)
-		return pubsub.ValidationReject, err
+		return pubsub.ValidationReject, withReason(reasonInvalidAggregationBits, err)
 	}
 
 	// Verify validator index is within the beacon committee.
@@ -216,7 +216,7 @@ func (s *Service) validateAggregatedAtt(ctx context.Context, signed ethpb.Signed
 		wrappedErr := errors.Wrapf(err, "could not validate selection for validator %d", aggregateAndProof.GetAggregatorIndex())
 		tracing.AnnotateError(span, wrappedErr)
 		attBadSelectionProofCount.Inc()
-		return pubsub.ValidationReject, wrappedErr
+		return pubsub.ValidationReject, withReason(reasonNotAggregator, wrappedErr)
 	}
 
 	// Verify selection signature, aggregator signature and attestation signature are valid.
@@ -225,13 +225,13 @@ func (s *Service) validateAggregatedAtt(ctx context.Context, signed ethpb.Signed
 	if err != nil {
 		wrappedErr := errors.Wrapf(err, "could not get aggregator sig set %d", aggregatorIndex)
 		tracing.AnnotateError(span, wrappedErr)
-		return pubsub.ValidationIgnore, wrappedErr
+		return pubsub.ValidationIgnore, withReason(reasonInternalError, wrappedErr)
 	}
 	attSigSet, err := blocks.AttestationSignatureBatch(ctx, bs, []ethpb.Att{aggregate})
 	if err != nil {
 		wrappedErr := errors.Wrapf(err, "could not verify aggregator signature %d", aggregatorIndex)
 		tracing.AnnotateError(span, wrappedErr)
-		return pubsub.ValidationIgnore, wrappedErr
+		return pubsub.ValidationIgnore, withReason(reasonInternalError, wrappedErr)
 	}
 	set := bls.NewSet()
 	set.Join(selectionSigSet).Join(aggregatorSigSet).Join(attSigSet)
@@ -282,7 +282,7 @@ func (s *Service) validateIndexInCommittee(ctx context.Context, a ethpb.Att, val
 	defer span.End()
 
 	if a.GetAggregationBits().Count() == 0 {
-		return pubsub.ValidationReject, errors.New("no attesting indices")
+		return pubsub.ValidationReject, withReason(reasonInvalidAggregationBits, errors.New("no attesting indices"))
 	}
 
 	var withinCommittee bool
@@ -293,8 +293,8 @@ func (s *Service) validateIndexInCommittee(ctx context.Context, a ethpb.Att, val
 		}
 	}
 	if !withinCommittee {
-		return pubsub.ValidationReject, fmt.Errorf("validator index %d is not within the committee: %v",
-			validatorIndex, committee)
+		return pubsub.ValidationReject, withReason(reasonNotInCommittee, fmt.Errorf("validator index %d is not within the committee: %v",
+			validatorIndex, committee))
 	}
 	return pubsub.ValidationAccept, nil
 }
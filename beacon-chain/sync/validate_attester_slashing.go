@@ -29,7 +29,7 @@ func (s *Service) validateAttesterSlashing(ctx context.Context, pid peer.ID, msg
 
 	// The head state will be too far away to validate any slashing.
 	if s.cfg.initialSync.Syncing() {
-		return pubsub.ValidationIgnore, nil
+		return pubsub.ValidationIgnore, withReason(reasonSyncing, nil)
 	}
 
 	ctx, span := trace.StartSpan(ctx, "sync.validateAttesterSlashing")
@@ -38,34 +38,34 @@ func (s *Service) validateAttesterSlashing(ctx context.Context, pid peer.ID, msg
 	m, err := s.decodePubsubMessage(msg)
 	if err != nil {
 		tracing.AnnotateError(span, err)
-		return pubsub.ValidationReject, err
+		return pubsub.ValidationReject, withReason(reasonDecodeFailed, err)
 	}
 	slashing, ok := m.(ethpb.AttSlashing)
 	if !ok {
-		return pubsub.ValidationReject, errWrongMessage
+		return pubsub.ValidationReject, withReason(reasonWrongMessageType, errWrongMessage)
 	}
 
 	slashedVals := blocks.SlashableAttesterIndices(slashing)
 	if slashedVals == nil {
-		return pubsub.ValidationReject, errNilMessage
+		return pubsub.ValidationReject, withReason(reasonMalformed, errNilMessage)
 	}
 	if s.hasSeenAttesterSlashingIndices(slashedVals) {
-		return pubsub.ValidationIgnore, nil
+		return pubsub.ValidationIgnore, withReason(reasonAlreadySeen, nil)
 	}
 
 	headState, err := s.cfg.chain.HeadState(ctx)
 	if err != nil {
-		return pubsub.ValidationIgnore, err
+		return pubsub.ValidationIgnore, withReason(reasonStateUnavailable, err)
 	}
 	if err := blocks.VerifyAttesterSlashing(ctx, headState, slashing); err != nil {
-		return pubsub.ValidationReject, err
+		return pubsub.ValidationReject, withReason(reasonInvalidOperation, err)
 	}
 	isSlashable := false
 	previouslySlashed := false
 	for _, v := range slashedVals {
 		val, err := headState.ValidatorAtIndexReadOnly(primitives.ValidatorIndex(v))
 		if err != nil {
-			return pubsub.ValidationIgnore, err
+			return pubsub.ValidationIgnore, withReason(reasonStateUnavailable, err)
 		}
 		if val.Slashed() {
 			previouslySlashed = true
@@ -78,9 +78,9 @@ func (s *Service) validateAttesterSlashing(ctx context.Context, pid peer.ID, msg
 	}
 	if !isSlashable {
 		if previouslySlashed {
-			return pubsub.ValidationIgnore, errors.Errorf("validators were previously slashed: %v", slashedVals)
+			return pubsub.ValidationIgnore, withReason(reasonAlreadySeen, errors.Errorf("validators were previously slashed: %v", slashedVals))
 		}
-		return pubsub.ValidationReject, errors.Errorf("none of the validators are slashable: %v", slashedVals)
+		return pubsub.ValidationReject, withReason(reasonInvalidOperation, errors.Errorf("none of the validators are slashable: %v", slashedVals))
 	}
 	s.cfg.chain.ReceiveAttesterSlashing(ctx, slashing)
 
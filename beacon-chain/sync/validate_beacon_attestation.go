@@ -41,34 +41,34 @@ func (s *Service) validateCommitteeIndexBeaconAttestation(ctx context.Context, p
 	// Attestation processing requires the target block to be present in the database, so we'll skip
 	// validating or processing attestations until fully synced.
 	if s.cfg.initialSync.Syncing() {
-		return pubsub.ValidationIgnore, nil
+		return pubsub.ValidationIgnore, withReason(reasonSyncing, nil)
 	}
 
 	ctx, span := trace.StartSpan(ctx, "sync.validateCommitteeIndexBeaconAttestation")
 	defer span.End()
 
 	if msg.Topic == nil {
-		return pubsub.ValidationReject, errInvalidTopic
+		return pubsub.ValidationReject, withReason(reasonInvalidTopic, errInvalidTopic)
 	}
 
 	m, err := s.decodePubsubMessage(msg)
 	if err != nil {
 		tracing.AnnotateError(span, err)
-		return pubsub.ValidationReject, err
+		return pubsub.ValidationReject, withReason(reasonDecodeFailed, err)
 	}
 
 	att, ok := m.(eth.Att)
 	if !ok {
-		return pubsub.ValidationReject, errWrongMessage
+		return pubsub.ValidationReject, withReason(reasonWrongMessageType, errWrongMessage)
 	}
 	if err := helpers.ValidateNilAttestation(att); err != nil {
-		return pubsub.ValidationReject, err
+		return pubsub.ValidationReject, withReason(reasonMalformed, err)
 	}
 	data := att.GetData()
 
 	// Do not process slot 0 attestations.
 	if data.Slot == 0 {
-		return pubsub.ValidationIgnore, nil
+		return pubsub.ValidationIgnore, withReason(reasonGenesisSlot, nil)
 	}
 
 	// Attestation's slot is within ATTESTATION_PROPAGATION_SLOT_RANGE and early attestation
@@ -76,10 +76,10 @@ func (s *Service) validateCommitteeIndexBeaconAttestation(ctx context.Context, p
 	if err := helpers.ValidateAttestationTime(data.Slot, s.cfg.clock.GenesisTime(),
 		earlyAttestationProcessingTolerance); err != nil {
 		tracing.AnnotateError(span, err)
-		return pubsub.ValidationIgnore, err
+		return pubsub.ValidationIgnore, withReason(reasonSlotOutOfRange, err)
 	}
 	if err := helpers.ValidateSlotTargetEpoch(data); err != nil {
-		return pubsub.ValidationReject, err
+		return pubsub.ValidationReject, withReason(reasonInvalidTargetEpoch, err)
 	}
 
 	committeeIndex := att.GetCommitteeIndex()
@@ -87,7 +87,7 @@ func (s *Service) validateCommitteeIndexBeaconAttestation(ctx context.Context, p
 	if !features.Get().EnableSlasher {
 		// Verify this the first attestation received for the participating validator for the slot.
 		if s.hasSeenCommitteeIndicesSlot(data.Slot, committeeIndex, att.GetAggregationBits()) {
-			return pubsub.ValidationIgnore, nil
+			return pubsub.ValidationIgnore, withReason(reasonAlreadySeen, nil)
 		}
 
 		// Reject an attestation if it references an invalid block.
@@ -95,7 +95,7 @@ func (s *Service) validateCommitteeIndexBeaconAttestation(ctx context.Context, p
 			s.hasBadBlock(bytesutil.ToBytes32(data.Target.Root)) ||
 			s.hasBadBlock(bytesutil.ToBytes32(data.Source.Root)) {
 			attBadBlockCount.Inc()
-			return pubsub.ValidationReject, errors.New("attestation data references bad block root")
+			return pubsub.ValidationReject, withReason(reasonBadBlock, errors.New("attestation data references bad block root"))
 		}
 	}
 
@@ -109,18 +109,18 @@ func (s *Service) validateCommitteeIndexBeaconAttestation(ctx context.Context, p
 
 	if !s.cfg.chain.InForkchoice(bytesutil.ToBytes32(data.BeaconBlockRoot)) {
 		tracing.AnnotateError(span, blockchain.ErrNotDescendantOfFinalized)
-		return pubsub.ValidationIgnore, blockchain.ErrNotDescendantOfFinalized
+		return pubsub.ValidationIgnore, withReason(reasonNotDescendantOfFinal, blockchain.ErrNotDescendantOfFinalized)
 	}
 	if err = s.cfg.chain.VerifyLmdFfgConsistency(ctx, att); err != nil {
 		tracing.AnnotateError(span, err)
 		attBadLmdConsistencyCount.Inc()
-		return pubsub.ValidationReject, err
+		return pubsub.ValidationReject, withReason(reasonInconsistentVote, err)
 	}
 
 	preState, err := s.cfg.chain.AttestationTargetState(ctx, data.Target)
 	if err != nil {
 		tracing.AnnotateError(span, err)
-		return pubsub.ValidationIgnore, err
+		return pubsub.ValidationIgnore, withReason(reasonStateUnavailable, err)
 	}
 
 	validationRes, err = s.validateUnaggregatedAttTopic(ctx, att, preState, *msg.Topic)
@@ -131,7 +131,7 @@ func (s *Service) validateCommitteeIndexBeaconAttestation(ctx context.Context, p
 	committee, err := helpers.BeaconCommitteeFromState(ctx, preState, att.GetData().Slot, committeeIndex)
 	if err != nil {
 		tracing.AnnotateError(span, err)
-		return pubsub.ValidationIgnore, err
+		return pubsub.ValidationIgnore, withReason(reasonStateUnavailable, err)
 	}
 
 	validationRes, err = validateAttesterData(ctx, att, committee)
@@ -143,7 +143,7 @@ func (s *Service) validateCommitteeIndexBeaconAttestation(ctx context.Context, p
 	if att.Version() >= version.Electra {
 		singleAtt, ok = att.(*eth.SingleAttestation)
 		if !ok {
-			return pubsub.ValidationIgnore, fmt.Errorf("attestation has wrong type (expected %T, got %T)", &eth.SingleAttestation{}, att)
+			return pubsub.ValidationIgnore, withReason(reasonWrongMessageType, fmt.Errorf("attestation has wrong type (expected %T, got %T)", &eth.SingleAttestation{}, att))
 		}
 		att = singleAtt.ToAttestationElectra(committee)
 	}
@@ -221,10 +221,10 @@ func (s *Service) validateUnaggregatedAttTopic(ctx context.Context, a eth.Att, b
 	digest, err := s.currentForkDigest()
 	if err != nil {
 		tracing.AnnotateError(span, err)
-		return pubsub.ValidationIgnore, err
+		return pubsub.ValidationIgnore, withReason(reasonInternalError, err)
 	}
 	if !strings.HasPrefix(t, fmt.Sprintf(format, digest, subnet)) {
-		return pubsub.ValidationReject, errors.New("attestation's subnet does not match with pubsub topic")
+		return pubsub.ValidationReject, withReason(reasonWrongSubnet, errors.New("attestation's subnet does not match with pubsub topic"))
 	}
 
 	return pubsub.ValidationAccept, nil
@@ -237,16 +237,16 @@ func (s *Service) validateCommitteeIndexAndCount(
 ) (primitives.CommitteeIndex, uint64, pubsub.ValidationResult, error) {
 	// - [REJECT] attestation.data.index == 0
 	if a.Version() >= version.Electra && a.GetData().CommitteeIndex != 0 {
-		return 0, 0, pubsub.ValidationReject, errors.New("attestation data's committee index must be 0")
+		return 0, 0, pubsub.ValidationReject, withReason(reasonInvalidCommitteeIndex, errors.New("attestation data's committee index must be 0"))
 	}
 	valCount, err := helpers.ActiveValidatorCount(ctx, bs, slots.ToEpoch(a.GetData().Slot))
 	if err != nil {
-		return 0, 0, pubsub.ValidationIgnore, err
+		return 0, 0, pubsub.ValidationIgnore, withReason(reasonStateUnavailable, err)
 	}
 	count := helpers.SlotCommitteeCount(valCount)
 	ci := a.GetCommitteeIndex()
 	if uint64(ci) > count {
-		return 0, 0, pubsub.ValidationReject, fmt.Errorf("committee index %d > %d", ci, count)
+		return 0, 0, pubsub.ValidationReject, withReason(reasonInvalidCommitteeIndex, fmt.Errorf("committee index %d > %d", ci, count))
 	}
 	return ci, valCount, pubsub.ValidationAccept, nil
 }
@@ -259,20 +259,20 @@ func validateAttesterData(
 	if a.Version() >= version.Electra {
 		singleAtt, ok := a.(*eth.SingleAttestation)
 		if !ok {
-			return pubsub.ValidationIgnore, fmt.Errorf("attestation has wrong type (expected %T, got %T)", &eth.SingleAttestation{}, a)
+			return pubsub.ValidationIgnore, withReason(reasonWrongMessageType, fmt.Errorf("attestation has wrong type (expected %T, got %T)", &eth.SingleAttestation{}, a))
 		}
 		return validateAttestingIndex(ctx, singleAtt.AttesterIndex, committee)
 	}
 
 	// Verify number of aggregation bits matches the committee size.
 	if err := helpers.VerifyBitfieldLength(a.GetAggregationBits(), uint64(len(committee))); err != nil {
-		return pubsub.ValidationReject, err
+		return pubsub.ValidationReject, withReason(reasonInvalidAggregationBits, err)
 	}
 	// Attestation must be unaggregated and the bit index must exist in the range of committee indices.
 	// Note: The Ethereum Beacon chain spec suggests (len(get_attesting_indices(state, attestation.data, attestation.aggregation_bits)) == 1)
 	// however this validation can be achieved without use of get_attesting_indices which is an O(n) lookup.
 	if a.GetAggregationBits().Count() != 1 || a.GetAggregationBits().BitIndices()[0] >= len(committee) {
-		return pubsub.ValidationReject, errors.New("attestation bitfield is invalid")
+		return pubsub.ValidationReject, withReason(reasonInvalidAggregationBits, errors.New("attestation bitfield is invalid"))
 	}
 
 	return pubsub.ValidationAccept, nil
@@ -287,7 +287,7 @@ func (s *Service) validateUnaggregatedAttWithState(ctx context.Context, a eth.At
 	if err != nil {
 		tracing.AnnotateError(span, err)
 		attBadSignatureBatchCount.Inc()
-		return pubsub.ValidationReject, err
+		return pubsub.ValidationReject, withReason(reasonInvalidSignature, err)
 	}
 
 	return s.validateWithBatchVerifier(ctx, "attestation", set)
@@ -311,7 +311,7 @@ func validateAttestingIndex(
 		}
 	}
 	if !inCommittee {
-		return pubsub.ValidationReject, errors.New("attester is not a member of the committee")
+		return pubsub.ValidationReject, withReason(reasonNotInCommittee, errors.New("attester is not a member of the committee"))
 	}
 
 	return pubsub.ValidationAccept, nil
@@ -350,7 +350,7 @@ func (s *Service) saveToPendingAttPool(att eth.Att) (pubsub.ValidationResult, er
 		a, ok := att.(*eth.SingleAttestation)
 		// This will never fail in practice because we asserted the version
 		if !ok {
-			return pubsub.ValidationIgnore, fmt.Errorf("attestation has wrong type (expected %T, got %T)", &eth.SingleAttestation{}, att)
+			return pubsub.ValidationIgnore, withReason(reasonWrongMessageType, fmt.Errorf("attestation has wrong type (expected %T, got %T)", &eth.SingleAttestation{}, att))
 		}
 		// Even though there is no AggregateAndProof type to hold a single attestation, our design of pending atts pool
 		// requires to have an AggregateAndProof object, even for unaggregated attestations.
@@ -365,9 +365,9 @@ func (s *Service) saveToPendingAttPool(att eth.Att) (pubsub.ValidationResult, er
 		a, ok := att.(*eth.Attestation)
 		// This will never fail in practice because we asserted the version
 		if !ok {
-			return pubsub.ValidationIgnore, fmt.Errorf("attestation has wrong type (expected %T, got %T)", &eth.Attestation{}, att)
+			return pubsub.ValidationIgnore, withReason(reasonWrongMessageType, fmt.Errorf("attestation has wrong type (expected %T, got %T)", &eth.Attestation{}, att))
 		}
 		s.savePendingAtt(&eth.SignedAggregateAttestationAndProof{Message: &eth.AggregateAttestationAndProof{Aggregate: a}})
 	}
-	return pubsub.ValidationIgnore, nil
+	return pubsub.ValidationIgnore, withReason(reasonUnknownBlock, nil)
 }
@@ -47,7 +47,7 @@ func (s *Service) validateBeaconBlockPubSub(ctx context.Context, pid peer.ID, ms
 
 	// We should not attempt to process blocks until fully synced, but propagation is OK.
 	if s.cfg.initialSync.Syncing() {
-		return pubsub.ValidationIgnore, nil
+		return pubsub.ValidationIgnore, withReason(reasonSyncing, nil)
 	}
 
 	ctx, span := trace.StartSpan(ctx, "sync.validateBeaconBlockPubSub")
@@ -56,7 +56,7 @@ func (s *Service) validateBeaconBlockPubSub(ctx context.Context, pid peer.ID, ms
 	m, err := s.decodePubsubMessage(msg)
 	if err != nil {
 		tracing.AnnotateError(span, err)
-		return pubsub.ValidationReject, errors.Wrap(err, "Could not decode message")
+		return pubsub.ValidationReject, withReason(reasonDecodeFailed, errors.Wrap(err, "Could not decode message"))
 	}
 
 	s.validateBlockLock.Lock()
@@ -64,11 +64,11 @@ func (s *Service) validateBeaconBlockPubSub(ctx context.Context, pid peer.ID, ms
 
 	blk, ok := m.(interfaces.ReadOnlySignedBeaconBlock)
 	if !ok {
-		return pubsub.ValidationReject, errors.New("msg is not ethpb.ReadOnlySignedBeaconBlock")
+		return pubsub.ValidationReject, withReason(reasonWrongMessageType, errors.New("msg is not ethpb.ReadOnlySignedBeaconBlock"))
 	}
 
 	if blk.IsNil() || blk.Block().IsNil() {
-		return pubsub.ValidationReject, errors.New("block.Block is nil")
+		return pubsub.ValidationReject, withReason(reasonMalformed, errors.New("block.Block is nil"))
 	}
 
 	// Broadcast the block on a feed to notify other services in the beacon node
@@ -94,34 +94,34 @@ func (s *Service) validateBeaconBlockPubSub(ctx context.Context, pid peer.ID, ms
 	}
 
 	if err := validateDenebBeaconBlock(blk.Block()); err != nil {
-		return pubsub.ValidationReject, err
+		return pubsub.ValidationReject, withReason(reasonMalformed, err)
 	}
 
 	// Verify the block is the first block received for the proposer for the slot.
 	if s.hasSeenBlockIndexSlot(blk.Block().Slot(), blk.Block().ProposerIndex()) {
-		return pubsub.ValidationIgnore, nil
+		return pubsub.ValidationIgnore, withReason(reasonAlreadySeen, nil)
 	}
 
 	blockRoot, err := blk.Block().HashTreeRoot()
 	if err != nil {
 		log.WithError(err).WithFields(getBlockFields(blk)).Debug("Ignored block")
-		return pubsub.ValidationIgnore, nil
+		return pubsub.ValidationIgnore, withReason(reasonInternalError, err)
 	}
 	if s.cfg.beaconDB.HasBlock(ctx, blockRoot) {
-		return pubsub.ValidationIgnore, nil
+		return pubsub.ValidationIgnore, withReason(reasonAlreadySeen, nil)
 	}
 	// Check if parent is a bad block and then reject the block.
 	if s.hasBadBlock(blk.Block().ParentRoot()) {
 		s.setBadBlock(ctx, blockRoot)
 		err := fmt.Errorf("received block with root %#x that has an invalid parent %#x", blockRoot, blk.Block().ParentRoot())
 		log.WithError(err).WithFields(getBlockFields(blk)).Debug("Received block with an invalid parent")
-		return pubsub.ValidationReject, err
+		return pubsub.ValidationReject, withReason(reasonInvalidParent, err)
 	}
 
 	s.pendingQueueLock.RLock()
 	if s.seenPendingBlocks[blockRoot] {
 		s.pendingQueueLock.RUnlock()
-		return pubsub.ValidationIgnore, nil
+		return pubsub.ValidationIgnore, withReason(reasonAlreadySeen, nil)
 	}
 	s.pendingQueueLock.RUnlock()
 
@@ -131,26 +131,27 @@ func (s *Service) validateBeaconBlockPubSub(ctx context.Context, pid peer.ID, ms
 	genesisTime := uint64(s.cfg.clock.GenesisTime().Unix())
 	if err := slots.VerifyTime(genesisTime, blk.Block().Slot(), earlyBlockProcessingTolerance); err != nil {
 		log.WithError(err).WithFields(getBlockFields(blk)).Debug("Ignored block: could not verify slot time")
-		return pubsub.ValidationIgnore, nil
+		return pubsub.ValidationIgnore, withReason(reasonFutureSlot, err)
 	}
 
 	// Add metrics for block arrival time subtracts slot start time.
 	if err := captureArrivalTimeMetric(genesisTime, blk.Block().Slot()); err != nil {
 		log.WithError(err).WithFields(getBlockFields(blk)).Debug("Ignored block: could not capture arrival time metric")
-		return pubsub.ValidationIgnore, nil
+		return pubsub.ValidationIgnore, withReason(reasonInternalError, err)
 	}
 
 	cp := s.cfg.chain.FinalizedCheckpt()
 	startSlot, err := slots.EpochStart(cp.Epoch)
 	if err != nil {
 		log.WithError(err).WithFields(getBlockFields(blk)).Debug("Ignored block: could not calculate epoch start slot")
-		return pubsub.ValidationIgnore, nil
+		return pubsub.ValidationIgnore, withReason(reasonInternalError, err)
 	}
 	if startSlot >= blk.Block().Slot() {
 		err := fmt.Errorf("finalized slot %d greater or equal to block slot %d", startSlot, blk.Block().Slot())
 		log.WithFields(getBlockFields(blk)).Debug(err)
-		return pubsub.ValidationIgnore, err
+		return pubsub.ValidationIgnore, withReason(reasonBeforeFinalized, err)
 	}
+	s.cfg.slotTimingCache.SetBlockArrival(blk.Block().Slot(), blockRoot, pid.String(), receivedTime)
 
 	// Process the block if the clock jitter is less than MAXIMUM_GOSSIP_CLOCK_DISPARITY.
 	// Otherwise queue it for processing in the right slot.
@@ -163,12 +164,12 @@ func (s *Service) validateBeaconBlockPubSub(ctx context.Context, pid peer.ID, ms
 		if err := s.insertBlockToPendingQueue(blk.Block().Slot(), blk, blockRoot); err != nil {
 			s.pendingQueueLock.Unlock()
 			log.WithError(err).WithFields(getBlockFields(blk)).Debug("Could not insert block to pending queue")
-			return pubsub.ValidationIgnore, err
+			return pubsub.ValidationIgnore, withReason(reasonInternalError, err)
 		}
 		s.pendingQueueLock.Unlock()
 		err := fmt.Errorf("early block, with current slot %d < block slot %d", s.cfg.clock.CurrentSlot(), blk.Block().Slot())
 		log.WithError(err).WithFields(getBlockFields(blk)).Debug("Could not process early block")
-		return pubsub.ValidationIgnore, err
+		return pubsub.ValidationIgnore, withReason(reasonFutureSlot, err)
 	}
 
 	// Handle block when the parent is unknown.
@@ -181,12 +182,12 @@ func (s *Service) validateBeaconBlockPubSub(ctx context.Context, pid peer.ID, ms
 		if err := s.insertBlockToPendingQueue(blk.Block().Slot(), blk, blockRoot); err != nil {
 			s.pendingQueueLock.Unlock()
 			log.WithError(err).WithFields(getBlockFields(blk)).Debug("Could not insert block to pending queue")
-			return pubsub.ValidationIgnore, err
+			return pubsub.ValidationIgnore, withReason(reasonInternalError, err)
 		}
 		s.pendingQueueLock.Unlock()
 		err := errors.Errorf("unknown parent for block with slot %d and parent root %#x", blk.Block().Slot(), blk.Block().ParentRoot())
 		log.WithError(err).WithFields(getBlockFields(blk)).Debug("Could not identify parent for block")
-		return pubsub.ValidationIgnore, err
+		return pubsub.ValidationIgnore, withReason(reasonUnknownBlock, err)
 	}
 
 	err = s.validateBeaconBlock(ctx, blk, blockRoot)
@@ -195,6 +196,7 @@ func (s *Service) validateBeaconBlockPubSub(ctx context.Context, pid peer.ID, ms
 		// This also does not penalize a peer which sends optimistic blocks
 		if !errors.Is(ErrOptimisticParent, err) {
 			log.WithError(err).WithFields(getBlockFields(blk)).Debug("Could not validate beacon block")
+			s.recordInvalidGossipBlock(ctx, blk, blockRoot, pid, err)
 			return pubsub.ValidationReject, err
 		}
 	}
@@ -204,7 +206,7 @@ func (s *Service) validateBeaconBlockPubSub(ctx context.Context, pid peer.ID, ms
 	blkPb, err := blk.Proto()
 	if err != nil {
 		log.WithError(err).WithFields(getBlockFields(blk)).Debug("Could not convert beacon block to protobuf type")
-		return pubsub.ValidationIgnore, err
+		return pubsub.ValidationIgnore, withReason(reasonInternalError, err)
 	}
 	msg.ValidatorData = blkPb // Used in downstream subscriber
 
@@ -237,7 +239,7 @@ func (s *Service) validateBeaconBlock(ctx context.Context, blk interfaces.ReadOn
 
 	if err := validateDenebBeaconBlock(blk.Block()); err != nil {
 		s.setBadBlock(ctx, blockRoot)
-		return err
+		return withReason(reasonMalformed, err)
 	}
 
 	parentState, err := s.validatePhase0Block(ctx, blk, blockRoot)
@@ -263,31 +265,31 @@ func (s *Service) validateBeaconBlock(ctx context.Context, blk interfaces.ReadOn
 func (s *Service) validatePhase0Block(ctx context.Context, blk interfaces.ReadOnlySignedBeaconBlock, blockRoot [32]byte) (state.BeaconState, error) {
 	if !s.cfg.chain.InForkchoice(blk.Block().ParentRoot()) {
 		s.setBadBlock(ctx, blockRoot)
-		return nil, blockchain.ErrNotDescendantOfFinalized
+		return nil, withReason(reasonNotDescendantOfFinal, blockchain.ErrNotDescendantOfFinalized)
 	}
 
 	parentState, err := s.cfg.stateGen.StateByRoot(ctx, blk.Block().ParentRoot())
 	if err != nil {
-		return nil, err
+		return nil, withReason(reasonStateUnavailable, err)
 	}
 
 	if err := blocks.VerifyBlockSignatureUsingCurrentFork(parentState, blk, blockRoot); err != nil {
-		return nil, err
+		return nil, withReason(reasonInvalidSignature, err)
 	}
 	// In the event the block is more than an epoch ahead from its
 	// parent state, we have to advance the state forward.
 	parentRoot := blk.Block().ParentRoot()
 	parentState, err = transition.ProcessSlotsUsingNextSlotCache(ctx, parentState, parentRoot[:], blk.Block().Slot())
 	if err != nil {
-		return nil, err
+		return nil, withReason(reasonInternalError, err)
 	}
 	idx, err := helpers.BeaconProposerIndex(ctx, parentState)
 	if err != nil {
-		return nil, err
+		return nil, withReason(reasonInternalError, err)
 	}
 	if blk.Block().ProposerIndex() != idx {
 		s.setBadBlock(ctx, blockRoot)
-		return nil, errors.New("incorrect proposer index")
+		return nil, withReason(reasonInvalidProposer, errors.New("incorrect proposer index"))
 	}
 	return parentState, nil
 }
@@ -326,13 +328,13 @@ func validateDenebBeaconBlock(blk interfaces.ReadOnlyBeaconBlock) error {
 func (s *Service) validateBellatrixBeaconBlock(ctx context.Context, parentState state.BeaconState, blk interfaces.ReadOnlyBeaconBlock) error {
 	// Error if block and state are not the same version
 	if parentState.Version() != blk.Version() {
-		return errors.New("block and state are not the same version")
+		return withReason(reasonMalformed, errors.New("block and state are not the same version"))
 	}
 
 	body := blk.Body()
 	executionEnabled, err := blocks.IsExecutionEnabled(parentState, body)
 	if err != nil {
-		return err
+		return withReason(reasonInvalidExecutionPayload, err)
 	}
 	if !executionEnabled {
 		return nil
@@ -340,22 +342,22 @@ func (s *Service) validateBellatrixBeaconBlock(ctx context.Context, parentState
 
 	t, err := slots.ToTime(parentState.GenesisTime(), blk.Slot())
 	if err != nil {
-		return err
+		return withReason(reasonInternalError, err)
 	}
 	payload, err := body.Execution()
 	if err != nil {
-		return err
+		return withReason(reasonInvalidExecutionPayload, err)
 	}
 	if payload == nil || payload.IsNil() {
-		return errors.New("execution payload is nil")
+		return withReason(reasonInvalidExecutionPayload, errors.New("execution payload is nil"))
 	}
 	if payload.Timestamp() != uint64(t.Unix()) {
-		return errors.New("incorrect timestamp")
+		return withReason(reasonInvalidExecutionPayload, errors.New("incorrect timestamp"))
 	}
 
 	isParentOptimistic, err := s.cfg.chain.IsOptimisticForRoot(ctx, blk.ParentRoot())
 	if err != nil {
-		return err
+		return withReason(reasonInternalError, err)
 	}
 	if isParentOptimistic {
 		return ErrOptimisticParent
@@ -367,16 +369,16 @@ func (s *Service) validateBellatrixBeaconBlock(ctx context.Context, parentState
 func (s *Service) verifyPendingBlockSignature(ctx context.Context, blk interfaces.ReadOnlySignedBeaconBlock, blkRoot [32]byte) (pubsub.ValidationResult, error) {
 	roState, err := s.cfg.chain.HeadStateReadOnly(ctx)
 	if err != nil {
-		return pubsub.ValidationIgnore, err
+		return pubsub.ValidationIgnore, withReason(reasonStateUnavailable, err)
 	}
 	// Ignore block in the event of non-existent proposer.
 	_, err = roState.ValidatorAtIndexReadOnly(blk.Block().ProposerIndex())
 	if err != nil {
-		return pubsub.ValidationIgnore, err
+		return pubsub.ValidationIgnore, withReason(reasonInvalidProposer, err)
 	}
 	if err := blocks.VerifyBlockSignatureUsingCurrentFork(roState, blk, blkRoot); err != nil {
 		s.setBadBlock(ctx, blkRoot)
-		return pubsub.ValidationReject, err
+		return pubsub.ValidationReject, withReason(reasonInvalidSignature, err)
 	}
 	return pubsub.ValidationAccept, nil
 }
@@ -398,8 +400,11 @@ func (s *Service) setSeenBlockIndexSlot(slot primitives.Slot, proposerIdx primit
 	s.seenBlockCache.Add(string(b), true)
 }
 
-// Returns true if the block is marked as a bad block.
+// Returns true if the block is marked as a bad block, or is known to be invalid by the invalid block registry.
 func (s *Service) hasBadBlock(root [32]byte) bool {
+	if s.invalidBlocks.IsInvalid(root) {
+		return true
+	}
 	s.badBlockLock.RLock()
 	defer s.badBlockLock.RUnlock()
 	_, seen := s.badBlockCache.Get(string(root[:]))
@@ -146,7 +146,7 @@ func TestValidateBeaconBlockPubSub_BlockAlreadyPresentInDB(t *testing.T) {
 		},
 	}
 	res, err := r.validateBeaconBlockPubSub(ctx, "", m)
-	assert.NoError(t, err)
+	assert.Equal(t, reasonAlreadySeen, gossipFailureReason(err))
 	assert.Equal(t, res, pubsub.ValidationIgnore, "block present in DB should be ignored")
 }
 
@@ -521,7 +521,7 @@ func TestValidateBeaconBlockPubSub_Syncing(t *testing.T) {
 		},
 	}
 	res, err := r.validateBeaconBlockPubSub(ctx, "", m)
-	assert.NoError(t, err)
+	assert.Equal(t, reasonSyncing, gossipFailureReason(err))
 	assert.Equal(t, res, pubsub.ValidationIgnore, "block is ignored until fully synced")
 }
 
@@ -638,7 +638,7 @@ func TestValidateBeaconBlockPubSub_RejectBlocksFromFuture(t *testing.T) {
 		},
 	}
 	res, err := r.validateBeaconBlockPubSub(ctx, "", m)
-	assert.NoError(t, err)
+	assert.Equal(t, reasonFutureSlot, gossipFailureReason(err))
 	assert.Equal(t, res, pubsub.ValidationIgnore, "block from the future should be ignored")
 }
 
@@ -751,7 +751,7 @@ func TestValidateBeaconBlockPubSub_SeenProposerSlot(t *testing.T) {
 	r.setSeenBlockIndexSlot(msg.Block.Slot, msg.Block.ProposerIndex)
 	time.Sleep(10 * time.Millisecond) // Wait for cached value to pass through buffers.
 	res, err := r.validateBeaconBlockPubSub(ctx, "", m)
-	assert.NoError(t, err)
+	assert.Equal(t, reasonAlreadySeen, gossipFailureReason(err))
 	assert.Equal(t, res, pubsub.ValidationIgnore, "seen proposer block should be ignored")
 }
 
@@ -818,7 +818,7 @@ func TestValidateBeaconBlockPubSub_FilterByFinalizedEpoch(t *testing.T) {
 	}
 
 	res, err = r.validateBeaconBlockPubSub(context.Background(), "", m)
-	assert.NoError(t, err)
+	assert.Equal(t, reasonFutureSlot, gossipFailureReason(err))
 	assert.Equal(t, pubsub.ValidationIgnore, res)
 }
 
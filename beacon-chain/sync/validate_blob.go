@@ -31,55 +31,55 @@ func (s *Service) validateBlob(ctx context.Context, pid peer.ID, msg *pubsub.Mes
 		return pubsub.ValidationAccept, nil
 	}
 	if s.cfg.initialSync.Syncing() {
-		return pubsub.ValidationIgnore, nil
+		return pubsub.ValidationIgnore, withReason(reasonSyncing, nil)
 	}
 	if msg.Topic == nil {
-		return pubsub.ValidationReject, errInvalidTopic
+		return pubsub.ValidationReject, withReason(reasonInvalidTopic, errInvalidTopic)
 	}
 	m, err := s.decodePubsubMessage(msg)
 	if err != nil {
 		log.WithError(err).Error("Failed to decode message")
-		return pubsub.ValidationReject, err
+		return pubsub.ValidationReject, withReason(reasonDecodeFailed, err)
 	}
 
 	bpb, ok := m.(*eth.BlobSidecar)
 	if !ok {
 		log.WithField("message", m).Error("Message is not of type *eth.BlobSidecar")
-		return pubsub.ValidationReject, errWrongMessage
+		return pubsub.ValidationReject, withReason(reasonWrongMessageType, errWrongMessage)
 	}
 	blob, err := blocks.NewROBlob(bpb)
 	if err != nil {
-		return pubsub.ValidationReject, errors.Wrap(err, "roblob conversion failure")
+		return pubsub.ValidationReject, withReason(reasonMalformed, errors.Wrap(err, "roblob conversion failure"))
 	}
 	vf := s.newBlobVerifier(blob, verification.GossipBlobSidecarRequirements)
 
 	if err := vf.BlobIndexInBounds(); err != nil {
-		return pubsub.ValidationReject, err
+		return pubsub.ValidationReject, withReason(reasonInvalidBlobIndex, err)
 	}
 
 	// [REJECT] The sidecar is for the correct subnet -- i.e. compute_subnet_for_blob_sidecar(sidecar.index) == subnet_id.
 	want := fmt.Sprintf("blob_sidecar_%d", computeSubnetForBlobSidecar(blob.Index, blob.Slot()))
 	if !strings.Contains(*msg.Topic, want) {
 		log.WithFields(blobFields(blob)).Debug("Sidecar index does not match topic")
-		return pubsub.ValidationReject, fmt.Errorf("wrong topic name: %s", *msg.Topic)
+		return pubsub.ValidationReject, withReason(reasonWrongSubnet, fmt.Errorf("wrong topic name: %s", *msg.Topic))
 	}
 
 	if err := vf.NotFromFutureSlot(); err != nil {
-		return pubsub.ValidationIgnore, err
+		return pubsub.ValidationIgnore, withReason(reasonFutureSlot, err)
 	}
 
 	startTime, err := slots.ToTime(uint64(s.cfg.chain.GenesisTime().Unix()), blob.Slot())
 	if err != nil {
-		return pubsub.ValidationIgnore, err
+		return pubsub.ValidationIgnore, withReason(reasonInternalError, err)
 	}
 
 	// [IGNORE] The sidecar is the first sidecar for the tuple (block_header.slot, block_header.proposer_index, sidecar.index) with valid header signature and sidecar inclusion proof
 	if s.hasSeenBlobIndex(blob.Slot(), blob.ProposerIndex(), blob.Index) {
-		return pubsub.ValidationIgnore, nil
+		return pubsub.ValidationIgnore, withReason(reasonAlreadySeen, nil)
 	}
 
 	if err := vf.SlotAboveFinalized(); err != nil {
-		return pubsub.ValidationIgnore, err
+		return pubsub.ValidationIgnore, withReason(reasonBeforeFinalized, err)
 	}
 
 	if err := vf.SidecarParentSeen(s.hasBadBlock); err != nil {
@@ -89,36 +89,39 @@ func (s *Service) validateBlob(ctx context.Context, pid peer.ID, msg *pubsub.Mes
 			}
 		}()
 		missingParentBlobSidecarCount.Inc()
-		return pubsub.ValidationIgnore, err
+		return pubsub.ValidationIgnore, withReason(reasonUnknownBlock, err)
 	}
 
 	if err := vf.ValidProposerSignature(ctx); err != nil {
-		return pubsub.ValidationReject, err
+		return pubsub.ValidationReject, withReason(reasonInvalidSignature, err)
 	}
 
 	if err := vf.SidecarParentValid(s.hasBadBlock); err != nil {
-		return pubsub.ValidationReject, err
+		return pubsub.ValidationReject, withReason(reasonInvalidParent, err)
 	}
 
 	if err := vf.SidecarParentSlotLower(); err != nil {
-		return pubsub.ValidationReject, err
+		return pubsub.ValidationReject, withReason(reasonInvalidParent, err)
 	}
 
 	if err := vf.SidecarDescendsFromFinalized(); err != nil {
-		return pubsub.ValidationReject, err
+		return pubsub.ValidationReject, withReason(reasonNotDescendantOfFinal, err)
 	}
 
 	if err := vf.SidecarInclusionProven(); err != nil {
-		return pubsub.ValidationReject, err
+		s.recordInvalidBlob(ctx, blob, pid, err)
+		return pubsub.ValidationReject, withReason(reasonInvalidInclusionProof, err)
 	}
 
 	if err := vf.SidecarKzgProofVerified(); err != nil {
 		saveInvalidBlobToTemp(blob)
-		return pubsub.ValidationReject, err
+		s.recordInvalidBlob(ctx, blob, pid, err)
+		return pubsub.ValidationReject, withReason(reasonInvalidKzgProof, err)
 	}
 
 	if err := vf.SidecarProposerExpected(ctx); err != nil {
-		return pubsub.ValidationReject, err
+		s.recordInvalidBlob(ctx, blob, pid, err)
+		return pubsub.ValidationReject, withReason(reasonInvalidProposer, err)
 	}
 
 	fields := blobFields(blob)
@@ -130,10 +133,12 @@ func (s *Service) validateBlob(ctx context.Context, pid peer.ID, msg *pubsub.Mes
 
 	blobSidecarVerificationGossipSummary.Observe(float64(validationTime.Milliseconds()))
 	blobSidecarArrivalGossipSummary.Observe(float64(sinceSlotStartTime.Milliseconds()))
+	blobSidecarArrivalHistogram.Observe(float64(sinceSlotStartTime.Milliseconds()))
+	s.cfg.slotTimingCache.SetBlobArrival(blob.Slot(), blob.BlockRoot(), blob.Index, receivedTime)
 
 	vBlobData, err := vf.VerifiedROBlob()
 	if err != nil {
-		return pubsub.ValidationReject, err
+		return pubsub.ValidationReject, withReason(reasonInternalError, err)
 	}
 	msg.ValidatorData = vBlobData
 
@@ -41,7 +41,7 @@ func TestValidateBlob_InitSync(t *testing.T) {
 	p := p2ptest.NewTestP2P(t)
 	s := &Service{cfg: &config{p2p: p, initialSync: &mockSync.Sync{IsSyncing: true}}}
 	result, err := s.validateBlob(ctx, "", nil)
-	require.NoError(t, err)
+	require.Equal(t, reasonSyncing, gossipFailureReason(err))
 	require.Equal(t, result, pubsub.ValidationIgnore)
 }
 
@@ -52,7 +52,7 @@ func TestValidateBlob_InvalidTopic(t *testing.T) {
 	result, err := s.validateBlob(ctx, "", &pubsub.Message{
 		Message: &pb.Message{},
 	})
-	require.ErrorIs(t, errInvalidTopic, err)
+	require.ErrorIs(t, err, errInvalidTopic)
 	require.Equal(t, result, pubsub.ValidationReject)
 }
 
@@ -77,7 +77,7 @@ func TestValidateBlob_InvalidMessageType(t *testing.T) {
 			Data:  buf.Bytes(),
 			Topic: &topic,
 		}})
-	require.ErrorIs(t, errWrongMessage, err)
+	require.ErrorIs(t, err, errWrongMessage)
 	require.Equal(t, result, pubsub.ValidationReject)
 }
 
@@ -138,7 +138,7 @@ func TestValidateBlob_AlreadySeenInCache(t *testing.T) {
 			Data:  buf.Bytes(),
 			Topic: &topic,
 		}})
-	require.NoError(t, err)
+	require.Equal(t, reasonAlreadySeen, gossipFailureReason(err))
 	require.Equal(t, result, pubsub.ValidationIgnore)
 }
 
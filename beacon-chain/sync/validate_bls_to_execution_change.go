@@ -20,7 +20,7 @@ func (s *Service) validateBlsToExecutionChange(ctx context.Context, pid peer.ID,
 
 	// The head state will be too far away to validate any execution change.
 	if s.cfg.initialSync.Syncing() {
-		return pubsub.ValidationIgnore, nil
+		return pubsub.ValidationIgnore, withReason(reasonSyncing, nil)
 	}
 
 	ctx, span := trace.StartSpan(ctx, "sync.validateBlsToExecutionChange")
@@ -29,34 +29,34 @@ func (s *Service) validateBlsToExecutionChange(ctx context.Context, pid peer.ID,
 	m, err := s.decodePubsubMessage(msg)
 	if err != nil {
 		tracing.AnnotateError(span, err)
-		return pubsub.ValidationReject, err
+		return pubsub.ValidationReject, withReason(reasonDecodeFailed, err)
 	}
 
 	blsChange, ok := m.(*ethpb.SignedBLSToExecutionChange)
 	if !ok {
-		return pubsub.ValidationReject, errWrongMessage
+		return pubsub.ValidationReject, withReason(reasonWrongMessageType, errWrongMessage)
 	}
 
 	// Check that the validator hasn't submitted a previous execution change.
 	if blsChange.Message == nil {
-		return pubsub.ValidationReject, errNilMessage
+		return pubsub.ValidationReject, withReason(reasonMalformed, errNilMessage)
 	}
 	if s.cfg.blsToExecPool.ValidatorExists(blsChange.Message.ValidatorIndex) {
-		return pubsub.ValidationIgnore, nil
+		return pubsub.ValidationIgnore, withReason(reasonAlreadySeen, nil)
 	}
 	st, err := s.cfg.chain.HeadStateReadOnly(ctx)
 	if err != nil {
-		return pubsub.ValidationIgnore, err
+		return pubsub.ValidationIgnore, withReason(reasonStateUnavailable, err)
 	}
 	// Validate that the execution change object is valid.
 	_, err = blocks.ValidateBLSToExecutionChange(st, blsChange)
 	if err != nil {
-		return pubsub.ValidationReject, err
+		return pubsub.ValidationReject, withReason(reasonInvalidOperation, err)
 	}
 	// Validate the signature of the message using our batch gossip verifier.
 	sigBatch, err := blocks.BLSChangesSignatureBatch(st, []*ethpb.SignedBLSToExecutionChange{blsChange})
 	if err != nil {
-		return pubsub.ValidationReject, err
+		return pubsub.ValidationReject, withReason(reasonInvalidSignature, err)
 	}
 	res, err := s.validateWithBatchVerifier(ctx, "bls to execution change", sigBatch)
 	if res != pubsub.ValidationAccept {
@@ -26,7 +26,7 @@ func (s *Service) validateProposerSlashing(ctx context.Context, pid peer.ID, msg
 
 	// The head state will be too far away to validate any slashing.
 	if s.cfg.initialSync.Syncing() {
-		return pubsub.ValidationIgnore, nil
+		return pubsub.ValidationIgnore, withReason(reasonSyncing, nil)
 	}
 
 	ctx, span := trace.StartSpan(ctx, "sync.validateProposerSlashing")
@@ -35,34 +35,34 @@ func (s *Service) validateProposerSlashing(ctx context.Context, pid peer.ID, msg
 	m, err := s.decodePubsubMessage(msg)
 	if err != nil {
 		tracing.AnnotateError(span, err)
-		return pubsub.ValidationReject, err
+		return pubsub.ValidationReject, withReason(reasonDecodeFailed, err)
 	}
 
 	slashing, ok := m.(*ethpb.ProposerSlashing)
 	if !ok {
-		return pubsub.ValidationReject, errWrongMessage
+		return pubsub.ValidationReject, withReason(reasonWrongMessageType, errWrongMessage)
 	}
 
 	if slashing.Header_1 == nil || slashing.Header_1.Header == nil {
-		return pubsub.ValidationReject, errNilMessage
+		return pubsub.ValidationReject, withReason(reasonMalformed, errNilMessage)
 	}
 	if s.hasSeenProposerSlashingIndex(slashing.Header_1.Header.ProposerIndex) {
-		return pubsub.ValidationIgnore, nil
+		return pubsub.ValidationIgnore, withReason(reasonAlreadySeen, nil)
 	}
 
 	headState, err := s.cfg.chain.HeadState(ctx)
 	if err != nil {
-		return pubsub.ValidationIgnore, err
+		return pubsub.ValidationIgnore, withReason(reasonStateUnavailable, err)
 	}
 	rov, err := headState.ValidatorAtIndexReadOnly(slashing.Header_1.Header.ProposerIndex)
 	if err != nil {
-		return pubsub.ValidationReject, err
+		return pubsub.ValidationReject, withReason(reasonInvalidOperation, err)
 	}
 	if rov.Slashed() {
-		return pubsub.ValidationIgnore, fmt.Errorf("proposer is already slashed: %d", slashing.Header_1.Header.ProposerIndex)
+		return pubsub.ValidationIgnore, withReason(reasonAlreadySeen, fmt.Errorf("proposer is already slashed: %d", slashing.Header_1.Header.ProposerIndex))
 	}
 	if err := blocks.VerifyProposerSlashing(headState, slashing); err != nil {
-		return pubsub.ValidationReject, err
+		return pubsub.ValidationReject, withReason(reasonInvalidOperation, err)
 	}
 
 	// notify events
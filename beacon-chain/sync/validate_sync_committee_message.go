@@ -54,18 +54,18 @@ func (s *Service) validateSyncCommitteeMessage(
 
 	// Basic validations before proceeding.
 	if s.cfg.initialSync.Syncing() {
-		return pubsub.ValidationIgnore, nil
+		return pubsub.ValidationIgnore, withReason(reasonSyncing, nil)
 	}
 
 	if msg.Topic == nil {
-		return pubsub.ValidationReject, errInvalidTopic
+		return pubsub.ValidationReject, withReason(reasonInvalidTopic, errInvalidTopic)
 	}
 
 	// Read the data from the pubsub message, and reject if there is an error.
 	m, err := s.readSyncCommitteeMessage(msg)
 	if err != nil {
 		tracing.AnnotateError(span, err)
-		return pubsub.ValidationReject, err
+		return pubsub.ValidationReject, withReason(reasonDecodeFailed, err)
 	}
 
 	// Validate sync message times before proceeding.
@@ -76,13 +76,13 @@ func (s *Service) validateSyncCommitteeMessage(
 		params.BeaconConfig().MaximumGossipClockDisparityDuration(),
 	); err != nil {
 		tracing.AnnotateError(span, err)
-		return pubsub.ValidationIgnore, err
+		return pubsub.ValidationIgnore, withReason(reasonSlotOutOfRange, err)
 	}
 
 	committeeIndices, err := s.cfg.chain.HeadSyncCommitteeIndices(ctx, m.ValidatorIndex, m.Slot)
 	if err != nil {
 		tracing.AnnotateError(span, err)
-		return pubsub.ValidationIgnore, err
+		return pubsub.ValidationIgnore, withReason(reasonStateUnavailable, err)
 	}
 
 	// Validate the message's data according to the p2p specification.
@@ -182,7 +182,7 @@ func (s *Service) rejectIncorrectSyncCommittee(
 		digest, err := s.currentForkDigest()
 		if err != nil {
 			tracing.AnnotateError(span, err)
-			return pubsub.ValidationIgnore, err
+			return pubsub.ValidationIgnore, withReason(reasonInternalError, err)
 		}
 
 		format := p2p.GossipTypeMapping[reflect.TypeOf(&ethpb.SyncCommitteeMessage{})]
@@ -196,7 +196,7 @@ func (s *Service) rejectIncorrectSyncCommittee(
 			}
 		}
 		if !isValid {
-			return pubsub.ValidationReject, errors.New("sync committee message references a different subnet")
+			return pubsub.ValidationReject, withReason(reasonWrongSubnet, errors.New("sync committee message references a different subnet"))
 		}
 		return pubsub.ValidationAccept, nil
 	}
@@ -219,7 +219,7 @@ func (s *Service) ignoreHasSeenSyncMsg(ctx context.Context,
 			}
 		}
 		if !isValid {
-			return pubsub.ValidationIgnore, nil
+			return pubsub.ValidationIgnore, withReason(reasonAlreadySeen, nil)
 		}
 		return pubsub.ValidationAccept, nil
 	}
@@ -236,13 +236,13 @@ func (s *Service) rejectInvalidSyncCommitteeSignature(m *ethpb.SyncCommitteeMess
 		d, err := s.cfg.chain.HeadSyncCommitteeDomain(ctx, m.Slot)
 		if err != nil {
 			tracing.AnnotateError(span, err)
-			return pubsub.ValidationIgnore, err
+			return pubsub.ValidationIgnore, withReason(reasonInternalError, err)
 		}
 		rawBytes := p2ptypes.SSZBytes(m.BlockRoot)
 		sigRoot, err := signing.ComputeSigningRoot(&rawBytes, d)
 		if err != nil {
 			tracing.AnnotateError(span, err)
-			return pubsub.ValidationIgnore, err
+			return pubsub.ValidationIgnore, withReason(reasonInternalError, err)
 		}
 
 		// Reject for a validator index that is not found, as we should not remain peered with a node
@@ -250,14 +250,14 @@ func (s *Service) rejectInvalidSyncCommitteeSignature(m *ethpb.SyncCommitteeMess
 		pubKey, err := s.cfg.chain.HeadValidatorIndexToPublicKey(ctx, m.ValidatorIndex)
 		if err != nil {
 			tracing.AnnotateError(span, err)
-			return pubsub.ValidationReject, err
+			return pubsub.ValidationReject, withReason(reasonStateUnavailable, err)
 		}
 
 		// Ignore a malformed public key from bytes according to the p2p specification.
 		pKey, err := bls.PublicKeyFromBytes(pubKey[:])
 		if err != nil {
 			tracing.AnnotateError(span, err)
-			return pubsub.ValidationIgnore, err
+			return pubsub.ValidationIgnore, withReason(reasonMalformed, err)
 		}
 
 		// Batch verify message signature before unmarshalling
@@ -276,7 +276,7 @@ func (s *Service) rejectInvalidSyncCommitteeSignature(m *ethpb.SyncCommitteeMess
 func ignoreEmptyCommittee(indices []primitives.CommitteeIndex) validationFn {
 	return func(ctx context.Context) (pubsub.ValidationResult, error) {
 		if len(indices) == 0 {
-			return pubsub.ValidationIgnore, nil
+			return pubsub.ValidationIgnore, withReason(reasonNotInCommittee, nil)
 		}
 		return pubsub.ValidationAccept, nil
 	}
@@ -49,19 +49,19 @@ func (s *Service) validateSyncContributionAndProof(ctx context.Context, pid peer
 
 	// Ignore the sync committee contribution if the beacon node is syncing.
 	if s.cfg.initialSync.Syncing() {
-		return pubsub.ValidationIgnore, nil
+		return pubsub.ValidationIgnore, withReason(reasonSyncing, nil)
 	}
 
 	m, err := s.readSyncContributionMessage(msg)
 	if err != nil {
 		tracing.AnnotateError(span, err)
-		return pubsub.ValidationReject, err
+		return pubsub.ValidationReject, withReason(reasonDecodeFailed, err)
 	}
 
 	// The contribution's slot is for the current slot (with a `MAXIMUM_GOSSIP_CLOCK_DISPARITY` allowance).
 	if err := altair.ValidateSyncMessageTime(m.Message.Contribution.Slot, s.cfg.clock.GenesisTime(), params.BeaconConfig().MaximumGossipClockDisparityDuration()); err != nil {
 		tracing.AnnotateError(span, err)
-		return pubsub.ValidationIgnore, err
+		return pubsub.ValidationIgnore, withReason(reasonSlotOutOfRange, err)
 	}
 	// Validate the message's data according to the p2p specification.
 	if result, err := validationPipeline(
@@ -80,7 +80,7 @@ func (s *Service) validateSyncContributionAndProof(ctx context.Context, pid peer
 
 	con := m.Message.Contribution
 	if err := s.setSyncContributionBits(con); err != nil {
-		return pubsub.ValidationIgnore, err
+		return pubsub.ValidationIgnore, withReason(reasonInternalError, err)
 	}
 	s.setSyncContributionIndexSlotSeen(con.Slot, m.Message.AggregatorIndex, primitives.CommitteeIndex(con.SubcommitteeIndex))
 
@@ -122,7 +122,7 @@ func rejectIncorrectSubcommitteeIndex(
 		defer span.End()
 		// The subcommittee index is in the allowed range, i.e. `contribution.subcommittee_index < SYNC_COMMITTEE_SUBNET_COUNT`.
 		if m.Message.Contribution.SubcommitteeIndex >= params.BeaconConfig().SyncCommitteeSubnetCount {
-			return pubsub.ValidationReject, errors.New("subcommittee index is invalid")
+			return pubsub.ValidationReject, withReason(reasonInvalidCommitteeIndex, errors.New("subcommittee index is invalid"))
 		}
 
 		return pubsub.ValidationAccept, nil
@@ -135,7 +135,7 @@ func rejectEmptyContribution(m *ethpb.SignedContributionAndProof) validationFn {
 		// In the event no bit is set for the
 		// sync contribution, we reject the message.
 		if bVector.Count() == 0 {
-			return pubsub.ValidationReject, errors.New("bitvector count is 0")
+			return pubsub.ValidationReject, withReason(reasonInvalidAggregationBits, errors.New("bitvector count is 0"))
 		}
 		return pubsub.ValidationAccept, nil
 	}
@@ -146,14 +146,14 @@ func (s *Service) ignoreSeenSyncContribution(m *ethpb.SignedContributionAndProof
 		c := m.Message.Contribution
 		seen, err := s.hasSeenSyncContributionBits(c)
 		if err != nil {
-			return pubsub.ValidationIgnore, err
+			return pubsub.ValidationIgnore, withReason(reasonInternalError, err)
 		}
 		if seen {
-			return pubsub.ValidationIgnore, nil
+			return pubsub.ValidationIgnore, withReason(reasonAlreadySeen, nil)
 		}
 		seen = s.hasSeenSyncContributionIndexSlot(c.Slot, m.Message.AggregatorIndex, primitives.CommitteeIndex(c.SubcommitteeIndex))
 		if seen {
-			return pubsub.ValidationIgnore, nil
+			return pubsub.ValidationIgnore, withReason(reasonAlreadySeen, nil)
 		}
 		return pubsub.ValidationAccept, nil
 	}
@@ -163,7 +163,7 @@ func rejectInvalidAggregator(m *ethpb.SignedContributionAndProof) validationFn {
 	return func(ctx context.Context) (pubsub.ValidationResult, error) {
 		// The `contribution_and_proof.selection_proof` selects the validator as an aggregator for the slot.
 		if isAggregator, err := altair.IsSyncCommitteeAggregator(m.Message.SelectionProof); err != nil || !isAggregator {
-			return pubsub.ValidationReject, err
+			return pubsub.ValidationReject, withReason(reasonNotAggregator, err)
 		}
 		return pubsub.ValidationAccept, nil
 	}
@@ -177,11 +177,11 @@ func (s *Service) rejectInvalidIndexInSubCommittee(m *ethpb.SignedContributionAn
 		committeeIndices, err := s.cfg.chain.HeadSyncCommitteeIndices(ctx, m.Message.AggregatorIndex, m.Message.Contribution.Slot)
 		if err != nil {
 			tracing.AnnotateError(span, err)
-			return pubsub.ValidationIgnore, err
+			return pubsub.ValidationIgnore, withReason(reasonStateUnavailable, err)
 		}
 		if len(committeeIndices) == 0 {
 			tracing.AnnotateError(span, err)
-			return pubsub.ValidationIgnore, err
+			return pubsub.ValidationIgnore, withReason(reasonNotInCommittee, err)
 		}
 		isValid := false
 		subCommitteeSize := params.BeaconConfig().SyncCommitteeSize / params.BeaconConfig().SyncCommitteeSubnetCount
@@ -192,7 +192,7 @@ func (s *Service) rejectInvalidIndexInSubCommittee(m *ethpb.SignedContributionAn
 			}
 		}
 		if !isValid {
-			return pubsub.ValidationReject, errors.New("invalid subcommittee index")
+			return pubsub.ValidationReject, withReason(reasonNotInCommittee, errors.New("invalid subcommittee index"))
 		}
 		return pubsub.ValidationAccept, nil
 	}
@@ -205,7 +205,7 @@ func (s *Service) rejectInvalidSelectionProof(m *ethpb.SignedContributionAndProo
 		// The `contribution_and_proof.selection_proof` is a valid signature of the `SyncAggregatorSelectionData`.
 		if err := s.verifySyncSelectionData(ctx, m.Message); err != nil {
 			tracing.AnnotateError(span, err)
-			return pubsub.ValidationReject, err
+			return pubsub.ValidationReject, withReason(reasonInvalidSignature, err)
 		}
 		return pubsub.ValidationAccept, nil
 	}
@@ -219,21 +219,21 @@ func (s *Service) rejectInvalidContributionSignature(m *ethpb.SignedContribution
 		d, err := s.cfg.chain.HeadSyncContributionProofDomain(ctx, m.Message.Contribution.Slot)
 		if err != nil {
 			tracing.AnnotateError(span, err)
-			return pubsub.ValidationIgnore, err
+			return pubsub.ValidationIgnore, withReason(reasonInternalError, err)
 		}
 		pubkey, err := s.cfg.chain.HeadValidatorIndexToPublicKey(ctx, m.Message.AggregatorIndex)
 		if err != nil {
-			return pubsub.ValidationIgnore, err
+			return pubsub.ValidationIgnore, withReason(reasonStateUnavailable, err)
 		}
 		publicKey, err := bls.PublicKeyFromBytes(pubkey[:])
 		if err != nil {
 			tracing.AnnotateError(span, err)
-			return pubsub.ValidationReject, err
+			return pubsub.ValidationReject, withReason(reasonMalformed, err)
 		}
 		root, err := signing.ComputeSigningRoot(m.Message, d)
 		if err != nil {
 			tracing.AnnotateError(span, err)
-			return pubsub.ValidationReject, err
+			return pubsub.ValidationReject, withReason(reasonInternalError, err)
 		}
 		set := &bls.SignatureBatch{
 			Messages:     [][32]byte{root},
@@ -254,13 +254,13 @@ func (s *Service) rejectInvalidSyncAggregateSignature(m *ethpb.SignedContributio
 		var activeRawPubkeys [][]byte
 		syncPubkeys, err := s.cfg.chain.HeadSyncCommitteePubKeys(ctx, m.Message.Contribution.Slot, primitives.CommitteeIndex(m.Message.Contribution.SubcommitteeIndex))
 		if err != nil {
-			return pubsub.ValidationIgnore, err
+			return pubsub.ValidationIgnore, withReason(reasonStateUnavailable, err)
 		}
 		bVector := m.Message.Contribution.AggregationBits
 		// In the event no bit is set for the
 		// sync contribution, we reject the message.
 		if bVector.Count() == 0 {
-			return pubsub.ValidationReject, errors.New("bitvector count is 0")
+			return pubsub.ValidationReject, withReason(reasonInvalidAggregationBits, errors.New("bitvector count is 0"))
 		}
 		for i, pk := range syncPubkeys {
 			if bVector.BitAt(uint64(i)) {
@@ -270,20 +270,20 @@ func (s *Service) rejectInvalidSyncAggregateSignature(m *ethpb.SignedContributio
 		d, err := s.cfg.chain.HeadSyncCommitteeDomain(ctx, m.Message.Contribution.Slot)
 		if err != nil {
 			tracing.AnnotateError(span, err)
-			return pubsub.ValidationIgnore, err
+			return pubsub.ValidationIgnore, withReason(reasonInternalError, err)
 		}
 		rawBytes := p2ptypes.SSZBytes(m.Message.Contribution.BlockRoot)
 		sigRoot, err := signing.ComputeSigningRoot(&rawBytes, d)
 		if err != nil {
 			tracing.AnnotateError(span, err)
-			return pubsub.ValidationIgnore, err
+			return pubsub.ValidationIgnore, withReason(reasonInternalError, err)
 		}
 		// Aggregate pubkeys separately again to allow
 		// for signature sets to be created for batch verification.
 		aggKey, err := bls.AggregatePublicKeys(activeRawPubkeys)
 		if err != nil {
 			tracing.AnnotateError(span, err)
-			return pubsub.ValidationIgnore, err
+			return pubsub.ValidationIgnore, withReason(reasonMalformed, err)
 		}
 		set := &bls.SignatureBatch{
 			Messages:     [][32]byte{sigRoot},
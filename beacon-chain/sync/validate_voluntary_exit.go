@@ -26,7 +26,7 @@ func (s *Service) validateVoluntaryExit(ctx context.Context, pid peer.ID, msg *p
 
 	// The head state will be too far away to validate any voluntary exit.
 	if s.cfg.initialSync.Syncing() {
-		return pubsub.ValidationIgnore, nil
+		return pubsub.ValidationIgnore, withReason(reasonSyncing, nil)
 	}
 
 	ctx, span := trace.StartSpan(ctx, "sync.validateVoluntaryExit")
@@ -35,35 +35,35 @@ func (s *Service) validateVoluntaryExit(ctx context.Context, pid peer.ID, msg *p
 	m, err := s.decodePubsubMessage(msg)
 	if err != nil {
 		tracing.AnnotateError(span, err)
-		return pubsub.ValidationReject, err
+		return pubsub.ValidationReject, withReason(reasonDecodeFailed, err)
 	}
 
 	exit, ok := m.(*ethpb.SignedVoluntaryExit)
 	if !ok {
-		return pubsub.ValidationReject, errWrongMessage
+		return pubsub.ValidationReject, withReason(reasonWrongMessageType, errWrongMessage)
 	}
 
 	if exit.Exit == nil {
-		return pubsub.ValidationReject, errNilMessage
+		return pubsub.ValidationReject, withReason(reasonMalformed, errNilMessage)
 	}
 	if s.hasSeenExitIndex(exit.Exit.ValidatorIndex) {
-		return pubsub.ValidationIgnore, nil
+		return pubsub.ValidationIgnore, withReason(reasonAlreadySeen, nil)
 	}
 
 	headState, err := s.cfg.chain.HeadStateReadOnly(ctx)
 	if err != nil {
-		return pubsub.ValidationIgnore, err
+		return pubsub.ValidationIgnore, withReason(reasonStateUnavailable, err)
 	}
 
 	if uint64(exit.Exit.ValidatorIndex) >= uint64(headState.NumValidators()) {
-		return pubsub.ValidationReject, errors.New("validator index is invalid")
+		return pubsub.ValidationReject, withReason(reasonInvalidOperation, errors.New("validator index is invalid"))
 	}
 	val, err := headState.ValidatorAtIndexReadOnly(exit.Exit.ValidatorIndex)
 	if err != nil {
-		return pubsub.ValidationIgnore, err
+		return pubsub.ValidationIgnore, withReason(reasonInternalError, err)
 	}
 	if err := blocks.VerifyExitAndSignature(val, headState, exit); err != nil {
-		return pubsub.ValidationReject, err
+		return pubsub.ValidationReject, withReason(reasonInvalidOperation, err)
 	}
 
 	msg.ValidatorData = exit // Used in downstream subscriber
@@ -31,6 +31,39 @@ func FlagOptions(c *cli.Context) ([]execution.Option, error) {
 	if len(jwtSecret) > 0 {
 		opts = append(opts, execution.WithHttpEndpointAndJWTSecret(endpoint, jwtSecret))
 	}
+	if dir := c.String(flags.ExecutionEngineJournalDir.Name); dir != "" {
+		opts = append(opts, execution.WithEngineJournal(dir))
+	}
+	backupOpts, err := parseBackupExecutionEndpoints(c, jwtSecret)
+	if err != nil {
+		return nil, err
+	}
+	return append(opts, backupOpts...), nil
+}
+
+// backupJWTSecretSeparator separates a backup execution endpoint from the path of its JWT secret file.
+// An explicit separator is used, as endpoint URLs may contain query strings.
+const backupJWTSecretSeparator = ";jwt-secret="
+
+// Parses the backup execution endpoints, given as endpoint;jwt-secret=path with the path of their JWT secret file.
+// The JWT secret of the primary execution endpoint is used when the path is omitted.
+func parseBackupExecutionEndpoints(c *cli.Context, primarySecret []byte) ([]execution.Option, error) {
+	var opts []execution.Option
+	for _, v := range c.StringSlice(flags.ExecutionEngineBackupEndpoints.Name) {
+		endpoint, secret := v, primarySecret
+		if e, path, ok := strings.Cut(v, backupJWTSecretSeparator); ok {
+			var err error
+			endpoint = e
+			secret, err = readJWTSecret(path)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not read JWT secret file for backup execution endpoint %s", endpoint)
+			}
+		}
+		if endpoint == "" {
+			return nil, fmt.Errorf("invalid backup execution endpoint %q", v)
+		}
+		opts = append(opts, execution.WithBackupHttpEndpoint(endpoint, secret))
+	}
 	return opts, nil
 }
 
@@ -47,6 +80,10 @@ func parseJWTSecretFromFile(c *cli.Context) ([]byte, error) {
 	if jwtSecretFile == "" {
 		return nil, nil
 	}
+	return readJWTSecret(jwtSecretFile)
+}
+
+func readJWTSecret(jwtSecretFile string) ([]byte, error) {
 	enc, err := file.ReadFileAsBytes(jwtSecretFile)
 	if err != nil {
 		return nil, err
@@ -99,3 +99,28 @@ func TestPowchainPreregistration_EmptyWeb3Provider(t *testing.T) {
 	_, err := parseExecutionChainEndpoint(ctx)
 	assert.ErrorContains(t, "you need to specify", err)
 }
+
+func Test_parseBackupExecutionEndpoints(t *testing.T) {
+	secretPath := filepath.Join(t.TempDir(), "backuphex")
+	require.NoError(t, file.WriteFile(secretPath, []byte(fmt.Sprintf("%#x", bytesutil.PadTo([]byte("bar"), 32)))))
+
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.Var(cli.NewStringSlice("http://backup1:8551;jwt-secret="+secretPath, "http://backup2:8551/?token=abc"), flags.ExecutionEngineBackupEndpoints.Name, "")
+	ctx := cli.NewContext(&app, set, nil)
+	opts, err := parseBackupExecutionEndpoints(ctx, []byte("primary"))
+	require.NoError(t, err)
+	require.Equal(t, 2, len(opts))
+
+	set = flag.NewFlagSet("test", 0)
+	set.Var(cli.NewStringSlice("http://backup1:8551/?a=b;jwt-secret=/tmp/askdjkajsd"), flags.ExecutionEngineBackupEndpoints.Name, "")
+	ctx = cli.NewContext(&app, set, nil)
+	_, err = parseBackupExecutionEndpoints(ctx, nil)
+	require.ErrorContains(t, "could not read JWT secret file for backup execution endpoint http://backup1:8551/?a=b", err)
+
+	set = flag.NewFlagSet("test", 0)
+	set.Var(cli.NewStringSlice(";jwt-secret="+secretPath), flags.ExecutionEngineBackupEndpoints.Name, "")
+	ctx = cli.NewContext(&app, set, nil)
+	_, err = parseBackupExecutionEndpoints(ctx, nil)
+	require.ErrorContains(t, "invalid backup execution endpoint", err)
+}
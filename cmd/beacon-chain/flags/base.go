@@ -4,6 +4,7 @@ package flags
 
 import (
 	"strings"
+	"time"
 
 	"github.com/prysmaticlabs/prysm/v5/cmd"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
@@ -28,6 +29,12 @@ var (
 		Usage: "A MEV builder relay string http endpoint, this will be used to interact MEV builder network using API defined in: https://ethereum.github.io/builder-specs/#/Builder",
 		Value: "",
 	}
+	// MevRelayEndpoints provides HTTP access endpoints to additional MEV relays.
+	MevRelayEndpoints = &cli.StringSliceFlag{
+		Name: "http-mev-relays",
+		Usage: "Additional MEV builder relay http endpoints. Headers are requested from all the relays in parallel " +
+			"and the bid of the highest value is used. Can be used multiple times.",
+	}
 	MaxBuilderConsecutiveMissedSlots = &cli.IntFlag{
 		Name:  "max-builder-consecutive-missed-slots",
 		Usage: "Number of consecutive skip slot to fallback from using relay/builder to local execution engine for block construction",
@@ -68,6 +75,22 @@ var (
 		Usage: "An execution client http endpoint. Can contain auth header as well in the format",
 		Value: "http://localhost:8551",
 	}
+	// ExecutionEngineBackupEndpoints provides execution client endpoints to which engine API calls are fanned out,
+	// and whose responses are used when the primary execution endpoint times out or is syncing.
+	ExecutionEngineBackupEndpoints = &cli.StringSliceFlag{
+		Name: "execution-backup-endpoint",
+		Usage: "A backup execution client http endpoint, with the path to its JWT secret file in the format " +
+			"endpoint;jwt-secret=path. The file of --jwt-secret is used when the path is omitted. Forkchoice updates and " +
+			"new payloads are sent to every endpoint, and the responses of backup endpoints are used when the " +
+			"primary endpoint times out or is syncing. Can be used multiple times.",
+	}
+	// ExecutionEngineJournalDir enables the journal of engine API calls.
+	ExecutionEngineJournalDir = &cli.StringFlag{
+		Name: "execution-journal-dir",
+		Usage: "Directory in which every engine API call made to the execution endpoints is recorded, with its " +
+			"response and latency, in rotating files. The journal can be replayed against another execution client " +
+			"with tools/engine-replay.",
+	}
 	// ExecutionEngineHeaders defines a list of HTTP headers to send with all execution client requests.
 	ExecutionEngineHeaders = &cli.StringFlag{
 		Name: "execution-headers",
@@ -180,12 +203,43 @@ var (
 		Usage: "The slot durations of when an archived state gets saved in the beaconDB.",
 		Value: 2048,
 	}
+	// SlotTimingsRetention specifies the number of slots for which block and blob timings are kept.
+	SlotTimingsRetention = &cli.Uint64Flag{
+		Name:  "slot-timings-retention",
+		Usage: "The number of recent slots for which block and blob arrival and processing timings are kept in the beacon DB for the slot timings API. Set to 0 to disable.",
+		Value: 7200,
+	}
+	// BuilderBidsRetention specifies the number of slots for which the builder bids of the proposals are kept.
+	BuilderBidsRetention = &cli.Uint64Flag{
+		Name:  "builder-bids-retention",
+		Usage: "The number of recent slots for which the builder bids received for the proposals of the node, and the payload selected, are kept in the database for the builder bids API. Set to 0 to disable.",
+		Value: 50400,
+	}
 	// BlockBatchLimit specifies the requested block batch size.
 	BlockBatchLimit = &cli.IntFlag{
 		Name:  "block-batch-limit",
 		Usage: "The amount of blocks the local peer is bounded to request and respond to in a batch. Maximum 128",
 		Value: 64,
 	}
+	// InitialSyncArchiveDir specifies a directory of finalized blocks imported by initial sync before syncing from peers.
+	InitialSyncArchiveDir = &cli.StringFlag{
+		Name: "initial-sync-archive-dir",
+		Usage: "Directory of finalized blocks, as ssz encoded signed blocks in .ssz files or as .era files, which " +
+			"initial sync imports before syncing the remaining blocks from peers.",
+	}
+	// SyncFallbackAPIURLs specifies trusted beacon node apis used to fetch blocks and blobs when peers fail to serve them.
+	SyncFallbackAPIURLs = &cli.StringSliceFlag{
+		Name: "sync-fallback-api-url",
+		Usage: "URL of a trusted beacon node api used by initial sync and the pending block queue to fetch blocks " +
+			"and blobs when fetching them from peers has been failing for sync-fallback-stall-period. " +
+			"The data is verified like data received from peers. Can be used multiple times, urls are tried in order.",
+	}
+	// SyncFallbackStallPeriod specifies how long fetching from peers must fail before the fallback apis are used.
+	SyncFallbackStallPeriod = &cli.DurationFlag{
+		Name:  "sync-fallback-stall-period",
+		Usage: "How long fetching blocks from peers must fail before sync-fallback-api-url is used.",
+		Value: 5 * time.Minute,
+	}
 	// BlockBatchLimitBurstFactor specifies the factor by which block batch size may increase.
 	BlockBatchLimitBurstFactor = &cli.IntFlag{
 		Name:  "block-batch-limit-burst-factor",
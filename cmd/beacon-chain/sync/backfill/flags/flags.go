@@ -5,8 +5,9 @@ import (
 )
 
 var (
-	backfillBatchSizeName   = "backfill-batch-size"
-	backfillWorkerCountName = "backfill-worker-count"
+	backfillBatchSizeName       = "backfill-batch-size"
+	backfillWorkerCountName     = "backfill-worker-count"
+	backfillBlobFallbackURLName = "backfill-blob-fallback-url"
 
 	// EnableExperimentalBackfill enables backfill for checkpoint synced nodes.
 	// This flag will be removed once backfill is enabled by default.
@@ -40,4 +41,17 @@ var (
 		Usage: "Specifies the oldest slot that backfill should download. " +
 			"If this value is greater than current_slot - MIN_EPOCHS_FOR_BLOCK_REQUESTS, it will be ignored with a warning log.",
 	}
+	// BackfillArchiveBlobs makes backfill download blobs older than the blob retention period, and keeps them in blob storage.
+	BackfillArchiveBlobs = &cli.BoolFlag{
+		Name: "backfill-archive-blobs",
+		Usage: "Backfill downloads and verifies the blob sidecars of all backfilled blocks, including those older than " +
+			"MIN_EPOCHS_FOR_BLOB_SIDECARS_REQUESTS, and the blob pruner keeps the blobs older than the checkpoint sync origin. " +
+			"Peers are not required to serve these blobs, see " + backfillBlobFallbackURLName + ".",
+	}
+	// BackfillBlobFallbackURL sets a beacon node api used to download the blobs which peers do not serve.
+	BackfillBlobFallbackURL = &cli.StringFlag{
+		Name: backfillBlobFallbackURLName,
+		Usage: "URL of a beacon node api used by backfill to download blob sidecars which peers fail to serve, " +
+			"e.g. blobs older than the retention period when backfill-archive-blobs is set.",
+	}
 )
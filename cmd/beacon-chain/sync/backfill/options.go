@@ -1,6 +1,7 @@
 package backfill
 
 import (
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/node"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/backfill"
 	"github.com/prysmaticlabs/prysm/v5/cmd/beacon-chain/sync/backfill/flags"
@@ -22,8 +23,18 @@ func BeaconNodeOptions(c *cli.Context) ([]node.Option, error) {
 			uv := c.Uint64(flags.BackfillOldestSlot.Name)
 			bno = append(bno, backfill.WithMinimumSlot(primitives.Slot(uv)))
 		}
+		if c.Bool(flags.BackfillArchiveBlobs.Name) {
+			bno = append(bno, backfill.WithBlobArchive())
+		}
+		if c.IsSet(flags.BackfillBlobFallbackURL.Name) {
+			bno = append(bno, backfill.WithBlobFallback(c.String(flags.BackfillBlobFallbackURL.Name)))
+		}
 		node.BackfillOpts = bno
 		return nil
 	}
-	return []node.Option{opt}, nil
+	opts := []node.Option{opt}
+	if c.Bool(flags.BackfillArchiveBlobs.Name) {
+		opts = append(opts, node.WithBlobStorageOptions(filesystem.WithArchive()))
+	}
+	return opts, nil
 }
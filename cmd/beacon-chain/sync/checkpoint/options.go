@@ -22,12 +22,26 @@ var (
 		Usage: "Rather than syncing from genesis, you can start processing from a ssz-serialized BeaconState+Block." +
 			" This flag allows you to specify a local file containing the checkpoint Block to load.",
 	}
-	RemoteURL = &cli.StringFlag{
+	RemoteURL = &cli.StringSliceFlag{
 		Name: "checkpoint-sync-url",
 		Usage: "URL of a synced beacon node to trust in obtaining checkpoint sync data. " +
+			"The flag can be repeated to use several providers, which must agree on the finalized checkpoint (see --checkpoint-sync-quorum). " +
 			"As an additional safety measure, it is strongly recommended to only use this option in conjunction with " +
 			"--weak-subjectivity-checkpoint flag",
 	}
+	// Quorum defines the number of checkpoint sync providers which must agree on the finalized checkpoint.
+	Quorum = &cli.IntFlag{
+		Name: "checkpoint-sync-quorum",
+		Usage: "Number of --checkpoint-sync-url providers which must agree on the finalized checkpoint " +
+			"before it is used. 0 requires all providers to agree. Providers are compared at the latest epoch " +
+			"finalized by the quorum, so providers which finalized later epochs still agree with lagging ones.",
+		Value: 0,
+	}
+	// BlobSidecars enables downloading the blob sidecars of the checkpoint block.
+	BlobSidecars = &cli.BoolFlag{
+		Name:  "checkpoint-sync-blobs",
+		Usage: "Downloads and verifies the blob sidecars of the checkpoint block when using --checkpoint-sync-url.",
+	}
 )
 
 // BeaconNodeOptions is responsible for determining if the checkpoint sync options have been used, and if so,
@@ -36,11 +50,15 @@ var (
 func BeaconNodeOptions(c *cli.Context) ([]node.Option, error) {
 	blockPath := c.Path(BlockPath.Name)
 	statePath := c.Path(StatePath.Name)
-	remoteURL := c.String(RemoteURL.Name)
-	if remoteURL != "" {
+	remoteURLs := c.StringSlice(RemoteURL.Name)
+	if len(remoteURLs) > 0 {
+		opts := []checkpoint.APIInitializerOption{checkpoint.WithQuorum(c.Int(Quorum.Name))}
+		if c.Bool(BlobSidecars.Name) {
+			opts = append(opts, checkpoint.WithBlobSidecars())
+		}
 		opt := func(node *node.BeaconNode) error {
 			var err error
-			node.CheckpointInitializer, err = checkpoint.NewAPIInitializer(remoteURL)
+			node.CheckpointInitializer, err = checkpoint.NewAPIInitializer(remoteURLs, opts...)
 			if err != nil {
 				return errors.Wrap(err, "error while constructing beacon node api client for checkpoint sync")
 			}
@@ -107,12 +107,19 @@ var appHelpFlagGroups = []flagGroup{
 			flags.HTTPServerPort,
 			flags.HTTPServerCorsDomain,
 			flags.ExecutionEngineEndpoint,
+			flags.ExecutionEngineBackupEndpoints,
+			flags.ExecutionEngineJournalDir,
 			flags.ExecutionEngineHeaders,
 			flags.ExecutionJWTSecretFlag,
 			flags.SetGCPercent,
 			flags.SlotsPerArchivedPoint,
+			flags.SlotTimingsRetention,
+			flags.BuilderBidsRetention,
 			flags.BlockBatchLimit,
 			flags.BlockBatchLimitBurstFactor,
+			flags.InitialSyncArchiveDir,
+			flags.SyncFallbackAPIURLs,
+			flags.SyncFallbackStallPeriod,
 			flags.BlobBatchLimit,
 			flags.BlobBatchLimitBurstFactor,
 			flags.DisableDebugRPCEndpoints,
@@ -125,6 +132,7 @@ var appHelpFlagGroups = []flagGroup{
 			flags.MinPeersPerSubnet,
 			flags.MaxConcurrentDials,
 			flags.MevRelayEndpoint,
+			flags.MevRelayEndpoints,
 			flags.MaxBuilderEpochMissedSlots,
 			flags.MaxBuilderConsecutiveMissedSlots,
 			flags.EngineEndpointTimeoutSeconds,
@@ -138,6 +146,8 @@ var appHelpFlagGroups = []flagGroup{
 			checkpoint.BlockPath,
 			checkpoint.StatePath,
 			checkpoint.RemoteURL,
+			checkpoint.Quorum,
+			checkpoint.BlobSidecars,
 			genesis.StatePath,
 			genesis.BeaconAPIURL,
 			storage.BlobStoragePathFlag,
@@ -147,6 +157,8 @@ var appHelpFlagGroups = []flagGroup{
 			backfill.BackfillWorkerCount,
 			backfill.BackfillBatchSize,
 			backfill.BackfillOldestSlot,
+			backfill.BackfillArchiveBlobs,
+			backfill.BackfillBlobFallbackURL,
 		},
 	},
 	{
@@ -170,6 +182,8 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.P2PMetadata,
 			cmd.P2PAllowList,
 			cmd.P2PDenyList,
+			cmd.P2PBanFile,
+			cmd.P2PPeerAllowList,
 			cmd.PubsubQueueSize,
 			cmd.StaticPeers,
 			cmd.EnableUPnPFlag,
@@ -186,6 +186,20 @@ var (
 			"192.168.0.0/16 would deny connections from peers on your local network only. The " +
 			"default is to accept all connections.",
 	}
+	// P2PBanFile defines a file containing peer bans by peer ID or agent version.
+	P2PBanFile = &cli.StringFlag{
+		Name: "p2p-ban-file",
+		Usage: "The JSON file containing a list of peer bans. Each entry bans either a \"peer_id\" or all peers " +
+			"whose agent version matches an \"agent\" regular expression, with an optional \"reason\" and \"expiry\". " +
+			"The file is reloaded whenever it changes.",
+	}
+	// P2PPeerAllowList defines a file containing the only peers the node may connect to.
+	P2PPeerAllowList = &cli.StringFlag{
+		Name: "p2p-peer-allowlist",
+		Usage: "The file containing the only peers the node is allowed to connect to, one per line, as a peer ID, " +
+			"multiaddr, ENR or hex node ID. Setting this disables discovery, and the node keeps connected to all " +
+			"listed peers with a known address. The file is reloaded whenever it changes.",
+	}
 	PubsubQueueSize = &cli.IntFlag{
 		Name:  "pubsub-queue-size",
 		Usage: "The size of the pubsub validation and outbound queue for the node.",
@@ -0,0 +1,13 @@
+package forkchoice
+
+import "github.com/urfave/cli/v2"
+
+var Commands = []*cli.Command{
+	{
+		Name:  "forkchoice",
+		Usage: "commands to analyze the fork choice of the beacon chain",
+		Subcommands: []*cli.Command{
+			simulateCmd,
+		},
+	},
+}
@@ -0,0 +1,106 @@
+package forkchoice
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+const (
+	formatJSON = "json"
+	formatDOT  = "dot"
+)
+
+// slotRecord is the outcome of fork choice during a simulated slot.
+type slotRecord struct {
+	Slot              primitives.Slot `json:"slot"`
+	Blocks            []*blockRecord  `json:"blocks,omitempty"`
+	Head              string          `json:"head"`
+	HeadSlot          primitives.Slot `json:"head_slot"`
+	ProposerBoostRoot string          `json:"proposer_boost_root"`
+	ProposerHead      string          `json:"proposer_head"`
+	LateBlockReorg    bool            `json:"late_block_reorg"`
+	OverrideFCU       bool            `json:"override_fcu"`
+	Reorg             bool            `json:"reorg"`
+	ReorgDepth        uint64          `json:"reorg_depth,omitempty"`
+	Error             string          `json:"error,omitempty"`
+}
+
+// blockRecord is a block received during a simulated slot. The arrival is relative to the start of the block's slot.
+type blockRecord struct {
+	Slot       primitives.Slot `json:"slot"`
+	Root       string          `json:"root"`
+	ParentRoot string          `json:"parent_root"`
+	Arrival    string          `json:"arrival"`
+	Error      string          `json:"error,omitempty"`
+}
+
+// simulationResult is the output of a simulation: the head at every slot and the final fork choice store.
+type simulationResult struct {
+	Slots      []*slotRecord                      `json:"slots"`
+	ForkChoice *structs.GetForkChoiceDumpResponse `json:"fork_choice"`
+}
+
+func writeResult(w io.Writer, format string, res *simulationResult) error {
+	switch format {
+	case formatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(res)
+	case formatDOT:
+		return writeDOT(w, res)
+	default:
+		return errors.Errorf("unknown output format %q", format)
+	}
+}
+
+// writeDOT renders the fork choice tree as a graphviz digraph. Nodes show their slot, root and weight,
+// the blocks that were head at the end of a slot are highlighted and the final head is filled.
+func writeDOT(w io.Writer, res *simulationResult) error {
+	heads := make(map[string]int)
+	for _, r := range res.Slots {
+		heads[r.Head]++
+	}
+	known := make(map[string]bool, len(res.ForkChoice.ForkChoiceNodes))
+	for _, n := range res.ForkChoice.ForkChoiceNodes {
+		known[n.BlockRoot] = true
+	}
+	if _, err := fmt.Fprintln(w, "digraph forkchoice {\n\trankdir=RL;\n\tnode [shape=box];"); err != nil {
+		return err
+	}
+	for _, n := range res.ForkChoice.ForkChoiceNodes {
+		label := fmt.Sprintf("slot %s\\n%s\\nweight %s", n.Slot, shortRoot(n.BlockRoot), n.Weight)
+		attrs := ""
+		if count := heads[n.BlockRoot]; count > 0 {
+			label += fmt.Sprintf("\\nhead at %d slots", count)
+			attrs = `, color="blue"`
+		}
+		if n.BlockRoot == res.ForkChoice.ExtraData.HeadRoot {
+			attrs = `, color="blue", style="filled", fillcolor="lightblue"`
+		}
+		if _, err := fmt.Fprintf(w, "\t\"%s\" [label=\"%s\"%s];\n", n.BlockRoot, label, attrs); err != nil {
+			return err
+		}
+	}
+	for _, n := range res.ForkChoice.ForkChoiceNodes {
+		if !known[n.ParentRoot] {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "\t\"%s\" -> \"%s\";\n", n.BlockRoot, n.ParentRoot); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
+
+func shortRoot(root string) string {
+	if len(root) > 10 {
+		return root[:10]
+	}
+	return root
+}
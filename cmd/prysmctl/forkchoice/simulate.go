@@ -0,0 +1,280 @@
+package forkchoice
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	simulateFlags = struct {
+		DataDir                         string
+		StartSlot                       uint64
+		EndSlot                         uint64
+		CaptureFile                     string
+		BlockArrival                    time.Duration
+		BlockArrivalOverrides           cli.StringSlice
+		AttestationArrival              time.Duration
+		ProposerScoreBoost              uint64
+		ReorgHeadWeightThreshold        uint64
+		ReorgParentWeightThreshold      uint64
+		ReorgMaxEpochsSinceFinalization uint64
+		DisableReorgLateBlocks          bool
+		ChainConfigFile                 string
+		Format                          string
+		Output                          string
+	}{}
+	log = logrus.WithField("prefix", "forkchoice")
+
+	simulateCmd = &cli.Command{
+		Name:  "simulate",
+		Usage: "Replay the blocks and attestations of a slot range through fork choice and output the head at every slot",
+		Description: `Loads the blocks of a slot range from a beacon node database, or from a gossip capture, and feeds them
+into an in-memory fork choice store at configurable arrival times. Fork choice is initialized from the finalized
+checkpoint before the start slot. The output contains, for every slot, the head and whether the proposer of the slot
+would have reorged a late block, followed by the final fork choice tree.
+
+A gossip capture is a file of json lines, one per received object:
+{"time": "2024-10-01T12:00:01.2Z", "type": "block|attestation|single_attestation", "ssz": "0x..."}`,
+		Action: func(cliCtx *cli.Context) error {
+			if err := cliActionSimulate(cliCtx); err != nil {
+				log.WithError(err).Fatal("Could not simulate fork choice")
+			}
+			return nil
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "datadir",
+				Usage:       "Data directory of the beacon node, containing the beaconchaindata directory",
+				Destination: &simulateFlags.DataDir,
+				Required:    true,
+			},
+			&cli.Uint64Flag{
+				Name:        "start-slot",
+				Usage:       "First slot to report",
+				Destination: &simulateFlags.StartSlot,
+				Required:    true,
+			},
+			&cli.Uint64Flag{
+				Name:        "end-slot",
+				Usage:       "Last slot to simulate",
+				Destination: &simulateFlags.EndSlot,
+				Required:    true,
+			},
+			&cli.StringFlag{
+				Name:        "capture",
+				Usage:       "Path to a gossip capture providing the blocks and attestations from the start slot, instead of the database",
+				Destination: &simulateFlags.CaptureFile,
+			},
+			&cli.DurationFlag{
+				Name:        "block-arrival",
+				Usage:       "Time after the start of the slot at which blocks from the database are received",
+				Destination: &simulateFlags.BlockArrival,
+				Value:       time.Second,
+			},
+			&cli.StringSliceFlag{
+				Name:        "block-arrival-override",
+				Usage:       "Arrival time of the block of a given slot, as slot=duration (e.g. 1234=4.5s). Can be repeated",
+				Destination: &simulateFlags.BlockArrivalOverrides,
+			},
+			&cli.DurationFlag{
+				Name:        "attestation-arrival",
+				Usage:       "Time after the start of the slot at which attestations from the database are received",
+				Destination: &simulateFlags.AttestationArrival,
+				Value:       4 * time.Second,
+			},
+			&cli.Uint64Flag{
+				Name:        "proposer-score-boost",
+				Usage:       "Overrides PROPOSER_SCORE_BOOST, in percent of the committee weight",
+				Destination: &simulateFlags.ProposerScoreBoost,
+			},
+			&cli.Uint64Flag{
+				Name:        "reorg-head-weight-threshold",
+				Usage:       "Overrides REORG_HEAD_WEIGHT_THRESHOLD, in percent of the committee weight",
+				Destination: &simulateFlags.ReorgHeadWeightThreshold,
+			},
+			&cli.Uint64Flag{
+				Name:        "reorg-parent-weight-threshold",
+				Usage:       "Overrides REORG_PARENT_WEIGHT_THRESHOLD, in percent of the committee weight",
+				Destination: &simulateFlags.ReorgParentWeightThreshold,
+			},
+			&cli.Uint64Flag{
+				Name:        "reorg-max-epochs-since-finalization",
+				Usage:       "Overrides REORG_MAX_EPOCHS_SINCE_FINALIZATION",
+				Destination: &simulateFlags.ReorgMaxEpochsSinceFinalization,
+			},
+			&cli.BoolFlag{
+				Name:        "disable-reorg-late-blocks",
+				Usage:       "Simulate a node which never reorgs late blocks",
+				Destination: &simulateFlags.DisableReorgLateBlocks,
+			},
+			&cli.StringFlag{
+				Name:        "chain-config-file",
+				Usage:       "The path to a YAML file with chain config values, mainnet is used otherwise",
+				Destination: &simulateFlags.ChainConfigFile,
+			},
+			&cli.StringFlag{
+				Name:        "format",
+				Usage:       "Output format (json|dot)",
+				Destination: &simulateFlags.Format,
+				Value:       formatJSON,
+			},
+			&cli.StringFlag{
+				Name:        "output",
+				Usage:       "Output file, stdout by default",
+				Destination: &simulateFlags.Output,
+			},
+		},
+	}
+)
+
+func cliActionSimulate(cliCtx *cli.Context) error {
+	f := &simulateFlags
+	if f.Format != formatJSON && f.Format != formatDOT {
+		return errors.Errorf("unknown output format %q", f.Format)
+	}
+	if f.StartSlot == 0 {
+		return errors.New("start slot must be after genesis")
+	}
+	if f.EndSlot < f.StartSlot {
+		return errors.New("end slot must not be before start slot")
+	}
+	if err := setSimulationParams(cliCtx); err != nil {
+		return err
+	}
+	overrides, err := parseArrivalOverrides(f.BlockArrivalOverrides.Value())
+	if err != nil {
+		return err
+	}
+	cfg := &simulationConfig{
+		startSlot:              primitives.Slot(f.StartSlot),
+		endSlot:                primitives.Slot(f.EndSlot),
+		blockArrival:           f.BlockArrival,
+		blockArrivalOverrides:  overrides,
+		attestationArrival:     f.AttestationArrival,
+		disableReorgLateBlocks: f.DisableReorgLateBlocks,
+	}
+
+	ctx := cliCtx.Context
+	dbPath := filepath.Join(f.DataDir, kv.BeaconNodeDbDirName)
+	exists, err := file.HasDir(dbPath)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.Errorf("no beacon node database in %s", f.DataDir)
+	}
+	db, err := kv.NewKVStore(ctx, dbPath)
+	if err != nil {
+		return errors.Wrap(err, "could not open database")
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.WithError(err).Error("Could not close database")
+		}
+	}()
+
+	sim := newSimulator(db, cfg)
+	if err := sim.initialize(ctx); err != nil {
+		return err
+	}
+	dbEnd := cfg.endSlot
+	var captured []*event
+	if f.CaptureFile != "" {
+		captured, err = captureEvents(f.CaptureFile)
+		if err != nil {
+			return err
+		}
+		// The database only provides the blocks warming up fork choice before the start slot.
+		dbEnd = cfg.startSlot - 1
+	}
+	events, err := dbEvents(ctx, db, sim.genesisTime, sim.anchorSlot+1, dbEnd, cfg)
+	if err != nil {
+		return err
+	}
+	records, err := sim.run(ctx, append(events, captured...))
+	if err != nil {
+		return err
+	}
+	dump, err := sim.fc.ForkChoiceDump(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not dump fork choice")
+	}
+	res := &simulationResult{
+		Slots:      records,
+		ForkChoice: structs.ForkChoiceDumpFromConsensus(dump),
+	}
+
+	var w io.Writer = os.Stdout
+	if f.Output != "" {
+		fh, err := os.Create(f.Output)
+		if err != nil {
+			return errors.Wrap(err, "could not create output file")
+		}
+		defer func() {
+			if err := fh.Close(); err != nil {
+				log.WithError(err).Error("Could not close output file")
+			}
+		}()
+		w = fh
+	}
+	return writeResult(w, f.Format, res)
+}
+
+// setSimulationParams loads the chain config and applies the fork choice parameter overrides.
+func setSimulationParams(cliCtx *cli.Context) error {
+	f := &simulateFlags
+	if f.ChainConfigFile != "" {
+		if err := params.LoadChainConfigFile(f.ChainConfigFile, nil); err != nil {
+			return errors.Wrap(err, "could not load chain config file")
+		}
+	}
+	cfg := params.BeaconConfig().Copy()
+	if cliCtx.IsSet("proposer-score-boost") {
+		cfg.ProposerScoreBoost = f.ProposerScoreBoost
+	}
+	if cliCtx.IsSet("reorg-head-weight-threshold") {
+		cfg.ReorgHeadWeightThreshold = f.ReorgHeadWeightThreshold
+	}
+	if cliCtx.IsSet("reorg-parent-weight-threshold") {
+		cfg.ReorgParentWeightThreshold = f.ReorgParentWeightThreshold
+	}
+	if cliCtx.IsSet("reorg-max-epochs-since-finalization") {
+		cfg.ReorgMaxEpochsSinceFinalization = primitives.Epoch(f.ReorgMaxEpochsSinceFinalization)
+	}
+	params.OverrideBeaconConfig(cfg)
+	return nil
+}
+
+// parseArrivalOverrides parses block arrival overrides of the form slot=duration.
+func parseArrivalOverrides(values []string) (map[primitives.Slot]time.Duration, error) {
+	overrides := make(map[primitives.Slot]time.Duration, len(values))
+	for _, v := range values {
+		parts := strings.SplitN(v, "=", 2)
+		if len(parts) != 2 {
+			return nil, errors.Errorf("invalid block arrival override %q, expected slot=duration", v)
+		}
+		slot, err := strconv.ParseUint(parts[0], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid slot in block arrival override %q", v)
+		}
+		d, err := time.ParseDuration(parts[1])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid duration in block arrival override %q", v)
+		}
+		overrides[primitives.Slot(slot)] = d
+	}
+	return overrides, nil
+}
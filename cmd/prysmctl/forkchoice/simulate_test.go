@@ -0,0 +1,162 @@
+package forkchoice
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	dbtest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestParseArrivalOverrides(t *testing.T) {
+	overrides, err := parseArrivalOverrides([]string{"10=4.5s", "12=-200ms"})
+	require.NoError(t, err)
+	require.Equal(t, 4500*time.Millisecond, overrides[10])
+	require.Equal(t, -200*time.Millisecond, overrides[12])
+
+	cfg := &simulationConfig{blockArrival: time.Second, blockArrivalOverrides: overrides}
+	require.Equal(t, time.Second, cfg.arrival(11))
+	require.Equal(t, 4500*time.Millisecond, cfg.arrival(10))
+
+	_, err = parseArrivalOverrides([]string{"10"})
+	require.ErrorContains(t, "expected slot=duration", err)
+	_, err = parseArrivalOverrides([]string{"a=1s"})
+	require.ErrorContains(t, "invalid slot", err)
+	_, err = parseArrivalOverrides([]string{"10=soon"})
+	require.ErrorContains(t, "invalid duration", err)
+}
+
+func captureLine(t *testing.T, ts time.Time, typ string, obj interface{ MarshalSSZ() ([]byte, error) }) string {
+	enc, err := obj.MarshalSSZ()
+	require.NoError(t, err)
+	return fmt.Sprintf(`{"time": %q, "type": %q, "ssz": %q}`, ts.Format(time.RFC3339Nano), typ, hexutil.Encode(enc))
+}
+
+func TestCaptureEvents(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.ElectraForkEpoch = 2
+	params.OverrideBeaconConfig(cfg)
+
+	ts := time.Unix(1606824023, 0).UTC()
+	blk := util.NewBeaconBlock()
+	blk.Block.Slot = 3
+	att := util.HydrateAttestation(&ethpb.Attestation{AggregationBits: bitfield.NewBitlist(4), Data: &ethpb.AttestationData{Slot: 3}})
+	electraAtt := util.HydrateAttestationElectra(&ethpb.AttestationElectra{AggregationBits: bitfield.NewBitlist(4), Data: &ethpb.AttestationData{Slot: 2 * cfg.SlotsPerEpoch}})
+	single := &ethpb.SingleAttestation{
+		AttesterIndex: 7,
+		Data:          util.HydrateAttestationData(&ethpb.AttestationData{Slot: 2 * cfg.SlotsPerEpoch}),
+		Signature:     make([]byte, 96),
+	}
+	lines := []string{
+		captureLine(t, ts.Add(2*time.Second), captureTypeBlock, blk),
+		"",
+		captureLine(t, ts.Add(time.Second), captureTypeAttestation, att),
+		captureLine(t, ts.Add(3*time.Second), captureTypeAttestation, electraAtt),
+		captureLine(t, ts.Add(4*time.Second), captureTypeSingleAttestation, single),
+	}
+	path := filepath.Join(t.TempDir(), "capture.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0600))
+
+	events, err := captureEvents(path)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(events))
+	sortEvents(events)
+	require.Equal(t, primitives.Slot(3), events[0].att.GetData().Slot)
+	_, ok := events[0].att.(*ethpb.Attestation)
+	require.Equal(t, true, ok)
+	root, err := blk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.Equal(t, root, events[1].root)
+	require.Equal(t, ts.Add(2*time.Second), events[1].time.UTC())
+	_, ok = events[2].att.(*ethpb.AttestationElectra)
+	require.Equal(t, true, ok)
+	_, ok = events[3].att.(*ethpb.SingleAttestation)
+	require.Equal(t, true, ok)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"time": "2020-12-01T12:00:23Z", "type": "aggregate", "ssz": "0x00"}`), 0600))
+	_, err = captureEvents(path)
+	require.ErrorIs(t, err, errInvalidCapture)
+	require.ErrorContains(t, "line 1", err)
+}
+
+func TestDBEvents(t *testing.T) {
+	ctx := context.Background()
+	db, ok := dbtest.SetupDB(t).(*kv.Store)
+	require.Equal(t, true, ok)
+	for _, slot := range []primitives.Slot{1, 2, 3, 4} {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = slot
+		b.Block.Body.Attestations = []*ethpb.Attestation{util.HydrateAttestation(&ethpb.Attestation{AggregationBits: bitfield.NewBitlist(4), Data: &ethpb.AttestationData{Slot: slot - 1}})}
+		util.SaveBlock(t, ctx, db, b)
+	}
+	genesis := uint64(1606824023)
+	cfg := &simulationConfig{
+		blockArrival:          time.Second,
+		blockArrivalOverrides: map[primitives.Slot]time.Duration{2: 5 * time.Second},
+		attestationArrival:    4 * time.Second,
+	}
+
+	events, err := dbEvents(ctx, db, genesis, 1, 2, cfg)
+	require.NoError(t, err)
+	sortEvents(events)
+	secondsPerSlot := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	start := time.Unix(int64(genesis), 0)
+	// The blocks of slot 1 and 2, and the attestations for slot 1 and 2 included in the blocks of slot 2 and 3.
+	require.Equal(t, 4, len(events))
+	require.Equal(t, primitives.Slot(1), events[0].block.Block().Slot())
+	require.Equal(t, start.Add(secondsPerSlot+time.Second), events[0].time)
+	require.Equal(t, primitives.Slot(1), events[1].att.GetData().Slot)
+	require.Equal(t, start.Add(secondsPerSlot+4*time.Second), events[1].time)
+	require.Equal(t, primitives.Slot(2), events[2].att.GetData().Slot)
+	require.Equal(t, primitives.Slot(2), events[3].block.Block().Slot())
+	require.Equal(t, start.Add(2*secondsPerSlot+5*time.Second), events[3].time)
+}
+
+func TestWriteResult(t *testing.T) {
+	res := &simulationResult{
+		Slots: []*slotRecord{
+			{Slot: 1, Head: "0xaa", HeadSlot: 1},
+			{Slot: 2, Head: "0xbb", HeadSlot: 2, Reorg: true, ReorgDepth: 1},
+		},
+		ForkChoice: &structs.GetForkChoiceDumpResponse{
+			ForkChoiceNodes: []*structs.ForkChoiceNode{
+				{Slot: "0", BlockRoot: "0x00", ParentRoot: "0xff", Weight: "30"},
+				{Slot: "1", BlockRoot: "0xaa", ParentRoot: "0x00", Weight: "10"},
+				{Slot: "1", BlockRoot: "0xbb", ParentRoot: "0x00", Weight: "20"},
+			},
+			ExtraData: &structs.ForkChoiceDumpExtraData{HeadRoot: "0xbb"},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, writeResult(buf, formatDOT, res))
+	dot := buf.String()
+	require.Equal(t, true, strings.HasPrefix(dot, "digraph forkchoice {"))
+	require.StringContains(t, `"0xaa" [label="slot 1\n0xaa\nweight 10\nhead at 1 slots", color="blue"];`, dot)
+	require.StringContains(t, `"0xbb" [label="slot 1\n0xbb\nweight 20\nhead at 1 slots", color="blue", style="filled", fillcolor="lightblue"];`, dot)
+	require.StringContains(t, `"0xbb" -> "0x00";`, dot)
+	// The parent of the tree root is not in fork choice.
+	require.Equal(t, false, strings.Contains(dot, `-> "0xff"`))
+
+	buf.Reset()
+	require.NoError(t, writeResult(buf, formatJSON, res))
+	require.StringContains(t, `"reorg_depth": 1`, buf.String())
+	require.StringContains(t, `"head_root": "0xbb"`, buf.String())
+
+	require.ErrorContains(t, "unknown output format", writeResult(buf, "svg", res))
+}
@@ -0,0 +1,423 @@
+package forkchoice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	coreTime "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	consensusblocks "github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/attestation"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// reorgLateBlockCountAttestations is the time before the end of the slot at which the beacon node processes
+// attestations and decides whether to override the forkchoice update for a late block.
+const reorgLateBlockCountAttestations = 2 * time.Second
+
+var errUnknownParent = errors.New("parent block is not in fork choice")
+
+// simulationConfig holds the settings of a fork choice simulation.
+type simulationConfig struct {
+	startSlot              primitives.Slot
+	endSlot                primitives.Slot
+	blockArrival           time.Duration
+	blockArrivalOverrides  map[primitives.Slot]time.Duration
+	attestationArrival     time.Duration
+	disableReorgLateBlocks bool
+}
+
+// arrival returns the delay after the start of the slot at which the block of the slot is received.
+func (c *simulationConfig) arrival(slot primitives.Slot) time.Duration {
+	if d, ok := c.blockArrivalOverrides[slot]; ok {
+		return d
+	}
+	return c.blockArrival
+}
+
+// simulator replays blocks and attestations into an in-memory fork choice store, following the
+// timeline of the beacon node: attestations are processed and head is updated at the start of the slot,
+// when a block is received, and shortly before the end of the slot.
+type simulator struct {
+	cfg              *simulationConfig
+	db               *kv.Store
+	sg               *stategen.State
+	fc               *doublylinkedtree.ForkChoice
+	genesisTime      uint64
+	now              time.Time
+	anchorSlot       primitives.Slot
+	states           map[[32]byte]state.BeaconState
+	checkpointStates map[forkchoicetypes.Checkpoint]state.ReadOnlyBeaconState
+	pending          []ethpb.Att
+}
+
+func newSimulator(db *kv.Store, cfg *simulationConfig) *simulator {
+	s := &simulator{
+		cfg:              cfg,
+		db:               db,
+		fc:               doublylinkedtree.New(),
+		states:           make(map[[32]byte]state.BeaconState),
+		checkpointStates: make(map[forkchoicetypes.Checkpoint]state.ReadOnlyBeaconState),
+	}
+	s.sg = stategen.New(db, s.fc)
+	s.fc.SetBalancesByRooter(s.activeBalances)
+	s.fc.SetClock(func() time.Time { return s.now })
+	return s
+}
+
+// initialize inserts into fork choice the finalized checkpoint of the canonical block before the start slot.
+// Blocks between the anchor and the start slot are replayed to warm up votes and weights, but are not reported.
+func (s *simulator) initialize(ctx context.Context) error {
+	_, roots, err := s.db.HighestRootsBelowSlot(ctx, s.cfg.startSlot)
+	if err != nil {
+		return errors.Wrap(err, "could not get block before start slot")
+	}
+	if len(roots) == 0 {
+		return errors.Errorf("no block found before slot %d", s.cfg.startSlot)
+	}
+	st, err := s.sg.StateByRoot(ctx, roots[0])
+	if err != nil {
+		return errors.Wrapf(err, "could not get state of block %#x", roots[0])
+	}
+	cp := st.FinalizedCheckpoint()
+	fRoot := bytesutil.ToBytes32(cp.Root)
+	if fRoot == params.BeaconConfig().ZeroHash {
+		fRoot, err = s.db.GenesisBlockRoot(ctx)
+		if err != nil {
+			return errors.Wrap(err, "could not get genesis block root")
+		}
+	}
+	fState, err := s.sg.StateByRoot(ctx, fRoot)
+	if err != nil {
+		return errors.Wrapf(err, "could not get finalized state %#x", fRoot)
+	}
+	fBlock, err := s.db.Block(ctx, fRoot)
+	if err != nil {
+		return errors.Wrapf(err, "could not get finalized block %#x", fRoot)
+	}
+	if fBlock == nil || fBlock.IsNil() {
+		return errors.Errorf("finalized block %#x not found", fRoot)
+	}
+	roblock, err := consensusblocks.NewROBlockWithRoot(fBlock, fRoot)
+	if err != nil {
+		return err
+	}
+
+	s.genesisTime = fState.GenesisTime()
+	s.anchorSlot = fBlock.Block().Slot()
+	s.now = slots.StartTime(s.genesisTime, s.anchorSlot)
+	s.states[fRoot] = fState
+	fc := &forkchoicetypes.Checkpoint{Epoch: cp.Epoch, Root: fRoot}
+	if err := s.fc.UpdateJustifiedCheckpoint(ctx, fc); err != nil {
+		return errors.Wrap(err, "could not update justified checkpoint")
+	}
+	if err := s.fc.UpdateFinalizedCheckpoint(fc); err != nil {
+		return errors.Wrap(err, "could not update finalized checkpoint")
+	}
+	s.fc.SetGenesisTime(s.genesisTime)
+	if err := s.fc.InsertNode(ctx, fState, roblock); err != nil {
+		return errors.Wrap(err, "could not insert finalized block")
+	}
+	log.WithFields(logrus.Fields{
+		"root":  fmt.Sprintf("%#x", fRoot),
+		"slot":  s.anchorSlot,
+		"epoch": cp.Epoch,
+	}).Info("Initialized fork choice from finalized checkpoint")
+	return nil
+}
+
+// run simulates every slot from the anchor to the end slot, delivering the given events in order of arrival.
+func (s *simulator) run(ctx context.Context, events []*event) ([]*slotRecord, error) {
+	sortEvents(events)
+	secondsPerSlot := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	prevHead := s.fc.CachedHeadRoot()
+	var records []*slotRecord
+	for slot := s.anchorSlot + 1; slot <= s.cfg.endSlot; slot++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		rec := &slotRecord{Slot: slot}
+		start := slots.StartTime(s.genesisTime, slot)
+		events = s.deliver(ctx, rec, events, start.Add(-time.Nanosecond))
+
+		s.now = start
+		if err := s.fc.NewSlot(ctx, slot); err != nil {
+			return nil, errors.Wrapf(err, "could not process new slot %d", slot)
+		}
+		head := s.updateHead(ctx, rec)
+		proposerHead := head
+		if !s.cfg.disableReorgLateBlocks {
+			proposerHead = s.fc.GetProposerHead()
+		}
+		rec.ProposerHead = fmt.Sprintf("%#x", proposerHead)
+		rec.LateBlockReorg = proposerHead != head
+
+		events = s.deliver(ctx, rec, events, start.Add(secondsPerSlot-reorgLateBlockCountAttestations))
+		s.now = start.Add(secondsPerSlot - reorgLateBlockCountAttestations)
+		s.updateHead(ctx, rec)
+		rec.OverrideFCU = !s.cfg.disableReorgLateBlocks && s.fc.ShouldOverrideFCU()
+
+		events = s.deliver(ctx, rec, events, start.Add(secondsPerSlot-time.Nanosecond))
+		head = s.fc.CachedHeadRoot()
+		headSlot, err := s.fc.Slot(head)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get slot of head %#x", head)
+		}
+		rec.Head = fmt.Sprintf("%#x", head)
+		rec.HeadSlot = headSlot
+		rec.ProposerBoostRoot = fmt.Sprintf("%#x", s.fc.ProposerBoost())
+		if head != prevHead {
+			ancestor, ancestorSlot, err := s.fc.CommonAncestor(ctx, prevHead, head)
+			if err == nil && ancestor != prevHead {
+				prevSlot, err := s.fc.Slot(prevHead)
+				if err == nil {
+					rec.Reorg = true
+					rec.ReorgDepth = uint64(prevSlot - ancestorSlot)
+				}
+			}
+		}
+		prevHead = head
+		s.prune(slot)
+
+		if slot >= s.cfg.startSlot {
+			records = append(records, rec)
+			log.WithFields(logrus.Fields{
+				"slot":           slot,
+				"head":           rec.Head,
+				"headSlot":       headSlot,
+				"lateBlockReorg": rec.LateBlockReorg,
+				"reorg":          rec.Reorg,
+			}).Info("Simulated slot")
+		}
+	}
+	return records, nil
+}
+
+// deliver processes the events received until the given time and returns the remaining ones.
+// The blocks are reported in the record of the slot in which they are received.
+func (s *simulator) deliver(ctx context.Context, rec *slotRecord, events []*event, until time.Time) []*event {
+	for len(events) > 0 && !events[0].time.After(until) {
+		e := events[0]
+		events = events[1:]
+		if e.time.After(s.now) {
+			s.now = e.time
+		}
+		if e.att != nil {
+			s.pending = append(s.pending, e.att)
+			continue
+		}
+		slot := e.block.Block().Slot()
+		if slot <= s.anchorSlot || slot > s.cfg.endSlot || s.fc.HasNode(e.root) {
+			continue
+		}
+		br := &blockRecord{
+			Slot:       slot,
+			Root:       fmt.Sprintf("%#x", e.root),
+			ParentRoot: fmt.Sprintf("%#x", e.block.Block().ParentRoot()),
+			Arrival:    e.time.Sub(slots.StartTime(s.genesisTime, slot)).String(),
+		}
+		rec.Blocks = append(rec.Blocks, br)
+		if err := s.insertBlock(ctx, e.block, e.root); err != nil {
+			br.Error = err.Error()
+			log.WithError(err).WithFields(logrus.Fields{
+				"slot": slot,
+				"root": br.Root,
+			}).Warn("Could not insert block")
+			continue
+		}
+		s.updateHead(ctx, rec)
+	}
+	return events
+}
+
+// updateHead processes the pending attestations and computes head, the same way the beacon node does before
+// notifying the execution client.
+func (s *simulator) updateHead(ctx context.Context, rec *slotRecord) [32]byte {
+	s.processAttestations(ctx)
+	head, err := s.fc.Head(ctx)
+	if err != nil {
+		rec.Error = err.Error()
+		return s.fc.CachedHeadRoot()
+	}
+	return head
+}
+
+// insertBlock computes the post state of the block and inserts the block into fork choice, together with
+// the votes and slashings it contains.
+func (s *simulator) insertBlock(ctx context.Context, b interfaces.ReadOnlySignedBeaconBlock, root [32]byte) error {
+	parentRoot := b.Block().ParentRoot()
+	if !s.fc.HasNode(parentRoot) {
+		return errors.Wrapf(errUnknownParent, "parent %#x", parentRoot)
+	}
+	preState, err := s.state(ctx, parentRoot)
+	if err != nil {
+		return err
+	}
+	_, postState, err := transition.ExecuteStateTransitionNoVerifyAnySig(ctx, preState.Copy(), b)
+	if err != nil {
+		return errors.Wrap(err, "could not execute state transition")
+	}
+	roblock, err := consensusblocks.NewROBlockWithRoot(b, root)
+	if err != nil {
+		return err
+	}
+	if err := s.fc.InsertNode(ctx, postState, roblock); err != nil {
+		return errors.Wrap(err, "could not insert block")
+	}
+	s.states[root] = postState
+
+	for _, a := range b.Block().Body().Attestations() {
+		committees, err := helpers.AttestationCommittees(ctx, postState, a)
+		if err != nil {
+			return err
+		}
+		indices, err := attestation.AttestingIndices(a, committees...)
+		if err != nil {
+			return err
+		}
+		r := bytesutil.ToBytes32(a.GetData().BeaconBlockRoot)
+		if s.fc.HasNode(r) {
+			s.fc.ProcessAttestation(ctx, indices, r, a.GetData().Target.Epoch)
+		}
+	}
+	for _, slashing := range b.Block().Body().AttesterSlashings() {
+		for _, index := range blocks.SlashableAttesterIndices(slashing) {
+			s.fc.InsertSlashedIndex(ctx, primitives.ValidatorIndex(index))
+		}
+	}
+	return nil
+}
+
+// processAttestations applies the pending attestations that the beacon node would consider at the current time:
+// attestations from past slots, allowing for clock disparity, for blocks known to fork choice.
+func (s *simulator) processAttestations(ctx context.Context) {
+	disparity := params.BeaconConfig().MaximumGossipClockDisparityDuration() + reorgLateBlockCountAttestations
+	currentSlot := slots.Duration(time.Unix(int64(s.genesisTime), 0), s.now)
+	remaining := s.pending[:0]
+	for _, a := range s.pending {
+		data := a.GetData()
+		if slots.StartTime(s.genesisTime, data.Slot+1).After(s.now.Add(disparity)) {
+			remaining = append(remaining, a)
+			continue
+		}
+		r := bytesutil.ToBytes32(data.BeaconBlockRoot)
+		if !s.fc.HasNode(r) {
+			// The block may still arrive, but attestations older than an epoch are dropped.
+			if data.Slot+params.BeaconConfig().SlotsPerEpoch > currentSlot {
+				remaining = append(remaining, a)
+			}
+			continue
+		}
+		indices, err := s.attestingIndices(ctx, a)
+		if err != nil {
+			log.WithError(err).WithField("slot", data.Slot).Debug("Could not get attesting indices")
+			continue
+		}
+		s.fc.ProcessAttestation(ctx, indices, r, data.Target.Epoch)
+	}
+	s.pending = remaining
+}
+
+func (s *simulator) attestingIndices(ctx context.Context, a ethpb.Att) ([]uint64, error) {
+	if sa, ok := a.(*ethpb.SingleAttestation); ok {
+		return []uint64{uint64(sa.AttesterIndex)}, nil
+	}
+	st, err := s.targetState(ctx, a.GetData().Target)
+	if err != nil {
+		return nil, err
+	}
+	committees, err := helpers.AttestationCommittees(ctx, st, a)
+	if err != nil {
+		return nil, err
+	}
+	return attestation.AttestingIndices(a, committees...)
+}
+
+// targetState returns the state of the target checkpoint of an attestation, advanced to the target epoch.
+func (s *simulator) targetState(ctx context.Context, target *ethpb.Checkpoint) (state.ReadOnlyBeaconState, error) {
+	cp := forkchoicetypes.Checkpoint{Epoch: target.Epoch, Root: bytesutil.ToBytes32(target.Root)}
+	if st, ok := s.checkpointStates[cp]; ok {
+		return st, nil
+	}
+	st, err := s.state(ctx, cp.Root)
+	if err != nil {
+		return nil, err
+	}
+	epochStart, err := slots.EpochStart(cp.Epoch)
+	if err != nil {
+		return nil, err
+	}
+	if st.Slot() < epochStart {
+		st, err = transition.ProcessSlots(ctx, st.Copy(), epochStart)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not process slots up to %d", epochStart)
+		}
+	}
+	s.checkpointStates[cp] = st
+	return st, nil
+}
+
+// state returns the post state of the given block, from memory or from the database.
+func (s *simulator) state(ctx context.Context, root [32]byte) (state.BeaconState, error) {
+	if st, ok := s.states[root]; ok {
+		return st, nil
+	}
+	st, err := s.sg.StateByRoot(ctx, root)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get state of block %#x", root)
+	}
+	return st, nil
+}
+
+// activeBalances returns the effective balances of the active and non-slashed validators at the state
+// with the given root. Fork choice uses it to obtain the justified balances.
+func (s *simulator) activeBalances(ctx context.Context, root [32]byte) ([]uint64, error) {
+	st, ok := s.states[root]
+	if !ok {
+		return s.sg.ActiveNonSlashedBalancesByRoot(ctx, root)
+	}
+	epoch := coreTime.CurrentEpoch(st)
+	balances := make([]uint64, st.NumValidators())
+	if err := st.ReadFromEveryValidator(func(idx int, val state.ReadOnlyValidator) error {
+		if helpers.IsActiveNonSlashedValidatorUsingTrie(val, epoch) {
+			balances[idx] = val.EffectiveBalance()
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return balances, nil
+}
+
+// prune drops the states which are older than two epochs, they are reloaded from the database if needed.
+func (s *simulator) prune(slot primitives.Slot) {
+	window := 2 * params.BeaconConfig().SlotsPerEpoch
+	if slot < window {
+		return
+	}
+	for root, st := range s.states {
+		if st.Slot()+window < slot {
+			delete(s.states, root)
+		}
+	}
+	for cp := range s.checkpointStates {
+		if cp.Epoch+2 < slots.ToEpoch(slot) {
+			delete(s.checkpointStates, cp)
+		}
+	}
+}
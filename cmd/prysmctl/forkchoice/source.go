@@ -0,0 +1,176 @@
+package forkchoice
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz/detect"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+const (
+	captureTypeBlock             = "block"
+	captureTypeAttestation       = "attestation"
+	captureTypeSingleAttestation = "single_attestation"
+
+	// maxCaptureLineSize bounds the size of a line of the capture file, which holds a hex encoded block.
+	maxCaptureLineSize = 64 << 20
+)
+
+var errInvalidCapture = errors.New("invalid capture")
+
+// event is a block or an attestation received by the simulated node at the given time.
+type event struct {
+	time  time.Time
+	block interfaces.ReadOnlySignedBeaconBlock
+	root  [32]byte
+	att   ethpb.Att
+}
+
+// captureEntry is a line of a gossip capture file.
+type captureEntry struct {
+	Time time.Time `json:"time"`
+	Type string    `json:"type"`
+	SSZ  string    `json:"ssz"`
+}
+
+func sortEvents(events []*event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].time.Before(events[j].time)
+	})
+}
+
+// dbEvents returns the blocks of the database in [start, end], received at their configured arrival time.
+// The attestations included in these blocks and in the blocks of the following epoch are received
+// at the configured attestation arrival time in their slot, as if they had been gossiped.
+func dbEvents(ctx context.Context, db *kv.Store, genesisTime uint64, start, end primitives.Slot, cfg *simulationConfig) ([]*event, error) {
+	if end < start {
+		return nil, nil
+	}
+	f := filters.NewFilter().SetStartSlot(start).SetEndSlot(end + params.BeaconConfig().SlotsPerEpoch)
+	blks, roots, err := db.Blocks(ctx, f)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get blocks from slot %d to %d", start, end)
+	}
+	var events []*event
+	for i, b := range blks {
+		slot := b.Block().Slot()
+		if slot <= end {
+			events = append(events, &event{
+				time:  slots.StartTime(genesisTime, slot).Add(cfg.arrival(slot)),
+				block: b,
+				root:  roots[i],
+			})
+		}
+		for _, a := range b.Block().Body().Attestations() {
+			if a.GetData().Slot < start || a.GetData().Slot > end {
+				continue
+			}
+			events = append(events, &event{
+				time: slots.StartTime(genesisTime, a.GetData().Slot).Add(cfg.attestationArrival),
+				att:  a,
+			})
+		}
+	}
+	return events, nil
+}
+
+// captureEvents reads the blocks and attestations of a gossip capture. The capture is a file of json lines with
+// the time at which the object was received, its type, and its hex encoded ssz serialization.
+func captureEvents(path string) ([]*event, error) {
+	fh, err := os.Open(path) // #nosec G304
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open capture file")
+	}
+	defer func() {
+		if err := fh.Close(); err != nil {
+			log.WithError(err).Error("Could not close capture file")
+		}
+	}()
+	var events []*event
+	scanner := bufio.NewScanner(fh)
+	scanner.Buffer(make([]byte, 0, 1<<20), maxCaptureLineSize)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		e, err := parseCaptureEntry(scanner.Bytes())
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", line)
+		}
+		events = append(events, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "could not read capture file")
+	}
+	return events, nil
+}
+
+func parseCaptureEntry(line []byte) (*event, error) {
+	entry := &captureEntry{}
+	if err := json.Unmarshal(line, entry); err != nil {
+		return nil, errors.Wrap(errInvalidCapture, err.Error())
+	}
+	enc, err := hexutil.Decode(entry.SSZ)
+	if err != nil {
+		return nil, errors.Wrap(errInvalidCapture, err.Error())
+	}
+	e := &event{time: entry.Time}
+	switch entry.Type {
+	case captureTypeBlock:
+		u, err := detect.FromBlock(enc)
+		if err != nil {
+			return nil, errors.Wrap(errInvalidCapture, err.Error())
+		}
+		e.block, err = u.UnmarshalBeaconBlock(enc)
+		if err != nil {
+			return nil, errors.Wrap(errInvalidCapture, err.Error())
+		}
+		e.root, err = e.block.Block().HashTreeRoot()
+		if err != nil {
+			return nil, err
+		}
+	case captureTypeAttestation:
+		// The attestation data, starting with the slot, follows the offset of the aggregation bits.
+		if len(enc) < 12 {
+			return nil, errors.Wrap(errInvalidCapture, "attestation too short")
+		}
+		slot := primitives.Slot(binary.LittleEndian.Uint64(enc[4:12]))
+		if slots.ToEpoch(slot) >= params.BeaconConfig().ElectraForkEpoch {
+			a := &ethpb.AttestationElectra{}
+			err = a.UnmarshalSSZ(enc)
+			e.att = a
+		} else {
+			a := &ethpb.Attestation{}
+			err = a.UnmarshalSSZ(enc)
+			e.att = a
+		}
+		if err != nil {
+			return nil, errors.Wrap(errInvalidCapture, err.Error())
+		}
+	case captureTypeSingleAttestation:
+		a := &ethpb.SingleAttestation{}
+		if err := a.UnmarshalSSZ(enc); err != nil {
+			return nil, errors.Wrap(errInvalidCapture, err.Error())
+		}
+		e.att = a
+	default:
+		return nil, errors.Wrapf(errInvalidCapture, "unknown type %q", entry.Type)
+	}
+	return e, nil
+}
@@ -843,6 +843,18 @@ type BlobAndProofJson struct {
 	KzgProof hexutil.Bytes `json:"proof"`
 }
 
+type BlobAndCellProofsJson struct {
+	Blob      hexutil.Bytes   `json:"blob"`
+	KzgProofs []hexutil.Bytes `json:"proofs"`
+}
+
+// BlobAndCellProofs is a blob of the execution engine mempool with the KZG proofs of its cells,
+// as returned by engine_getBlobsV2.
+type BlobAndCellProofs struct {
+	Blob      []byte
+	KzgProofs [][]byte
+}
+
 // MarshalJSON --
 func (e *ExecutionPayloadDeneb) MarshalJSON() ([]byte, error) {
 	transactions := make([]hexutil.Bytes, len(e.Transactions))
@@ -1265,6 +1277,30 @@ func RecastHexutilByteSlice(h []hexutil.Bytes) [][]byte {
 	return r
 }
 
+// UnmarshalJSON implements the json unmarshaler interface for BlobAndCellProofs.
+func (b *BlobAndCellProofs) UnmarshalJSON(enc []byte) error {
+	var dec *BlobAndCellProofsJson
+	if err := json.Unmarshal(enc, &dec); err != nil {
+		return err
+	}
+	if dec == nil {
+		return errors.New("missing blob and cell proofs")
+	}
+
+	blob := make([]byte, fieldparams.BlobLength)
+	copy(blob, dec.Blob)
+	b.Blob = blob
+
+	b.KzgProofs = make([][]byte, len(dec.KzgProofs))
+	for i, p := range dec.KzgProofs {
+		proof := make([]byte, fieldparams.BLSPubkeyLength)
+		copy(proof, p)
+		b.KzgProofs[i] = proof
+	}
+
+	return nil
+}
+
 // UnmarshalJSON implements the json unmarshaler interface for BlobAndProof.
 func (b *BlobAndProof) UnmarshalJSON(enc []byte) error {
 	var dec *BlobAndProofJson
@@ -14,12 +14,16 @@ import (
 	"crypto/ecdsa"
 	"crypto/rand"
 	"encoding/hex"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
 	gcrypto "github.com/ethereum/go-ethereum/crypto"
@@ -31,6 +35,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/prysm/v5/async"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
@@ -55,13 +60,36 @@ var (
 	forkVersion           = flag.String("fork-version", "", "Fork Version that the bootnode uses")
 	genesisValidatorsRoot = flag.String("genesis-root", "", "Genesis Validators Root the beacon node uses")
 	seedNode              = flag.String("seed-node", "", "External node to connect to")
+	nodeDBPath            = flag.String("node-db", "", "Path of the database persisting the node table across restarts. The table is kept in memory if empty")
+	lookupInterval        = flag.Duration("lookup-interval", 30*time.Second, "Interval between random lookups refreshing the node table, 0 disables them")
 	log                   = logrus.WithField("prefix", "bootnode")
 	discv5PeersCount      = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "bootstrap_node_discv5_peers",
 		Help: "The current number of discv5 peers of the bootstrap node",
 	})
+	forkDigestPeersCount = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "bootstrap_node_fork_digest_peers",
+		Help: "The current number of discv5 peers of the bootstrap node per fork digest",
+	}, []string{"fork_digest"})
+	lookupsCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "bootstrap_node_discv5_lookups_total",
+		Help: "The number of random lookups performed by the bootstrap node",
+	})
+	lookupNodes = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "bootstrap_node_discv5_lookup_nodes",
+		Help:    "The number of nodes found by random lookups",
+		Buckets: []float64{0, 1, 2, 4, 8, 12, 16},
+	})
+	lookupDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "bootstrap_node_discv5_lookup_seconds",
+		Help:    "The time taken by random lookups",
+		Buckets: []float64{0.1, 0.25, 0.5, 1, 2, 5, 10, 30},
+	})
 )
 
+// Fork digest reported for nodes whose record has no valid eth2 entry.
+const unknownForkDigest = "unknown"
+
 type handler struct {
 	listener *discover.UDPv5
 }
@@ -106,29 +134,49 @@ func main() {
 	node := listener.Self()
 	log.Infof("Running bootnode: %s", node.String())
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Update metrics once per slot.
+	slotDuration := time.Duration(params.BeaconConfig().SecondsPerSlot)
+	async.RunEvery(ctx, slotDuration*time.Second, func() {
+		updateMetrics(listener)
+	})
+	if *lookupInterval > 0 {
+		async.RunEvery(ctx, *lookupInterval, func() {
+			randomLookup(listener)
+		})
+	}
+
 	handler := &handler{
 		listener: listener,
 	}
 	mux := http.NewServeMux()
 	mux.HandleFunc("/p2p", handler.httpHandler)
+	mux.HandleFunc("/enrs", handler.enrsHandler)
+	mux.Handle("/metrics", promhttp.Handler())
 
 	srv := &http.Server{
 		Addr:              fmt.Sprintf(":%d", *metricsPort),
 		ReadHeaderTimeout: 3 * time.Second,
 		Handler:           mux,
 	}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.WithError(err).Fatal("Failed to start server")
+		}
+	}()
 
-	if err := srv.ListenAndServe(); err != nil {
-		log.WithError(err).Fatal("Failed to start server")
+	// Close the listener and the node database on shutdown, so that the node table is flushed to disk.
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM)
+	<-sigc
+	log.Info("Shutting down bootnode")
+	if err := srv.Shutdown(ctx); err != nil {
+		log.WithError(err).Error("Failed to shut down server")
 	}
-
-	// Update metrics once per slot.
-	slotDuration := time.Duration(params.BeaconConfig().SecondsPerSlot)
-	async.RunEvery(context.Background(), slotDuration*time.Second, func() {
-		updateMetrics(listener)
-	})
-
-	select {}
+	listener.Close()
+	listener.LocalNode().Database().Close()
 }
 
 func createListener(ipAddr string, port int, cfg discover.Config) *discover.UDPv5 {
@@ -187,8 +235,57 @@ func (h *handler) httpHandler(w http.ResponseWriter, _ *http.Request) {
 	}
 }
 
+type nodeRecord struct {
+	ENR        string `json:"enr"`
+	NodeID     string `json:"node_id"`
+	Seq        uint64 `json:"seq"`
+	IP         string `json:"ip"`
+	UDP        int    `json:"udp"`
+	TCP        int    `json:"tcp"`
+	ForkDigest string `json:"fork_digest"`
+}
+
+// enrsHandler lists the records of the nodes in the table as JSON. The nodes can be
+// filtered by the fork digest they advertise, with the fork_digest query parameter.
+func (h *handler) enrsHandler(w http.ResponseWriter, r *http.Request) {
+	filter := r.URL.Query().Get("fork_digest")
+	if filter != "" {
+		digest, err := hex.DecodeString(strings.TrimPrefix(filter, "0x"))
+		if err != nil || len(digest) != 4 {
+			http.Error(w, "Invalid fork digest", http.StatusBadRequest)
+			return
+		}
+		filter = fmt.Sprintf("%#x", digest)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(nodeRecords(h.listener.AllNodes(), filter)); err != nil {
+		log.WithError(err).Error("Failed to write to http response")
+	}
+}
+
+// nodeRecords returns the records of the nodes advertising the given fork digest, or of all nodes if it is empty.
+func nodeRecords(nodes []*enode.Node, filter string) []nodeRecord {
+	records := make([]nodeRecord, 0, len(nodes))
+	for _, n := range nodes {
+		digest := forkDigestString(n)
+		if filter != "" && digest != filter {
+			continue
+		}
+		records = append(records, nodeRecord{
+			ENR:        n.String(),
+			NodeID:     n.ID().String(),
+			Seq:        n.Seq(),
+			IP:         n.IP().String(),
+			UDP:        n.UDP(),
+			TCP:        n.TCP(),
+			ForkDigest: digest,
+		})
+	}
+	return records
+}
+
 func createLocalNode(privKey *ecdsa.PrivateKey, ipAddr net.IP, port int) (*enode.LocalNode, error) {
-	db, err := enode.OpenDB("")
+	db, err := enode.OpenDB(*nodeDBPath)
 	if err != nil {
 		return nil, errors.Wrap(err, "Could not open node's peer database")
 	}
@@ -279,7 +376,49 @@ func extractPrivateKey() *ecdsa.PrivateKey {
 }
 
 func updateMetrics(listener *discover.UDPv5) {
-	if listener != nil {
-		discv5PeersCount.Set(float64(len(listener.AllNodes())))
+	if listener == nil {
+		return
+	}
+	nodes := listener.AllNodes()
+	discv5PeersCount.Set(float64(len(nodes)))
+	forkDigestPeersCount.Reset()
+	for _, n := range nodes {
+		forkDigestPeersCount.WithLabelValues(forkDigestString(n)).Inc()
+	}
+}
+
+// randomLookup looks up a random node ID, which fills the node table with nodes
+// from across the network.
+func randomLookup(listener *discover.UDPv5) {
+	var target enode.ID
+	if _, err := rand.Read(target[:]); err != nil {
+		log.WithError(err).Error("Could not generate lookup target")
+		return
+	}
+	start := time.Now()
+	nodes := listener.Lookup(target)
+	lookupsCount.Inc()
+	lookupDuration.Observe(time.Since(start).Seconds())
+	lookupNodes.Observe(float64(len(nodes)))
+}
+
+// forkDigest returns the current fork digest advertised in the eth2 entry of a node record.
+func forkDigest(n *enode.Node) ([4]byte, error) {
+	var entry []byte
+	if err := n.Record().Load(enr.WithEntry("eth2", &entry)); err != nil {
+		return [4]byte{}, err
+	}
+	forkID := &pb.ENRForkID{}
+	if err := forkID.UnmarshalSSZ(entry); err != nil {
+		return [4]byte{}, err
+	}
+	return bytesutil.ToBytes4(forkID.CurrentForkDigest), nil
+}
+
+func forkDigestString(n *enode.Node) string {
+	digest, err := forkDigest(n)
+	if err != nil {
+		return unknownForkDigest
 	}
+	return fmt.Sprintf("%#x", digest)
 }
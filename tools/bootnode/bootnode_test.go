@@ -5,7 +5,9 @@ import (
 	"crypto/rand"
 	"fmt"
 	"io"
+	"net"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -82,3 +84,42 @@ func TestPrivateKey_ParsesCorrectly(t *testing.T) {
 	assert.Equal(t, true, isVerified, "Unmarshalled key is not the same as the key that was given to the function")
 	*privateKey = ""
 }
+
+func TestNodeRecords_FilterByForkDigest(t *testing.T) {
+	ip := net.ParseIP("127.0.0.1")
+	ln1, err := createLocalNode(extractPrivateKey(), ip, 4000)
+	require.NoError(t, err)
+	defer ln1.Database().Close()
+	*forkVersion = "01020304"
+	ln2, err := createLocalNode(extractPrivateKey(), ip, 4001)
+	*forkVersion = ""
+	require.NoError(t, err)
+	defer ln2.Database().Close()
+
+	nodes := []*enode.Node{ln1.Node(), ln2.Node()}
+	d1, err := forkDigest(nodes[0])
+	require.NoError(t, err)
+	d2, err := forkDigest(nodes[1])
+	require.NoError(t, err)
+	assert.NotEqual(t, d1, d2)
+
+	records := nodeRecords(nodes, "")
+	require.Equal(t, 2, len(records))
+	records = nodeRecords(nodes, fmt.Sprintf("%#x", d2))
+	require.Equal(t, 1, len(records))
+	assert.Equal(t, nodes[1].String(), records[0].ENR)
+	assert.Equal(t, nodes[1].ID().String(), records[0].NodeID)
+	assert.Equal(t, fmt.Sprintf("%#x", d2), records[0].ForkDigest)
+	assert.Equal(t, 4001, records[0].UDP)
+	assert.Equal(t, 0, len(nodeRecords(nodes, "0x00000000")))
+}
+
+func TestCreateLocalNode_PersistsNodeDB(t *testing.T) {
+	*nodeDBPath = filepath.Join(t.TempDir(), "nodes")
+	defer func() { *nodeDBPath = "" }()
+	ln, err := createLocalNode(extractPrivateKey(), net.ParseIP("127.0.0.1"), 4000)
+	require.NoError(t, err)
+	ln.Database().Close()
+	_, err = os.Stat(*nodeDBPath)
+	require.NoError(t, err)
+}
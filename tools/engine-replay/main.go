@@ -0,0 +1,139 @@
+// Package main implements a tool replaying a journal of engine API calls, recorded by a beacon node with
+// --execution-journal-dir, against an execution client. Every call is sent in the order of the journal, and the
+// responses are compared with the recorded responses to find where the execution client diverges.
+//
+// Usage:
+//
+//	engine-replay --journal /path/to/journal-dir --endpoint http://localhost:8551 --jwt-secret /path/to/jwt.hex
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"flag"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/execution"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	"github.com/prysmaticlabs/prysm/v5/network"
+	"github.com/prysmaticlabs/prysm/v5/network/authorization"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	journalPath   = flag.String("journal", "", "Journal directory, or a single journal file")
+	endpoint      = flag.String("endpoint", "http://localhost:8551", "Execution client endpoint to replay the calls against")
+	jwtSecretPath = flag.String("jwt-secret", "", "Path to the hex encoded JWT secret of the execution client")
+	source        = flag.String("source", "", "Only replay the calls made to this endpoint, as recorded in the journal. All calls are replayed if empty")
+	methods       = flag.String("methods", "", "Comma separated list of methods to replay, without version suffix to match every version. All engine methods are replayed if empty")
+	strict        = flag.Bool("strict", false, "Compare responses in full, instead of the payload statuses and errors the beacon node acts upon")
+	failFast      = flag.Bool("fail-fast", false, "Stop at the first divergence")
+	timeout       = flag.Duration("timeout", 8*time.Second, "Timeout of every call")
+	log           = logrus.WithField("prefix", "engine-replay")
+)
+
+var errDivergence = errors.New("execution client diverged from the journal")
+
+func main() {
+	flag.Parse()
+	if *journalPath == "" {
+		log.Fatal("Must provide --journal")
+	}
+	files, err := journalFiles(*journalPath)
+	if err != nil {
+		log.WithError(err).Fatal("Could not find journal files")
+	}
+	ep := network.HttpEndpoint(*endpoint)
+	if *jwtSecretPath != "" {
+		secret, err := readJWTSecret(*jwtSecretPath)
+		if err != nil {
+			log.WithError(err).Fatal("Could not read jwt secret")
+		}
+		ep.Auth.Method = authorization.Bearer
+		ep.Auth.Value = string(secret)
+	}
+	ctx := context.Background()
+	client, err := network.NewExecutionRPCClient(ctx, ep, nil)
+	if err != nil {
+		log.WithError(err).Fatal("Could not dial execution client")
+	}
+	defer client.Close()
+
+	r := newReplayer(client, *timeout, *strict)
+	var filter []string
+	if *methods != "" {
+		filter = strings.Split(*methods, ",")
+	}
+	err = readJournal(files, func(e *execution.JournalEntry) error {
+		if (*source != "" && e.Endpoint != *source) || !matchMethod(e.Method, filter) {
+			return nil
+		}
+		diffs := r.replay(ctx, e)
+		if len(diffs) == 0 {
+			return nil
+		}
+		log.WithFields(logrus.Fields{
+			"method":   e.Method,
+			"recorded": e.Time.Format(time.RFC3339Nano),
+			"endpoint": e.Endpoint,
+		}).Warn("Response diverged from the journal:\n  " + strings.Join(diffs, "\n  "))
+		if *failFast {
+			return errDivergence
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDivergence) {
+		log.WithError(err).Fatal("Could not replay journal")
+	}
+	log.WithFields(logrus.Fields{
+		"replayed":  r.replayed,
+		"divergent": r.divergent,
+		"skipped":   r.skipped,
+	}).Info("Replayed journal")
+	if r.divergent > 0 {
+		os.Exit(1)
+	}
+}
+
+// journalFiles returns the files of the journal directory in order, or the journal file.
+func journalFiles(path string) ([]string, error) {
+	isDir, err := file.HasDir(path)
+	if err != nil {
+		return nil, err
+	}
+	if !isDir {
+		return []string{path}, nil
+	}
+	files, err := execution.JournalFiles(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, errors.Errorf("no journal file in %s", path)
+	}
+	return files, nil
+}
+
+// matchMethod returns true if the method is in the filter, ignoring its version if the filter has none.
+func matchMethod(method string, filter []string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	for _, m := range filter {
+		if m == method || (strings.HasPrefix(method, m+"V") && strings.TrimLeft(method[len(m)+1:], "0123456789") == "") {
+			return true
+		}
+	}
+	return false
+}
+
+func readJWTSecret(path string) ([]byte, error) {
+	enc, err := file.ReadFileAsBytes(path)
+	if err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(enc)), "0x"))
+}
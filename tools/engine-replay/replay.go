@@ -0,0 +1,240 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	gethRPC "github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/execution"
+)
+
+// Maximum number of differences reported for a call.
+const maxDifferences = 10
+
+// caller is the subset of the rpc client used to replay calls.
+type caller interface {
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+}
+
+// replayer sends the calls of a journal to an execution client and compares the responses with the journal.
+type replayer struct {
+	client  caller
+	timeout time.Duration
+	strict  bool
+	// payloadIDs maps the payload ids of the journal to those returned by the execution client, as every
+	// client returns its own payload ids for the same payload build.
+	payloadIDs map[string]json.RawMessage
+	replayed   int
+	skipped    int
+	divergent  int
+}
+
+func newReplayer(client caller, timeout time.Duration, strict bool) *replayer {
+	return &replayer{client: client, timeout: timeout, strict: strict, payloadIDs: make(map[string]json.RawMessage)}
+}
+
+// replay sends the call of the entry and returns the differences between the response and the journal.
+func (r *replayer) replay(ctx context.Context, e *execution.JournalEntry) []string {
+	if e.Error != nil && e.Error.Code == 0 {
+		// The engine could not be reached or did not respond in time, there is no response to compare with.
+		r.skipped++
+		return nil
+	}
+	params := make([]interface{}, len(e.Params))
+	for i, p := range e.Params {
+		params[i] = p
+	}
+	if strings.HasPrefix(e.Method, "engine_getPayloadV") && len(params) == 1 {
+		if id, ok := r.payloadIDs[string(bytes.TrimSpace(e.Params[0]))]; ok {
+			params[0] = id
+		}
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	var raw json.RawMessage
+	err := r.client.CallContext(callCtx, &raw, e.Method, params...)
+	r.replayed++
+	if err == nil && strings.HasPrefix(e.Method, "engine_forkchoiceUpdatedV") {
+		r.mapPayloadID(e.Result, raw)
+	}
+	diffs := r.compare(e, raw, err)
+	if len(diffs) > 0 {
+		r.divergent++
+	}
+	return diffs
+}
+
+func (r *replayer) mapPayloadID(recorded, replayed json.RawMessage) {
+	var rec, rep struct {
+		PayloadID json.RawMessage `json:"payloadId"`
+	}
+	if json.Unmarshal(recorded, &rec) != nil || json.Unmarshal(replayed, &rep) != nil {
+		return
+	}
+	if len(rec.PayloadID) > 0 && string(rec.PayloadID) != "null" && len(rep.PayloadID) > 0 {
+		r.payloadIDs[string(rec.PayloadID)] = rep.PayloadID
+	}
+}
+
+// compare returns the differences between the replayed response and the journal. Unless strict, only what the
+// beacon node acts upon is compared: the payload status of new payload and forkchoice updated calls, whether a
+// payload is built and returned, and errors. Other responses are compared in full.
+func (r *replayer) compare(e *execution.JournalEntry, raw json.RawMessage, err error) []string {
+	if e.Error != nil || err != nil {
+		return compareErrors(e.Error, err)
+	}
+	var recorded, replayed interface{}
+	if err := json.Unmarshal(e.Result, &recorded); err != nil {
+		return []string{fmt.Sprintf("could not decode recorded result: %v", err)}
+	}
+	if err := json.Unmarshal(raw, &replayed); err != nil {
+		return []string{fmt.Sprintf("could not decode result: %v", err)}
+	}
+	var diffs []string
+	switch {
+	case r.strict:
+		diff("result", recorded, replayed, &diffs)
+	case strings.HasPrefix(e.Method, "engine_newPayloadV"):
+		diff("result.status", field(recorded, "status"), field(replayed, "status"), &diffs)
+		diff("result.latestValidHash", field(recorded, "latestValidHash"), field(replayed, "latestValidHash"), &diffs)
+	case strings.HasPrefix(e.Method, "engine_forkchoiceUpdatedV"):
+		diff("result.payloadStatus.status", field(recorded, "payloadStatus", "status"), field(replayed, "payloadStatus", "status"), &diffs)
+		diff("result.payloadStatus.latestValidHash", field(recorded, "payloadStatus", "latestValidHash"), field(replayed, "payloadStatus", "latestValidHash"), &diffs)
+		diff("result.payloadId != null", field(recorded, "payloadId") != nil, field(replayed, "payloadId") != nil, &diffs)
+	case strings.HasPrefix(e.Method, "engine_getPayloadV"),
+		strings.HasPrefix(e.Method, "engine_exchangeCapabilities"),
+		strings.HasPrefix(e.Method, "engine_getClientVersion"):
+		// Built payloads and client capabilities differ between clients.
+	default:
+		diff("result", recorded, replayed, &diffs)
+	}
+	return diffs
+}
+
+func compareErrors(recorded *execution.JournalError, err error) []string {
+	switch {
+	case recorded == nil:
+		return []string{fmt.Sprintf("error %q, recorded a result", err)}
+	case err == nil:
+		return []string{fmt.Sprintf("result, recorded error %q", recorded.Message)}
+	}
+	var rpcErr gethRPC.Error
+	if !errors.As(err, &rpcErr) {
+		return []string{fmt.Sprintf("error %q, recorded error %q", err, recorded.Message)}
+	}
+	if rpcErr.ErrorCode() != recorded.Code {
+		return []string{fmt.Sprintf("error code %d, recorded %d", rpcErr.ErrorCode(), recorded.Code)}
+	}
+	return nil
+}
+
+// field returns the value at the path of the decoded json value, or nil.
+func field(v interface{}, path ...string) interface{} {
+	for _, p := range path {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v = m[p]
+	}
+	return v
+}
+
+// diff appends the paths at which the decoded json values differ.
+func diff(path string, a, b interface{}, out *[]string) {
+	if len(*out) >= maxDifferences || reflect.DeepEqual(a, b) {
+		return
+	}
+	switch av := a.(type) {
+	case map[string]interface{}:
+		if bv, ok := b.(map[string]interface{}); ok {
+			keys := make(map[string]bool)
+			for k := range av {
+				keys[k] = true
+			}
+			for k := range bv {
+				keys[k] = true
+			}
+			sorted := make([]string, 0, len(keys))
+			for k := range keys {
+				sorted = append(sorted, k)
+			}
+			sort.Strings(sorted)
+			for _, k := range sorted {
+				diff(path+"."+k, av[k], bv[k], out)
+			}
+			return
+		}
+	case []interface{}:
+		if bv, ok := b.([]interface{}); ok && len(av) == len(bv) {
+			for i := range av {
+				diff(fmt.Sprintf("%s[%d]", path, i), av[i], bv[i], out)
+			}
+			return
+		}
+	}
+	*out = append(*out, fmt.Sprintf("%s: %s, recorded %s", path, short(b), short(a)))
+}
+
+func short(v interface{}) string {
+	enc, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	if len(enc) > 80 {
+		return string(enc[:77]) + "..."
+	}
+	return string(enc)
+}
+
+// readJournal calls f with every entry of the journal files, in order.
+func readJournal(files []string, f func(*execution.JournalEntry) error) error {
+	for _, path := range files {
+		if err := readJournalFile(path, f); err != nil {
+			return errors.Wrapf(err, "could not read %s", path)
+		}
+	}
+	return nil
+}
+
+func readJournalFile(path string, f func(*execution.JournalEntry) error) error {
+	file, err := os.Open(path) // #nosec G304
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.WithError(err).Error("Could not close journal file")
+		}
+	}()
+	reader := bufio.NewReader(file)
+	for line := 1; ; line++ {
+		enc, err := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(enc)) > 0 {
+			e := &execution.JournalEntry{}
+			if err := json.Unmarshal(enc, e); err != nil {
+				return errors.Wrapf(err, "invalid entry at line %d", line)
+			}
+			if err := f(e); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gethRPC "github.com/ethereum/go-ethereum/rpc"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/execution"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+type payloadStatus struct {
+	Status          string  `json:"status"`
+	LatestValidHash *string `json:"latestValidHash"`
+}
+
+type fcuResponse struct {
+	PayloadStatus payloadStatus `json:"payloadStatus"`
+	PayloadID     *string       `json:"payloadId"`
+}
+
+// testEngine returns its own payload id, and an INVALID status for the 0xbad block hash.
+type testEngine struct{}
+
+func (testEngine) NewPayloadV3(payload map[string]string, _ []string, _ string) payloadStatus {
+	if payload["blockHash"] == "0xbad" {
+		return payloadStatus{Status: "INVALID"}
+	}
+	h := payload["blockHash"]
+	return payloadStatus{Status: "VALID", LatestValidHash: &h}
+}
+
+func (testEngine) ForkchoiceUpdatedV3(_ map[string]string, attrs map[string]string) fcuResponse {
+	resp := fcuResponse{PayloadStatus: payloadStatus{Status: "VALID"}}
+	if attrs != nil {
+		id := "0x0200000000000000"
+		resp.PayloadID = &id
+	}
+	return resp
+}
+
+func (testEngine) GetPayloadV3(id string) (map[string]string, error) {
+	if id != "0x0200000000000000" {
+		return nil, &testError{}
+	}
+	return map[string]string{"blockHash": "0xother"}, nil
+}
+
+type testError struct{}
+
+func (*testError) Error() string  { return "Unknown payload" }
+func (*testError) ErrorCode() int { return -38001 }
+
+func entry(method, params, result string) *execution.JournalEntry {
+	var p []json.RawMessage
+	if err := json.Unmarshal([]byte(params), &p); err != nil {
+		panic(err)
+	}
+	return &execution.JournalEntry{Method: method, Params: p, Result: json.RawMessage(result)}
+}
+
+func TestReplayer(t *testing.T) {
+	ctx := context.Background()
+	server := gethRPC.NewServer()
+	require.NoError(t, server.RegisterName("engine", testEngine{}))
+	defer server.Stop()
+	r := newReplayer(gethRPC.DialInProc(server), time.Second, false)
+
+	t.Run("same status", func(t *testing.T) {
+		e := entry("engine_newPayloadV3", `[{"blockHash":"0x01"},[],"0x00"]`, `{"status":"VALID","latestValidHash":"0x01","validationError":null}`)
+		assert.Equal(t, 0, len(r.replay(ctx, e)))
+	})
+	t.Run("diverging status", func(t *testing.T) {
+		e := entry("engine_newPayloadV3", `[{"blockHash":"0xbad"},[],"0x00"]`, `{"status":"VALID","latestValidHash":"0xbad"}`)
+		diffs := r.replay(ctx, e)
+		require.Equal(t, 2, len(diffs))
+		assert.Equal(t, `result.status: "INVALID", recorded "VALID"`, diffs[0])
+	})
+	t.Run("payload id mapped", func(t *testing.T) {
+		fcu := entry("engine_forkchoiceUpdatedV3", `[{},{"timestamp":"0x1"}]`, `{"payloadStatus":{"status":"VALID"},"payloadId":"0x0100000000000000"}`)
+		assert.Equal(t, 0, len(r.replay(ctx, fcu)))
+		get := entry("engine_getPayloadV3", `["0x0100000000000000"]`, `{"blockHash":"0x01"}`)
+		assert.Equal(t, 0, len(r.replay(ctx, get)))
+		r.strict = true
+		diffs := r.replay(ctx, get)
+		r.strict = false
+		require.DeepEqual(t, []string{`result.blockHash: "0xother", recorded "0x01"`}, diffs)
+	})
+	t.Run("errors", func(t *testing.T) {
+		e := entry("engine_getPayloadV3", `["0x0300000000000000"]`, ``)
+		e.Error = &execution.JournalError{Code: -38001, Message: "Unknown payload"}
+		assert.Equal(t, 0, len(r.replay(ctx, e)))
+		e.Error.Code = -32000
+		require.DeepEqual(t, []string{"error code -38001, recorded -32000"}, r.replay(ctx, e))
+	})
+	t.Run("unreachable engine skipped", func(t *testing.T) {
+		e := entry("engine_newPayloadV3", `[{"blockHash":"0xbad"},[],"0x00"]`, ``)
+		e.Error = &execution.JournalError{Message: "context deadline exceeded"}
+		assert.Equal(t, 0, len(r.replay(ctx, e)))
+	})
+	assert.Equal(t, 7, r.replayed)
+	assert.Equal(t, 3, r.divergent)
+	assert.Equal(t, 1, r.skipped)
+}
+
+func TestMatchMethod(t *testing.T) {
+	assert.Equal(t, true, matchMethod("engine_newPayloadV3", nil))
+	assert.Equal(t, true, matchMethod("engine_newPayloadV3", []string{"engine_forkchoiceUpdated", "engine_newPayload"}))
+	assert.Equal(t, true, matchMethod("engine_newPayloadV3", []string{"engine_newPayloadV3"}))
+	assert.Equal(t, false, matchMethod("engine_newPayloadV3", []string{"engine_newPayloadV4"}))
+	assert.Equal(t, false, matchMethod("engine_getPayloadBodiesByHashV1", []string{"engine_getPayload"}))
+}
+
+func TestReadJournal(t *testing.T) {
+	dir := t.TempDir()
+	lines := `{"method":"engine_newPayloadV3","params":[]}` + "\n\n" + `{"method":"engine_forkchoiceUpdatedV3","params":[]}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, execution.JournalFileName), []byte(lines), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "engine-journal.20240101T000000.000000000.jsonl"), []byte(`{"method":"engine_exchangeCapabilities"}`+"\n"), 0600))
+	files, err := journalFiles(dir)
+	require.NoError(t, err)
+
+	var methods []string
+	require.NoError(t, readJournal(files, func(e *execution.JournalEntry) error {
+		methods = append(methods, e.Method)
+		return nil
+	}))
+	require.DeepEqual(t, []string{"engine_exchangeCapabilities", "engine_newPayloadV3", "engine_forkchoiceUpdatedV3"}, methods)
+
+	stop := errors.New("stop")
+	err = readJournal(files, func(*execution.JournalEntry) error {
+		return stop
+	})
+	require.ErrorIs(t, err, stop)
+}
@@ -0,0 +1,337 @@
+package main
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	gethRPC "github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
+	pb "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+const (
+	maxBlobsRequest  = 128
+	maxBodiesRequest = 1024
+)
+
+var errTooLargeRequest = &rpcError{Code: -38004, Message: "Too large request"}
+
+// engineAPI is the engine namespace of the json-rpc server.
+type engineAPI struct {
+	engine *engine
+}
+
+// ExchangeCapabilities returns the engine API methods supported by the engine.
+func (*engineAPI) ExchangeCapabilities(_ []string) []string {
+	return supportedMethods
+}
+
+func (api *engineAPI) NewPayloadV1(p *pb.ExecutionPayload) (*pb.PayloadStatus, error) {
+	b, err := payloadFromBellatrix(p)
+	if err != nil {
+		return nil, err
+	}
+	return api.engine.newPayload("engine_newPayloadV1", b, nil)
+}
+
+func (api *engineAPI) NewPayloadV2(p *pb.ExecutionPayloadCapellaJSON) (*pb.PayloadStatus, error) {
+	b, err := payloadFromCapella(p)
+	if err != nil {
+		return nil, err
+	}
+	return api.engine.newPayload("engine_newPayloadV2", b, nil)
+}
+
+func (api *engineAPI) NewPayloadV3(p *pb.ExecutionPayloadDenebJSON, versionedHashes []common.Hash, _ common.Hash) (*pb.PayloadStatus, error) {
+	if versionedHashes == nil {
+		return nil, errInvalidParams
+	}
+	b, err := payloadFromJSON(version.Deneb, p, nil)
+	if err != nil {
+		return nil, err
+	}
+	return api.engine.newPayload("engine_newPayloadV3", b, versionedHashes)
+}
+
+func (api *engineAPI) NewPayloadV4(
+	p *pb.ExecutionPayloadDenebJSON,
+	versionedHashes []common.Hash,
+	_ common.Hash,
+	requests []hexutil.Bytes,
+) (*pb.PayloadStatus, error) {
+	if versionedHashes == nil || requests == nil {
+		return nil, errInvalidParams
+	}
+	b, err := payloadFromJSON(version.Electra, p, requests)
+	if err != nil {
+		return nil, err
+	}
+	return api.engine.newPayload("engine_newPayloadV4", b, versionedHashes)
+}
+
+func (api *engineAPI) ForkchoiceUpdatedV1(state *pb.ForkchoiceState, attrs *pb.PayloadAttributes) (*forkchoiceUpdatedResponse, error) {
+	var a *buildAttributes
+	if attrs != nil {
+		a = &buildAttributes{
+			version:      version.Bellatrix,
+			timestamp:    attrs.Timestamp,
+			prevRandao:   common.BytesToHash(attrs.PrevRandao),
+			feeRecipient: common.BytesToAddress(attrs.SuggestedFeeRecipient),
+		}
+	}
+	return api.engine.forkchoiceUpdated("engine_forkchoiceUpdatedV1", state, a)
+}
+
+func (api *engineAPI) ForkchoiceUpdatedV2(state *pb.ForkchoiceState, attrs *pb.PayloadAttributesV2) (*forkchoiceUpdatedResponse, error) {
+	var a *buildAttributes
+	if attrs != nil {
+		a = &buildAttributes{
+			version:      version.Capella,
+			timestamp:    attrs.Timestamp,
+			prevRandao:   common.BytesToHash(attrs.PrevRandao),
+			feeRecipient: common.BytesToAddress(attrs.SuggestedFeeRecipient),
+			withdrawals:  attrs.Withdrawals,
+		}
+	}
+	return api.engine.forkchoiceUpdated("engine_forkchoiceUpdatedV2", state, a)
+}
+
+func (api *engineAPI) ForkchoiceUpdatedV3(state *pb.ForkchoiceState, attrs *pb.PayloadAttributesV3) (*forkchoiceUpdatedResponse, error) {
+	var a *buildAttributes
+	if attrs != nil {
+		a = &buildAttributes{
+			version:               version.Deneb,
+			timestamp:             attrs.Timestamp,
+			prevRandao:            common.BytesToHash(attrs.PrevRandao),
+			feeRecipient:          common.BytesToAddress(attrs.SuggestedFeeRecipient),
+			withdrawals:           attrs.Withdrawals,
+			parentBeaconBlockRoot: common.BytesToHash(attrs.ParentBeaconBlockRoot),
+		}
+	}
+	return api.engine.forkchoiceUpdated("engine_forkchoiceUpdatedV3", state, a)
+}
+
+func (api *engineAPI) GetPayloadV1(id pb.PayloadIDBytes) (*pb.ExecutionPayload, error) {
+	p, err := api.engine.getPayload("engine_getPayloadV1", id)
+	if err != nil {
+		return nil, err
+	}
+	return bellatrixPayload(p.block)
+}
+
+func (api *engineAPI) GetPayloadV2(id pb.PayloadIDBytes) (*pb.GetPayloadV2ResponseJson, error) {
+	p, err := api.engine.getPayload("engine_getPayloadV2", id)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetPayloadV2ResponseJson{
+		ExecutionPayload: capellaPayload(p.block),
+		BlockValue:       hexutil.EncodeBig(p.value),
+	}, nil
+}
+
+func (api *engineAPI) GetPayloadV3(id pb.PayloadIDBytes) (*pb.GetPayloadV3ResponseJson, error) {
+	p, err := api.engine.getPayload("engine_getPayloadV3", id)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetPayloadV3ResponseJson{
+		ExecutionPayload: p.block.payload,
+		BlockValue:       hexutil.EncodeBig(p.value),
+		BlobsBundle:      p.blobsBundle,
+	}, nil
+}
+
+func (api *engineAPI) GetPayloadV4(id pb.PayloadIDBytes) (*pb.GetPayloadV4ResponseJson, error) {
+	p, err := api.engine.getPayload("engine_getPayloadV4", id)
+	if err != nil {
+		return nil, err
+	}
+	requests := p.block.requests
+	if requests == nil {
+		requests = []hexutil.Bytes{}
+	}
+	return &pb.GetPayloadV4ResponseJson{
+		ExecutionPayload:  p.block.payload,
+		BlockValue:        hexutil.EncodeBig(p.value),
+		BlobsBundle:       p.blobsBundle,
+		ExecutionRequests: requests,
+	}, nil
+}
+
+// GetBlobsV1 returns the blobs of the payloads built by the engine, and null for unknown blobs.
+func (api *engineAPI) GetBlobsV1(hashes []common.Hash) ([]*pb.BlobAndProofJson, error) {
+	if len(hashes) > maxBlobsRequest {
+		return nil, errTooLargeRequest
+	}
+	if err := api.engine.script.call("engine_getBlobsV1", nil, common.Hash{}).apply(); err != nil {
+		return nil, err
+	}
+	api.engine.Lock()
+	defer api.engine.Unlock()
+	blobs := make([]*pb.BlobAndProofJson, len(hashes))
+	for i, h := range hashes {
+		blobs[i] = api.engine.blobs[h]
+	}
+	return blobs, nil
+}
+
+type payloadBodyV1 struct {
+	Transactions []hexutil.Bytes  `json:"transactions"`
+	Withdrawals  []*pb.Withdrawal `json:"withdrawals"`
+}
+
+func payloadBody(b *block) *payloadBodyV1 {
+	body := &payloadBodyV1{Transactions: b.payload.Transactions}
+	if b.version >= version.Capella {
+		body.Withdrawals = b.payload.Withdrawals
+	}
+	return body
+}
+
+func (api *engineAPI) GetPayloadBodiesByHashV1(hashes []common.Hash) ([]*payloadBodyV1, error) {
+	if len(hashes) > maxBodiesRequest {
+		return nil, errTooLargeRequest
+	}
+	bodies := make([]*payloadBodyV1, len(hashes))
+	for i, h := range hashes {
+		if b, ok := api.engine.block(h); ok {
+			bodies[i] = payloadBody(b)
+		}
+	}
+	return bodies, nil
+}
+
+func (api *engineAPI) GetPayloadBodiesByRangeV1(start, count hexutil.Uint64) ([]*payloadBodyV1, error) {
+	if start == 0 || count == 0 {
+		return nil, errInvalidParams
+	}
+	if count > maxBodiesRequest {
+		return nil, errTooLargeRequest
+	}
+	bodies := make([]*payloadBodyV1, 0, count)
+	for n := uint64(start); n < uint64(start+count); n++ {
+		b, ok := api.engine.canonicalBlock(n)
+		if !ok {
+			break
+		}
+		bodies = append(bodies, payloadBody(b))
+	}
+	return bodies, nil
+}
+
+// ethAPI is the subset of the eth namespace used by the beacon node.
+type ethAPI struct {
+	engine *engine
+}
+
+func (api *ethAPI) ChainId() hexutil.Uint64 {
+	return hexutil.Uint64(api.engine.cfg.chainID)
+}
+
+func (api *ethAPI) BlockNumber() hexutil.Uint64 {
+	api.engine.Lock()
+	defer api.engine.Unlock()
+	return hexutil.Uint64(api.engine.head.number())
+}
+
+func (*ethAPI) Syncing() bool {
+	return false
+}
+
+// GetLogs returns no logs, as the engine executes no contract.
+func (*ethAPI) GetLogs(_ map[string]interface{}) []*gethtypes.Log {
+	return []*gethtypes.Log{}
+}
+
+func (api *ethAPI) GetBlockByNumber(number gethRPC.BlockNumber, full bool) (*pb.ExecutionBlock, error) {
+	e := api.engine
+	e.Lock()
+	var hash common.Hash
+	switch number {
+	case gethRPC.LatestBlockNumber, gethRPC.PendingBlockNumber:
+		hash = e.head.hash()
+	case gethRPC.SafeBlockNumber:
+		hash = e.safe
+	case gethRPC.FinalizedBlockNumber:
+		hash = e.finalized
+	case gethRPC.EarliestBlockNumber:
+		hash = e.canonical[0]
+	default:
+		hash = e.canonical[uint64(number.Int64())]
+	}
+	e.Unlock()
+	return api.GetBlockByHash(hash, full)
+}
+
+func (api *ethAPI) GetBlockByHash(hash common.Hash, full bool) (*pb.ExecutionBlock, error) {
+	b, ok := api.engine.block(hash)
+	if !ok {
+		return nil, nil
+	}
+	return executionBlock(b, full)
+}
+
+// executionBlock returns the block in the format of the eth namespace.
+func executionBlock(b *block, full bool) (*pb.ExecutionBlock, error) {
+	p := b.payload
+	baseFee, err := hexutil.DecodeBig(p.BaseFeePerGas)
+	if err != nil {
+		return nil, err
+	}
+	txs := make(gethtypes.Transactions, len(p.Transactions))
+	for i, enc := range p.Transactions {
+		txs[i] = &gethtypes.Transaction{}
+		if err := txs[i].UnmarshalBinary(enc); err != nil {
+			return nil, err
+		}
+	}
+	eb := &pb.ExecutionBlock{
+		Version: version.Bellatrix,
+		Header: gethtypes.Header{
+			ParentHash:  *p.ParentHash,
+			UncleHash:   gethtypes.EmptyUncleHash,
+			Coinbase:    *p.FeeRecipient,
+			Root:        *p.StateRoot,
+			TxHash:      gethtypes.DeriveSha(txs, trie.NewStackTrie(nil)),
+			ReceiptHash: *p.ReceiptsRoot,
+			Bloom:       gethtypes.BytesToBloom(*p.LogsBloom),
+			Difficulty:  new(big.Int),
+			Number:      new(big.Int).SetUint64(b.number()),
+			GasLimit:    uint64(*p.GasLimit),
+			GasUsed:     uint64(*p.GasUsed),
+			Time:        uint64(*p.Timestamp),
+			Extra:       p.ExtraData,
+			MixDigest:   *p.PrevRandao,
+			BaseFee:     baseFee,
+		},
+		Hash:            b.hash(),
+		TotalDifficulty: "0x0",
+	}
+	if b.version >= version.Capella {
+		eb.Version = version.Capella
+		eb.Withdrawals = p.Withdrawals
+		withdrawals := make(gethtypes.Withdrawals, len(p.Withdrawals))
+		for i, w := range p.Withdrawals {
+			withdrawals[i] = &gethtypes.Withdrawal{
+				Index:     w.Index,
+				Validator: uint64(w.ValidatorIndex),
+				Address:   common.BytesToAddress(w.Address),
+				Amount:    w.Amount,
+			}
+		}
+		withdrawalsHash := gethtypes.DeriveSha(withdrawals, trie.NewStackTrie(nil))
+		eb.WithdrawalsHash = &withdrawalsHash
+	}
+	if b.version >= version.Deneb {
+		blobGasUsed, excessBlobGas := uint64(*p.BlobGasUsed), uint64(*p.ExcessBlobGas)
+		eb.BlobGasUsed, eb.ExcessBlobGas = &blobGasUsed, &excessBlobGas
+	}
+	if full {
+		eb.Transactions = txs
+	}
+	return eb, nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/pkg/errors"
+)
+
+// Maximum difference between the issued at claim of a token and the time of the engine.
+const jwtIssuedAtLeeway = 60 * time.Second
+
+// jwtHandler only passes the requests carrying a valid engine API token to the next handler.
+type jwtHandler struct {
+	secret []byte
+	next   http.Handler
+}
+
+func (h *jwtHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := h.verify(r.Header.Get("Authorization")); err != nil {
+		log.WithError(err).Debug("Rejected request")
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	h.next.ServeHTTP(w, r)
+}
+
+// verify checks that the token is signed with the secret using HS256, and was issued recently.
+func (h *jwtHandler) verify(header string) error {
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok {
+		return errors.New("missing bearer token")
+	}
+	claims := jwt.MapClaims{}
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithoutClaimsValidation())
+	if _, err := parser.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) {
+		return h.secret, nil
+	}); err != nil {
+		return errors.Wrap(err, "invalid token")
+	}
+	iat, ok := claims["iat"].(float64)
+	if !ok {
+		return errors.New("missing issued at claim")
+	}
+	if d := time.Since(time.Unix(int64(iat), 0)); d > jwtIssuedAtLeeway || d < -jwtIssuedAtLeeway {
+		return errors.New("stale token")
+	}
+	return nil
+}
+
+// readJWTSecret reads a hex encoded 32 bytes secret, as written for execution clients.
+func readJWTSecret(path string) ([]byte, error) {
+	enc, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read jwt secret")
+	}
+	s := strings.TrimSpace(string(enc))
+	if !strings.HasPrefix(s, "0x") {
+		s = "0x" + s
+	}
+	secret, err := hexutil.Decode(s)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode jwt secret")
+	}
+	if len(secret) != 32 {
+		return nil, errors.Errorf("jwt secret is %d bytes long, expected 32", len(secret))
+	}
+	return secret, nil
+}
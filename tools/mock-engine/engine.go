@@ -0,0 +1,381 @@
+package main
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	pb "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/sirupsen/logrus"
+)
+
+// Maximum number of built payloads kept for get payload calls, along with their blobs.
+const builtPayloadsLimit = 64
+
+var (
+	errInvalidParams  = &rpcError{Code: -32602, Message: "Invalid params"}
+	errUnknownPayload = &rpcError{Code: -38001, Message: "Unknown payload"}
+
+	supportedMethods = []string{
+		"engine_newPayloadV1",
+		"engine_newPayloadV2",
+		"engine_newPayloadV3",
+		"engine_newPayloadV4",
+		"engine_forkchoiceUpdatedV1",
+		"engine_forkchoiceUpdatedV2",
+		"engine_forkchoiceUpdatedV3",
+		"engine_getPayloadV1",
+		"engine_getPayloadV2",
+		"engine_getPayloadV3",
+		"engine_getPayloadV4",
+		"engine_getPayloadBodiesByHashV1",
+		"engine_getPayloadBodiesByRangeV1",
+		"engine_getBlobsV1",
+	}
+)
+
+type config struct {
+	chainID           uint64
+	transactions      int
+	blobs             int
+	executionRequests bool
+	extraData         []byte
+}
+
+// engine is an in-memory execution chain. Payloads are always valid, unless a rule of the script says otherwise.
+// A payload whose parent is unknown is reported as syncing, but is kept so that its descendants are valid.
+type engine struct {
+	sync.Mutex
+	cfg       *config
+	script    *script
+	blocks    map[common.Hash]*block
+	canonical map[uint64]common.Hash
+	head      *block
+	safe      common.Hash
+	finalized common.Hash
+	payloads  map[pb.PayloadIDBytes]*builtPayload
+	built     []pb.PayloadIDBytes
+	blobs     map[common.Hash]*pb.BlobAndProofJson
+}
+
+func newEngine(cfg *config, s *script, genesis *block) *engine {
+	return &engine{
+		cfg:       cfg,
+		script:    s,
+		blocks:    map[common.Hash]*block{genesis.hash(): genesis},
+		canonical: map[uint64]common.Hash{genesis.number(): genesis.hash()},
+		head:      genesis,
+		payloads:  make(map[pb.PayloadIDBytes]*builtPayload),
+		blobs:     make(map[common.Hash]*pb.BlobAndProofJson),
+	}
+}
+
+func (e *engine) block(hash common.Hash) (*block, bool) {
+	e.Lock()
+	defer e.Unlock()
+	b, ok := e.blocks[hash]
+	return b, ok
+}
+
+func (e *engine) newPayload(method string, b *block, versionedHashes []common.Hash) (*pb.PayloadStatus, error) {
+	number := b.number()
+	r := e.script.call(method, &number, b.hash())
+	if err := r.apply(); err != nil {
+		return nil, err
+	}
+	if r.overridesStatus() {
+		return r.payloadStatus(b.hash()), nil
+	}
+	if versionedHashes != nil {
+		if err := checkVersionedHashes(b, versionedHashes); err != nil {
+			return &pb.PayloadStatus{
+				Status:          pb.PayloadStatus_INVALID,
+				LatestValidHash: b.payload.ParentHash.Bytes(),
+				ValidationError: err.Error(),
+			}, nil
+		}
+	}
+
+	e.Lock()
+	defer e.Unlock()
+	_, parentKnown := e.blocks[*b.payload.ParentHash]
+	e.blocks[b.hash()] = b
+	log.WithFields(logrus.Fields{
+		"number":        number,
+		"hash":          b.hash(),
+		"txs":           len(b.payload.Transactions),
+		"unknownParent": !parentKnown,
+	}).Debug("Received payload")
+	if !parentKnown {
+		return &pb.PayloadStatus{Status: pb.PayloadStatus_SYNCING}, nil
+	}
+	return &pb.PayloadStatus{Status: pb.PayloadStatus_VALID, LatestValidHash: b.hash().Bytes()}, nil
+}
+
+// checkVersionedHashes verifies the versioned hashes of the blob transactions of the payload.
+func checkVersionedHashes(b *block, versionedHashes []common.Hash) error {
+	var hashes []common.Hash
+	for _, enc := range b.payload.Transactions {
+		tx := &gethtypes.Transaction{}
+		if err := tx.UnmarshalBinary(enc); err != nil {
+			return errors.Wrap(err, "could not decode transaction")
+		}
+		hashes = append(hashes, tx.BlobHashes()...)
+	}
+	if len(hashes) != len(versionedHashes) {
+		return errors.Errorf("expected %d versioned hashes, got %d", len(hashes), len(versionedHashes))
+	}
+	for i := range hashes {
+		if hashes[i] != versionedHashes[i] {
+			return errors.Errorf("versioned hash %d mismatch", i)
+		}
+	}
+	return nil
+}
+
+type forkchoiceUpdatedResponse struct {
+	PayloadStatus *pb.PayloadStatus  `json:"payloadStatus"`
+	PayloadId     *pb.PayloadIDBytes `json:"payloadId"`
+}
+
+func (e *engine) forkchoiceUpdated(method string, state *pb.ForkchoiceState, attrs *buildAttributes) (*forkchoiceUpdatedResponse, error) {
+	if state == nil {
+		return nil, errInvalidParams
+	}
+	headHash := common.BytesToHash(state.HeadBlockHash)
+	head, ok := e.block(headHash)
+	var number *uint64
+	if ok {
+		n := head.number()
+		number = &n
+	}
+	r := e.script.call(method, number, headHash)
+	if err := r.apply(); err != nil {
+		return nil, err
+	}
+	if r.overridesStatus() {
+		return &forkchoiceUpdatedResponse{PayloadStatus: r.payloadStatus(headHash)}, nil
+	}
+	if !ok {
+		return &forkchoiceUpdatedResponse{PayloadStatus: &pb.PayloadStatus{Status: pb.PayloadStatus_SYNCING}}, nil
+	}
+
+	e.Lock()
+	defer e.Unlock()
+	e.setHead(head)
+	e.safe = common.BytesToHash(state.SafeBlockHash)
+	e.finalized = common.BytesToHash(state.FinalizedBlockHash)
+	resp := &forkchoiceUpdatedResponse{
+		PayloadStatus: &pb.PayloadStatus{Status: pb.PayloadStatus_VALID, LatestValidHash: headHash.Bytes()},
+	}
+	if attrs == nil {
+		return resp, nil
+	}
+	id, err := attrs.payloadID(headHash)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := e.payloads[id]; !ok {
+		p, err := e.buildPayload(head, attrs)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not build payload")
+		}
+		e.addPayload(id, p)
+		log.WithFields(logrus.Fields{
+			"number":    p.block.number(),
+			"hash":      p.block.hash(),
+			"payloadId": hexutil.Encode(id[:]),
+		}).Debug("Built payload")
+	}
+	resp.PayloadId = &id
+	return resp, nil
+}
+
+// setHead updates the canonical chain to end with the head. Requires a lock on the engine.
+func (e *engine) setHead(head *block) {
+	for n := range e.canonical {
+		if n > head.number() {
+			delete(e.canonical, n)
+		}
+	}
+	for b, ok := head, true; ok; b, ok = e.blocks[*b.payload.ParentHash] {
+		if e.canonical[b.number()] == b.hash() {
+			break
+		}
+		e.canonical[b.number()] = b.hash()
+		if b.number() == 0 {
+			break
+		}
+	}
+	e.head = head
+}
+
+// addPayload keeps a built payload and its blobs, evicting the oldest payload above the limit.
+// Requires a lock on the engine.
+func (e *engine) addPayload(id pb.PayloadIDBytes, p *builtPayload) {
+	e.payloads[id] = p
+	e.built = append(e.built, id)
+	for i, commitment := range p.blobsBundle.Commitments {
+		e.blobs[versionedHash(commitment)] = &pb.BlobAndProofJson{Blob: p.blobsBundle.Blobs[i], KzgProof: p.blobsBundle.Proofs[i]}
+	}
+	if len(e.built) <= builtPayloadsLimit {
+		return
+	}
+	old := e.payloads[e.built[0]]
+	for _, commitment := range old.blobsBundle.Commitments {
+		delete(e.blobs, versionedHash(commitment))
+	}
+	delete(e.payloads, e.built[0])
+	e.built = e.built[1:]
+}
+
+func (e *engine) getPayload(method string, id pb.PayloadIDBytes) (*builtPayload, error) {
+	if err := e.script.call(method, nil, common.Hash{}).apply(); err != nil {
+		return nil, err
+	}
+	e.Lock()
+	defer e.Unlock()
+	p, ok := e.payloads[id]
+	if !ok {
+		return nil, errUnknownPayload
+	}
+	return p, nil
+}
+
+// canonicalBlock returns the canonical block at the given number.
+func (e *engine) canonicalBlock(number uint64) (*block, bool) {
+	e.Lock()
+	defer e.Unlock()
+	hash, ok := e.canonical[number]
+	if !ok {
+		return nil, false
+	}
+	return e.blocks[hash], true
+}
+
+// payloadFromJSON returns the block of a payload received in a new payload call, checking its required fields.
+func payloadFromJSON(v int, p *pb.ExecutionPayloadDenebJSON, requests []hexutil.Bytes) (*block, error) {
+	if p == nil || p.ParentHash == nil || p.FeeRecipient == nil || p.StateRoot == nil || p.ReceiptsRoot == nil ||
+		p.LogsBloom == nil || p.PrevRandao == nil || p.BlockNumber == nil || p.GasLimit == nil || p.GasUsed == nil ||
+		p.Timestamp == nil || p.BlockHash == nil {
+		return nil, errInvalidParams
+	}
+	if v >= version.Deneb && (p.BlobGasUsed == nil || p.ExcessBlobGas == nil) {
+		return nil, errInvalidParams
+	}
+	return &block{version: v, payload: p, requests: requests}, nil
+}
+
+func payloadFromBellatrix(p *pb.ExecutionPayload) (*block, error) {
+	if p == nil {
+		return nil, errInvalidParams
+	}
+	parentHash, stateRoot := common.BytesToHash(p.ParentHash), common.BytesToHash(p.StateRoot)
+	receiptsRoot, prevRandao := common.BytesToHash(p.ReceiptsRoot), common.BytesToHash(p.PrevRandao)
+	blockHash, feeRecipient := common.BytesToHash(p.BlockHash), common.BytesToAddress(p.FeeRecipient)
+	number, gasLimit, gasUsed := hexutil.Uint64(p.BlockNumber), hexutil.Uint64(p.GasLimit), hexutil.Uint64(p.GasUsed)
+	timestamp := hexutil.Uint64(p.Timestamp)
+	bloom := hexutil.Bytes(p.LogsBloom)
+	txs := make([]hexutil.Bytes, len(p.Transactions))
+	for i, tx := range p.Transactions {
+		txs[i] = tx
+	}
+	return payloadFromJSON(version.Bellatrix, &pb.ExecutionPayloadDenebJSON{
+		ParentHash:    &parentHash,
+		FeeRecipient:  &feeRecipient,
+		StateRoot:     &stateRoot,
+		ReceiptsRoot:  &receiptsRoot,
+		LogsBloom:     &bloom,
+		PrevRandao:    &prevRandao,
+		BlockNumber:   &number,
+		GasLimit:      &gasLimit,
+		GasUsed:       &gasUsed,
+		Timestamp:     &timestamp,
+		ExtraData:     p.ExtraData,
+		BaseFeePerGas: hexutil.EncodeBig(new(big.Int).SetBytes(bytesutil.ReverseByteOrder(p.BaseFeePerGas))),
+		BlockHash:     &blockHash,
+		Transactions:  txs,
+	}, nil)
+}
+
+func payloadFromCapella(p *pb.ExecutionPayloadCapellaJSON) (*block, error) {
+	if p == nil {
+		return nil, errInvalidParams
+	}
+	v := version.Capella
+	if p.Withdrawals == nil {
+		v = version.Bellatrix
+	}
+	return payloadFromJSON(v, &pb.ExecutionPayloadDenebJSON{
+		ParentHash:    p.ParentHash,
+		FeeRecipient:  p.FeeRecipient,
+		StateRoot:     p.StateRoot,
+		ReceiptsRoot:  p.ReceiptsRoot,
+		LogsBloom:     p.LogsBloom,
+		PrevRandao:    p.PrevRandao,
+		BlockNumber:   p.BlockNumber,
+		GasLimit:      p.GasLimit,
+		GasUsed:       p.GasUsed,
+		Timestamp:     p.Timestamp,
+		ExtraData:     p.ExtraData,
+		BaseFeePerGas: p.BaseFeePerGas,
+		BlockHash:     p.BlockHash,
+		Transactions:  p.Transactions,
+		Withdrawals:   p.Withdrawals,
+	}, nil)
+}
+
+func bellatrixPayload(b *block) (*pb.ExecutionPayload, error) {
+	p := b.payload
+	baseFee, err := hexutil.DecodeBig(p.BaseFeePerGas)
+	if err != nil {
+		return nil, err
+	}
+	txs := make([][]byte, len(p.Transactions))
+	for i, tx := range p.Transactions {
+		txs[i] = tx
+	}
+	return &pb.ExecutionPayload{
+		ParentHash:    p.ParentHash.Bytes(),
+		FeeRecipient:  p.FeeRecipient.Bytes(),
+		StateRoot:     p.StateRoot.Bytes(),
+		ReceiptsRoot:  p.ReceiptsRoot.Bytes(),
+		LogsBloom:     *p.LogsBloom,
+		PrevRandao:    p.PrevRandao.Bytes(),
+		BlockNumber:   uint64(*p.BlockNumber),
+		GasLimit:      uint64(*p.GasLimit),
+		GasUsed:       uint64(*p.GasUsed),
+		Timestamp:     uint64(*p.Timestamp),
+		ExtraData:     p.ExtraData,
+		BaseFeePerGas: bytesutil.PadTo(bytesutil.ReverseByteOrder(baseFee.Bytes()), fieldparams.RootLength),
+		BlockHash:     p.BlockHash.Bytes(),
+		Transactions:  txs,
+	}, nil
+}
+
+func capellaPayload(b *block) *pb.ExecutionPayloadCapellaJSON {
+	p := b.payload
+	return &pb.ExecutionPayloadCapellaJSON{
+		ParentHash:    p.ParentHash,
+		FeeRecipient:  p.FeeRecipient,
+		StateRoot:     p.StateRoot,
+		ReceiptsRoot:  p.ReceiptsRoot,
+		LogsBloom:     p.LogsBloom,
+		PrevRandao:    p.PrevRandao,
+		BlockNumber:   p.BlockNumber,
+		GasLimit:      p.GasLimit,
+		GasUsed:       p.GasUsed,
+		Timestamp:     p.Timestamp,
+		ExtraData:     p.ExtraData,
+		BaseFeePerGas: p.BaseFeePerGas,
+		BlockHash:     p.BlockHash,
+		Transactions:  p.Transactions,
+		Withdrawals:   p.Withdrawals,
+	}
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	gethRPC "github.com/ethereum/go-ethereum/rpc"
+	"github.com/golang-jwt/jwt/v4"
+	pb "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func newTestEngine(t *testing.T, cfg *config) (*engine, *gethRPC.Client) {
+	e := newEngine(cfg, newScript(), genesisBlock(common.Hash{}, 0))
+	server, err := newServer(e)
+	require.NoError(t, err)
+	t.Cleanup(server.Stop)
+	return e, gethRPC.DialInProc(server)
+}
+
+func attributesV3(timestamp uint64) *pb.PayloadAttributesV3 {
+	return &pb.PayloadAttributesV3{
+		Timestamp:             timestamp,
+		PrevRandao:            make([]byte, 32),
+		SuggestedFeeRecipient: make([]byte, 20),
+		Withdrawals:           []*pb.Withdrawal{},
+		ParentBeaconBlockRoot: make([]byte, 32),
+	}
+}
+
+// buildBlock builds a payload on top of the parent and returns it, as returned by get payload v4.
+func buildBlock(t *testing.T, client *gethRPC.Client, parent common.Hash, timestamp uint64) *pb.GetPayloadV4ResponseJson {
+	ctx := context.Background()
+	fcu := &forkchoiceUpdatedResponse{}
+	state := &pb.ForkchoiceState{HeadBlockHash: parent.Bytes(), SafeBlockHash: parent.Bytes(), FinalizedBlockHash: parent.Bytes()}
+	require.NoError(t, client.CallContext(ctx, fcu, "engine_forkchoiceUpdatedV3", state, attributesV3(timestamp)))
+	require.Equal(t, pb.PayloadStatus_VALID, fcu.PayloadStatus.Status)
+	require.NotNil(t, fcu.PayloadId)
+	resp := &pb.GetPayloadV4ResponseJson{}
+	require.NoError(t, client.CallContext(ctx, resp, "engine_getPayloadV4", fcu.PayloadId))
+	return resp
+}
+
+func TestEngine_BuildAndImport(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config{chainID: 1337, transactions: 2, blobs: 2, executionRequests: true}
+	e, client := newTestEngine(t, cfg)
+	genesis := e.head.hash()
+
+	resp := buildBlock(t, client, genesis, 12)
+	require.Equal(t, 3, len(resp.ExecutionPayload.Transactions))
+	require.Equal(t, 2, len(resp.BlobsBundle.Blobs))
+	require.Equal(t, 3, len(resp.ExecutionRequests))
+	for i := range resp.BlobsBundle.Blobs {
+		blob := kzg4844.Blob(resp.BlobsBundle.Blobs[i])
+		commitment := kzg4844.Commitment(resp.BlobsBundle.Commitments[i])
+		require.NoError(t, kzg4844.VerifyBlobProof(&blob, commitment, kzg4844.Proof(resp.BlobsBundle.Proofs[i])))
+	}
+
+	// The same build request on another engine gives the same payload.
+	_, other := newTestEngine(t, cfg)
+	require.DeepEqual(t, resp.ExecutionPayload.BlockHash, buildBlock(t, other, genesis, 12).ExecutionPayload.BlockHash)
+
+	hashes := []common.Hash{versionedHash(resp.BlobsBundle.Commitments[0]), versionedHash(resp.BlobsBundle.Commitments[1])}
+	status := &pb.PayloadStatus{}
+	require.NoError(t, client.CallContext(ctx, status, "engine_newPayloadV4", resp.ExecutionPayload, hashes, common.Hash{}, resp.ExecutionRequests))
+	require.Equal(t, pb.PayloadStatus_VALID, status.Status)
+	require.DeepEqual(t, resp.ExecutionPayload.BlockHash.Bytes(), status.LatestValidHash)
+
+	// Versioned hashes not matching the blob transactions make the payload invalid.
+	require.NoError(t, client.CallContext(ctx, status, "engine_newPayloadV4", resp.ExecutionPayload, hashes[:1], common.Hash{}, resp.ExecutionRequests))
+	require.Equal(t, pb.PayloadStatus_INVALID, status.Status)
+
+	var blobs []*pb.BlobAndProofJson
+	require.NoError(t, client.CallContext(ctx, &blobs, "engine_getBlobsV1", append(hashes, common.Hash{1})))
+	require.Equal(t, 3, len(blobs))
+	require.DeepEqual(t, resp.BlobsBundle.Blobs[1], blobs[1].Blob)
+	require.Equal(t, true, blobs[2] == nil)
+
+	// The imported block becomes the head.
+	buildBlock(t, client, *resp.ExecutionPayload.BlockHash, 24)
+	var number hexutil.Uint64
+	require.NoError(t, client.CallContext(ctx, &number, "eth_blockNumber"))
+	require.Equal(t, hexutil.Uint64(1), number)
+	block := &pb.ExecutionBlock{}
+	require.NoError(t, client.CallContext(ctx, block, "eth_getBlockByNumber", "latest", true))
+	require.Equal(t, *resp.ExecutionPayload.BlockHash, block.Hash)
+	require.Equal(t, 3, len(block.Transactions))
+	var bodies []*payloadBodyV1
+	require.NoError(t, client.CallContext(ctx, &bodies, "engine_getPayloadBodiesByRangeV1", hexutil.Uint64(1), hexutil.Uint64(4)))
+	require.Equal(t, 1, len(bodies))
+	require.Equal(t, 3, len(bodies[0].Transactions))
+}
+
+func TestEngine_UnknownParent(t *testing.T) {
+	ctx := context.Background()
+	_, builder := newTestEngine(t, &config{})
+	e, client := newTestEngine(t, &config{})
+	first := buildBlock(t, builder, e.head.hash(), 12)
+	status := &pb.PayloadStatus{}
+	require.NoError(t, builder.CallContext(ctx, status, "engine_newPayloadV3", first.ExecutionPayload, []common.Hash{}, common.Hash{}))
+	second := buildBlock(t, builder, *first.ExecutionPayload.BlockHash, 24)
+
+	require.NoError(t, client.CallContext(ctx, status, "engine_newPayloadV3", second.ExecutionPayload, []common.Hash{}, common.Hash{}))
+	require.Equal(t, pb.PayloadStatus_SYNCING, status.Status)
+	fcu := &forkchoiceUpdatedResponse{}
+	state := &pb.ForkchoiceState{HeadBlockHash: first.ExecutionPayload.BlockHash.Bytes()}
+	require.NoError(t, client.CallContext(ctx, fcu, "engine_forkchoiceUpdatedV3", state, nil))
+	require.Equal(t, pb.PayloadStatus_SYNCING, fcu.PayloadStatus.Status)
+
+	var unknown *pb.GetPayloadV3ResponseJson
+	err := client.CallContext(ctx, &unknown, "engine_getPayloadV3", pb.PayloadIDBytes{1})
+	require.ErrorContains(t, "Unknown payload", err)
+}
+
+func TestEngine_Script(t *testing.T) {
+	ctx := context.Background()
+	e, client := newTestEngine(t, &config{})
+	genesis := e.head.hash()
+	first := buildBlock(t, client, genesis, 12)
+
+	number := uint64(1)
+	require.NoError(t, client.CallContext(ctx, nil, "mock_addRule", &rule{Method: "engine_newPayload", BlockNumber: &number, Status: "INVALID", LatestValidHash: &genesis}))
+	require.NoError(t, client.CallContext(ctx, nil, "mock_addRule", &rule{Method: "engine_forkchoiceUpdatedV3", FromCall: 3, Count: 1, Status: "SYNCING"}))
+	require.NoError(t, client.CallContext(ctx, nil, "mock_addRule", &rule{Method: "engine_getPayload", Error: &rpcError{Code: -32000, Message: "scripted"}}))
+
+	status := &pb.PayloadStatus{}
+	require.NoError(t, client.CallContext(ctx, status, "engine_newPayloadV3", first.ExecutionPayload, []common.Hash{}, common.Hash{}))
+	require.Equal(t, pb.PayloadStatus_INVALID, status.Status)
+	require.DeepEqual(t, genesis.Bytes(), status.LatestValidHash)
+
+	state := &pb.ForkchoiceState{HeadBlockHash: genesis.Bytes()}
+	fcu := &forkchoiceUpdatedResponse{}
+	require.NoError(t, client.CallContext(ctx, fcu, "engine_forkchoiceUpdatedV3", state, nil))
+	require.Equal(t, pb.PayloadStatus_VALID, fcu.PayloadStatus.Status)
+	require.NoError(t, client.CallContext(ctx, fcu, "engine_forkchoiceUpdatedV3", state, attributesV3(24)))
+	require.Equal(t, pb.PayloadStatus_SYNCING, fcu.PayloadStatus.Status)
+	require.Equal(t, true, fcu.PayloadId == nil)
+	require.NoError(t, client.CallContext(ctx, fcu, "engine_forkchoiceUpdatedV3", state, attributesV3(24)))
+	require.Equal(t, pb.PayloadStatus_VALID, fcu.PayloadStatus.Status)
+
+	err := client.CallContext(ctx, &pb.GetPayloadV3ResponseJson{}, "engine_getPayloadV3", fcu.PayloadId)
+	require.ErrorContains(t, "scripted", err)
+
+	require.NoError(t, client.CallContext(ctx, nil, "mock_clearRules"))
+	require.NoError(t, client.CallContext(ctx, &pb.GetPayloadV3ResponseJson{}, "engine_getPayloadV3", fcu.PayloadId))
+}
+
+func TestLoadScript(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "script.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[{"method": "engine_newPayloadV3", "delay": "1ms", "status": "ACCEPTED"}]`), 0600))
+	s, err := loadScript(path)
+	require.NoError(t, err)
+	r := s.call("engine_newPayloadV3", nil, common.Hash{})
+	require.NotNil(t, r)
+	require.Equal(t, time.Millisecond, r.delay)
+	require.Equal(t, pb.PayloadStatus_ACCEPTED, r.status())
+	require.Equal(t, true, s.call("engine_newPayloadV2", nil, common.Hash{}) == nil)
+
+	require.NoError(t, os.WriteFile(path, []byte(`[{"method": "engine_newPayload", "status": "DONE"}]`), 0600))
+	_, err = loadScript(path)
+	require.ErrorContains(t, "unknown payload status", err)
+}
+
+func TestJWTHandler(t *testing.T) {
+	secret := make([]byte, 32)
+	secret[0] = 1
+	h := &jwtHandler{secret: secret, next: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})}
+	token := func(key []byte, iat time.Time) string {
+		s, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"iat": iat.Unix()}).SignedString(key)
+		require.NoError(t, err)
+		return "Bearer " + s
+	}
+	tests := []struct {
+		name   string
+		header string
+		code   int
+	}{
+		{name: "valid", header: token(secret, time.Now()), code: http.StatusOK},
+		{name: "missing", header: "", code: http.StatusUnauthorized},
+		{name: "wrong secret", header: token(make([]byte, 32), time.Now()), code: http.StatusUnauthorized},
+		{name: "stale", header: token(secret, time.Now().Add(-2*time.Minute)), code: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			req.Header.Set("Authorization", tt.header)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			require.Equal(t, tt.code, rec.Code)
+		})
+	}
+}
+
+func TestReadJWTSecret(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jwt.hex")
+	require.NoError(t, os.WriteFile(path, []byte("0x"+common.Bytes2Hex(make([]byte, 32))+"\n"), 0600))
+	secret, err := readJWTSecret(path)
+	require.NoError(t, err)
+	require.Equal(t, 32, len(secret))
+
+	require.NoError(t, os.WriteFile(path, []byte("abcd"), 0600))
+	_, err = readJWTSecret(path)
+	require.ErrorContains(t, "expected 32", err)
+}
+
+func TestPayloadID_Deterministic(t *testing.T) {
+	a := &buildAttributes{timestamp: 12}
+	id1, err := a.payloadID(common.Hash{1})
+	require.NoError(t, err)
+	id2, err := a.payloadID(common.Hash{1})
+	require.NoError(t, err)
+	require.Equal(t, id1, id2)
+	id3, err := a.payloadID(common.Hash{2})
+	require.NoError(t, err)
+	require.NotEqual(t, id1, id3)
+}
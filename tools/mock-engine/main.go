@@ -0,0 +1,120 @@
+// Package main implements a deterministic execution engine serving the engine API, for devnets and tests
+// of the beacon node which do not need a real execution client.
+//
+// The engine keeps an in-memory chain: payloads are always valid and built payloads only carry synthetic
+// transfers, blob transactions and execution requests, derived from their parent and payload attributes.
+// Responses can be overridden with a script of rules, loaded at startup or added at runtime through the
+// mock namespace, to return INVALID or SYNCING statuses, errors, or to delay calls.
+//
+// Usage:
+//
+//	mock-engine --port 8551 --jwt-secret /path/to/jwt.hex --blobs-per-payload 2 --script rules.json
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethRPC "github.com/ethereum/go-ethereum/rpc"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	debug             = flag.Bool("debug", false, "Enable debug logging")
+	host              = flag.String("host", "127.0.0.1", "Host to listen on")
+	port              = flag.Int("port", 8551, "Port to listen on")
+	jwtSecretPath     = flag.String("jwt-secret", "", "Path to the hex encoded JWT secret. Requests are not authenticated if empty")
+	chainID           = flag.Uint64("chain-id", params.E2ETestConfig().DepositChainID, "Chain ID returned by eth_chainId")
+	genesisHash       = flag.String("genesis-block-hash", "", "Hash of the genesis block. Derived from the genesis timestamp if empty")
+	genesisTimestamp  = flag.Uint64("genesis-timestamp", 0, "Timestamp of the genesis block")
+	transactions      = flag.Int("transactions-per-payload", 0, "Number of synthetic transfers in every built payload")
+	blobs             = flag.Int("blobs-per-payload", 0, "Number of synthetic blobs in every built payload from Deneb")
+	executionRequests = flag.Bool("execution-requests", false, "Include synthetic deposit, withdrawal and consolidation requests in built payloads")
+	extraData         = flag.String("extra-data", "mock-engine", "Extra data of built payloads")
+	scriptPath        = flag.String("script", "", "Path to a JSON array of rules overriding the responses of the engine")
+	log               = logrus.WithField("prefix", "mock-engine")
+)
+
+func main() {
+	flag.Parse()
+	if *debug {
+		logrus.SetLevel(logrus.DebugLevel)
+	}
+	if *blobs > params.BeaconConfig().MaxBlobsPerBlockByVersion(version.Electra) {
+		log.Fatalf("Too many blobs per payload: %d", *blobs)
+	}
+	s, err := loadScript(*scriptPath)
+	if err != nil {
+		log.WithError(err).Fatal("Could not load script")
+	}
+	cfg := &config{
+		chainID:           *chainID,
+		transactions:      *transactions,
+		blobs:             *blobs,
+		executionRequests: *executionRequests,
+		extraData:         []byte(*extraData),
+	}
+	e := newEngine(cfg, s, genesisBlock(common.HexToHash(*genesisHash), *genesisTimestamp))
+
+	server, err := newServer(e)
+	if err != nil {
+		log.WithError(err).Fatal("Could not create rpc server")
+	}
+	defer server.Stop()
+	var handler http.Handler = server
+	if *jwtSecretPath != "" {
+		secret, err := readJWTSecret(*jwtSecretPath)
+		if err != nil {
+			log.WithError(err).Fatal("Could not load jwt secret")
+		}
+		handler = &jwtHandler{secret: secret, next: server}
+	} else {
+		log.Warn("No jwt secret provided, requests are not authenticated")
+	}
+
+	srv := &http.Server{
+		Addr:              net.JoinHostPort(*host, fmt.Sprint(*port)),
+		Handler:           handler,
+		ReadHeaderTimeout: 3 * time.Second,
+	}
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+		<-sig
+		if err := srv.Close(); err != nil {
+			log.WithError(err).Error("Could not close server")
+		}
+	}()
+	log.WithFields(logrus.Fields{
+		"address":     srv.Addr,
+		"genesisHash": e.head.hash(),
+		"chainId":     cfg.chainID,
+	}).Info("Serving the engine API")
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.WithError(err).Fatal("Server failed")
+	}
+}
+
+// newServer returns a json-rpc server with the engine, eth and mock namespaces.
+func newServer(e *engine) (*gethRPC.Server, error) {
+	server := gethRPC.NewServer()
+	if err := server.RegisterName("engine", &engineAPI{engine: e}); err != nil {
+		return nil, err
+	}
+	if err := server.RegisterName("eth", &ethAPI{engine: e}); err != nil {
+		return nil, err
+	}
+	if err := server.RegisterName("mock", &mockAPI{script: e.script}); err != nil {
+		return nil, err
+	}
+	return server, nil
+}
@@ -0,0 +1,311 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/holiman/uint256"
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	pb "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+const (
+	payloadGasLimit = 30_000_000
+	payloadBaseFee  = 7
+	transferGas     = 21_000
+	blobGasPerBlob  = 1 << 17
+)
+
+// Fee recipient of the synthetic transactions, and source address of the synthetic execution requests.
+var syntheticAddress = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+// block is an execution block known to the engine, either built by the engine or received in a new payload call.
+type block struct {
+	version  int
+	payload  *pb.ExecutionPayloadDenebJSON
+	requests []hexutil.Bytes
+}
+
+func (b *block) hash() common.Hash {
+	return *b.payload.BlockHash
+}
+
+func (b *block) number() uint64 {
+	return uint64(*b.payload.BlockNumber)
+}
+
+// builtPayload is a payload built by the engine, to be returned by a get payload call.
+type builtPayload struct {
+	block       *block
+	blobsBundle *pb.BlobBundleJSON
+	value       *big.Int
+}
+
+// buildAttributes are the payload attributes of a forkchoice update, whatever their version.
+type buildAttributes struct {
+	version               int
+	timestamp             uint64
+	prevRandao            common.Hash
+	feeRecipient          common.Address
+	withdrawals           []*pb.Withdrawal
+	parentBeaconBlockRoot common.Hash
+}
+
+// payloadID derives the payload id from the parent and the attributes, so that the same build request
+// always gets the same id.
+func (a *buildAttributes) payloadID(parent common.Hash) (pb.PayloadIDBytes, error) {
+	enc, err := json.Marshal(a.withdrawals)
+	if err != nil {
+		return pb.PayloadIDBytes{}, err
+	}
+	h := crypto.Keccak256(
+		parent.Bytes(),
+		binary.BigEndian.AppendUint64(nil, a.timestamp),
+		a.prevRandao.Bytes(),
+		a.feeRecipient.Bytes(),
+		enc,
+		a.parentBeaconBlockRoot.Bytes(),
+	)
+	var id pb.PayloadIDBytes
+	copy(id[:], h)
+	return id, nil
+}
+
+// genesisBlock returns the block the chain starts from, with the given hash if not zero.
+func genesisBlock(hash common.Hash, timestamp uint64) *block {
+	number, gasLimit, zero := hexutil.Uint64(0), hexutil.Uint64(payloadGasLimit), hexutil.Uint64(0)
+	ts := hexutil.Uint64(timestamp)
+	bloom := hexutil.Bytes(make([]byte, fieldparams.LogsBloomLength))
+	receiptsRoot := gethtypes.EmptyReceiptsHash
+	b := &block{
+		version: version.Deneb,
+		payload: &pb.ExecutionPayloadDenebJSON{
+			ParentHash:    &common.Hash{},
+			FeeRecipient:  &common.Address{},
+			StateRoot:     &common.Hash{},
+			ReceiptsRoot:  &receiptsRoot,
+			LogsBloom:     &bloom,
+			PrevRandao:    &common.Hash{},
+			BlockNumber:   &number,
+			GasLimit:      &gasLimit,
+			GasUsed:       &zero,
+			Timestamp:     &ts,
+			ExtraData:     hexutil.Bytes{},
+			BaseFeePerGas: hexutil.EncodeBig(big.NewInt(payloadBaseFee)),
+			BlobGasUsed:   &zero,
+			ExcessBlobGas: &zero,
+			Transactions:  []hexutil.Bytes{},
+			Withdrawals:   []*pb.Withdrawal{},
+		},
+	}
+	if hash == (common.Hash{}) {
+		hash = blockHash(b)
+	}
+	b.payload.BlockHash = &hash
+	return b
+}
+
+// blockHash is the keccak256 hash of the json encoding of the payload without its block hash,
+// followed by its execution requests.
+func blockHash(b *block) common.Hash {
+	p := *b.payload
+	p.BlockHash = nil
+	enc, err := json.Marshal(&p)
+	if err != nil {
+		// The payload only holds json serializable values.
+		panic(err)
+	}
+	data := [][]byte{enc}
+	for _, r := range b.requests {
+		data = append(data, r)
+	}
+	return crypto.Keccak256Hash(data...)
+}
+
+// seededBytes returns n bytes derived from the seed.
+func seededBytes(seed []byte, n int) []byte {
+	out := make([]byte, 0, n+32)
+	h := seed
+	for len(out) < n {
+		h = crypto.Keccak256(h)
+		out = append(out, h...)
+	}
+	return out[:n]
+}
+
+// buildPayload builds a payload on top of the parent. It includes the configured number of synthetic
+// transfers and blobs, and synthetic execution requests if enabled. Everything in the payload derives from
+// the parent and the attributes.
+func (e *engine) buildPayload(parent *block, attrs *buildAttributes) (*builtPayload, error) {
+	number := hexutil.Uint64(parent.number() + 1)
+	seed := crypto.Keccak256(parent.hash().Bytes(), binary.BigEndian.AppendUint64(nil, attrs.timestamp))
+	nonce := parent.number() * uint64(e.cfg.transactions+1)
+
+	txs := make([]hexutil.Bytes, 0, e.cfg.transactions+1)
+	gasUsed := uint64(0)
+	for i := 0; i < e.cfg.transactions; i++ {
+		tx := gethtypes.NewTx(&gethtypes.LegacyTx{
+			Nonce:    nonce + uint64(i),
+			GasPrice: big.NewInt(payloadBaseFee),
+			Gas:      transferGas,
+			To:       &syntheticAddress,
+			Value:    big.NewInt(1),
+			Data:     seededBytes(crypto.Keccak256(seed, []byte{'t', byte(i)}), 32),
+		})
+		enc, err := tx.MarshalBinary()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not encode transaction")
+		}
+		txs = append(txs, enc)
+		gasUsed += transferGas
+	}
+
+	bundle := &pb.BlobBundleJSON{Commitments: []hexutil.Bytes{}, Proofs: []hexutil.Bytes{}, Blobs: []hexutil.Bytes{}}
+	blobGasUsed := hexutil.Uint64(0)
+	excessBlobGas := hexutil.Uint64(0)
+	if attrs.version >= version.Deneb && e.cfg.blobs > 0 {
+		hashes := make([]common.Hash, e.cfg.blobs)
+		for i := range hashes {
+			blob, commitment, proof, err := e.syntheticBlob(crypto.Keccak256(seed, []byte{'b', byte(i)}))
+			if err != nil {
+				return nil, err
+			}
+			hashes[i] = kzg4844.CalcBlobHashV1(sha256.New(), &commitment)
+			bundle.Blobs = append(bundle.Blobs, blob[:])
+			bundle.Commitments = append(bundle.Commitments, commitment[:])
+			bundle.Proofs = append(bundle.Proofs, proof[:])
+		}
+		tx := gethtypes.NewTx(&gethtypes.BlobTx{
+			ChainID:    uint256.NewInt(e.cfg.chainID),
+			Nonce:      nonce + uint64(e.cfg.transactions),
+			GasTipCap:  uint256.NewInt(1),
+			GasFeeCap:  uint256.NewInt(payloadBaseFee),
+			Gas:        transferGas,
+			To:         syntheticAddress,
+			Value:      uint256.NewInt(0),
+			BlobFeeCap: uint256.NewInt(1),
+			BlobHashes: hashes,
+		})
+		enc, err := tx.MarshalBinary()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not encode blob transaction")
+		}
+		txs = append(txs, enc)
+		gasUsed += transferGas
+		blobGasUsed = hexutil.Uint64(e.cfg.blobs * blobGasPerBlob)
+	}
+
+	gasLimit, used, ts := hexutil.Uint64(payloadGasLimit), hexutil.Uint64(gasUsed), hexutil.Uint64(attrs.timestamp)
+	parentHash := parent.hash()
+	stateRoot := crypto.Keccak256Hash(parent.payload.StateRoot.Bytes(), seed)
+	bloom := hexutil.Bytes(make([]byte, fieldparams.LogsBloomLength))
+	receiptsRoot := gethtypes.EmptyReceiptsHash
+	if len(txs) > 0 {
+		receiptsRoot = crypto.Keccak256Hash(seed, []byte{'r'})
+	}
+	withdrawals := attrs.withdrawals
+	if withdrawals == nil && attrs.version >= version.Capella {
+		withdrawals = []*pb.Withdrawal{}
+	}
+	b := &block{
+		version: attrs.version,
+		payload: &pb.ExecutionPayloadDenebJSON{
+			ParentHash:    &parentHash,
+			FeeRecipient:  &attrs.feeRecipient,
+			StateRoot:     &stateRoot,
+			ReceiptsRoot:  &receiptsRoot,
+			LogsBloom:     &bloom,
+			PrevRandao:    &attrs.prevRandao,
+			BlockNumber:   &number,
+			GasLimit:      &gasLimit,
+			GasUsed:       &used,
+			Timestamp:     &ts,
+			ExtraData:     hexutil.Bytes(e.cfg.extraData),
+			BaseFeePerGas: hexutil.EncodeBig(big.NewInt(payloadBaseFee)),
+			BlobGasUsed:   &blobGasUsed,
+			ExcessBlobGas: &excessBlobGas,
+			Transactions:  txs,
+			Withdrawals:   withdrawals,
+		},
+	}
+	if e.cfg.executionRequests && attrs.version >= version.Deneb {
+		requests, err := syntheticRequests(seed, parent.number()+1)
+		if err != nil {
+			return nil, err
+		}
+		b.requests = requests
+	}
+	hash := blockHash(b)
+	b.payload.BlockHash = &hash
+	return &builtPayload{
+		block:       b,
+		blobsBundle: bundle,
+		value:       new(big.Int).SetUint64(gasUsed),
+	}, nil
+}
+
+// syntheticBlob returns a blob of field elements derived from the seed, with its commitment and proof.
+func (e *engine) syntheticBlob(seed []byte) (*kzg4844.Blob, kzg4844.Commitment, kzg4844.Proof, error) {
+	blob := &kzg4844.Blob{}
+	data := seededBytes(seed, fieldparams.BlobLength)
+	for i := 0; i < fieldparams.BlobLength; i += 32 {
+		// Keep every field element below the modulus of the field.
+		copy(blob[i+1:i+32], data[i+1:i+32])
+	}
+	commitment, err := kzg4844.BlobToCommitment(blob)
+	if err != nil {
+		return nil, kzg4844.Commitment{}, kzg4844.Proof{}, errors.Wrap(err, "could not compute blob commitment")
+	}
+	proof, err := kzg4844.ComputeBlobProof(blob, commitment)
+	if err != nil {
+		return nil, kzg4844.Commitment{}, kzg4844.Proof{}, errors.Wrap(err, "could not compute blob proof")
+	}
+	return blob, commitment, proof, nil
+}
+
+// syntheticRequests returns a deposit, a withdrawal and a consolidation request derived from the seed.
+// The requests are well formed but refer to unknown validators and carry no valid signature, so the
+// consensus layer records and then ignores them.
+func syntheticRequests(seed []byte, index uint64) ([]hexutil.Bytes, error) {
+	pubkey := seededBytes(crypto.Keccak256(seed, []byte{'p'}), fieldparams.BLSPubkeyLength)
+	credentials := make([]byte, fieldparams.RootLength)
+	credentials[0] = params.BeaconConfig().ETH1AddressWithdrawalPrefixByte
+	copy(credentials[12:], syntheticAddress.Bytes())
+	requests := &pb.ExecutionRequests{
+		Deposits: []*pb.DepositRequest{{
+			Pubkey:                pubkey,
+			WithdrawalCredentials: credentials,
+			Amount:                params.BeaconConfig().MinActivationBalance,
+			Signature:             make([]byte, fieldparams.BLSSignatureLength),
+			Index:                 index,
+		}},
+		Withdrawals: []*pb.WithdrawalRequest{{
+			SourceAddress:   syntheticAddress.Bytes(),
+			ValidatorPubkey: pubkey,
+			Amount:          0,
+		}},
+		Consolidations: []*pb.ConsolidationRequest{{
+			SourceAddress: syntheticAddress.Bytes(),
+			SourcePubkey:  pubkey,
+			TargetPubkey:  seededBytes(crypto.Keccak256(seed, []byte{'c'}), fieldparams.BLSPubkeyLength),
+		}},
+	}
+	return pb.EncodeExecutionRequests(requests)
+}
+
+// versionedHash returns the versioned hash of a blob commitment.
+func versionedHash(commitment []byte) common.Hash {
+	var c kzg4844.Commitment
+	copy(c[:], commitment)
+	return kzg4844.CalcBlobHashV1(sha256.New(), &c)
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	pb "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+)
+
+var methodVersion = regexp.MustCompile(`V[0-9]+$`)
+
+// rule overrides the response of the engine to the calls of a method. Rules are matched in order,
+// the first matching rule applies.
+//
+//	{"method": "engine_newPayload", "block_number": 12, "status": "INVALID"}
+//	{"method": "engine_forkchoiceUpdated", "from_call": 5, "count": 3, "status": "SYNCING"}
+//	{"method": "engine_getPayload", "error": {"code": -38001, "message": "Unknown payload"}}
+//	{"method": "engine_newPayloadV4", "delay": "2s"}
+type rule struct {
+	// Method matches every version of the method when it has no version suffix.
+	Method string `json:"method"`
+	// BlockNumber and BlockHash match the payload of a new payload call, or the head of a forkchoice update.
+	BlockNumber *uint64      `json:"block_number,omitempty"`
+	BlockHash   *common.Hash `json:"block_hash,omitempty"`
+	// FromCall is the first call of the method, counting from 1, to which the rule applies.
+	FromCall uint64 `json:"from_call,omitempty"`
+	// Count is the number of calls to which the rule applies, unlimited if zero.
+	Count uint64 `json:"count,omitempty"`
+	// Status is the payload status returned by new payload and forkchoice updated calls.
+	Status          string       `json:"status,omitempty"`
+	LatestValidHash *common.Hash `json:"latest_valid_hash,omitempty"`
+	Error           *rpcError    `json:"error,omitempty"`
+	Delay           string       `json:"delay,omitempty"`
+
+	delay   time.Duration
+	applied uint64
+}
+
+func (r *rule) validate() error {
+	if r.Method == "" {
+		return errors.New("rule without method")
+	}
+	if r.Status != "" {
+		if _, ok := pb.PayloadStatus_Status_value[r.Status]; !ok {
+			return errors.Errorf("unknown payload status %q", r.Status)
+		}
+	}
+	if r.Delay != "" {
+		d, err := time.ParseDuration(r.Delay)
+		if err != nil {
+			return errors.Wrapf(err, "invalid delay %q", r.Delay)
+		}
+		r.delay = d
+	}
+	return nil
+}
+
+func (r *rule) status() pb.PayloadStatus_Status {
+	return pb.PayloadStatus_Status(pb.PayloadStatus_Status_value[r.Status])
+}
+
+// rpcError is a JSON-RPC error returned by a rule.
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return e.Message
+}
+
+// ErrorCode makes the rpc server return the code of the error.
+func (e *rpcError) ErrorCode() int {
+	return e.Code
+}
+
+// script holds the rules and counts the calls of every method.
+type script struct {
+	sync.Mutex
+	rules []*rule
+	calls map[string]uint64
+}
+
+func newScript() *script {
+	return &script{calls: make(map[string]uint64)}
+}
+
+// loadScript reads a json array of rules.
+func loadScript(path string) (*script, error) {
+	s := newScript()
+	if path == "" {
+		return s, nil
+	}
+	enc, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read script")
+	}
+	var rules []*rule
+	if err := json.Unmarshal(enc, &rules); err != nil {
+		return nil, errors.Wrap(err, "could not decode script")
+	}
+	for _, r := range rules {
+		if err := s.add(r); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (s *script) add(r *rule) error {
+	if err := r.validate(); err != nil {
+		return err
+	}
+	s.Lock()
+	defer s.Unlock()
+	s.rules = append(s.rules, r)
+	return nil
+}
+
+func (s *script) clear() {
+	s.Lock()
+	defer s.Unlock()
+	s.rules = nil
+}
+
+// call counts a call of the method and returns the rule applying to it, if any. The number and hash
+// are those of the block the call is about, when known.
+func (s *script) call(method string, number *uint64, hash common.Hash) *rule {
+	s.Lock()
+	defer s.Unlock()
+	s.calls[method]++
+	n := s.calls[method]
+	for _, r := range s.rules {
+		if r.Method != method && r.Method != methodVersion.ReplaceAllString(method, "") {
+			continue
+		}
+		if r.FromCall > n || (r.Count > 0 && r.applied >= r.Count) {
+			continue
+		}
+		if r.BlockNumber != nil && (number == nil || *number != *r.BlockNumber) {
+			continue
+		}
+		if r.BlockHash != nil && *r.BlockHash != hash {
+			continue
+		}
+		r.applied++
+		return r
+	}
+	return nil
+}
+
+// apply waits for the delay of the rule and returns its error.
+func (r *rule) apply() error {
+	if r == nil {
+		return nil
+	}
+	time.Sleep(r.delay)
+	if r.Error != nil {
+		return r.Error
+	}
+	return nil
+}
+
+// overridesStatus returns true if the rule replaces the payload status of the response.
+func (r *rule) overridesStatus() bool {
+	return r != nil && r.Status != ""
+}
+
+// payloadStatus returns the payload status of the rule.
+func (r *rule) payloadStatus(hash common.Hash) *pb.PayloadStatus {
+	status := &pb.PayloadStatus{Status: r.status()}
+	switch {
+	case r.LatestValidHash != nil:
+		status.LatestValidHash = r.LatestValidHash.Bytes()
+	case status.Status == pb.PayloadStatus_VALID:
+		status.LatestValidHash = hash.Bytes()
+	}
+	if status.Status == pb.PayloadStatus_INVALID || status.Status == pb.PayloadStatus_INVALID_BLOCK_HASH {
+		status.ValidationError = "scripted " + strings.ToLower(r.Status) + " response"
+	}
+	return status
+}
+
+// mockAPI is the mock namespace, scripting the responses of the engine at runtime.
+type mockAPI struct {
+	script *script
+}
+
+// AddRule adds a rule after the existing rules.
+func (api *mockAPI) AddRule(r *rule) error {
+	if r == nil {
+		return errors.New("nil rule")
+	}
+	return api.script.add(r)
+}
+
+// ClearRules removes all rules.
+func (api *mockAPI) ClearRules() {
+	api.script.clear()
+}